@@ -1,19 +1,35 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"github.com/lokeam/qko-beta/internal/addons"
 	"github.com/lokeam/qko-beta/internal/analytics"
 	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/barcodes"
+	"github.com/lokeam/qko-beta/internal/collections"
 	"github.com/lokeam/qko-beta/internal/dashboard"
+	"github.com/lokeam/qko-beta/internal/data_export"
+	"github.com/lokeam/qko-beta/internal/data_restore"
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/game_metadata"
+	"github.com/lokeam/qko-beta/internal/infrastructure/blobstore"
 	"github.com/lokeam/qko-beta/internal/infrastructure/cache"
+	"github.com/lokeam/qko-beta/internal/interfaces"
 	"github.com/lokeam/qko-beta/internal/library"
+	"github.com/lokeam/qko-beta/internal/library_import"
+	"github.com/lokeam/qko-beta/internal/loans"
 	"github.com/lokeam/qko-beta/internal/locations/digital"
 	"github.com/lokeam/qko-beta/internal/locations/physical"
 	"github.com/lokeam/qko-beta/internal/locations/sublocation"
+	"github.com/lokeam/qko-beta/internal/platforms"
+	"github.com/lokeam/qko-beta/internal/pricing"
 	"github.com/lokeam/qko-beta/internal/search"
 	"github.com/lokeam/qko-beta/internal/services"
 	"github.com/lokeam/qko-beta/internal/spend_tracking"
+	"github.com/lokeam/qko-beta/internal/trash"
 	"github.com/lokeam/qko-beta/internal/wishlist"
 )
 
@@ -28,6 +44,26 @@ type Services struct {
 	SpendTracking services.SpendTrackingService
 	Dashboard     services.DashboardService
 	Analytics     analytics.Service
+	LibraryImport services.LibraryImportService
+	Collections   services.CollectionsService
+	Loans         services.LoansService
+	DataExport    services.DataExportService
+	DataRestore   services.DataRestoreService
+	Trash         services.TrashService
+	Barcodes      services.BarcodesService
+	Addons        services.AddonsService
+	BlobStore     interfaces.BlobStore
+
+	// Background queues started by StartBackgroundJobs
+	libraryImportService *library_import.GameLibraryImportService
+	dataExportService    *data_export.UserDataExportService
+	loansService         *loans.GameLoansService
+	trashService         *trash.GameTrashService
+	gameMetadataService  *game_metadata.GameMetadataRefreshService
+	platformSyncService  *platforms.PlatformSyncService
+	priceCheckService    *wishlist.WishlistPriceCheckService
+	releaseReminders     *wishlist.WishlistReleaseReminderService
+	emailQueue           *email.EmailQueue
 }
 
 // NewServices initializes all application services
@@ -123,6 +159,7 @@ func NewServices(appCtx *appcontext.AppContext) (*Services, error) {
 		spendTrackingDbAdapter,
 		spendTrackingCacheAdapter,
 		dashboardCacheAdapter,
+		libraryCacheAdapter,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing spend tracking service: %w", err)
@@ -130,7 +167,34 @@ func NewServices(appCtx *appcontext.AppContext) (*Services, error) {
 	servicesObj.SpendTracking = spendTrackingService
 
 	// Initialize wishlist service
-	wishlistService, err := wishlist.NewGameWishlistService(appCtx)
+
+	// Create wishlist cache adapter
+	wishlistCacheAdapter, err := wishlist.NewWishlistCacheAdapter(cacheWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("initializing wishlist cache adapter: %w", err)
+	}
+
+	// Create wishlist db adapter
+	wishlistDbAdapter, err := wishlist.NewWishlistDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing wishlist db adapter: %w", err)
+	}
+
+	// Wishlist budget suggestions read this month's spending from spend tracking
+	spendTrackingCalculator, err := spend_tracking.NewSpendTrackingCalculator(appCtx, spendTrackingDbAdapter)
+	if err != nil {
+		return nil, fmt.Errorf("initializing spend tracking calculator: %w", err)
+	}
+
+	wishlistService, err := wishlist.NewGameWishlistService(
+		appCtx,
+		wishlistDbAdapter,
+		wishlistCacheAdapter,
+		dashboardCacheAdapter,
+		libraryCacheAdapter,
+		spendTrackingCacheAdapter,
+		spendTrackingCalculator,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing wishlist service: %w", err)
 	}
@@ -150,6 +214,268 @@ func NewServices(appCtx *appcontext.AppContext) (*Services, error) {
 	}
 	servicesObj.Analytics = analyticsService
 
+	// One IGDB adapter is shared by the import, barcodes, the background jobs and add-ons,
+	// so a token refreshed by one of them is used by all of them
+	igdbAdapter, err := search.NewIGDBAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing IGDB adapter: %w", err)
+	}
+
+	// Initialize library import service
+	libraryImportDbAdapter, err := library_import.NewLibraryImportDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing library import db adapter: %w", err)
+	}
+
+	libraryImportService, err := library_import.NewGameLibraryImportService(
+		appCtx,
+		libraryImportDbAdapter,
+		igdbAdapter,
+		libraryService,
+		digitalService,
+		analyticsService,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing library import service: %w", err)
+	}
+	servicesObj.LibraryImport = libraryImportService
+	servicesObj.libraryImportService = libraryImportService
+
+	// Initialize barcodes service, scan sessions add their games through the library import
+	barcodesDbAdapter, err := barcodes.NewBarcodesDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing barcodes db adapter: %w", err)
+	}
+
+	barcodesService, err := barcodes.NewGameBarcodesService(
+		appCtx,
+		barcodesDbAdapter,
+		igdbAdapter,
+		libraryService,
+		libraryImportService,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing barcodes service: %w", err)
+	}
+	servicesObj.Barcodes = barcodesService
+
+	// Initialize game metadata refresh, a background job keeps the games table in line with IGDB
+	gameMetadataDbAdapter, err := game_metadata.NewGameMetadataDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing game metadata db adapter: %w", err)
+	}
+
+	gameMetadataService, err := game_metadata.NewGameMetadataRefreshService(
+		appCtx,
+		gameMetadataDbAdapter,
+		igdbAdapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing game metadata refresh service: %w", err)
+	}
+	servicesObj.gameMetadataService = gameMetadataService
+
+	// Initialize platform sync, a background job fills the platforms table from IGDB's platform taxonomy
+	platformsDbAdapter, err := platforms.NewPlatformsDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing platforms db adapter: %w", err)
+	}
+
+	platformSyncService, err := platforms.NewPlatformSyncService(
+		appCtx,
+		platformsDbAdapter,
+		igdbAdapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing platform sync service: %w", err)
+	}
+	servicesObj.platformSyncService = platformSyncService
+
+	// Initialize add-ons service, a game's DLC and expansions are fetched from IGDB when it is viewed
+	addonsDbAdapter, err := addons.NewAddonsDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing addons db adapter: %w", err)
+	}
+
+	addonsService, err := addons.NewGameAddonsService(
+		appCtx,
+		addonsDbAdapter,
+		igdbAdapter,
+		spendTrackingCacheAdapter,
+		dashboardCacheAdapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing addons service: %w", err)
+	}
+	servicesObj.Addons = addonsService
+
+	// Initialize tags and collections service
+	collectionsDbAdapter, err := collections.NewCollectionsDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing collections db adapter: %w", err)
+	}
+
+	collectionsService, err := collections.NewGameCollectionsService(appCtx, collectionsDbAdapter, analyticsService)
+	if err != nil {
+		return nil, fmt.Errorf("initializing collections service: %w", err)
+	}
+	servicesObj.Collections = collectionsService
+
+	// Initialize data export service
+	if appCtx.Config.Export == nil {
+		return nil, fmt.Errorf("export configuration is required")
+	}
+
+	blobStore, err := blobstore.NewLocalBlobStore(
+		appCtx.Config.Export.StorageDir,
+		appCtx.Config.Export.PublicBaseURL,
+		appCtx.Config.Export.SigningKey,
+		appCtx.Logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing blob store: %w", err)
+	}
+	servicesObj.BlobStore = blobStore
+
+	dataExportDbAdapter, err := data_export.NewDataExportDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing data export db adapter: %w", err)
+	}
+
+	// Email is optional outside of production, exports are still available from their status endpoint.
+	// emailQueue stays a nil interface without email, so services can check it against nil.
+	var emailQueue email.JobEnqueuer
+	emailService, err := email.NewResendEmailService(appCtx)
+	if err != nil {
+		appCtx.Logger.Warn("Email service unavailable, emails are disabled", map[string]any{
+			"error": err,
+		})
+	} else {
+		servicesObj.emailQueue = email.NewEmailQueue(appCtx, emailService, 0, 0)
+		emailQueue = servicesObj.emailQueue
+	}
+
+	dataExportService, err := data_export.NewUserDataExportService(
+		appCtx,
+		dataExportDbAdapter,
+		blobStore,
+		emailQueue,
+		appCtx.Config.Export.LinkTTL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing data export service: %w", err)
+	}
+	servicesObj.DataExport = dataExportService
+	servicesObj.dataExportService = dataExportService
+
+	// Initialize loans service, overdue reminders need the email queue
+	loansDbAdapter, err := loans.NewLoansDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing loans db adapter: %w", err)
+	}
+
+	loansService, err := loans.NewGameLoansService(appCtx, loansDbAdapter, libraryCacheAdapter, emailQueue)
+	if err != nil {
+		return nil, fmt.Errorf("initializing loans service: %w", err)
+	}
+	servicesObj.Loans = loansService
+	servicesObj.loansService = loansService
+
+	// Initialize wishlist price checks, turned off when no price provider is configured
+	priceProvider, err := pricing.NewPriceProvider(appCtx.Config.Pricing)
+	if err != nil {
+		return nil, fmt.Errorf("initializing price provider: %w", err)
+	}
+	if priceProvider == nil {
+		appCtx.Logger.Warn("No price provider configured, wishlist price checks are disabled", nil)
+	} else {
+		priceCheckService, err := wishlist.NewWishlistPriceCheckService(
+			appCtx,
+			wishlistDbAdapter,
+			wishlistCacheAdapter,
+			dashboardCacheAdapter,
+			priceProvider,
+			emailQueue,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing wishlist price checks: %w", err)
+		}
+		servicesObj.priceCheckService = priceCheckService
+	}
+
+	// Initialize wishlist release reminders, they need the email queue
+	releaseReminders, err := wishlist.NewWishlistReleaseReminderService(appCtx, wishlistDbAdapter, emailQueue)
+	if err != nil {
+		return nil, fmt.Errorf("initializing wishlist release reminders: %w", err)
+	}
+	servicesObj.releaseReminders = releaseReminders
+
+	// Initialize data restore service
+	dataRestoreDbAdapter, err := data_restore.NewDataRestoreDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing data restore db adapter: %w", err)
+	}
+
+	physicalCacheAdapter, err := physical.NewPhysicalCacheAdapter(cacheWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("initializing physical cache adapter: %w", err)
+	}
+
+	sublocationCacheAdapter, err := sublocation.NewSublocationCacheAdapter(cacheWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("initializing sublocation cache adapter: %w", err)
+	}
+
+	digitalCacheAdapter, err := digital.NewDigitalCacheAdapter(cacheWrapper)
+	if err != nil {
+		return nil, fmt.Errorf("initializing digital cache adapter: %w", err)
+	}
+
+	dataRestoreService, err := data_restore.NewUserDataRestoreService(
+		appCtx,
+		dataRestoreDbAdapter,
+		analyticsService,
+		physicalCacheAdapter,
+		sublocationCacheAdapter,
+		digitalCacheAdapter,
+		libraryCacheAdapter,
+		spendTrackingCacheAdapter,
+		dashboardCacheAdapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing data restore service: %w", err)
+	}
+	servicesObj.DataRestore = dataRestoreService
+
+	// Initialize trash service, a purge job removes deleted items after the retention period
+	trashDbAdapter, err := trash.NewTrashDbAdapter(appCtx)
+	if err != nil {
+		return nil, fmt.Errorf("initializing trash db adapter: %w", err)
+	}
+
+	trashRetentionDays := 0
+	if appCtx.Config.Trash != nil {
+		trashRetentionDays = appCtx.Config.Trash.RetentionDays
+	}
+
+	trashService, err := trash.NewGameTrashService(
+		appCtx,
+		trashDbAdapter,
+		analyticsService,
+		trashRetentionDays,
+		physicalCacheAdapter,
+		sublocationCacheAdapter,
+		digitalCacheAdapter,
+		libraryCacheAdapter,
+		spendTrackingCacheAdapter,
+		dashboardCacheAdapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing trash service: %w", err)
+	}
+	servicesObj.Trash = trashService
+	servicesObj.trashService = trashService
+
 	// Initialize dashboard service
 	dashboardDbAdapter, err := dashboard.NewDashboardDbAdapter(appCtx)
 	if err != nil {
@@ -167,4 +493,129 @@ func NewServices(appCtx *appcontext.AppContext) (*Services, error) {
 	servicesObj.Dashboard = dashboardService
 
 	return servicesObj, nil
-}
\ No newline at end of file
+}
+
+// StartBackgroundJobs starts the queues that process work outside of a request.
+// Every job is started even when one fails, the failures are returned together.
+func (s *Services) StartBackgroundJobs(ctx context.Context) error {
+	var errs []error
+
+	// Email first, the other queues hand work to it
+	if s.emailQueue != nil {
+		if err := s.emailQueue.Start(); err != nil {
+			errs = append(errs, fmt.Errorf("starting email queue: %w", err))
+		}
+	}
+
+	if s.libraryImportService != nil {
+		if err := s.libraryImportService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting library import queue: %w", err))
+		}
+	}
+
+	if s.dataExportService != nil {
+		if err := s.dataExportService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting data export queue: %w", err))
+		}
+	}
+
+	if s.loansService != nil {
+		if err := s.loansService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting loan reminders: %w", err))
+		}
+	}
+
+	if s.trashService != nil {
+		if err := s.trashService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting trash purge: %w", err))
+		}
+	}
+
+	if s.gameMetadataService != nil {
+		if err := s.gameMetadataService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting game metadata refresh: %w", err))
+		}
+	}
+
+	if s.platformSyncService != nil {
+		if err := s.platformSyncService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting platform sync: %w", err))
+		}
+	}
+
+	if s.priceCheckService != nil {
+		if err := s.priceCheckService.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting wishlist price checks: %w", err))
+		}
+	}
+
+	if s.releaseReminders != nil {
+		if err := s.releaseReminders.Start(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("starting wishlist release reminders: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// StopBackgroundJobs stops everything started by StartBackgroundJobs.
+// Every job is stopped even when one fails, the failures are returned together.
+func (s *Services) StopBackgroundJobs() error {
+	var errs []error
+
+	if s.libraryImportService != nil {
+		if err := s.libraryImportService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping library import queue: %w", err))
+		}
+	}
+
+	if s.dataExportService != nil {
+		if err := s.dataExportService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping data export queue: %w", err))
+		}
+	}
+
+	if s.loansService != nil {
+		if err := s.loansService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping loan reminders: %w", err))
+		}
+	}
+
+	if s.trashService != nil {
+		if err := s.trashService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping trash purge: %w", err))
+		}
+	}
+
+	if s.gameMetadataService != nil {
+		if err := s.gameMetadataService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping game metadata refresh: %w", err))
+		}
+	}
+
+	if s.platformSyncService != nil {
+		if err := s.platformSyncService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping platform sync: %w", err))
+		}
+	}
+
+	if s.priceCheckService != nil {
+		if err := s.priceCheckService.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping wishlist price checks: %w", err))
+		}
+	}
+
+	if s.releaseReminders != nil {
+		if err := s.releaseReminders.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping wishlist release reminders: %w", err))
+		}
+	}
+
+	if s.emailQueue != nil {
+		if err := s.emailQueue.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("stopping email queue: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -31,6 +32,9 @@ type Config struct {
 	Redis  RedisConfig
 	Postgres *PostgresConfig
 	Email  *EmailConfig
+	Export *ExportConfig
+	Trash  *TrashConfig
+	Pricing *PricingConfig
 	HealthStatus string
 	Auth0  Auth0Config
 }
@@ -83,6 +87,27 @@ type EmailConfig struct {
 	TemplateDir  string
 }
 
+// ExportConfig controls where data export archives are kept and how download links are signed
+type ExportConfig struct {
+	StorageDir    string
+	SigningKey    string
+	PublicBaseURL string
+	LinkTTL       time.Duration
+}
+
+// TrashConfig controls how long deleted items can be restored before they are purged
+type TrashConfig struct {
+	RetentionDays int
+}
+
+// PricingConfig picks where wishlist prices come from, an empty Provider turns price checks off
+type PricingConfig struct {
+	Provider      string
+	FilePath      string
+	BaseURL       string
+	CheckInterval time.Duration
+}
+
 func Load() (*Config, error) {
 	env := os.Getenv(EnvEnvironment)
 
@@ -180,6 +205,27 @@ func Load() (*Config, error) {
 		TemplateDir:  "internal/email/templates",
 	}
 
+	// Data Export Configuration
+	exportConfig := &ExportConfig{
+		StorageDir:    getEnvOrDefault(EnvExportStorageDir, filepath.Join(os.TempDir(), "qko-exports")),
+		SigningKey:    os.Getenv(EnvExportSigningKey),
+		PublicBaseURL: strings.TrimSuffix(getEnvOrDefault(EnvExportPublicBaseURL, fmt.Sprintf("http://%s:%d", host, port)), "/"),
+		LinkTTL:       DefaultExportLinkTTL,
+	}
+
+	// Trash Configuration
+	trashConfig := &TrashConfig{
+		RetentionDays: getEnvIntOrDefault(EnvTrashRetentionDays, DefaultTrashRetentionDays),
+	}
+
+	// Pricing Configuration
+	pricingConfig := &PricingConfig{
+		Provider:      os.Getenv(EnvPriceProvider),
+		FilePath:      os.Getenv(EnvPriceFilePath),
+		BaseURL:       strings.TrimSuffix(os.Getenv(EnvPriceAPIBaseURL), "/"),
+		CheckInterval: time.Duration(getEnvIntOrDefault(EnvPriceCheckIntervalHours, DefaultPriceCheckIntervalHours)) * time.Hour,
+	}
+
 	auth0Config := Auth0Config{
 		Domain:              os.Getenv("AUTH0_DOMAIN"),
 		ClientID:            os.Getenv("AUTH0_CLIENT_ID"),
@@ -200,6 +246,9 @@ func Load() (*Config, error) {
 		Redis:        redisConfig,
 		Postgres:     postgresConfig,
 		Email:        emailConfig,
+		Export:       exportConfig,
+		Trash:        trashConfig,
+		Pricing:      pricingConfig,
 		HealthStatus: healthStatus,
 		Auth0:        auth0Config,
 	}, nil
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // Environment Variables
 const (
 	// Server
@@ -20,6 +22,20 @@ const (
 	EnvResendAPIKey    = "RESEND_API_KEY"
 	EnvEmailFromAddress = "EMAIL_FROM_ADDRESS"
 	EnvEmailFromName   = "EMAIL_FROM_NAME"
+
+	// Data exports
+	EnvExportStorageDir    = "EXPORT_STORAGE_DIR"
+	EnvExportSigningKey    = "EXPORT_SIGNING_KEY"
+	EnvExportPublicBaseURL = "EXPORT_PUBLIC_BASE_URL"
+
+	// Trash
+	EnvTrashRetentionDays = "TRASH_RETENTION_DAYS"
+
+	// Wishlist price checks
+	EnvPriceProvider           = "PRICE_PROVIDER"
+	EnvPriceFilePath           = "PRICE_FILE_PATH"
+	EnvPriceAPIBaseURL         = "PRICE_API_BASE_URL"
+	EnvPriceCheckIntervalHours = "PRICE_CHECK_INTERVAL_HOURS"
 )
 
 // IGDB API endpoints
@@ -39,4 +55,19 @@ const (
 const (
 	DefaultPort = 8000
 	DefaultHost = "localhost"
+
+	// Matches the expiry promised in the data export email
+	DefaultExportLinkTTL = 7 * 24 * time.Hour
+
+	// Deleted items stay in the trash this long before they are purged
+	DefaultTrashRetentionDays = 30
+
+	// Wishlist prices are checked this often
+	DefaultPriceCheckIntervalHours = 6
+)
+
+// Price providers
+const (
+	PriceProviderFile = "file"
+	PriceProviderHTTP = "http"
 )
\ No newline at end of file
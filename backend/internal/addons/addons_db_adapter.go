@@ -0,0 +1,313 @@
+package addons
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type AddonsDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewAddonsDbAdapter(appContext *appcontext.AppContext) (*AddonsDbAdapter, error) {
+	appContext.Logger.Debug("Creating AddonsDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &AddonsDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetAddonsSyncedAt returns when the game's add-ons were last fetched, null if they never were. Returns:
+//   - ErrGameNotInLibrary if the user has no active copy of the game
+func (aa *AddonsDbAdapter) GetAddonsSyncedAt(ctx context.Context, userID string, gameID int64) (sql.NullTime, error) {
+	var syncedAt sql.NullTime
+	if err := aa.db.GetContext(ctx, &syncedAt, GetAddonsSyncedAtQuery, userID, gameID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullTime{}, ErrGameNotInLibrary
+		}
+		return sql.NullTime{}, fmt.Errorf("error getting add-ons sync time: %w", err)
+	}
+
+	return syncedAt, nil
+}
+
+// PUT
+// SaveGameAddons stores the add-ons fetched for a game and stamps when they were fetched
+func (aa *AddonsDbAdapter) SaveGameAddons(
+	ctx context.Context,
+	gameID int64,
+	addons []models.GameAddon,
+	syncedAt time.Time,
+) error {
+	return postgres.WithTransaction(ctx, aa.db, aa.logger, func(tx *sqlx.Tx) error {
+		if len(addons) > 0 {
+			ids := make([]int64, len(addons))
+			names := make([]string, len(addons))
+			addonTypes := make([]string, len(addons))
+			coverURLs := make([]string, len(addons))
+			releaseDates := make([]int64, len(addons))
+			for i, addon := range addons {
+				ids[i] = addon.ID
+				names[i] = addon.Name
+				addonTypes[i] = addon.AddonType
+				coverURLs[i] = addon.CoverURL
+				releaseDates[i] = addon.FirstReleaseDate
+			}
+
+			_, err := tx.ExecContext(
+				ctx,
+				UpsertGameAddonsQuery,
+				gameID,
+				pq.Array(ids),
+				pq.Array(names),
+				pq.Array(addonTypes),
+				pq.Array(coverURLs),
+				pq.Array(releaseDates),
+			)
+			if err != nil {
+				return fmt.Errorf("error saving game add-ons: %w", err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, MarkAddonsSyncedQuery, gameID, syncedAt); err != nil {
+			return fmt.Errorf("error marking game add-ons synced: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GET
+// GetGameAddons retrieves a game's add-ons along with whether the user owns them
+func (aa *AddonsDbAdapter) GetGameAddons(ctx context.Context, userID string, gameID int64) ([]models.GameAddonDB, error) {
+	var addons []models.GameAddonDB
+	if err := aa.db.SelectContext(ctx, &addons, GetGameAddonsQuery, userID, gameID); err != nil {
+		return nil, fmt.Errorf("error getting game add-ons: %w", err)
+	}
+
+	return addons, nil
+}
+
+// GET
+// GetGameEditions retrieves the user's active copies of a game with their editions and contents
+func (aa *AddonsDbAdapter) GetGameEditions(ctx context.Context, userID string, gameID int64) ([]models.GameEditionDB, error) {
+	var editions []models.GameEditionDB
+	if err := aa.db.SelectContext(ctx, &editions, GetGameEditionsQuery, userID, gameID); err != nil {
+		return nil, fmt.Errorf("error getting game editions: %w", err)
+	}
+	if len(editions) == 0 {
+		return editions, nil
+	}
+
+	if err := aa.attachEditionContents(ctx, aa.db, editions); err != nil {
+		return nil, err
+	}
+
+	return editions, nil
+}
+
+// POST
+// CreateOwnedAddon records an add-on the user bought, linking its purchase as a DLC purchase. Returns:
+//   - ErrAddonNotFound if the add-on isn't one of a game in the user's library
+//   - ErrValidationFailed if the platform is unknown
+//   - ErrPurchaseNotFound if the purchase isn't one of the user's
+//   - ErrAddonAlreadyOwned if the add-on is already recorded on that platform
+func (aa *AddonsDbAdapter) CreateOwnedAddon(
+	ctx context.Context,
+	userID string,
+	addon models.OwnedAddonToSave,
+) (models.OwnedAddonDB, error) {
+	var created models.OwnedAddonDB
+	err := postgres.WithTransaction(ctx, aa.db, aa.logger, func(tx *sqlx.Tx) error {
+		var parentGameID int64
+		if err := tx.GetContext(ctx, &parentGameID, GetAddonInLibraryQuery, userID, addon.AddonID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAddonNotFound
+			}
+			return fmt.Errorf("error checking add-on: %w", err)
+		}
+
+		var platformExists bool
+		if err := tx.GetContext(ctx, &platformExists, CheckPlatformExistsQuery, addon.PlatformID); err != nil {
+			return fmt.Errorf("error checking platform: %w", err)
+		}
+		if !platformExists {
+			return fmt.Errorf("%w: unknown platform %d", ErrValidationFailed, addon.PlatformID)
+		}
+
+		if addon.PurchaseID != nil {
+			result, err := tx.ExecContext(ctx, LinkDLCPurchaseQuery, userID, *addon.PurchaseID)
+			if err != nil {
+				return fmt.Errorf("error linking DLC purchase: %w", err)
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error checking linked DLC purchase: %w", err)
+			}
+			if rowsAffected == 0 {
+				return ErrPurchaseNotFound
+			}
+		}
+
+		var ownedAddonID int64
+		err := tx.GetContext(
+			ctx,
+			&ownedAddonID,
+			InsertOwnedAddonQuery,
+			userID,
+			addon.AddonID,
+			addon.PlatformID,
+			addon.PurchaseID,
+			addon.AcquiredDate,
+			addon.Notes,
+		)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAddonAlreadyOwned
+			}
+			return fmt.Errorf("error recording owned add-on: %w", err)
+		}
+
+		if err := tx.GetContext(ctx, &created, GetOwnedAddonQuery, userID, ownedAddonID); err != nil {
+			return fmt.Errorf("error reading owned add-on: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.OwnedAddonDB{}, err
+	}
+
+	return created, nil
+}
+
+// DELETE
+// DeleteOwnedAddon removes an add-on from the user's collection, a linked purchase keeps its DLC category. Returns:
+//   - ErrOwnedAddonNotFound if it isn't the user's
+func (aa *AddonsDbAdapter) DeleteOwnedAddon(ctx context.Context, userID string, ownedAddonID int64) error {
+	result, err := aa.db.ExecContext(ctx, DeleteOwnedAddonQuery, userID, ownedAddonID)
+	if err != nil {
+		return fmt.Errorf("error deleting owned add-on: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking deleted owned add-on: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrOwnedAddonNotFound
+	}
+
+	return nil
+}
+
+// PUT
+// UpdateEdition sets the edition of a copy and replaces what came with it. Returns:
+//   - ErrCopyNotFound if the copy isn't in the user's library
+//   - ErrValidationFailed if a content's add-on isn't one of the copy's game
+func (aa *AddonsDbAdapter) UpdateEdition(
+	ctx context.Context,
+	userID string,
+	edition models.EditionToSave,
+) (models.GameEditionDB, error) {
+	var updated models.GameEditionDB
+	err := postgres.WithTransaction(ctx, aa.db, aa.logger, func(tx *sqlx.Tx) error {
+		var gameID int64
+		if err := tx.GetContext(ctx, &gameID, UpdateCopyEditionQuery, userID, edition.UserGameID, edition.Edition); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCopyNotFound
+			}
+			return fmt.Errorf("error updating copy edition: %w", err)
+		}
+
+		names := make([]string, len(edition.Contents))
+		addonIDs := make([]sql.NullInt64, len(edition.Contents))
+		var linkedAddonIDs []int64
+		for i, content := range edition.Contents {
+			names[i] = content.Name
+			if content.AddonID != nil {
+				addonIDs[i] = sql.NullInt64{Int64: *content.AddonID, Valid: true}
+				linkedAddonIDs = append(linkedAddonIDs, *content.AddonID)
+			}
+		}
+
+		if len(linkedAddonIDs) > 0 {
+			var found int
+			if err := tx.GetContext(ctx, &found, CountGameAddonsQuery, gameID, pq.Array(linkedAddonIDs)); err != nil {
+				return fmt.Errorf("error checking edition add-ons: %w", err)
+			}
+			if found != len(linkedAddonIDs) {
+				return fmt.Errorf("%w: edition contents include an add-on of another game", ErrValidationFailed)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, DeleteEditionContentsQuery, edition.UserGameID); err != nil {
+			return fmt.Errorf("error clearing edition contents: %w", err)
+		}
+		if len(edition.Contents) > 0 {
+			if _, err := tx.ExecContext(ctx, InsertEditionContentsQuery, edition.UserGameID, pq.Array(names), pq.Array(addonIDs)); err != nil {
+				return fmt.Errorf("error saving edition contents: %w", err)
+			}
+		}
+
+		if err := tx.GetContext(ctx, &updated, GetGameEditionQuery, userID, edition.UserGameID); err != nil {
+			return fmt.Errorf("error reading copy edition: %w", err)
+		}
+
+		editions := []models.GameEditionDB{updated}
+		if err := aa.attachEditionContents(ctx, tx, editions); err != nil {
+			return err
+		}
+		updated = editions[0]
+
+		return nil
+	})
+	if err != nil {
+		return models.GameEditionDB{}, err
+	}
+
+	return updated, nil
+}
+
+// attachEditionContents fills in the contents of each copy's edition
+func (aa *AddonsDbAdapter) attachEditionContents(
+	ctx context.Context,
+	q sqlx.QueryerContext,
+	editions []models.GameEditionDB,
+) error {
+	userGameIDs := make([]int64, len(editions))
+	byUserGameID := make(map[int64]int, len(editions))
+	for i, edition := range editions {
+		userGameIDs[i] = edition.UserGameID
+		byUserGameID[edition.UserGameID] = i
+		editions[i].Contents = []models.EditionContentDB{}
+	}
+
+	var contents []models.EditionContentDB
+	if err := sqlx.SelectContext(ctx, q, &contents, GetEditionContentsQuery, pq.Array(userGameIDs)); err != nil {
+		return fmt.Errorf("error getting edition contents: %w", err)
+	}
+
+	for _, content := range contents {
+		i := byUserGameID[content.UserGameID]
+		editions[i].Contents = append(editions[i].Contents, content)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Recording owned add-ons and editions
+
+	Scenarios:
+	- CreateOwnedAddon files a linked purchase under DLC spending
+	- CreateOwnedAddon returns ErrPurchaseNotFound for another user's purchase
+	- CreateOwnedAddon returns ErrAddonAlreadyOwned for an add-on recorded on the same platform
+	- UpdateEdition rejects add-ons of another game
+*/
+
+func TestAddonsDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	purchaseID := int64(12)
+
+	setupMockDB := func() (*AddonsDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &AddonsDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	addon := models.OwnedAddonToSave{
+		AddonID:    2680,
+		PlatformID: 6,
+		PurchaseID: &purchaseID,
+	}
+
+	expectAddonChecks := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT ga.parent_game_id FROM game_addons").
+			WithArgs(userID, addon.AddonID).
+			WillReturnRows(sqlmock.NewRows([]string{"parent_game_id"}).AddRow(1942))
+		mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM platforms").
+			WithArgs(addon.PlatformID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	}
+
+	/*
+		GIVEN an add-on of a library game bought with one of the user's purchases
+		WHEN CreateOwnedAddon is called
+		THEN the purchase should move to the dlc spending category
+		AND the add-on should be recorded with the purchase
+	*/
+	t.Run("CreateOwnedAddon links the purchase as DLC spending", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		expectAddonChecks(mock)
+		mock.ExpectExec("UPDATE one_time_purchases SET spending_category_id = \\(SELECT id FROM spending_categories WHERE media_type = 'dlc'").
+			WithArgs(userID, purchaseID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("INSERT INTO user_game_addons").
+			WithArgs(userID, addon.AddonID, addon.PlatformID, &purchaseID, addon.AcquiredDate, addon.Notes).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectQuery("SELECT uga.id").
+			WithArgs(userID, int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "addon_id", "addon_name", "addon_type", "parent_game_id", "platform_id", "platform_name", "purchase_id",
+			}).AddRow(3, addon.AddonID, "Hearts of Stone", models.AddonTypeExpansion, 1942, addon.PlatformID, "PC", purchaseID))
+		mock.ExpectCommit()
+
+		// WHEN
+		created, err := adapter.CreateOwnedAddon(context.Background(), userID, addon)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if created.ID != 3 || created.PurchaseID.Int64 != purchaseID {
+			t.Errorf("Expected owned add-on 3 with purchase %d, got %+v", purchaseID, created)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a purchase that isn't one of the user's
+		WHEN CreateOwnedAddon is called
+		THEN it should return ErrPurchaseNotFound without recording the add-on
+	*/
+	t.Run("CreateOwnedAddon rejects unknown purchases", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		expectAddonChecks(mock)
+		mock.ExpectExec("UPDATE one_time_purchases").
+			WithArgs(userID, purchaseID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.CreateOwnedAddon(context.Background(), userID, addon)
+
+		// THEN
+		if !errors.Is(err, ErrPurchaseNotFound) {
+			t.Errorf("Expected ErrPurchaseNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN an add-on already recorded on the same platform
+		WHEN CreateOwnedAddon is called
+		THEN it should return ErrAddonAlreadyOwned
+	*/
+	t.Run("CreateOwnedAddon rejects add-ons owned on the platform", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		withoutPurchase := addon
+		withoutPurchase.PurchaseID = nil
+		expectAddonChecks(mock)
+		mock.ExpectQuery("INSERT INTO user_game_addons").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.CreateOwnedAddon(context.Background(), userID, withoutPurchase)
+
+		// THEN
+		if !errors.Is(err, ErrAddonAlreadyOwned) {
+			t.Errorf("Expected ErrAddonAlreadyOwned, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN edition contents listing an add-on that isn't one of the copy's game
+		WHEN UpdateEdition is called
+		THEN it should return ErrValidationFailed without replacing the contents
+	*/
+	t.Run("UpdateEdition rejects add-ons of another game", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		otherAddonID := int64(99)
+		edition := models.EditionToSave{
+			UserGameID: 7,
+			Edition:    "Collector's Edition",
+			Contents: []models.EditionContentToSave{
+				{Name: "Art book"},
+				{Name: "Someone else's DLC", AddonID: &otherAddonID},
+			},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("UPDATE user_games SET edition").
+			WithArgs(userID, edition.UserGameID, edition.Edition).
+			WillReturnRows(sqlmock.NewRows([]string{"game_id"}).AddRow(1942))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM game_addons").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.UpdateEdition(context.Background(), userID, edition)
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
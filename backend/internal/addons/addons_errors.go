@@ -0,0 +1,38 @@
+package addons
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrGameNotInLibrary   = errors.New("game not found in library")
+	ErrAddonNotFound      = errors.New("add-on not found for a game in library")
+	ErrOwnedAddonNotFound = errors.New("owned add-on not found")
+	ErrCopyNotFound       = errors.New("game copy not found in library")
+	ErrPurchaseNotFound   = errors.New("purchase not found")
+	ErrAddonAlreadyOwned  = errors.New("add-on is already owned on this platform")
+	ErrValidationFailed   = errors.New("validation failed")
+	ErrDatabaseError      = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrGameNotInLibrary),
+		errors.Is(err, ErrAddonNotFound),
+		errors.Is(err, ErrOwnedAddonNotFound),
+		errors.Is(err, ErrCopyNotFound),
+		errors.Is(err, ErrPurchaseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrAddonAlreadyOwned):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,196 @@
+package addons
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// RegisterAddonRoutes registers DLC, expansion and edition tracking under /library/addons
+func RegisterAddonRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	addonsService services.AddonsService,
+) {
+	r.Get("/games/{gameID}", GetGameAddons(appCtx, addonsService))
+	r.Post("/", CreateOwnedAddon(appCtx, addonsService))
+	r.Delete("/{ownedAddonID}", DeleteOwnedAddon(appCtx, addonsService))
+	r.Put("/editions/{userGameID}", UpdateEdition(appCtx, addonsService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the user from the request context, logging and responding when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, requestID string) (string, bool) {
+	userID := httputils.GetUserID(r)
+	if userID == "" {
+		appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+			"request_id": requestID,
+		})
+		handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+		return "", false
+	}
+	return userID, true
+}
+
+// helper fn to read a numeric path parameter
+func parseIDParam(r *http.Request, param string) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid %s", ErrValidationFailed, param)
+	}
+	return id, nil
+}
+
+// helper fn to decode a JSON body into a request type, an empty body leaves the request untouched
+func decodeRequest(r *http.Request, request any) error {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: invalid request body", ErrValidationFailed)
+	}
+	return nil
+}
+
+// helper fn to write a successful response
+func respond(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, userID string, status int, data map[string]any) {
+	response := httputils.NewAPIResponse(r, userID, data)
+
+	httputils.RespondWithJSON(
+		httputils.NewResponseWriterAdapter(w),
+		appCtx.Logger,
+		status,
+		response,
+	)
+}
+
+// GetGameAddons handles GET requests for a library game's owned and missing add-ons
+func GetGameAddons(appCtx *appcontext.AppContext, addonsService services.AddonsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		gameID, err := parseIDParam(r, "gameID")
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		addons, err := addonsService.GetGameAddons(r.Context(), userID, gameID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"addons": addons})
+	}
+}
+
+// CreateOwnedAddon handles POST requests that record an add-on the user bought
+func CreateOwnedAddon(appCtx *appcontext.AppContext, addonsService services.AddonsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.CreateOwnedAddonRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		addon, err := addonsService.CreateOwnedAddon(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusCreated, map[string]any{"addon": addon})
+	}
+}
+
+// DeleteOwnedAddon handles DELETE requests that remove an add-on from the user's collection
+func DeleteOwnedAddon(appCtx *appcontext.AppContext, addonsService services.AddonsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		ownedAddonID, err := parseIDParam(r, "ownedAddonID")
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		if err := addonsService.DeleteOwnedAddon(r.Context(), userID, ownedAddonID); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{
+			"id":      ownedAddonID,
+			"message": "add-on removed",
+		})
+	}
+}
+
+// UpdateEdition handles PUT requests that set the edition of a copy and what came with it
+func UpdateEdition(appCtx *appcontext.AppContext, addonsService services.AddonsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		userGameID, err := parseIDParam(r, "userGameID")
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		var request types.UpdateEditionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		edition, err := addonsService.UpdateEdition(r.Context(), userID, userGameID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"edition": edition})
+	}
+}
@@ -0,0 +1,182 @@
+package addons
+
+// A game's add-ons are only shown to users with an active copy of it, every query below takes the user ID as $1.
+const (
+	GetAddonsSyncedAtQuery = `
+		SELECT g.addons_synced_at
+		FROM games g
+		WHERE g.id = $2
+		AND EXISTS (
+			SELECT 1
+			FROM user_games ug
+			WHERE ug.user_id = $1 AND ug.game_id = g.id AND ug.deleted_at IS NULL
+		)
+	`
+
+	// An add-on bought on several platforms is listed once, with the first one recorded.
+	// An add-on that came with an edition is listed with the first copy that has it.
+	GetGameAddonsQuery = `
+		SELECT
+			ga.id,
+			ga.parent_game_id,
+			ga.name,
+			ga.addon_type,
+			COALESCE(ga.cover_url, '') AS cover_url,
+			COALESCE(ga.first_release_date, 0) AS first_release_date,
+			owned.id AS owned_addon_id,
+			owned.platform_name,
+			owned.purchase_id,
+			owned.acquired_date,
+			included.edition AS included_in_edition
+		FROM game_addons ga
+		LEFT JOIN LATERAL (
+			SELECT uga.id, p.name AS platform_name, uga.purchase_id, uga.acquired_date
+			FROM user_game_addons uga
+			JOIN platforms p ON p.id = uga.platform_id
+			WHERE uga.addon_id = ga.id AND uga.user_id = $1
+			ORDER BY uga.created_at, uga.id
+			LIMIT 1
+		) owned ON true
+		LEFT JOIN LATERAL (
+			SELECT ug.edition
+			FROM user_game_edition_contents ugec
+			JOIN user_games ug ON ug.id = ugec.user_game_id
+			WHERE ugec.addon_id = ga.id AND ug.user_id = $1 AND ug.deleted_at IS NULL
+			ORDER BY ug.id
+			LIMIT 1
+		) included ON true
+		WHERE ga.parent_game_id = $2
+		ORDER BY ga.first_release_date NULLS LAST, ga.name
+	`
+
+	GetGameEditionsQuery = `
+		SELECT ug.id AS user_game_id, p.name AS platform_name, ug.game_type, ug.edition
+		FROM user_games ug
+		JOIN platforms p ON p.id = ug.platform_id
+		WHERE ug.user_id = $1 AND ug.game_id = $2 AND ug.deleted_at IS NULL
+		ORDER BY ug.id
+	`
+
+	GetGameEditionQuery = `
+		SELECT ug.id AS user_game_id, p.name AS platform_name, ug.game_type, ug.edition
+		FROM user_games ug
+		JOIN platforms p ON p.id = ug.platform_id
+		WHERE ug.user_id = $1 AND ug.id = $2 AND ug.deleted_at IS NULL
+	`
+
+	GetEditionContentsQuery = `
+		SELECT user_game_id, name, addon_id
+		FROM user_game_edition_contents
+		WHERE user_game_id = ANY($1)
+		ORDER BY id
+	`
+)
+
+// Add-ons fetched from IGDB are shared by every user, a sync replaces their details and stamps the game
+const (
+	UpsertGameAddonsQuery = `
+		INSERT INTO game_addons (id, parent_game_id, name, addon_type, cover_url, first_release_date)
+		SELECT addon.id, $1, addon.name, addon.addon_type, NULLIF(addon.cover_url, ''), NULLIF(addon.first_release_date, 0)
+		FROM UNNEST($2::bigint[], $3::text[], $4::text[], $5::text[], $6::bigint[])
+			AS addon(id, name, addon_type, cover_url, first_release_date)
+		ON CONFLICT (id) DO UPDATE SET
+			parent_game_id = EXCLUDED.parent_game_id,
+			name = EXCLUDED.name,
+			addon_type = EXCLUDED.addon_type,
+			cover_url = EXCLUDED.cover_url,
+			first_release_date = EXCLUDED.first_release_date,
+			updated_at = NOW()
+	`
+
+	MarkAddonsSyncedQuery = `
+		UPDATE games
+		SET addons_synced_at = $2
+		WHERE id = $1
+	`
+)
+
+// Recording an add-on the user bought on its own
+const (
+	GetAddonInLibraryQuery = `
+		SELECT ga.parent_game_id
+		FROM game_addons ga
+		WHERE ga.id = $2
+		AND EXISTS (
+			SELECT 1
+			FROM user_games ug
+			WHERE ug.user_id = $1 AND ug.game_id = ga.parent_game_id AND ug.deleted_at IS NULL
+		)
+	`
+
+	CheckPlatformExistsQuery = `
+		SELECT EXISTS (SELECT 1 FROM platforms WHERE id = $1)
+	`
+
+	// DLC purchases are filed under the dlc spending category so spend tracking reports them as such
+	LinkDLCPurchaseQuery = `
+		UPDATE one_time_purchases
+		SET
+			spending_category_id = (SELECT id FROM spending_categories WHERE media_type = 'dlc' ORDER BY id LIMIT 1),
+			updated_at = NOW()
+		WHERE id = $2 AND user_id = $1 AND deleted_at IS NULL
+	`
+
+	InsertOwnedAddonQuery = `
+		INSERT INTO user_game_addons (user_id, addon_id, platform_id, purchase_id, acquired_date, notes)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (user_id, addon_id, platform_id) DO NOTHING
+		RETURNING id
+	`
+
+	GetOwnedAddonQuery = `
+		SELECT
+			uga.id,
+			uga.addon_id,
+			ga.name AS addon_name,
+			ga.addon_type,
+			ga.parent_game_id,
+			uga.platform_id,
+			p.name AS platform_name,
+			uga.purchase_id,
+			uga.acquired_date,
+			uga.notes,
+			uga.created_at
+		FROM user_game_addons uga
+		JOIN game_addons ga ON ga.id = uga.addon_id
+		JOIN platforms p ON p.id = uga.platform_id
+		WHERE uga.user_id = $1 AND uga.id = $2
+	`
+
+	DeleteOwnedAddonQuery = `
+		DELETE FROM user_game_addons
+		WHERE user_id = $1 AND id = $2
+	`
+)
+
+// Editions replace a copy's contents as a whole
+const (
+	UpdateCopyEditionQuery = `
+		UPDATE user_games
+		SET edition = NULLIF($3, '')
+		WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL
+		RETURNING game_id
+	`
+
+	CountGameAddonsQuery = `
+		SELECT COUNT(*)
+		FROM game_addons
+		WHERE parent_game_id = $1 AND id = ANY($2)
+	`
+
+	DeleteEditionContentsQuery = `
+		DELETE FROM user_game_edition_contents
+		WHERE user_game_id = $1
+	`
+
+	InsertEditionContentsQuery = `
+		INSERT INTO user_game_edition_contents (user_game_id, name, addon_id)
+		SELECT $1, content.name, content.addon_id
+		FROM UNNEST($2::text[], $3::bigint[]) WITH ORDINALITY AS content(name, addon_id, position)
+		ORDER BY content.position
+	`
+)
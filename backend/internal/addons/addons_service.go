@@ -0,0 +1,215 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/search"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// addonsStaleAfter is how long a game's add-on list is trusted before it is fetched from IGDB again
+const addonsStaleAfter = 7 * 24 * time.Hour
+
+type GameAddonsService struct {
+	appContext         *appcontext.AppContext
+	dbAdapter          interfaces.AddonsDbAdapter
+	igdbAdapter        interfaces.IGDBAddonAdapter
+	spendTrackingCache interfaces.SpendTrackingCacheWrapper
+	dashboardCache     interfaces.DashboardCacheWrapper
+	validator          interfaces.AddonsValidator
+	logger             interfaces.Logger
+	now                func() time.Time
+}
+
+type AddonsService interface {
+	GetGameAddons(ctx context.Context, userID string, gameID int64) (types.GameAddonsResponse, error)
+	CreateOwnedAddon(ctx context.Context, userID string, request types.CreateOwnedAddonRequest) (types.OwnedAddonResponse, error)
+	DeleteOwnedAddon(ctx context.Context, userID string, ownedAddonID int64) error
+	UpdateEdition(ctx context.Context, userID string, userGameID int64, request types.UpdateEditionRequest) (types.GameEditionResponse, error)
+}
+
+// NewGameAddonsService creates the add-ons service.
+// A game's add-ons are fetched from IGDB when it is first viewed and again once they are stale.
+func NewGameAddonsService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.AddonsDbAdapter,
+	igdbAdapter interfaces.IGDBAddonAdapter,
+	spendTrackingCache interfaces.SpendTrackingCacheWrapper,
+	dashboardCache interfaces.DashboardCacheWrapper,
+) (*GameAddonsService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if igdbAdapter == nil {
+		return nil, fmt.Errorf("igdbAdapter is required")
+	}
+	if spendTrackingCache == nil {
+		return nil, fmt.Errorf("spendTrackingCache is required")
+	}
+	if dashboardCache == nil {
+		return nil, fmt.Errorf("dashboardCache is required")
+	}
+
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		return nil, fmt.Errorf("creating sanitizer: %w", err)
+	}
+
+	validator, err := NewAddonsValidator(sanitizer)
+	if err != nil {
+		return nil, fmt.Errorf("creating addons validator: %w", err)
+	}
+
+	return &GameAddonsService{
+		appContext:         appContext,
+		dbAdapter:          dbAdapter,
+		igdbAdapter:        igdbAdapter,
+		spendTrackingCache: spendTrackingCache,
+		dashboardCache:     dashboardCache,
+		validator:          validator,
+		logger:             appContext.Logger,
+		now:                time.Now,
+	}, nil
+}
+
+// GET
+// GetGameAddons lists the owned and missing add-ons of a game in the user's library, with the editions of their copies.
+// When IGDB can't be reached the add-ons fetched last time are listed.
+func (as *GameAddonsService) GetGameAddons(ctx context.Context, userID string, gameID int64) (types.GameAddonsResponse, error) {
+	if err := as.validator.ValidateUserID(userID); err != nil {
+		return types.GameAddonsResponse{}, err
+	}
+	if err := as.validator.ValidateGameID(gameID); err != nil {
+		return types.GameAddonsResponse{}, err
+	}
+
+	syncedAt, err := as.dbAdapter.GetAddonsSyncedAt(ctx, userID, gameID)
+	if err != nil {
+		return types.GameAddonsResponse{}, err
+	}
+
+	now := as.now()
+	if !syncedAt.Valid || now.Sub(syncedAt.Time) > addonsStaleAfter {
+		if err := as.syncGameAddons(ctx, gameID, now); err != nil {
+			as.logger.Error("Failed to sync game add-ons from IGDB, listing stored add-ons", map[string]any{
+				"error":  err,
+				"gameID": gameID,
+			})
+		}
+	}
+
+	addons, err := as.dbAdapter.GetGameAddons(ctx, userID, gameID)
+	if err != nil {
+		return types.GameAddonsResponse{}, err
+	}
+	editions, err := as.dbAdapter.GetGameEditions(ctx, userID, gameID)
+	if err != nil {
+		return types.GameAddonsResponse{}, err
+	}
+
+	return TransformGameAddonsToResponse(gameID, addons, editions), nil
+}
+
+// POST
+// CreateOwnedAddon records an add-on the user bought on its own, a linked purchase is filed under DLC spending
+func (as *GameAddonsService) CreateOwnedAddon(
+	ctx context.Context,
+	userID string,
+	request types.CreateOwnedAddonRequest,
+) (types.OwnedAddonResponse, error) {
+	if err := as.validator.ValidateUserID(userID); err != nil {
+		return types.OwnedAddonResponse{}, err
+	}
+	if err := as.validator.ValidateCreateOwnedAddonRequest(request, as.now()); err != nil {
+		return types.OwnedAddonResponse{}, err
+	}
+
+	addon := TransformCreateOwnedAddonRequestToModel(request)
+	created, err := as.dbAdapter.CreateOwnedAddon(ctx, userID, addon)
+	if err != nil {
+		return types.OwnedAddonResponse{}, err
+	}
+
+	if addon.PurchaseID != nil {
+		as.invalidateSpending(ctx, userID)
+	}
+	return TransformOwnedAddonToResponse(created), nil
+}
+
+// DELETE
+// DeleteOwnedAddon removes an add-on from the user's collection
+func (as *GameAddonsService) DeleteOwnedAddon(ctx context.Context, userID string, ownedAddonID int64) error {
+	if err := as.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := as.validator.ValidateOwnedAddonID(ownedAddonID); err != nil {
+		return err
+	}
+
+	return as.dbAdapter.DeleteOwnedAddon(ctx, userID, ownedAddonID)
+}
+
+// PUT
+// UpdateEdition sets the edition of a copy and what came with it, add-ons in the box count as owned
+func (as *GameAddonsService) UpdateEdition(
+	ctx context.Context,
+	userID string,
+	userGameID int64,
+	request types.UpdateEditionRequest,
+) (types.GameEditionResponse, error) {
+	if err := as.validator.ValidateUserID(userID); err != nil {
+		return types.GameEditionResponse{}, err
+	}
+	if err := as.validator.ValidateUpdateEditionRequest(userGameID, request); err != nil {
+		return types.GameEditionResponse{}, err
+	}
+
+	edition, err := as.dbAdapter.UpdateEdition(ctx, userID, TransformUpdateEditionRequestToModel(userGameID, request))
+	if err != nil {
+		return types.GameEditionResponse{}, err
+	}
+
+	return TransformEditionToResponse(edition), nil
+}
+
+// syncGameAddons fetches a game's add-ons from IGDB and stores them
+func (as *GameAddonsService) syncGameAddons(ctx context.Context, gameID int64, now time.Time) error {
+	var fetched []*models.GameAddon
+	err := search.WithTokenRefresh(ctx, as.appContext, as.igdbAdapter, func() error {
+		var err error
+		fetched, err = as.igdbAdapter.GetGameAddons(ctx, gameID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	addons := make([]models.GameAddon, 0, len(fetched))
+	for _, addon := range fetched {
+		addons = append(addons, *addon)
+	}
+
+	return as.dbAdapter.SaveGameAddons(ctx, gameID, addons, now)
+}
+
+// invalidateSpending refreshes the spend tracking views after a purchase moved to DLC spending
+func (as *GameAddonsService) invalidateSpending(ctx context.Context, userID string) {
+	if err := as.spendTrackingCache.InvalidateUserCache(ctx, userID); err != nil {
+		as.logger.Error("Failed to invalidate spend tracking cache after linking DLC purchase", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+	if err := as.dashboardCache.InvalidateUserCache(ctx, userID); err != nil {
+		as.logger.Error("Failed to invalidate dashboard cache after linking DLC purchase", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package addons
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformCreateOwnedAddonRequestToModel trims a validated request and reads its purchase ID
+func TransformCreateOwnedAddonRequestToModel(request types.CreateOwnedAddonRequest) models.OwnedAddonToSave {
+	addon := models.OwnedAddonToSave{
+		AddonID:    request.AddonID,
+		PlatformID: request.PlatformID,
+		Notes:      strings.TrimSpace(request.Notes),
+	}
+
+	if request.PurchaseID != "" {
+		if purchaseID, err := ParsePurchaseID(request.PurchaseID); err == nil {
+			addon.PurchaseID = &purchaseID
+		}
+	}
+	if request.AcquiredDate != nil {
+		acquiredDate := time.Unix(*request.AcquiredDate, 0).UTC()
+		addon.AcquiredDate = &acquiredDate
+	}
+
+	return addon
+}
+
+// TransformUpdateEditionRequestToModel trims a validated edition and its contents
+func TransformUpdateEditionRequestToModel(userGameID int64, request types.UpdateEditionRequest) models.EditionToSave {
+	edition := models.EditionToSave{
+		UserGameID: userGameID,
+		Edition:    strings.TrimSpace(request.Edition),
+		Contents:   make([]models.EditionContentToSave, 0, len(request.Contents)),
+	}
+
+	for _, content := range request.Contents {
+		edition.Contents = append(edition.Contents, models.EditionContentToSave{
+			Name:    strings.TrimSpace(content.Name),
+			AddonID: content.AddonID,
+		})
+	}
+
+	return edition
+}
+
+// TransformGameAddonsToResponse splits a game's add-ons into the ones the user has and the ones they are missing
+func TransformGameAddonsToResponse(
+	gameID int64,
+	addons []models.GameAddonDB,
+	editions []models.GameEditionDB,
+) types.GameAddonsResponse {
+	response := types.GameAddonsResponse{
+		GameID:   gameID,
+		Owned:    make([]types.GameAddonResponse, 0),
+		Missing:  make([]types.GameAddonResponse, 0),
+		Editions: make([]types.GameEditionResponse, 0, len(editions)),
+	}
+
+	for _, addon := range addons {
+		addonResponse := types.GameAddonResponse{
+			ID:               addon.ID,
+			Name:             addon.Name,
+			AddonType:        addonTypeResponse(addon.AddonType),
+			CoverURL:         addon.CoverURL,
+			FirstReleaseDate: addon.FirstReleaseDate,
+		}
+
+		if !addon.Owned() {
+			response.Missing = append(response.Missing, addonResponse)
+			continue
+		}
+
+		addonResponse.Ownership = &types.AddonOwnershipResponse{
+			ID:                addon.OwnedAddonID.Int64,
+			PlatformName:      addon.PlatformName.String,
+			PurchaseID:        purchaseIDToString(addon.PurchaseID),
+			AcquiredDate:      nullTimeToUnix(addon.AcquiredDate),
+			IncludedInEdition: addon.IncludedInEdition.String,
+		}
+		response.Owned = append(response.Owned, addonResponse)
+	}
+
+	for _, edition := range editions {
+		response.Editions = append(response.Editions, TransformEditionToResponse(edition))
+	}
+
+	return response
+}
+
+func TransformEditionToResponse(edition models.GameEditionDB) types.GameEditionResponse {
+	response := types.GameEditionResponse{
+		UserGameID:   edition.UserGameID,
+		PlatformName: edition.PlatformName,
+		GameType:     edition.GameType,
+		Edition:      edition.Edition.String,
+		Contents:     make([]types.EditionContentResponse, 0, len(edition.Contents)),
+	}
+
+	for _, content := range edition.Contents {
+		response.Contents = append(response.Contents, types.EditionContentResponse{
+			Name:    content.Name,
+			AddonID: content.AddonID.Int64,
+		})
+	}
+
+	return response
+}
+
+func TransformOwnedAddonToResponse(addon models.OwnedAddonDB) types.OwnedAddonResponse {
+	return types.OwnedAddonResponse{
+		ID:           addon.ID,
+		AddonID:      addon.AddonID,
+		AddonName:    addon.AddonName,
+		AddonType:    addonTypeResponse(addon.AddonType),
+		GameID:       addon.ParentGameID,
+		PlatformID:   addon.PlatformID,
+		PlatformName: addon.PlatformName,
+		PurchaseID:   purchaseIDToString(addon.PurchaseID),
+		AcquiredDate: nullTimeToUnix(addon.AcquiredDate),
+		Notes:        addon.Notes.String,
+	}
+}
+
+// addonTypeResponse looks up the display text of an add-on type in types.GameTypes
+func addonTypeResponse(addonType string) types.GameTypeResponse {
+	for _, gameType := range types.GameTypes {
+		if gameType.NormalizedText == addonType {
+			return types.GameTypeResponse{
+				DisplayText:    gameType.DisplayText,
+				NormalizedText: gameType.NormalizedText,
+			}
+		}
+	}
+	return types.GameTypeResponse{NormalizedText: addonType}
+}
+
+// purchaseIDToString formats a purchase the way spend tracking lists it
+func purchaseIDToString(purchaseID sql.NullInt64) string {
+	if !purchaseID.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", purchaseIDPrefix, purchaseID.Int64)
+}
+
+func nullTimeToUnix(value sql.NullTime) int64 {
+	if !value.Valid {
+		return 0
+	}
+	return value.Time.Unix()
+}
@@ -0,0 +1,150 @@
+package addons
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	MaxEditionLength         = 100
+	MaxEditionContentLength  = 255
+	MaxEditionContents       = 50
+	MaxOwnedAddonNotesLength = 500
+
+	// purchaseIDPrefix marks one-time purchase IDs, as they are listed by spend tracking
+	purchaseIDPrefix = "one-"
+)
+
+type AddonsValidatorImpl struct {
+	sanitizer interfaces.Sanitizer
+}
+
+func NewAddonsValidator(sanitizer interfaces.Sanitizer) (interfaces.AddonsValidator, error) {
+	if sanitizer == nil {
+		return nil, fmt.Errorf("sanitizer cannot be nil")
+	}
+
+	return &AddonsValidatorImpl{sanitizer: sanitizer}, nil
+}
+
+func (v *AddonsValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *AddonsValidatorImpl) ValidateGameID(gameID int64) error {
+	if gameID <= 0 {
+		return fmt.Errorf("%w: invalid game ID %d", ErrValidationFailed, gameID)
+	}
+	return nil
+}
+
+func (v *AddonsValidatorImpl) ValidateOwnedAddonID(ownedAddonID int64) error {
+	if ownedAddonID <= 0 {
+		return fmt.Errorf("%w: invalid owned add-on ID %d", ErrValidationFailed, ownedAddonID)
+	}
+	return nil
+}
+
+func (v *AddonsValidatorImpl) ValidateCreateOwnedAddonRequest(request types.CreateOwnedAddonRequest, now time.Time) error {
+	if request.AddonID <= 0 {
+		return fmt.Errorf("%w: addonId is required", ErrValidationFailed)
+	}
+	if request.PlatformID <= 0 {
+		return fmt.Errorf("%w: platformId is required", ErrValidationFailed)
+	}
+
+	if request.PurchaseID != "" {
+		if _, err := ParsePurchaseID(request.PurchaseID); err != nil {
+			return err
+		}
+	}
+
+	if request.AcquiredDate != nil && time.Unix(*request.AcquiredDate, 0).After(now) {
+		return fmt.Errorf("%w: acquiredDate cannot be in the future", ErrValidationFailed)
+	}
+
+	notes := strings.TrimSpace(request.Notes)
+	if utf8.RuneCountInString(notes) > MaxOwnedAddonNotesLength {
+		return fmt.Errorf("%w: notes must be %d characters or less", ErrValidationFailed, MaxOwnedAddonNotesLength)
+	}
+	return v.checkPlainText("notes", notes)
+}
+
+func (v *AddonsValidatorImpl) ValidateUpdateEditionRequest(userGameID int64, request types.UpdateEditionRequest) error {
+	if userGameID <= 0 {
+		return fmt.Errorf("%w: invalid copy ID %d", ErrValidationFailed, userGameID)
+	}
+
+	edition := strings.TrimSpace(request.Edition)
+	if edition == "" && len(request.Contents) > 0 {
+		return fmt.Errorf("%w: contents need an edition", ErrValidationFailed)
+	}
+	if utf8.RuneCountInString(edition) > MaxEditionLength {
+		return fmt.Errorf("%w: edition must be %d characters or less", ErrValidationFailed, MaxEditionLength)
+	}
+	if err := v.checkPlainText("edition", edition); err != nil {
+		return err
+	}
+
+	if len(request.Contents) > MaxEditionContents {
+		return fmt.Errorf("%w: an edition can list at most %d contents", ErrValidationFailed, MaxEditionContents)
+	}
+
+	seenAddons := make(map[int64]bool, len(request.Contents))
+	for _, content := range request.Contents {
+		name := strings.TrimSpace(content.Name)
+		if name == "" {
+			return fmt.Errorf("%w: content name is required", ErrValidationFailed)
+		}
+		if utf8.RuneCountInString(name) > MaxEditionContentLength {
+			return fmt.Errorf("%w: content name must be %d characters or less", ErrValidationFailed, MaxEditionContentLength)
+		}
+		if err := v.checkPlainText("content name", name); err != nil {
+			return err
+		}
+
+		if content.AddonID != nil {
+			if *content.AddonID <= 0 {
+				return fmt.Errorf("%w: invalid content addonId %d", ErrValidationFailed, *content.AddonID)
+			}
+			if seenAddons[*content.AddonID] {
+				return fmt.Errorf("%w: add-on %d is listed more than once", ErrValidationFailed, *content.AddonID)
+			}
+			seenAddons[*content.AddonID] = true
+		}
+	}
+
+	return nil
+}
+
+// ParsePurchaseID reads a one-time purchase ID ("one-123") as its numeric ID
+func ParsePurchaseID(purchaseID string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(purchaseID, purchaseIDPrefix), 10, 64)
+	if err != nil || !strings.HasPrefix(purchaseID, purchaseIDPrefix) || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid purchase ID '%s'", ErrValidationFailed, purchaseID)
+	}
+	return id, nil
+}
+
+// checkPlainText rejects input the sanitizer would change.
+// The escaped output isn't stored, so names like "Director's Cut" keep their apostrophe.
+func (v *AddonsValidatorImpl) checkPlainText(field string, value string) error {
+	sanitized, err := v.sanitizer.SanitizeString(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s content: %v", ErrValidationFailed, field, err)
+	}
+	if html.UnescapeString(sanitized) != value {
+		return fmt.Errorf("%w: %s must not contain HTML", ErrValidationFailed, field)
+	}
+	return nil
+}
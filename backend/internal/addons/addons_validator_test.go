@@ -0,0 +1,174 @@
+package addons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Validating add-on ownership and edition requests
+
+	Scenarios:
+	- Owning an add-on needs the add-on and a platform, a purchase must be a one-time purchase ID
+	- Acquired dates can't be in the future
+	- Edition names and contents are plain text, contents need an edition
+	- An add-on is only listed once in an edition
+*/
+
+func TestAddonsValidator(t *testing.T) {
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		t.Fatalf("Failed to create sanitizer: %v", err)
+	}
+	validator, err := NewAddonsValidator(sanitizer)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	unix := func(t time.Time) *int64 {
+		value := t.Unix()
+		return &value
+	}
+	addonID := func(id int64) *int64 {
+		return &id
+	}
+
+	createRequests := []struct {
+		name      string
+		request   types.CreateOwnedAddonRequest
+		expectErr bool
+	}{
+		{
+			name: "Valid DLC purchase",
+			request: types.CreateOwnedAddonRequest{
+				AddonID:      2680,
+				PlatformID:   6,
+				PurchaseID:   "one-12",
+				AcquiredDate: unix(now.AddDate(0, -1, 0)),
+				Notes:        "Bought in the summer sale",
+			},
+		},
+		{
+			name:      "Missing add-on",
+			request:   types.CreateOwnedAddonRequest{PlatformID: 6},
+			expectErr: true,
+		},
+		{
+			name:      "Missing platform",
+			request:   types.CreateOwnedAddonRequest{AddonID: 2680},
+			expectErr: true,
+		},
+		{
+			name:      "Purchase without prefix",
+			request:   types.CreateOwnedAddonRequest{AddonID: 2680, PlatformID: 6, PurchaseID: "12"},
+			expectErr: true,
+		},
+		{
+			name:      "Acquired in the future",
+			request:   types.CreateOwnedAddonRequest{AddonID: 2680, PlatformID: 6, AcquiredDate: unix(now.Add(time.Hour))},
+			expectErr: true,
+		},
+		{
+			name:      "HTML notes",
+			request:   types.CreateOwnedAddonRequest{AddonID: 2680, PlatformID: 6, Notes: "<b>key</b>"},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range createRequests {
+		/*
+			GIVEN a request to record an owned add-on
+			WHEN ValidateCreateOwnedAddonRequest is called
+			THEN it should only return ErrValidationFailed for invalid requests
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			err := validator.ValidateCreateOwnedAddonRequest(testCase.request, now)
+
+			// THEN
+			if testCase.expectErr && !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+			if !testCase.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+
+	editionRequests := []struct {
+		name      string
+		request   types.UpdateEditionRequest
+		expectErr bool
+	}{
+		{
+			name: "Collector's edition with an add-on and extras",
+			request: types.UpdateEditionRequest{
+				Edition: "Collector's Edition",
+				Contents: []types.EditionContentRequest{
+					{Name: "Hearts of Stone", AddonID: addonID(10)},
+					{Name: "Art book"},
+				},
+			},
+		},
+		{
+			name:    "Clearing the edition",
+			request: types.UpdateEditionRequest{},
+		},
+		{
+			name:      "Contents without an edition",
+			request:   types.UpdateEditionRequest{Contents: []types.EditionContentRequest{{Name: "Art book"}}},
+			expectErr: true,
+		},
+		{
+			name:      "Overlong edition",
+			request:   types.UpdateEditionRequest{Edition: strings.Repeat("a", MaxEditionLength+1)},
+			expectErr: true,
+		},
+		{
+			name: "Content without a name",
+			request: types.UpdateEditionRequest{
+				Edition:  "Deluxe Edition",
+				Contents: []types.EditionContentRequest{{Name: " "}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Add-on listed twice",
+			request: types.UpdateEditionRequest{
+				Edition: "Deluxe Edition",
+				Contents: []types.EditionContentRequest{
+					{Name: "Season Pass", AddonID: addonID(12)},
+					{Name: "Season Pass (code)", AddonID: addonID(12)},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range editionRequests {
+		/*
+			GIVEN a request to set a copy's edition
+			WHEN ValidateUpdateEditionRequest is called
+			THEN it should only return ErrValidationFailed for invalid requests
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			err := validator.ValidateUpdateEditionRequest(7, testCase.request)
+
+			// THEN
+			if testCase.expectErr && !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+			if !testCase.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
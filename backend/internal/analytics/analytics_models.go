@@ -12,6 +12,9 @@ type GeneralStats struct {
 	MonthlySubscriptionCost float64 `json:"monthly_subscription_cost" db:"monthly_subscription_cost"`
 	TotalDigitalLocations   int     `json:"total_digital_locations" db:"total_digital_locations"`
 	TotalPhysicalLocations  int     `json:"total_physical_locations" db:"total_physical_locations"`
+	BacklogGames            int     `json:"backlog_games" db:"backlog_games"`
+	CompletedGames          int     `json:"completed_games" db:"completed_games"`
+	CompletionRate          float64 `json:"completion_rate" db:"completion_rate"` // percentage of owned games completed
 }
 
 // FinancialStats contains detailed financial information
@@ -20,6 +23,14 @@ type FinancialStats struct {
 	TotalServices          int              `json:"total_services" db:"total_services"`
 	RenewalsThisMonth      int              `json:"renewals_this_month" db:"renewals_this_month"`
 	Services               []ServiceDetails `json:"services"`
+	AveragePricePerGame    float64          `json:"average_price_per_game" db:"average_price_per_game"` // over games with a linked purchase
+	PlatformSpend          []PlatformSpend  `json:"platform_spend"`
+}
+
+// PlatformSpend is how much one-time purchases linked to copies on a platform add up to
+type PlatformSpend struct {
+	Platform string  `json:"platform"`
+	Amount   float64 `json:"amount"`
 }
 
 // ServiceDetails contains information about a digital service subscription
@@ -117,6 +128,9 @@ type InventoryStats struct {
 	TotalItemCount int                  `json:"total_item_count" db:"total_item_count"`
 	NewItemCount   int                  `json:"new_item_count" db:"new_item_count"`
 	PlatformCounts []PlatformItemCount  `json:"platform_counts"`
+	TagCounts      []TagItemCount       `json:"tag_counts"`
+	GenreCounts    []GenreItemCount     `json:"genre_counts"`
+	ThemeCounts    []ThemeItemCount     `json:"theme_counts"`
 }
 
 // PlatformItemCount provides item counts per platform
@@ -125,6 +139,26 @@ type PlatformItemCount struct {
 	ItemCount int    `json:"item_count"`
 }
 
+// TagItemCount provides item counts per user tag
+type TagItemCount struct {
+	Tag       string `json:"tag"`
+	ItemCount int    `json:"item_count"`
+}
+
+// GenreItemCount provides item counts per IGDB genre
+type GenreItemCount struct {
+	GenreID   int64  `json:"genre_id"`
+	Genre     string `json:"genre"`
+	ItemCount int    `json:"item_count"`
+}
+
+// ThemeItemCount provides item counts per IGDB theme
+type ThemeItemCount struct {
+	ThemeID   int64  `json:"theme_id"`
+	Theme     string `json:"theme"`
+	ItemCount int    `json:"item_count"`
+}
+
 // WishlistStats contains information about wishlisted items
 type WishlistStats struct {
 	TotalWishlistItems   int     `json:"total_wishlist_items" db:"total_wishlist_items"`
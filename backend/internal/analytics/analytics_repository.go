@@ -47,7 +47,7 @@ func (r *repository) GetGeneralStats(ctx context.Context, userID string) (*Gener
 	// Get physical locations count
 	var physicalLocationsCount int
 	err := r.db.GetContext(ctx, &physicalLocationsCount,
-		`SELECT COUNT(*) FROM physical_locations WHERE user_id = $1`, userID)
+		`SELECT COUNT(*) FROM physical_locations WHERE user_id = $1 AND deleted_at IS NULL`, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get physical locations count: %w", err)
 	}
@@ -83,7 +83,7 @@ func (r *repository) GetGeneralStats(ctx context.Context, userID string) (*Gener
 	// Get total games count (if user_games table exists)
 	var gamesCount int
 	err = r.db.GetContext(ctx, &gamesCount,
-		`SELECT COUNT(*) FROM user_games WHERE user_id = $1`, userID)
+		`SELECT COUNT(*) FROM user_games WHERE user_id = $1 AND deleted_at IS NULL`, userID)
 	if err != nil {
 		// This is expected to fail if user_games doesn't exist yet
 		// Just log and continue with 0
@@ -91,6 +91,28 @@ func (r *repository) GetGeneralStats(ctx context.Context, userID string) (*Gener
 	}
 	stats.TotalGames = gamesCount
 
+	// Get backlog size and completion counts, play status is shared by every copy of a game
+	var playStatusCounts struct {
+		OwnedGames     int `db:"owned_games"`
+		BacklogGames   int `db:"backlog_games"`
+		CompletedGames int `db:"completed_games"`
+	}
+	err = r.db.GetContext(ctx, &playStatusCounts, `
+		SELECT
+			COUNT(DISTINCT game_id) as owned_games,
+			COUNT(DISTINCT game_id) FILTER (WHERE play_status = 'backlog') as backlog_games,
+			COUNT(DISTINCT game_id) FILTER (WHERE play_status IN ('completed', 'hundred_percent')) as completed_games
+		FROM user_games
+		WHERE user_id = $1 AND deleted_at IS NULL`, userID)
+	// Like the games count above, keep zeroes if play status isn't available yet
+	if err == nil {
+		stats.BacklogGames = playStatusCounts.BacklogGames
+		stats.CompletedGames = playStatusCounts.CompletedGames
+		if playStatusCounts.OwnedGames > 0 {
+			stats.CompletionRate = float64(playStatusCounts.CompletedGames) / float64(playStatusCounts.OwnedGames) * 100
+		}
+	}
+
 	return stats, nil
 }
 
@@ -169,9 +191,57 @@ func (r *repository) GetFinancialStats(ctx context.Context, userID string) (*Fin
 	}
 
 	stats.Services = services
+
+	// Get average price per game, purchases covering several copies are split evenly
+	err = r.db.GetContext(ctx, &stats.AveragePricePerGame, `
+		SELECT COALESCE(ROUND(SUM(costs.amount) / NULLIF(COUNT(DISTINCT ug.game_id), 0), 2), 0)
+		FROM (`+copyCostsQuery+`) costs
+		JOIN user_games ug ON ug.id = costs.user_game_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get average price per game: %w", err)
+	}
+
+	// Get spend per platform
+	platformSpend := []PlatformSpend{}
+	spendRows, err := r.db.QueryxContext(ctx, `
+		SELECT
+			p.name as platform,
+			ROUND(SUM(costs.amount), 2) as amount
+		FROM (`+copyCostsQuery+`) costs
+		JOIN user_games ug ON ug.id = costs.user_game_id
+		JOIN platforms p ON p.id = ug.platform_id
+		GROUP BY p.name
+		ORDER BY amount DESC, p.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform spend: %w", err)
+	}
+	defer spendRows.Close()
+
+	for spendRows.Next() {
+		var spend PlatformSpend
+		if err := spendRows.Scan(&spend.Platform, &spend.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan platform spend: %w", err)
+		}
+		platformSpend = append(platformSpend, spend)
+	}
+
+	stats.PlatformSpend = platformSpend
 	return stats, nil
 }
 
+// copyCostsQuery is what each linked copy cost the user ($1), one row per purchase link.
+// Trashed copies and purchases are left out.
+const copyCostsQuery = `
+	SELECT
+		otpg.user_game_id,
+		otp.amount / COUNT(*) OVER (PARTITION BY otpg.purchase_id) as amount
+	FROM one_time_purchase_games otpg
+	JOIN one_time_purchases otp ON otp.id = otpg.purchase_id
+	JOIN user_games cug ON cug.id = otpg.user_game_id
+	WHERE otp.user_id = $1
+	AND otp.deleted_at IS NULL
+	AND cug.deleted_at IS NULL`
+
 // GetStorageStats retrieves storage location statistics for a user
 func (r *repository) GetStorageStats(ctx context.Context, userID string) (*StorageStats, error) {
 	stats := &StorageStats{}
@@ -186,7 +256,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 			COUNT(CASE WHEN location_type = 'physical' THEN 1 END) as physical_count,
 			COUNT(CASE WHEN location_type = 'digital' THEN 1 END) as digital_count
 		FROM (
-			SELECT 'physical' as location_type FROM physical_locations WHERE user_id = $1
+			SELECT 'physical' as location_type FROM physical_locations WHERE user_id = $1 AND deleted_at IS NULL
 			UNION ALL
 			SELECT 'digital' as location_type FROM digital_locations WHERE user_id = $1
 		) locations`, userID).Scan(&physicalCount, &digitalCount)
@@ -212,11 +282,12 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 				l.bg_color,
 				l.created_at,
 				l.updated_at,
-				COUNT(DISTINCT pgl.id) as item_count
+				COUNT(DISTINCT lug.id) as item_count
 			FROM physical_locations l
-			LEFT JOIN sublocations sl ON l.id = sl.physical_location_id
+			LEFT JOIN sublocations sl ON l.id = sl.physical_location_id AND sl.deleted_at IS NULL
 			LEFT JOIN physical_game_locations pgl ON sl.id = pgl.sublocation_id
-			WHERE l.user_id = $1
+			LEFT JOIN user_games lug ON lug.id = pgl.user_game_id AND lug.deleted_at IS NULL
+			WHERE l.user_id = $1 AND l.deleted_at IS NULL
 			GROUP BY l.id, l.name, l.location_type, l.map_coordinates, l.bg_color, l.created_at, l.updated_at
 		)
 		SELECT
@@ -244,7 +315,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 								JOIN user_games ug ON pgl.user_game_id = ug.id
 								JOIN games g ON ug.game_id = g.id
 								JOIN platforms p ON ug.platform_id = p.id
-								WHERE pgl.sublocation_id = sl.id
+								WHERE pgl.sublocation_id = sl.id AND ug.deleted_at IS NULL
 							),
 							'[]'::json
 						)
@@ -253,7 +324,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 				'[]'::json
 			) as sublocations
 		FROM physical_location_data pld
-		LEFT JOIN sublocations sl ON pld.id = sl.physical_location_id
+		LEFT JOIN sublocations sl ON pld.id = sl.physical_location_id AND sl.deleted_at IS NULL
 		GROUP BY pld.id, pld.name, pld.location_type, pld.map_coordinates, pld.bg_color, pld.created_at, pld.updated_at, pld.item_count
 		ORDER BY pld.name`, userID)
 	if err != nil {
@@ -332,7 +403,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 				l.url,
 				l.created_at,
 				l.updated_at,
-				COUNT(DISTINCT dgl.id) as item_count,
+				COUNT(DISTINCT dug.id) as item_count,
 				CASE WHEN s.id IS NOT NULL THEN true ELSE false END as is_subscription,
 				COALESCE(CASE
 					WHEN s.billing_cycle = '1 month' THEN ROUND(s.cost_per_cycle::numeric, 2)
@@ -360,6 +431,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 				END as next_payment_date
 			FROM digital_locations l
 			LEFT JOIN digital_game_locations dgl ON l.id = dgl.digital_location_id
+			LEFT JOIN user_games dug ON dug.id = dgl.user_game_id AND dug.deleted_at IS NULL
 			LEFT JOIN digital_location_subscriptions s ON l.id = s.digital_location_id
 			WHERE l.user_id = $1
 			GROUP BY
@@ -383,7 +455,7 @@ func (r *repository) GetStorageStats(ctx context.Context, userID string) (*Stora
 					JOIN user_games ug ON dgl.user_game_id = ug.id
 					JOIN games g ON ug.game_id = g.id
 					JOIN platforms p ON ug.platform_id = p.id
-					WHERE dgl.digital_location_id = dld.id
+					WHERE dgl.digital_location_id = dld.id AND ug.deleted_at IS NULL
 				),
 				'[]'::json
 			) as items
@@ -444,7 +516,7 @@ func (r *repository) GetInventoryStats(ctx context.Context, userID string) (*Inv
 
 	// Get total item count
 	err := r.db.GetContext(ctx, &stats.TotalItemCount, `
-		SELECT COUNT(*) FROM user_games WHERE user_id = $1`, userID)
+		SELECT COUNT(*) FROM user_games WHERE user_id = $1 AND deleted_at IS NULL`, userID)
 	if err != nil {
 		// This might fail if user_games table doesn't exist yet
 		stats.TotalItemCount = 0
@@ -456,22 +528,23 @@ func (r *repository) GetInventoryStats(ctx context.Context, userID string) (*Inv
 		SELECT COUNT(*)
 		FROM user_games
 		WHERE user_id = $1
+		AND deleted_at IS NULL
 		AND TO_CHAR(created_at, 'YYYY-MM') = $2`, userID, currentMonth)
 	if err != nil {
 		// This might fail if user_games table doesn't exist yet
 		stats.NewItemCount = 0
 	}
 
-	// Get platform counts if user_games and game_platforms exist
+	// Get platform counts if user_games and game_platforms exist, grouped by platform family
 	platformCounts := []PlatformItemCount{}
 	rows, err := r.db.QueryxContext(ctx, `
 		SELECT
-			p.name as platform,
+			COALESCE(p.platform_group, p.name) as platform,
 			COUNT(ug.id) as item_count
 		FROM user_games ug
 		JOIN platforms p ON ug.platform_id = p.id
-		WHERE ug.user_id = $1
-		GROUP BY p.name
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY COALESCE(p.platform_group, p.name)
 		ORDER BY item_count DESC`, userID)
 	if err == nil {
 		defer rows.Close()
@@ -488,6 +561,89 @@ func (r *repository) GetInventoryStats(ctx context.Context, userID string) (*Inv
 	// If error, just use empty slice
 	stats.PlatformCounts = platformCounts
 
+	// Get tag counts, tags without any games are left out
+	tagCounts := []TagItemCount{}
+	tagRows, err := r.db.QueryxContext(ctx, `
+		SELECT
+			t.name as tag,
+			COUNT(ugt.user_game_id) as item_count
+		FROM user_tags t
+		JOIN user_game_tags ugt ON ugt.tag_id = t.id
+		JOIN user_games ug ON ug.id = ugt.user_game_id
+		WHERE t.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY t.id, t.name
+		ORDER BY item_count DESC, t.name`, userID)
+	if err == nil {
+		defer tagRows.Close()
+
+		for tagRows.Next() {
+			var tagCount TagItemCount
+			err := tagRows.Scan(&tagCount.Tag, &tagCount.ItemCount)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan tag count: %w", err)
+			}
+			tagCounts = append(tagCounts, tagCount)
+		}
+	}
+	// If error, just use empty slice
+	stats.TagCounts = tagCounts
+
+	// Get genre counts, a copy counts once for each of its game's genres
+	genreCounts := []GenreItemCount{}
+	genreRows, err := r.db.QueryxContext(ctx, `
+		SELECT
+			gen.id as genre_id,
+			gen.name as genre,
+			COUNT(ug.id) as item_count
+		FROM user_games ug
+		JOIN game_genres gg ON gg.game_id = ug.game_id
+		JOIN genres gen ON gen.id = gg.genre_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY gen.id, gen.name
+		ORDER BY item_count DESC, gen.name`, userID)
+	if err == nil {
+		defer genreRows.Close()
+
+		for genreRows.Next() {
+			var genreCount GenreItemCount
+			err := genreRows.Scan(&genreCount.GenreID, &genreCount.Genre, &genreCount.ItemCount)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan genre count: %w", err)
+			}
+			genreCounts = append(genreCounts, genreCount)
+		}
+	}
+	// If error, just use empty slice
+	stats.GenreCounts = genreCounts
+
+	// Get theme counts, a copy counts once for each of its game's themes
+	themeCounts := []ThemeItemCount{}
+	themeRows, err := r.db.QueryxContext(ctx, `
+		SELECT
+			th.id as theme_id,
+			th.name as theme,
+			COUNT(ug.id) as item_count
+		FROM user_games ug
+		JOIN game_themes gt ON gt.game_id = ug.game_id
+		JOIN themes th ON th.id = gt.theme_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY th.id, th.name
+		ORDER BY item_count DESC, th.name`, userID)
+	if err == nil {
+		defer themeRows.Close()
+
+		for themeRows.Next() {
+			var themeCount ThemeItemCount
+			err := themeRows.Scan(&themeCount.ThemeID, &themeCount.Theme, &themeCount.ItemCount)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan theme count: %w", err)
+			}
+			themeCounts = append(themeCounts, themeCount)
+		}
+	}
+	// If error, just use empty slice
+	stats.ThemeCounts = themeCounts
+
 	return stats, nil
 }
 
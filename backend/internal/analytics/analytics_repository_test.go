@@ -71,6 +71,11 @@ func TestAnalyticsRepository(t *testing.T) {
 			WithArgs(userID).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
 
+		// Mock play status counts
+		mock.ExpectQuery("SELECT (.+) FROM user_games").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"owned_games", "backlog_games", "completed_games"}).AddRow(20, 12, 5))
+
 		// Execute
 		stats, err := repo.GetGeneralStats(context.Background(), userID)
 
@@ -93,6 +98,15 @@ func TestAnalyticsRepository(t *testing.T) {
 		if stats.TotalGames != 42 {
 			t.Errorf("Expected TotalGames=42, got %d", stats.TotalGames)
 		}
+		if stats.BacklogGames != 12 {
+			t.Errorf("Expected BacklogGames=12, got %d", stats.BacklogGames)
+		}
+		if stats.CompletedGames != 5 {
+			t.Errorf("Expected CompletedGames=5, got %d", stats.CompletedGames)
+		}
+		if stats.CompletionRate != 25 {
+			t.Errorf("Expected CompletionRate=25, got %f", stats.CompletionRate)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unmet expectations: %v", err)
@@ -234,6 +248,19 @@ func TestAnalyticsRepository(t *testing.T) {
 			WithArgs(userID).
 			WillReturnRows(serviceRows)
 
+		// Mock average price per game
+		mock.ExpectQuery("SELECT COALESCE\\(ROUND\\(SUM\\(costs.amount\\)").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"average_price_per_game"}).AddRow(42.5))
+
+		// Mock platform spend
+		spendRows := sqlmock.NewRows([]string{"platform", "amount"}).
+			AddRow("PlayStation 5", 120.0).
+			AddRow("Nintendo Switch", 50.0)
+		mock.ExpectQuery("SELECT p.name as platform, ROUND\\(SUM\\(costs.amount\\), 2\\) as amount").
+			WithArgs(userID).
+			WillReturnRows(spendRows)
+
 		// Execute
 		stats, err := repo.GetFinancialStats(context.Background(), userID)
 
@@ -256,6 +283,12 @@ func TestAnalyticsRepository(t *testing.T) {
 		if len(stats.Services) != 3 {
 			t.Errorf("Expected 3 services, got %d", len(stats.Services))
 		}
+		if stats.AveragePricePerGame != 42.5 {
+			t.Errorf("Expected AveragePricePerGame=42.5, got %f", stats.AveragePricePerGame)
+		}
+		if len(stats.PlatformSpend) != 2 || stats.PlatformSpend[0].Platform != "PlayStation 5" {
+			t.Errorf("Expected 2 platforms led by 'PlayStation 5', got %+v", stats.PlatformSpend)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unmet expectations: %v", err)
@@ -399,10 +432,35 @@ func TestAnalyticsRepository(t *testing.T) {
 			AddRow("Xbox", 10).
 			AddRow("PC", 5)
 
-		mock.ExpectQuery("SELECT p.name as platform, COUNT\\(ug.id\\) as item_count").
+		mock.ExpectQuery("SELECT COALESCE\\(p.platform_group, p.name\\) as platform, COUNT\\(ug.id\\) as item_count").
 			WithArgs(userID).
 			WillReturnRows(platformRows)
 
+		// Mock tag counts
+		tagRows := sqlmock.NewRows([]string{"tag", "item_count"}).
+			AddRow("Couch co-op", 8).
+			AddRow("Sealed investments", 3)
+
+		mock.ExpectQuery("SELECT t.name as tag, COUNT\\(ugt.user_game_id\\) as item_count").
+			WithArgs(userID).
+			WillReturnRows(tagRows)
+
+		// Mock genre and theme counts
+		genreRows := sqlmock.NewRows([]string{"genre_id", "genre", "item_count"}).
+			AddRow(12, "Role-playing (RPG)", 18).
+			AddRow(31, "Adventure", 9)
+
+		mock.ExpectQuery("SELECT gen.id as genre_id, gen.name as genre, COUNT\\(ug.id\\) as item_count").
+			WithArgs(userID).
+			WillReturnRows(genreRows)
+
+		themeRows := sqlmock.NewRows([]string{"theme_id", "theme", "item_count"}).
+			AddRow(17, "Fantasy", 14)
+
+		mock.ExpectQuery("SELECT th.id as theme_id, th.name as theme, COUNT\\(ug.id\\) as item_count").
+			WithArgs(userID).
+			WillReturnRows(themeRows)
+
 		// Execute
 		stats, err := repo.GetInventoryStats(context.Background(), userID)
 
@@ -422,6 +480,15 @@ func TestAnalyticsRepository(t *testing.T) {
 		if len(stats.PlatformCounts) != 4 {
 			t.Errorf("Expected 4 platforms, got %d", len(stats.PlatformCounts))
 		}
+		if len(stats.TagCounts) != 2 || stats.TagCounts[0].Tag != "Couch co-op" {
+			t.Errorf("Expected 2 tags led by 'Couch co-op', got %+v", stats.TagCounts)
+		}
+		if len(stats.GenreCounts) != 2 || stats.GenreCounts[0].GenreID != 12 {
+			t.Errorf("Expected 2 genres led by RPG, got %+v", stats.GenreCounts)
+		}
+		if len(stats.ThemeCounts) != 1 || stats.ThemeCounts[0].Theme != "Fantasy" {
+			t.Errorf("Expected 1 theme 'Fantasy', got %+v", stats.ThemeCounts)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("Unmet expectations: %v", err)
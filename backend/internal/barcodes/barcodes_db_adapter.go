@@ -0,0 +1,96 @@
+package barcodes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type BarcodesDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewBarcodesDbAdapter(appContext *appcontext.AppContext) (*BarcodesDbAdapter, error) {
+	appContext.Logger.Debug("Creating BarcodesDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &BarcodesDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetBarcodeMappings retrieves every game a code maps to, the most confirmed first
+func (ba *BarcodesDbAdapter) GetBarcodeMappings(ctx context.Context, userID string, code string) ([]models.BarcodeMappingDB, error) {
+	var mappings []models.BarcodeMappingDB
+	if err := ba.db.SelectContext(ctx, &mappings, GetBarcodeMappingsQuery, code, userID); err != nil {
+		return nil, fmt.Errorf("error getting barcode mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// GET
+// GetTopBarcodeMappings retrieves the best mapping of each code, unknown codes are left out
+func (ba *BarcodesDbAdapter) GetTopBarcodeMappings(ctx context.Context, userID string, codes []string) ([]models.BarcodeMappingDB, error) {
+	var mappings []models.BarcodeMappingDB
+	if err := ba.db.SelectContext(ctx, &mappings, GetTopBarcodeMappingsQuery, pq.Array(codes), userID); err != nil {
+		return nil, fmt.Errorf("error getting top barcode mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// POST
+// SaveBarcodeMapping adds a mapping if it's new and moves the user's confirmation for the code onto it
+func (ba *BarcodesDbAdapter) SaveBarcodeMapping(
+	ctx context.Context,
+	userID string,
+	mapping models.BarcodeMappingToSave,
+) (models.BarcodeMappingDB, error) {
+	var saved models.BarcodeMappingDB
+	err := postgres.WithTransaction(ctx, ba.db, ba.logger, func(tx *sqlx.Tx) error {
+		var mappingID int64
+		if err := tx.GetContext(
+			ctx,
+			&mappingID,
+			UpsertBarcodeMappingQuery,
+			mapping.Code,
+			mapping.GameID,
+			mapping.GameName,
+			mapping.PlatformID,
+			mapping.PlatformName,
+			mapping.Region,
+			userID,
+		); err != nil {
+			return fmt.Errorf("error saving barcode mapping: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, DeleteOtherBarcodeConfirmationsQuery, userID, mapping.Code, mappingID); err != nil {
+			return fmt.Errorf("error moving barcode confirmation: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, ConfirmBarcodeMappingQuery, mappingID, userID); err != nil {
+			return fmt.Errorf("error confirming barcode mapping: %w", err)
+		}
+
+		if err := tx.GetContext(ctx, &saved, GetBarcodeMappingByIDQuery, mappingID, userID); err != nil {
+			return fmt.Errorf("error getting saved barcode mapping: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.BarcodeMappingDB{}, err
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,130 @@
+package barcodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Storing barcode mappings and the users confirming them
+
+	Scenarios:
+	- SaveBarcodeMapping moves the user's confirmation for the code onto the saved mapping
+	- GetTopBarcodeMappings returns one mapping per known code
+*/
+
+func TestBarcodesDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	createdAt := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+	mappingColumns := []string{
+		"id", "code", "game_id", "game_name", "platform_id", "platform_name",
+		"region", "confirmations", "confirmed_by_user", "created_at",
+	}
+
+	setupMockDB := func() (*BarcodesDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &BarcodesDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	mapping := models.BarcodeMappingToSave{
+		Code:         "0036000291452",
+		GameID:       1942,
+		GameName:     "The Witcher 3: Wild Hunt",
+		PlatformID:   48,
+		PlatformName: "PlayStation 4",
+		Region:       models.BarcodeRegionNTSCU,
+	}
+
+	/*
+		GIVEN a mapping for a code the user confirmed differently before
+		WHEN SaveBarcodeMapping is called
+		THEN it should upsert the mapping, drop the user's other confirmation for the code and confirm this one
+	*/
+	t.Run("SaveBarcodeMapping moves the user's confirmation", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO barcodes").
+			WithArgs(mapping.Code, mapping.GameID, mapping.GameName, mapping.PlatformID, mapping.PlatformName, mapping.Region, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("DELETE FROM barcode_confirmations").
+			WithArgs(userID, mapping.Code, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO barcode_confirmations").
+			WithArgs(int64(7), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("WHERE b.id = \\$1").
+			WithArgs(int64(7), userID).
+			WillReturnRows(sqlmock.NewRows(mappingColumns).AddRow(
+				7, mapping.Code, mapping.GameID, mapping.GameName, mapping.PlatformID, mapping.PlatformName,
+				mapping.Region, 3, true, createdAt,
+			))
+		mock.ExpectCommit()
+
+		// WHEN
+		saved, err := adapter.SaveBarcodeMapping(context.Background(), userID, mapping)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if saved.ID != 7 || saved.Confirmations != 3 || !saved.ConfirmedByUser {
+			t.Errorf("Expected mapping 7 confirmed by the user with 3 confirmations, got %+v", saved)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN two scanned codes, one of them unknown
+		WHEN GetTopBarcodeMappings is called
+		THEN it should return only the known code's mapping
+	*/
+	t.Run("GetTopBarcodeMappings leaves out unknown codes", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectQuery("SELECT DISTINCT ON \\(ranked.code\\)").
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnRows(sqlmock.NewRows(mappingColumns).AddRow(
+				7, mapping.Code, mapping.GameID, mapping.GameName, mapping.PlatformID, mapping.PlatformName,
+				mapping.Region, 3, false, createdAt,
+			))
+
+		// WHEN
+		mappings, err := adapter.GetTopBarcodeMappings(context.Background(), userID, []string{mapping.Code, "4006381333931"})
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(mappings) != 1 || mappings[0].Code != mapping.Code {
+			t.Errorf("Expected one mapping for '%s', got %+v", mapping.Code, mappings)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package barcodes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lokeam/qko-beta/internal/library_import"
+)
+
+// Package errors with errors.Is
+var (
+	ErrGameNotFound     = errors.New("game not found on IGDB")
+	ErrValidationFailed = errors.New("validation failed")
+	ErrDatabaseError    = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error.
+// Scan sessions surface the library import's errors as they are.
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrGameNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return library_import.GetStatusCodeForError(err)
+	}
+}
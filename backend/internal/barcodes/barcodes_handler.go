@@ -0,0 +1,151 @@
+package barcodes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// RegisterBarcodeRoutes registers barcode lookups and scan sessions under /library/barcodes
+func RegisterBarcodeRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	barcodesService services.BarcodesService,
+) {
+	r.Get("/{code}", LookupBarcode(appCtx, barcodesService))
+	r.Post("/", SaveBarcodeMapping(appCtx, barcodesService))
+	r.Post("/scan-sessions", CreateScanSession(appCtx, barcodesService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the user from the request context, logging and responding when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, requestID string) (string, bool) {
+	userID := httputils.GetUserID(r)
+	if userID == "" {
+		appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+			"request_id": requestID,
+		})
+		handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+		return "", false
+	}
+	return userID, true
+}
+
+// helper fn to decode a JSON body into a request type, an empty body leaves the request untouched
+func decodeRequest(r *http.Request, request any) error {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: invalid request body", ErrValidationFailed)
+	}
+	return nil
+}
+
+// helper fn to write a successful response
+func respond(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, userID string, status int, data map[string]any) {
+	response := httputils.NewAPIResponse(r, userID, data)
+
+	httputils.RespondWithJSON(
+		httputils.NewResponseWriterAdapter(w),
+		appCtx.Logger,
+		status,
+		response,
+	)
+}
+
+// LookupBarcode handles GET requests for the games a scanned code maps to
+func LookupBarcode(appCtx *appcontext.AppContext, barcodesService services.BarcodesService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		lookup, err := barcodesService.LookupBarcode(r.Context(), userID, chi.URLParam(r, "code"))
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"barcode": lookup})
+	}
+}
+
+// SaveBarcodeMapping handles POST requests that teach or confirm the game a code belongs to
+func SaveBarcodeMapping(appCtx *appcontext.AppContext, barcodesService services.BarcodesService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.BarcodeMappingRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		candidate, err := barcodesService.SaveBarcodeMapping(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"candidate": candidate})
+	}
+}
+
+// CreateScanSession handles POST requests that add a batch of scanned codes to a sublocation.
+// The games are added in the background, the response includes the import to poll.
+func CreateScanSession(appCtx *appcontext.AppContext, barcodesService services.BarcodesService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.BarcodeScanSessionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		session, err := barcodesService.CreateScanSession(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		status := http.StatusOK
+		if session.Import != nil {
+			status = http.StatusAccepted
+		}
+		respond(w, r, appCtx, userID, status, map[string]any{"scanSession": session})
+	}
+}
@@ -0,0 +1,95 @@
+package barcodes
+
+// Mapping lookups take the user ID as $2 to flag the mapping the user confirmed themselves.
+// Candidates are ranked by how many users confirmed them, the oldest mapping wins a tie.
+const (
+	GetBarcodeMappingsQuery = `
+		SELECT
+			b.id,
+			b.code,
+			b.game_id,
+			b.game_name,
+			b.platform_id,
+			b.platform_name,
+			b.region,
+			COUNT(bc.user_id) AS confirmations,
+			COALESCE(BOOL_OR(bc.user_id = $2), false) AS confirmed_by_user,
+			b.created_at
+		FROM barcodes b
+		LEFT JOIN barcode_confirmations bc ON bc.barcode_id = b.id
+		WHERE b.code = $1
+		GROUP BY b.id
+		ORDER BY confirmations DESC, b.created_at, b.id
+	`
+
+	// The best mapping of each code, the one the user confirmed comes before the most confirmed one
+	GetTopBarcodeMappingsQuery = `
+		SELECT DISTINCT ON (ranked.code) ranked.*
+		FROM (
+			SELECT
+				b.id,
+				b.code,
+				b.game_id,
+				b.game_name,
+				b.platform_id,
+				b.platform_name,
+				b.region,
+				COUNT(bc.user_id) AS confirmations,
+				COALESCE(BOOL_OR(bc.user_id = $2), false) AS confirmed_by_user,
+				b.created_at
+			FROM barcodes b
+			LEFT JOIN barcode_confirmations bc ON bc.barcode_id = b.id
+			WHERE b.code = ANY($1)
+			GROUP BY b.id
+		) ranked
+		ORDER BY ranked.code, ranked.confirmed_by_user DESC, ranked.confirmations DESC, ranked.created_at, ranked.id
+	`
+
+	GetBarcodeMappingByIDQuery = `
+		SELECT
+			b.id,
+			b.code,
+			b.game_id,
+			b.game_name,
+			b.platform_id,
+			b.platform_name,
+			b.region,
+			COUNT(bc.user_id) AS confirmations,
+			COALESCE(BOOL_OR(bc.user_id = $2), false) AS confirmed_by_user,
+			b.created_at
+		FROM barcodes b
+		LEFT JOIN barcode_confirmations bc ON bc.barcode_id = b.id
+		WHERE b.id = $1
+		GROUP BY b.id
+	`
+)
+
+// Teaching a mapping adds it if it's new and records the user's confirmation.
+// A known mapping keeps its region unless nobody had set one yet.
+const (
+	UpsertBarcodeMappingQuery = `
+		INSERT INTO barcodes (code, game_id, game_name, platform_id, platform_name, region, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (code, game_id, platform_id) DO UPDATE
+		SET
+			region = CASE WHEN barcodes.region = 'unknown' THEN EXCLUDED.region ELSE barcodes.region END,
+			updated_at = NOW()
+		RETURNING id
+	`
+
+	// A user confirms one mapping per code, confirming another one moves their confirmation
+	DeleteOtherBarcodeConfirmationsQuery = `
+		DELETE FROM barcode_confirmations bc
+		USING barcodes b
+		WHERE bc.barcode_id = b.id
+		AND bc.user_id = $1
+		AND b.code = $2
+		AND b.id <> $3
+	`
+
+	ConfirmBarcodeMappingQuery = `
+		INSERT INTO barcode_confirmations (barcode_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (barcode_id, user_id) DO NOTHING
+	`
+)
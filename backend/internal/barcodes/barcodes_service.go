@@ -0,0 +1,296 @@
+package barcodes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/search"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// Scan session result statuses
+const (
+	ScanResultQueued  = "queued"
+	ScanResultUnknown = "unknown"
+	ScanResultInvalid = "invalid"
+)
+
+type GameBarcodesService struct {
+	appContext     *appcontext.AppContext
+	dbAdapter      interfaces.BarcodesDbAdapter
+	igdbAdapter    interfaces.IGDBAdapter
+	libraryService services.LibraryService
+	importService  services.LibraryImportService
+	validator      interfaces.BarcodesValidator
+	logger         interfaces.Logger
+}
+
+type BarcodesService interface {
+	LookupBarcode(ctx context.Context, userID string, code string) (types.BarcodeLookupResponse, error)
+	SaveBarcodeMapping(ctx context.Context, userID string, request types.BarcodeMappingRequest) (types.BarcodeCandidateResponse, error)
+	CreateScanSession(ctx context.Context, userID string, request types.BarcodeScanSessionRequest) (types.BarcodeScanSessionResponse, error)
+}
+
+// NewGameBarcodesService creates the barcodes service.
+// Games are loaded from IGDB by ID, scan sessions are handed to the library import.
+func NewGameBarcodesService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.BarcodesDbAdapter,
+	igdbAdapter interfaces.IGDBAdapter,
+	libraryService services.LibraryService,
+	importService services.LibraryImportService,
+) (*GameBarcodesService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if igdbAdapter == nil {
+		return nil, fmt.Errorf("igdbAdapter is required")
+	}
+	if libraryService == nil {
+		return nil, fmt.Errorf("libraryService is required")
+	}
+	if importService == nil {
+		return nil, fmt.Errorf("importService is required")
+	}
+
+	return &GameBarcodesService{
+		appContext:     appContext,
+		dbAdapter:      dbAdapter,
+		igdbAdapter:    igdbAdapter,
+		libraryService: libraryService,
+		importService:  importService,
+		validator:      NewBarcodesValidator(),
+		logger:         appContext.Logger,
+	}, nil
+}
+
+// GET
+// LookupBarcode returns the games a code maps to, an unknown code has no candidates and can be taught
+func (bs *GameBarcodesService) LookupBarcode(ctx context.Context, userID string, code string) (types.BarcodeLookupResponse, error) {
+	if err := bs.validator.ValidateUserID(userID); err != nil {
+		return types.BarcodeLookupResponse{}, err
+	}
+	code, err := bs.validator.ValidateCode(code)
+	if err != nil {
+		return types.BarcodeLookupResponse{}, err
+	}
+
+	mappings, err := bs.dbAdapter.GetBarcodeMappings(ctx, userID, code)
+	if err != nil {
+		return types.BarcodeLookupResponse{}, err
+	}
+
+	gameIDs := make([]int64, 0, len(mappings))
+	for _, mapping := range mappings {
+		gameIDs = append(gameIDs, mapping.GameID)
+	}
+
+	// A candidate is still worth showing when IGDB is unavailable
+	games, err := bs.findGames(ctx, gameIDs)
+	if err != nil {
+		bs.logger.Error("Failed to load barcode candidates from IGDB", map[string]any{
+			"code":    code,
+			"gameIDs": gameIDs,
+			"error":   err,
+		})
+	}
+
+	candidates := make([]types.BarcodeCandidateResponse, 0, len(mappings))
+	for _, mapping := range mappings {
+		game := games[mapping.GameID]
+		if game != nil {
+			bs.markInLibrary(ctx, userID, game)
+		}
+
+		candidates = append(candidates, TransformMappingToCandidate(mapping, game))
+	}
+
+	return types.BarcodeLookupResponse{
+		Code:       code,
+		Candidates: candidates,
+	}, nil
+}
+
+// POST
+// SaveBarcodeMapping teaches a new mapping or confirms a known one, the game must be found on IGDB
+// and be available on the platform
+func (bs *GameBarcodesService) SaveBarcodeMapping(
+	ctx context.Context,
+	userID string,
+	request types.BarcodeMappingRequest,
+) (types.BarcodeCandidateResponse, error) {
+	if err := bs.validator.ValidateUserID(userID); err != nil {
+		return types.BarcodeCandidateResponse{}, err
+	}
+	if err := bs.validator.ValidateMappingRequest(request); err != nil {
+		return types.BarcodeCandidateResponse{}, err
+	}
+	code, err := bs.validator.ValidateCode(request.Code)
+	if err != nil {
+		return types.BarcodeCandidateResponse{}, err
+	}
+
+	games, err := bs.findGames(ctx, []int64{request.GameID})
+	if err != nil {
+		return types.BarcodeCandidateResponse{}, fmt.Errorf("error loading barcode game: %w", err)
+	}
+	game := games[request.GameID]
+	if game == nil {
+		return types.BarcodeCandidateResponse{}, fmt.Errorf("%w: no game %d found on IGDB", ErrGameNotFound, request.GameID)
+	}
+
+	var platform *models.PlatformInfo
+	for i := range game.Platforms {
+		if game.Platforms[i].ID == request.PlatformID {
+			platform = &game.Platforms[i]
+			break
+		}
+	}
+	if platform == nil {
+		return types.BarcodeCandidateResponse{}, fmt.Errorf("%w: '%s' is not available on platform %d", ErrValidationFailed, game.Name, request.PlatformID)
+	}
+
+	mapping, err := bs.dbAdapter.SaveBarcodeMapping(ctx, userID, TransformMappingRequestToModel(request, code, *game, *platform))
+	if err != nil {
+		return types.BarcodeCandidateResponse{}, err
+	}
+
+	bs.markInLibrary(ctx, userID, game)
+	return TransformMappingToCandidate(mapping, game), nil
+}
+
+// POST
+// CreateScanSession adds each recognized code as a physical copy in the sublocation through a library import.
+// Each code uses the mapping the user confirmed, or the most confirmed one. Scanning a code twice adds two copies.
+func (bs *GameBarcodesService) CreateScanSession(
+	ctx context.Context,
+	userID string,
+	request types.BarcodeScanSessionRequest,
+) (types.BarcodeScanSessionResponse, error) {
+	bs.logger.Info("GameBarcodesService - CreateScanSession called", map[string]any{
+		"userID":        userID,
+		"sublocationID": request.SublocationID,
+		"codes":         len(request.Codes),
+		"dryRun":        request.DryRun,
+	})
+
+	if err := bs.validator.ValidateUserID(userID); err != nil {
+		return types.BarcodeScanSessionResponse{}, err
+	}
+	if err := bs.validator.ValidateScanSessionRequest(request); err != nil {
+		return types.BarcodeScanSessionResponse{}, err
+	}
+
+	codes := make([]string, len(request.Codes))
+	var validCodes []string
+	results := make([]types.BarcodeScanResultResponse, len(request.Codes))
+	for i, scanned := range request.Codes {
+		code, err := bs.validator.ValidateCode(scanned)
+		if err != nil {
+			results[i] = types.BarcodeScanResultResponse{Code: scanned, Status: ScanResultInvalid, Message: err.Error()}
+			continue
+		}
+		codes[i] = code
+		validCodes = append(validCodes, code)
+	}
+
+	mappingsByCode := make(map[string]models.BarcodeMappingDB)
+	if len(validCodes) > 0 {
+		mappings, err := bs.dbAdapter.GetTopBarcodeMappings(ctx, userID, validCodes)
+		if err != nil {
+			return types.BarcodeScanSessionResponse{}, err
+		}
+		for _, mapping := range mappings {
+			mappingsByCode[mapping.Code] = mapping
+		}
+	}
+
+	var rows []models.LibraryImportRowToSave
+	for i, code := range codes {
+		if code == "" {
+			continue
+		}
+
+		mapping, ok := mappingsByCode[code]
+		if !ok {
+			results[i] = types.BarcodeScanResultResponse{Code: code, Status: ScanResultUnknown, Message: "no game is mapped to this code yet"}
+			continue
+		}
+
+		row := models.LibraryImportRowToSave{
+			RowNumber:  len(rows) + 1,
+			Title:      mapping.GameName,
+			IGDBID:     mapping.GameID,
+			Platform:   strconv.FormatInt(mapping.PlatformID, 10),
+			GameType:   "physical",
+			Location:   request.SublocationID,
+			ExternalID: code,
+		}
+		rows = append(rows, row)
+
+		results[i] = types.BarcodeScanResultResponse{
+			Code:         code,
+			Status:       ScanResultQueued,
+			RowNumber:    row.RowNumber,
+			GameID:       mapping.GameID,
+			GameName:     mapping.GameName,
+			PlatformName: mapping.PlatformName,
+		}
+	}
+
+	response := types.BarcodeScanSessionResponse{Results: results}
+	if len(rows) == 0 {
+		return response, nil
+	}
+
+	job, err := bs.importService.CreateImportJob(ctx, userID, models.LibraryImportSourceBarcode, request.DryRun, rows)
+	if err != nil {
+		return types.BarcodeScanSessionResponse{}, err
+	}
+	response.Import = &job
+
+	return response, nil
+}
+
+// findGames loads the games with the mappings' IGDB IDs, keyed by ID.
+// IDs IGDB doesn't return are missing from the map.
+func (bs *GameBarcodesService) findGames(ctx context.Context, gameIDs []int64) (map[int64]*models.Game, error) {
+	games := make(map[int64]*models.Game, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return games, nil
+	}
+
+	var fetched []*models.Game
+	err := search.WithTokenRefresh(ctx, bs.appContext, bs.igdbAdapter, func() error {
+		var err error
+		fetched, err = bs.igdbAdapter.GetGamesByIDs(ctx, gameIDs)
+		return err
+	})
+	if err != nil {
+		return games, err
+	}
+
+	for _, game := range fetched {
+		if game != nil {
+			games[game.ID] = game
+		}
+	}
+	return games, nil
+}
+
+// markInLibrary flags a game the user already owns, a failed check leaves it unflagged
+func (bs *GameBarcodesService) markInLibrary(ctx context.Context, userID string, game *models.Game) {
+	isInLibrary, err := bs.libraryService.IsGameInLibraryBFF(ctx, userID, game.ID)
+	if err != nil {
+		bs.logger.Error("Failed to check if game is in library", map[string]any{
+			"gameID": game.ID,
+			"error":  err,
+		})
+	}
+	game.IsInLibrary = isInLibrary
+}
@@ -0,0 +1,57 @@
+package barcodes
+
+import (
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformMappingRequestToModel builds a mapping from a validated request, names are taken from the IGDB game
+func TransformMappingRequestToModel(
+	request types.BarcodeMappingRequest,
+	code string,
+	game models.Game,
+	platform models.PlatformInfo,
+) models.BarcodeMappingToSave {
+	region := strings.TrimSpace(request.Region)
+	if region == "" {
+		region = models.BarcodeRegionUnknown
+	}
+
+	return models.BarcodeMappingToSave{
+		Code:         code,
+		GameID:       game.ID,
+		GameName:     game.Name,
+		PlatformID:   platform.ID,
+		PlatformName: platform.Name,
+		Region:       region,
+	}
+}
+
+// TransformMappingToCandidate converts a mapping, game is nil when search didn't find it
+func TransformMappingToCandidate(mapping models.BarcodeMappingDB, game *models.Game) types.BarcodeCandidateResponse {
+	candidate := types.BarcodeCandidateResponse{
+		ID:              mapping.ID,
+		GameID:          mapping.GameID,
+		GameName:        mapping.GameName,
+		PlatformID:      mapping.PlatformID,
+		PlatformName:    mapping.PlatformName,
+		Region:          mapping.Region,
+		Confirmations:   mapping.Confirmations,
+		ConfirmedByUser: mapping.ConfirmedByUser,
+	}
+
+	if game != nil {
+		candidate.Game = &types.BarcodeGameResponse{
+			ID:               game.ID,
+			Name:             game.Name,
+			CoverURL:         game.CoverURL,
+			FirstReleaseDate: game.FirstReleaseDate,
+			Rating:           game.Rating,
+			IsInLibrary:      game.IsInLibrary,
+		}
+	}
+
+	return candidate
+}
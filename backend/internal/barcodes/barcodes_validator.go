@@ -0,0 +1,113 @@
+package barcodes
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	// MaxScanSessionCodes caps a scan session, larger shelves can be scanned in several sessions
+	MaxScanSessionCodes = 200
+	MaxGameNameLength   = 255
+)
+
+type BarcodesValidatorImpl struct{}
+
+func NewBarcodesValidator() interfaces.BarcodesValidator {
+	return &BarcodesValidatorImpl{}
+}
+
+func (v *BarcodesValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+// ValidateCode checks a scanned UPC-A or EAN-13 code and returns it as a GTIN-13.
+// Spaces and dashes are ignored, UPC-A codes get a leading zero.
+func (v *BarcodesValidatorImpl) ValidateCode(code string) (string, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+	if normalized == "" {
+		return "", fmt.Errorf("%w: code is required", ErrValidationFailed)
+	}
+	for _, r := range normalized {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: code '%s' must only contain digits", ErrValidationFailed, code)
+		}
+	}
+
+	switch len(normalized) {
+	case 12:
+		normalized = "0" + normalized
+	case 13:
+	default:
+		return "", fmt.Errorf("%w: code '%s' must be a 12 digit UPC or a 13 digit EAN", ErrValidationFailed, code)
+	}
+
+	if !hasValidCheckDigit(normalized) {
+		return "", fmt.Errorf("%w: code '%s' has an invalid check digit", ErrValidationFailed, code)
+	}
+	return normalized, nil
+}
+
+func (v *BarcodesValidatorImpl) ValidateMappingRequest(request types.BarcodeMappingRequest) error {
+	if _, err := v.ValidateCode(request.Code); err != nil {
+		return err
+	}
+	if request.GameID <= 0 {
+		return fmt.Errorf("%w: gameId is required", ErrValidationFailed)
+	}
+
+	gameName := strings.TrimSpace(request.GameName)
+	if gameName == "" {
+		return fmt.Errorf("%w: gameName is required", ErrValidationFailed)
+	}
+	if utf8.RuneCountInString(gameName) > MaxGameNameLength {
+		return fmt.Errorf("%w: gameName must be %d characters or less", ErrValidationFailed, MaxGameNameLength)
+	}
+
+	if request.PlatformID <= 0 {
+		return fmt.Errorf("%w: platformId is required", ErrValidationFailed)
+	}
+
+	if request.Region == "" {
+		return nil
+	}
+	for _, region := range models.BarcodeRegions {
+		if request.Region == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: invalid region '%s', must be one of: %s", ErrValidationFailed, request.Region, strings.Join(models.BarcodeRegions, ", "))
+}
+
+// ValidateScanSessionRequest checks the session itself, codes are checked one by one so a bad scan doesn't fail the session
+func (v *BarcodesValidatorImpl) ValidateScanSessionRequest(request types.BarcodeScanSessionRequest) error {
+	if _, err := uuid.Parse(request.SublocationID); err != nil {
+		return fmt.Errorf("%w: invalid sublocationId '%s'", ErrValidationFailed, request.SublocationID)
+	}
+	if len(request.Codes) == 0 || len(request.Codes) > MaxScanSessionCodes {
+		return fmt.Errorf("%w: a scan session must have between 1 and %d codes", ErrValidationFailed, MaxScanSessionCodes)
+	}
+	return nil
+}
+
+// hasValidCheckDigit applies the GTIN check digit, digits alternate between a weight of 1 and 3
+func hasValidCheckDigit(code string) bool {
+	sum := 0
+	for i := 0; i < len(code)-1; i++ {
+		digit := int(code[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
@@ -0,0 +1,155 @@
+package barcodes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Validating scanned codes, mapping requests and scan sessions
+
+	Scenarios:
+	- UPC-A codes are stored as GTIN-13, spaces and dashes are ignored
+	- Codes with a wrong length, letters or a bad check digit are rejected
+	- A mapping needs a game, a platform and a known region
+	- A scan session needs a sublocation and a bounded number of codes
+*/
+
+func TestBarcodesValidator(t *testing.T) {
+	validator := NewBarcodesValidator()
+
+	/*
+		GIVEN a 12 digit UPC-A code with spaces
+		WHEN ValidateCode is called
+		THEN it should return the code as a GTIN-13
+	*/
+	t.Run("UPC-A codes are padded to GTIN-13", func(t *testing.T) {
+		// WHEN
+		code, err := validator.ValidateCode(" 0 36000 29145 2 ")
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if code != "0036000291452" {
+			t.Errorf("Expected code '0036000291452', got '%s'", code)
+		}
+	})
+
+	/*
+		GIVEN a 13 digit EAN code with dashes
+		WHEN ValidateCode is called
+		THEN it should return the digits unchanged
+	*/
+	t.Run("EAN-13 codes are kept", func(t *testing.T) {
+		// WHEN
+		code, err := validator.ValidateCode("400-6381-333931")
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if code != "4006381333931" {
+			t.Errorf("Expected code '4006381333931', got '%s'", code)
+		}
+	})
+
+	/*
+		GIVEN codes that aren't valid UPC or EAN barcodes
+		WHEN ValidateCode is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("Invalid codes are rejected", func(t *testing.T) {
+		// GIVEN
+		codes := []string{
+			"",
+			"12345",
+			"03600029145A",
+			"036000291453",  // wrong check digit
+			"4006381333932", // wrong check digit
+		}
+
+		for _, code := range codes {
+			// WHEN
+			_, err := validator.ValidateCode(code)
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed for '%s', got %v", code, err)
+			}
+		}
+	})
+
+	/*
+		GIVEN mapping requests missing a field or with an unknown region
+		WHEN ValidateMappingRequest is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("Mapping requests need a game, platform and known region", func(t *testing.T) {
+		// GIVEN
+		valid := types.BarcodeMappingRequest{
+			Code:       "036000291452",
+			GameID:     1942,
+			GameName:   "The Witcher 3",
+			PlatformID: 48,
+			Region:     "ntsc-u",
+		}
+		if err := validator.ValidateMappingRequest(valid); err != nil {
+			t.Fatalf("Expected valid request, got %v", err)
+		}
+
+		missingGame := valid
+		missingGame.GameID = 0
+		missingName := valid
+		missingName.GameName = "  "
+		missingPlatform := valid
+		missingPlatform.PlatformID = 0
+		unknownRegion := valid
+		unknownRegion.Region = "europe"
+
+		for name, request := range map[string]types.BarcodeMappingRequest{
+			"missing game":     missingGame,
+			"missing name":     missingName,
+			"missing platform": missingPlatform,
+			"unknown region":   unknownRegion,
+		} {
+			// WHEN
+			err := validator.ValidateMappingRequest(request)
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed for %s, got %v", name, err)
+			}
+		}
+	})
+
+	/*
+		GIVEN scan sessions without a sublocation, without codes or with too many codes
+		WHEN ValidateScanSessionRequest is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("Scan sessions need a sublocation and codes", func(t *testing.T) {
+		// GIVEN
+		sublocationID := "4b3c2d1e-5f6a-4b7c-8d9e-0f1a2b3c4d5e"
+		tooManyCodes := make([]string, MaxScanSessionCodes+1)
+
+		requests := map[string]types.BarcodeScanSessionRequest{
+			"invalid sublocation": {SublocationID: "shelf", Codes: []string{"036000291452"}},
+			"no codes":            {SublocationID: sublocationID},
+			"too many codes":      {SublocationID: sublocationID, Codes: tooManyCodes},
+		}
+
+		for name, request := range requests {
+			// WHEN
+			err := validator.ValidateScanSessionRequest(request)
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed for %s, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,360 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type CollectionsDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewCollectionsDbAdapter(appContext *appcontext.AppContext) (*CollectionsDbAdapter, error) {
+	appContext.Logger.Debug("Creating CollectionsDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &CollectionsDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// -- TAGS --
+
+// GET
+// GetTags retrieves the user's tags, ordered by name
+func (ca *CollectionsDbAdapter) GetTags(ctx context.Context, userID string) ([]models.UserTagDB, error) {
+	var tags []models.UserTagDB
+	if err := ca.db.SelectContext(ctx, &tags, GetTagsQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// POST
+// CreateTag adds a tag, returns ErrDuplicateName if the user already has one with the same name
+func (ca *CollectionsDbAdapter) CreateTag(ctx context.Context, userID string, name string) (models.UserTagDB, error) {
+	var tag models.UserTagDB
+	if err := ca.db.GetContext(ctx, &tag, CreateTagQuery, userID, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.UserTagDB{}, fmt.Errorf("%w: a tag named '%s' already exists", ErrDuplicateName, name)
+		}
+		return models.UserTagDB{}, fmt.Errorf("error creating tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// PUT
+// UpdateTag renames a tag
+func (ca *CollectionsDbAdapter) UpdateTag(
+	ctx context.Context,
+	userID string,
+	tagID string,
+	name string,
+) (models.UserTagDB, error) {
+	var tag models.UserTagDB
+	err := postgres.WithTransaction(ctx, ca.db, ca.logger, func(tx *sqlx.Tx) error {
+		var taken bool
+		if err := tx.GetContext(ctx, &taken, CheckTagNameTakenQuery, userID, name, tagID); err != nil {
+			return fmt.Errorf("error checking tag name: %w", err)
+		}
+		if taken {
+			return fmt.Errorf("%w: a tag named '%s' already exists", ErrDuplicateName, name)
+		}
+
+		if err := tx.GetContext(ctx, &tag, UpdateTagQuery, userID, tagID, name); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTagNotFound
+			}
+			return fmt.Errorf("error updating tag: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.UserTagDB{}, err
+	}
+
+	return tag, nil
+}
+
+// DELETE
+// DeleteTag removes a tag, its links to library games go with it
+func (ca *CollectionsDbAdapter) DeleteTag(ctx context.Context, userID string, tagID string) error {
+	result, err := ca.db.ExecContext(ctx, DeleteTagQuery, userID, tagID)
+	if err != nil {
+		return fmt.Errorf("error deleting tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking deleted tag: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrTagNotFound
+	}
+
+	return nil
+}
+
+// POST
+// TagGames links every selected copy to every tag, links that already exist are skipped.
+// Returns ErrTagNotFound if any tag doesn't belong to the user.
+func (ca *CollectionsDbAdapter) TagGames(
+	ctx context.Context,
+	userID string,
+	tagIDs []string,
+	selection models.LibraryGameSelection,
+) (int64, error) {
+	return ca.updateTagLinks(ctx, userID, tagIDs, selection, TagGamesQuery)
+}
+
+// DELETE
+// UntagGames removes the tags from every selected copy
+func (ca *CollectionsDbAdapter) UntagGames(
+	ctx context.Context,
+	userID string,
+	tagIDs []string,
+	selection models.LibraryGameSelection,
+) (int64, error) {
+	return ca.updateTagLinks(ctx, userID, tagIDs, selection, UntagGamesQuery)
+}
+
+func (ca *CollectionsDbAdapter) updateTagLinks(
+	ctx context.Context,
+	userID string,
+	tagIDs []string,
+	selection models.LibraryGameSelection,
+	query string,
+) (int64, error) {
+	var updated int64
+	err := postgres.WithTransaction(ctx, ca.db, ca.logger, func(tx *sqlx.Tx) error {
+		var ownedTags int
+		if err := tx.GetContext(ctx, &ownedTags, CountUserTagsQuery, userID, pq.Array(tagIDs)); err != nil {
+			return fmt.Errorf("error checking tags: %w", err)
+		}
+		if ownedTags != len(tagIDs) {
+			return ErrTagNotFound
+		}
+
+		result, err := tx.ExecContext(
+			ctx,
+			query,
+			userID,
+			pq.Array(tagIDs),
+			int64Array(selection.UserGameIDs),
+			int64Array(selection.GameIDs),
+		)
+		if err != nil {
+			return fmt.Errorf("error updating game tags: %w", err)
+		}
+
+		updated, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking updated game tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// -- COLLECTIONS --
+
+// GET
+// GetCollections retrieves the user's collections without their games
+func (ca *CollectionsDbAdapter) GetCollections(ctx context.Context, userID string) ([]models.CollectionDB, error) {
+	var collections []models.CollectionDB
+	if err := ca.db.SelectContext(ctx, &collections, GetCollectionsQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// GET
+// GetCollection retrieves a collection and the copies in it
+func (ca *CollectionsDbAdapter) GetCollection(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+) (models.CollectionDB, []models.CollectionGameDB, error) {
+	var collection models.CollectionDB
+	if err := ca.db.GetContext(ctx, &collection, GetCollectionQuery, userID, collectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.CollectionDB{}, nil, ErrCollectionNotFound
+		}
+		return models.CollectionDB{}, nil, fmt.Errorf("error getting collection: %w", err)
+	}
+
+	var games []models.CollectionGameDB
+	if err := ca.db.SelectContext(ctx, &games, GetCollectionGamesQuery, collectionID); err != nil {
+		return models.CollectionDB{}, nil, fmt.Errorf("error getting collection games: %w", err)
+	}
+
+	return collection, games, nil
+}
+
+// POST
+// CreateCollection adds a collection, returns ErrDuplicateName if the name is already in use
+func (ca *CollectionsDbAdapter) CreateCollection(
+	ctx context.Context,
+	userID string,
+	name string,
+	description string,
+) (models.CollectionDB, error) {
+	var collection models.CollectionDB
+	if err := ca.db.GetContext(ctx, &collection, CreateCollectionQuery, userID, name, description); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.CollectionDB{}, fmt.Errorf("%w: a collection named '%s' already exists", ErrDuplicateName, name)
+		}
+		return models.CollectionDB{}, fmt.Errorf("error creating collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+// PUT
+// UpdateCollection replaces a collection's name and description
+func (ca *CollectionsDbAdapter) UpdateCollection(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	name string,
+	description string,
+) (models.CollectionDB, error) {
+	var collection models.CollectionDB
+	err := postgres.WithTransaction(ctx, ca.db, ca.logger, func(tx *sqlx.Tx) error {
+		var taken bool
+		if err := tx.GetContext(ctx, &taken, CheckCollectionNameTakenQuery, userID, name, collectionID); err != nil {
+			return fmt.Errorf("error checking collection name: %w", err)
+		}
+		if taken {
+			return fmt.Errorf("%w: a collection named '%s' already exists", ErrDuplicateName, name)
+		}
+
+		if err := tx.GetContext(ctx, &collection, UpdateCollectionQuery, userID, collectionID, name, description); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCollectionNotFound
+			}
+			return fmt.Errorf("error updating collection: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.CollectionDB{}, err
+	}
+
+	return collection, nil
+}
+
+// DELETE
+// DeleteCollection removes a collection, the games in it stay in the library
+func (ca *CollectionsDbAdapter) DeleteCollection(ctx context.Context, userID string, collectionID string) error {
+	result, err := ca.db.ExecContext(ctx, DeleteCollectionQuery, userID, collectionID)
+	if err != nil {
+		return fmt.Errorf("error deleting collection: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking deleted collection: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+// POST
+// AddCollectionGames adds the selected copies to a collection, copies already in it are skipped
+func (ca *CollectionsDbAdapter) AddCollectionGames(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	selection models.LibraryGameSelection,
+) (int64, error) {
+	return ca.updateCollectionGames(ctx, userID, collectionID, selection, AddCollectionGamesQuery)
+}
+
+// DELETE
+// RemoveCollectionGames takes the selected copies out of a collection
+func (ca *CollectionsDbAdapter) RemoveCollectionGames(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	selection models.LibraryGameSelection,
+) (int64, error) {
+	return ca.updateCollectionGames(ctx, userID, collectionID, selection, RemoveCollectionGamesQuery)
+}
+
+func (ca *CollectionsDbAdapter) updateCollectionGames(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	selection models.LibraryGameSelection,
+	query string,
+) (int64, error) {
+	var updated int64
+	err := postgres.WithTransaction(ctx, ca.db, ca.logger, func(tx *sqlx.Tx) error {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, CheckCollectionExistsQuery, userID, collectionID); err != nil {
+			return fmt.Errorf("error checking collection: %w", err)
+		}
+		if !exists {
+			return ErrCollectionNotFound
+		}
+
+		result, err := tx.ExecContext(
+			ctx,
+			query,
+			userID,
+			collectionID,
+			int64Array(selection.UserGameIDs),
+			int64Array(selection.GameIDs),
+		)
+		if err != nil {
+			return fmt.Errorf("error updating collection games: %w", err)
+		}
+
+		updated, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking updated collection games: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// int64Array always sends an array, ANY() over NULL would make the whole selection NULL
+func int64Array(values []int64) any {
+	if values == nil {
+		values = []int64{}
+	}
+	return pq.Array(values)
+}
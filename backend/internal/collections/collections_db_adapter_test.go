@@ -0,0 +1,168 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Creating tags and collections with unique names
+	- Linking library games to tags
+
+	Scenarios:
+	- CreateTag returns ErrDuplicateName when the name is taken
+	- TagGames returns ErrTagNotFound when a tag belongs to someone else
+	- TagGames reports how many links were added
+	- UpdateCollection returns ErrCollectionNotFound for unknown collections
+*/
+
+func TestCollectionsDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	tagID := "0b0d6c3e-8f43-4b8e-9e4c-7f5d0b1b2c3d"
+	collectionID := "5a7c2e10-3b9d-4f6a-8c1e-2d4f6a8b0c1e"
+
+	setupMockDB := func() (*CollectionsDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &CollectionsDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN a tag name the user already has
+		WHEN CreateTag is called
+		THEN it should return ErrDuplicateName
+	*/
+	t.Run("CreateTag returns duplicate name", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectQuery("INSERT INTO user_tags").
+			WithArgs(userID, "Couch co-op").
+			WillReturnError(sql.ErrNoRows)
+
+		// WHEN
+		_, err = adapter.CreateTag(context.Background(), userID, "Couch co-op")
+
+		// THEN
+		if !errors.Is(err, ErrDuplicateName) {
+			t.Errorf("Expected ErrDuplicateName, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a tag ID the user doesn't own
+		WHEN TagGames is called
+		THEN it should return ErrTagNotFound without linking anything
+	*/
+	t.Run("TagGames rejects unknown tags", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM user_tags").
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.TagGames(context.Background(), userID, []string{tagID}, models.LibraryGameSelection{GameIDs: []int64{1942}})
+
+		// THEN
+		if !errors.Is(err, ErrTagNotFound) {
+			t.Errorf("Expected ErrTagNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a tag the user owns and two copies of a game
+		WHEN TagGames is called
+		THEN it should report both new links
+	*/
+	t.Run("TagGames reports added links", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM user_tags").
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO user_game_tags").
+			WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		// WHEN
+		updated, err := adapter.TagGames(context.Background(), userID, []string{tagID}, models.LibraryGameSelection{GameIDs: []int64{1942}})
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated != 2 {
+			t.Errorf("Expected 2 links, got %d", updated)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a collection ID that doesn't belong to the user
+		WHEN UpdateCollection is called
+		THEN it should return ErrCollectionNotFound
+	*/
+	t.Run("UpdateCollection returns not found", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(userID, "Sealed investments", collectionID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery("UPDATE collections").
+			WithArgs(userID, collectionID, "Sealed investments", "").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.UpdateCollection(context.Background(), userID, collectionID, "Sealed investments", "")
+
+		// THEN
+		if !errors.Is(err, ErrCollectionNotFound) {
+			t.Errorf("Expected ErrCollectionNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package collections
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrDuplicateName      = errors.New("name is already in use")
+	ErrValidationFailed   = errors.New("validation failed")
+	ErrDatabaseError      = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrTagNotFound), errors.Is(err, ErrCollectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrDuplicateName):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,399 @@
+package collections
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// RegisterTagRoutes registers tag CRUD and bulk tagging under /library/tags
+func RegisterTagRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	collectionsService services.CollectionsService,
+) {
+	r.Get("/", GetTags(appCtx, collectionsService))
+	r.Post("/", CreateTag(appCtx, collectionsService))
+	r.Post("/bulk-tag", BulkTagGames(appCtx, collectionsService))
+	r.Post("/bulk-untag", BulkUntagGames(appCtx, collectionsService))
+	r.Put("/{tagID}", UpdateTag(appCtx, collectionsService))
+	r.Delete("/{tagID}", DeleteTag(appCtx, collectionsService))
+}
+
+// RegisterCollectionRoutes registers collection CRUD and membership under /library/collections
+func RegisterCollectionRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	collectionsService services.CollectionsService,
+) {
+	r.Get("/", GetCollections(appCtx, collectionsService))
+	r.Post("/", CreateCollection(appCtx, collectionsService))
+	r.Get("/{collectionID}", GetCollection(appCtx, collectionsService))
+	r.Put("/{collectionID}", UpdateCollection(appCtx, collectionsService))
+	r.Delete("/{collectionID}", DeleteCollection(appCtx, collectionsService))
+	r.Post("/{collectionID}/games", AddCollectionGames(appCtx, collectionsService))
+	r.Post("/{collectionID}/games/remove", RemoveCollectionGames(appCtx, collectionsService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the user from the request context, logging and responding when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, requestID string) (string, bool) {
+	userID := httputils.GetUserID(r)
+	if userID == "" {
+		appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+			"request_id": requestID,
+		})
+		handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+		return "", false
+	}
+	return userID, true
+}
+
+// helper fn to decode a JSON body into a request type
+func decodeRequest(r *http.Request, request any) error {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		return fmt.Errorf("%w: invalid request body", ErrValidationFailed)
+	}
+	return nil
+}
+
+// helper fn to write a successful response
+func respond(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, userID string, status int, data map[string]any) {
+	response := httputils.NewAPIResponse(r, userID, data)
+
+	httputils.RespondWithJSON(
+		httputils.NewResponseWriterAdapter(w),
+		appCtx.Logger,
+		status,
+		response,
+	)
+}
+
+// -- TAGS --
+
+// GetTags handles GET requests for the user's tags
+func GetTags(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		tags, err := collectionsService.GetTags(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"tags": tags})
+	}
+}
+
+// CreateTag handles POST requests to create a tag
+func CreateTag(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.TagRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		tag, err := collectionsService.CreateTag(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusCreated, map[string]any{"tag": tag})
+	}
+}
+
+// UpdateTag handles PUT requests to rename a tag
+func UpdateTag(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.TagRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		tag, err := collectionsService.UpdateTag(r.Context(), userID, chi.URLParam(r, "tagID"), request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"tag": tag})
+	}
+}
+
+// DeleteTag handles DELETE requests for a tag, the tagged games stay in the library
+func DeleteTag(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		tagID := chi.URLParam(r, "tagID")
+		if err := collectionsService.DeleteTag(r.Context(), userID, tagID); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{
+			"id":      tagID,
+			"message": "tag deleted",
+		})
+	}
+}
+
+// BulkTagGames handles POST requests that add tags to many library games at once
+func BulkTagGames(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.BulkTagRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		result, err := collectionsService.BulkTagGames(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"result": result})
+	}
+}
+
+// BulkUntagGames handles POST requests that remove tags from many library games at once
+func BulkUntagGames(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.BulkTagRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		result, err := collectionsService.BulkUntagGames(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"result": result})
+	}
+}
+
+// -- COLLECTIONS --
+
+// GetCollections handles GET requests for the user's collections
+func GetCollections(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		collections, err := collectionsService.GetCollections(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"collections": collections})
+	}
+}
+
+// GetCollection handles GET requests for a collection and the games in it
+func GetCollection(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		collection, err := collectionsService.GetCollection(r.Context(), userID, chi.URLParam(r, "collectionID"))
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"collection": collection})
+	}
+}
+
+// CreateCollection handles POST requests to create a collection
+func CreateCollection(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.CollectionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		collection, err := collectionsService.CreateCollection(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusCreated, map[string]any{"collection": collection})
+	}
+}
+
+// UpdateCollection handles PUT requests to rename or describe a collection
+func UpdateCollection(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.CollectionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		collection, err := collectionsService.UpdateCollection(r.Context(), userID, chi.URLParam(r, "collectionID"), request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"collection": collection})
+	}
+}
+
+// DeleteCollection handles DELETE requests for a collection, the games in it stay in the library
+func DeleteCollection(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		collectionID := chi.URLParam(r, "collectionID")
+		if err := collectionsService.DeleteCollection(r.Context(), userID, collectionID); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{
+			"id":      collectionID,
+			"message": "collection deleted",
+		})
+	}
+}
+
+// AddCollectionGames handles POST requests that add library games to a collection
+func AddCollectionGames(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.LibraryGameSelectionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		result, err := collectionsService.AddCollectionGames(r.Context(), userID, chi.URLParam(r, "collectionID"), request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"result": result})
+	}
+}
+
+// RemoveCollectionGames handles POST requests that take library games out of a collection
+func RemoveCollectionGames(appCtx *appcontext.AppContext, collectionsService services.CollectionsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.LibraryGameSelectionRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		result, err := collectionsService.RemoveCollectionGames(r.Context(), userID, chi.URLParam(r, "collectionID"), request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"result": result})
+	}
+}
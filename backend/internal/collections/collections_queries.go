@@ -0,0 +1,166 @@
+package collections
+
+const (
+	// -- TAGS --
+	GetTagsQuery = `
+		SELECT t.id, t.user_id, t.name, t.created_at, t.updated_at,
+			(SELECT COUNT(*) FROM user_game_tags ugt
+				JOIN user_games ug ON ug.id = ugt.user_game_id
+				WHERE ugt.tag_id = t.id AND ug.deleted_at IS NULL) as game_count
+		FROM user_tags t
+		WHERE t.user_id = $1
+		ORDER BY LOWER(t.name)
+	`
+
+	// A name that is already taken inserts nothing, the adapter turns that into ErrDuplicateName
+	CreateTagQuery = `
+		INSERT INTO user_tags (user_id, name)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+		RETURNING id, user_id, name, created_at, updated_at, 0 as game_count
+	`
+
+	UpdateTagQuery = `
+		UPDATE user_tags t
+		SET name = $3, updated_at = NOW()
+		WHERE t.id = $2 AND t.user_id = $1
+		RETURNING t.id, t.user_id, t.name, t.created_at, t.updated_at,
+			(SELECT COUNT(*) FROM user_game_tags ugt
+				JOIN user_games ug ON ug.id = ugt.user_game_id
+				WHERE ugt.tag_id = t.id AND ug.deleted_at IS NULL) as game_count
+	`
+
+	DeleteTagQuery = `
+		DELETE FROM user_tags
+		WHERE id = $2 AND user_id = $1
+	`
+
+	CheckTagNameTakenQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM user_tags
+			WHERE user_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3
+		)
+	`
+
+	CountUserTagsQuery = `
+		SELECT COUNT(*) FROM user_tags
+		WHERE user_id = $1 AND id = ANY($2::uuid[])
+	`
+
+	// $3 user_games IDs, $4 game IDs, a game ID selects every copy of the game
+	TagGamesQuery = `
+		INSERT INTO user_game_tags (user_game_id, tag_id)
+		SELECT ug.id, t.id
+		FROM user_games ug
+		JOIN user_tags t ON t.user_id = ug.user_id
+		WHERE ug.user_id = $1
+			AND ug.deleted_at IS NULL
+			AND t.id = ANY($2::uuid[])
+			AND (ug.id = ANY($3::int[]) OR ug.game_id = ANY($4::bigint[]))
+		ON CONFLICT DO NOTHING
+	`
+
+	UntagGamesQuery = `
+		DELETE FROM user_game_tags ugt
+		USING user_games ug
+		WHERE ugt.user_game_id = ug.id
+			AND ug.user_id = $1
+			AND ug.deleted_at IS NULL
+			AND ugt.tag_id = ANY($2::uuid[])
+			AND (ug.id = ANY($3::int[]) OR ug.game_id = ANY($4::bigint[]))
+	`
+
+	// -- COLLECTIONS --
+	GetCollectionsQuery = `
+		SELECT c.id, c.user_id, c.name, c.description, c.created_at, c.updated_at,
+			(SELECT COUNT(*) FROM collection_games cg
+				JOIN user_games ug ON ug.id = cg.user_game_id
+				WHERE cg.collection_id = c.id AND ug.deleted_at IS NULL) as game_count
+		FROM collections c
+		WHERE c.user_id = $1
+		ORDER BY LOWER(c.name)
+	`
+
+	GetCollectionQuery = `
+		SELECT c.id, c.user_id, c.name, c.description, c.created_at, c.updated_at,
+			(SELECT COUNT(*) FROM collection_games cg
+				JOIN user_games ug ON ug.id = cg.user_game_id
+				WHERE cg.collection_id = c.id AND ug.deleted_at IS NULL) as game_count
+		FROM collections c
+		WHERE c.id = $2 AND c.user_id = $1
+	`
+
+	GetCollectionGamesQuery = `
+		SELECT
+			ug.id as user_game_id,
+			g.id as game_id,
+			g.name as game_name,
+			COALESCE(g.cover_url, '') as cover_url,
+			p.id as platform_id,
+			p.name as platform_name,
+			ug.game_type,
+			cg.created_at as added_at
+		FROM collection_games cg
+		JOIN user_games ug ON ug.id = cg.user_game_id
+		JOIN games g ON g.id = ug.game_id
+		JOIN platforms p ON p.id = ug.platform_id
+		WHERE cg.collection_id = $1 AND ug.deleted_at IS NULL
+		ORDER BY LOWER(g.name), p.name
+	`
+
+	CreateCollectionQuery = `
+		INSERT INTO collections (user_id, name, description)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT DO NOTHING
+		RETURNING id, user_id, name, description, created_at, updated_at, 0 as game_count
+	`
+
+	UpdateCollectionQuery = `
+		UPDATE collections c
+		SET name = $3, description = NULLIF($4, ''), updated_at = NOW()
+		WHERE c.id = $2 AND c.user_id = $1
+		RETURNING c.id, c.user_id, c.name, c.description, c.created_at, c.updated_at,
+			(SELECT COUNT(*) FROM collection_games cg
+				JOIN user_games ug ON ug.id = cg.user_game_id
+				WHERE cg.collection_id = c.id AND ug.deleted_at IS NULL) as game_count
+	`
+
+	DeleteCollectionQuery = `
+		DELETE FROM collections
+		WHERE id = $2 AND user_id = $1
+	`
+
+	CheckCollectionNameTakenQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM collections
+			WHERE user_id = $1 AND LOWER(name) = LOWER($2) AND id <> $3
+		)
+	`
+
+	CheckCollectionExistsQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM collections
+			WHERE id = $2 AND user_id = $1
+		)
+	`
+
+	AddCollectionGamesQuery = `
+		INSERT INTO collection_games (collection_id, user_game_id)
+		SELECT $2::uuid, ug.id
+		FROM user_games ug
+		WHERE ug.user_id = $1
+			AND ug.deleted_at IS NULL
+			AND (ug.id = ANY($3::int[]) OR ug.game_id = ANY($4::bigint[]))
+		ON CONFLICT DO NOTHING
+	`
+
+	RemoveCollectionGamesQuery = `
+		DELETE FROM collection_games cg
+		USING user_games ug
+		WHERE cg.user_game_id = ug.id
+			AND cg.collection_id = $2
+			AND ug.user_id = $1
+			AND ug.deleted_at IS NULL
+			AND (ug.id = ANY($3::int[]) OR ug.game_id = ANY($4::bigint[]))
+	`
+)
@@ -0,0 +1,366 @@
+package collections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+type GameCollectionsService struct {
+	dbAdapter        interfaces.CollectionsDbAdapter
+	analyticsService analytics.Service
+	validator        interfaces.CollectionsValidator
+	logger           interfaces.Logger
+}
+
+type CollectionsService interface {
+	GetTags(ctx context.Context, userID string) ([]types.TagResponse, error)
+	CreateTag(ctx context.Context, userID string, request types.TagRequest) (types.TagResponse, error)
+	UpdateTag(ctx context.Context, userID string, tagID string, request types.TagRequest) (types.TagResponse, error)
+	DeleteTag(ctx context.Context, userID string, tagID string) error
+	BulkTagGames(ctx context.Context, userID string, request types.BulkTagRequest) (types.LibraryGameSelectionResponse, error)
+	BulkUntagGames(ctx context.Context, userID string, request types.BulkTagRequest) (types.LibraryGameSelectionResponse, error)
+
+	GetCollections(ctx context.Context, userID string) ([]types.CollectionResponse, error)
+	GetCollection(ctx context.Context, userID string, collectionID string) (types.CollectionResponse, error)
+	CreateCollection(ctx context.Context, userID string, request types.CollectionRequest) (types.CollectionResponse, error)
+	UpdateCollection(ctx context.Context, userID string, collectionID string, request types.CollectionRequest) (types.CollectionResponse, error)
+	DeleteCollection(ctx context.Context, userID string, collectionID string) error
+	AddCollectionGames(ctx context.Context, userID string, collectionID string, request types.LibraryGameSelectionRequest) (types.LibraryGameSelectionResponse, error)
+	RemoveCollectionGames(ctx context.Context, userID string, collectionID string, request types.LibraryGameSelectionRequest) (types.LibraryGameSelectionResponse, error)
+}
+
+func NewGameCollectionsService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.CollectionsDbAdapter,
+	analyticsService analytics.Service,
+) (*GameCollectionsService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if analyticsService == nil {
+		return nil, fmt.Errorf("analyticsService is required")
+	}
+
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		return nil, fmt.Errorf("creating sanitizer: %w", err)
+	}
+
+	validator, err := NewCollectionsValidator(sanitizer)
+	if err != nil {
+		return nil, fmt.Errorf("creating collections validator: %w", err)
+	}
+
+	return &GameCollectionsService{
+		dbAdapter:        dbAdapter,
+		analyticsService: analyticsService,
+		validator:        validator,
+		logger:           appContext.Logger,
+	}, nil
+}
+
+// -- TAGS --
+
+// GET
+func (cs *GameCollectionsService) GetTags(ctx context.Context, userID string) ([]types.TagResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	tags, err := cs.dbAdapter.GetTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformTagsToResponse(tags), nil
+}
+
+// POST
+func (cs *GameCollectionsService) CreateTag(
+	ctx context.Context,
+	userID string,
+	request types.TagRequest,
+) (types.TagResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.TagResponse{}, err
+	}
+	name, err := cs.validator.ValidateName(request.Name)
+	if err != nil {
+		return types.TagResponse{}, err
+	}
+
+	tag, err := cs.dbAdapter.CreateTag(ctx, userID, name)
+	if err != nil {
+		return types.TagResponse{}, err
+	}
+
+	return TransformTagToResponse(tag), nil
+}
+
+// PUT
+// UpdateTag renames a tag, the inventory breakdown is keyed by name so it is refreshed
+func (cs *GameCollectionsService) UpdateTag(
+	ctx context.Context,
+	userID string,
+	tagID string,
+	request types.TagRequest,
+) (types.TagResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.TagResponse{}, err
+	}
+	if err := cs.validator.ValidateID("tag ID", tagID); err != nil {
+		return types.TagResponse{}, err
+	}
+	name, err := cs.validator.ValidateName(request.Name)
+	if err != nil {
+		return types.TagResponse{}, err
+	}
+
+	tag, err := cs.dbAdapter.UpdateTag(ctx, userID, tagID, name)
+	if err != nil {
+		return types.TagResponse{}, err
+	}
+
+	cs.invalidateInventory(ctx, userID)
+	return TransformTagToResponse(tag), nil
+}
+
+// DELETE
+func (cs *GameCollectionsService) DeleteTag(ctx context.Context, userID string, tagID string) error {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := cs.validator.ValidateID("tag ID", tagID); err != nil {
+		return err
+	}
+
+	if err := cs.dbAdapter.DeleteTag(ctx, userID, tagID); err != nil {
+		return err
+	}
+
+	cs.invalidateInventory(ctx, userID)
+	return nil
+}
+
+// POST
+// BulkTagGames adds every tag in the request to every selected copy
+func (cs *GameCollectionsService) BulkTagGames(
+	ctx context.Context,
+	userID string,
+	request types.BulkTagRequest,
+) (types.LibraryGameSelectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+	if err := cs.validator.ValidateBulkTagRequest(request); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	updated, err := cs.dbAdapter.TagGames(ctx, userID, uniqueIDs(request.TagIDs), transformSelection(request.LibraryGameSelectionRequest))
+	if err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	if updated > 0 {
+		cs.invalidateInventory(ctx, userID)
+	}
+	return types.LibraryGameSelectionResponse{Updated: updated}, nil
+}
+
+// POST
+// BulkUntagGames removes every tag in the request from every selected copy
+func (cs *GameCollectionsService) BulkUntagGames(
+	ctx context.Context,
+	userID string,
+	request types.BulkTagRequest,
+) (types.LibraryGameSelectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+	if err := cs.validator.ValidateBulkTagRequest(request); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	updated, err := cs.dbAdapter.UntagGames(ctx, userID, uniqueIDs(request.TagIDs), transformSelection(request.LibraryGameSelectionRequest))
+	if err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	if updated > 0 {
+		cs.invalidateInventory(ctx, userID)
+	}
+	return types.LibraryGameSelectionResponse{Updated: updated}, nil
+}
+
+// -- COLLECTIONS --
+
+// GET
+func (cs *GameCollectionsService) GetCollections(ctx context.Context, userID string) ([]types.CollectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	collections, err := cs.dbAdapter.GetCollections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformCollectionsToResponse(collections), nil
+}
+
+// GET
+func (cs *GameCollectionsService) GetCollection(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+) (types.CollectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.CollectionResponse{}, err
+	}
+	if err := cs.validator.ValidateID("collection ID", collectionID); err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	collection, games, err := cs.dbAdapter.GetCollection(ctx, userID, collectionID)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	return TransformCollectionToResponse(collection, games), nil
+}
+
+// POST
+func (cs *GameCollectionsService) CreateCollection(
+	ctx context.Context,
+	userID string,
+	request types.CollectionRequest,
+) (types.CollectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.CollectionResponse{}, err
+	}
+	name, err := cs.validator.ValidateName(request.Name)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+	description, err := cs.validator.ValidateDescription(request.Description)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	collection, err := cs.dbAdapter.CreateCollection(ctx, userID, name, description)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	return TransformCollectionToResponse(collection, nil), nil
+}
+
+// PUT
+func (cs *GameCollectionsService) UpdateCollection(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	request types.CollectionRequest,
+) (types.CollectionResponse, error) {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return types.CollectionResponse{}, err
+	}
+	if err := cs.validator.ValidateID("collection ID", collectionID); err != nil {
+		return types.CollectionResponse{}, err
+	}
+	name, err := cs.validator.ValidateName(request.Name)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+	description, err := cs.validator.ValidateDescription(request.Description)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	collection, err := cs.dbAdapter.UpdateCollection(ctx, userID, collectionID, name, description)
+	if err != nil {
+		return types.CollectionResponse{}, err
+	}
+
+	return TransformCollectionToResponse(collection, nil), nil
+}
+
+// DELETE
+func (cs *GameCollectionsService) DeleteCollection(ctx context.Context, userID string, collectionID string) error {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := cs.validator.ValidateID("collection ID", collectionID); err != nil {
+		return err
+	}
+
+	return cs.dbAdapter.DeleteCollection(ctx, userID, collectionID)
+}
+
+// POST
+func (cs *GameCollectionsService) AddCollectionGames(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	request types.LibraryGameSelectionRequest,
+) (types.LibraryGameSelectionResponse, error) {
+	if err := cs.validateCollectionSelection(userID, collectionID, request); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	updated, err := cs.dbAdapter.AddCollectionGames(ctx, userID, collectionID, transformSelection(request))
+	if err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	return types.LibraryGameSelectionResponse{Updated: updated}, nil
+}
+
+// POST
+func (cs *GameCollectionsService) RemoveCollectionGames(
+	ctx context.Context,
+	userID string,
+	collectionID string,
+	request types.LibraryGameSelectionRequest,
+) (types.LibraryGameSelectionResponse, error) {
+	if err := cs.validateCollectionSelection(userID, collectionID, request); err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	updated, err := cs.dbAdapter.RemoveCollectionGames(ctx, userID, collectionID, transformSelection(request))
+	if err != nil {
+		return types.LibraryGameSelectionResponse{}, err
+	}
+
+	return types.LibraryGameSelectionResponse{Updated: updated}, nil
+}
+
+func (cs *GameCollectionsService) validateCollectionSelection(
+	userID string,
+	collectionID string,
+	request types.LibraryGameSelectionRequest,
+) error {
+	if err := cs.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := cs.validator.ValidateID("collection ID", collectionID); err != nil {
+		return err
+	}
+	return cs.validator.ValidateSelection(request)
+}
+
+// invalidateInventory refreshes the analytics inventory breakdown, which includes per tag counts
+func (cs *GameCollectionsService) invalidateInventory(ctx context.Context, userID string) {
+	if err := cs.analyticsService.InvalidateDomain(ctx, userID, analytics.DomainInventory); err != nil {
+		cs.logger.Warn("Failed to invalidate analytics inventory cache after tag change", map[string]any{
+			"userID": userID,
+			"error":  err,
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package collections
+
+import (
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+func TransformTagToResponse(tag models.UserTagDB) types.TagResponse {
+	return types.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		GameCount: tag.GameCount,
+		CreatedAt: tag.CreatedAt.Unix(),
+		UpdatedAt: tag.UpdatedAt.Unix(),
+	}
+}
+
+func TransformTagsToResponse(tags []models.UserTagDB) []types.TagResponse {
+	response := make([]types.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		response = append(response, TransformTagToResponse(tag))
+	}
+	return response
+}
+
+// TransformCollectionToResponse converts a collection, games are only included for the detail view
+func TransformCollectionToResponse(collection models.CollectionDB, games []models.CollectionGameDB) types.CollectionResponse {
+	response := types.CollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description.String,
+		GameCount:   collection.GameCount,
+		CreatedAt:   collection.CreatedAt.Unix(),
+		UpdatedAt:   collection.UpdatedAt.Unix(),
+	}
+
+	if len(games) > 0 {
+		response.Games = make([]types.CollectionGameResponse, 0, len(games))
+		for _, game := range games {
+			response.Games = append(response.Games, types.CollectionGameResponse{
+				UserGameID:   game.UserGameID,
+				GameID:       game.GameID,
+				GameName:     game.GameName,
+				CoverURL:     game.CoverURL,
+				PlatformID:   game.PlatformID,
+				PlatformName: game.PlatformName,
+				GameType:     game.GameType,
+				AddedAt:      game.AddedAt.Unix(),
+			})
+		}
+	}
+
+	return response
+}
+
+func TransformCollectionsToResponse(collections []models.CollectionDB) []types.CollectionResponse {
+	response := make([]types.CollectionResponse, 0, len(collections))
+	for _, collection := range collections {
+		response = append(response, TransformCollectionToResponse(collection, nil))
+	}
+	return response
+}
+
+// transformSelection drops repeated IDs so counts in the response match what changed
+func transformSelection(selection types.LibraryGameSelectionRequest) models.LibraryGameSelection {
+	return models.LibraryGameSelection{
+		UserGameIDs: uniqueInt64s(selection.UserGameIDs),
+		GameIDs:     uniqueInt64s(selection.GameIDs),
+	}
+}
+
+func uniqueInt64s(values []int64) []int64 {
+	seen := make(map[int64]bool, len(values))
+	unique := make([]int64, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// uniqueIDs lowercases UUIDs before comparing them, the db adapter counts them to check ownership
+func uniqueIDs(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
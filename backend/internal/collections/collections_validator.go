@@ -0,0 +1,121 @@
+package collections
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 1000
+
+	// MaxBulkTags and MaxBulkGames bound a single bulk tag or collection request
+	MaxBulkTags  = 20
+	MaxBulkGames = 500
+)
+
+type CollectionsValidatorImpl struct {
+	sanitizer interfaces.Sanitizer
+}
+
+func NewCollectionsValidator(sanitizer interfaces.Sanitizer) (interfaces.CollectionsValidator, error) {
+	if sanitizer == nil {
+		return nil, fmt.Errorf("sanitizer cannot be nil")
+	}
+
+	return &CollectionsValidatorImpl{sanitizer: sanitizer}, nil
+}
+
+func (v *CollectionsValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *CollectionsValidatorImpl) ValidateID(field string, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("%w: invalid %s '%s'", ErrValidationFailed, field, id)
+	}
+	return nil
+}
+
+// ValidateName trims a tag or collection name and rejects markup
+func (v *CollectionsValidatorImpl) ValidateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrValidationFailed)
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: name must be %d characters or less", ErrValidationFailed, MaxNameLength)
+	}
+
+	if err := v.checkPlainText("name", name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ValidateDescription trims a collection description, an empty description is allowed
+func (v *CollectionsValidatorImpl) ValidateDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return "", fmt.Errorf("%w: description must be %d characters or less", ErrValidationFailed, MaxDescriptionLength)
+	}
+
+	if err := v.checkPlainText("description", description); err != nil {
+		return "", err
+	}
+
+	return description, nil
+}
+
+func (v *CollectionsValidatorImpl) ValidateBulkTagRequest(request types.BulkTagRequest) error {
+	if len(request.TagIDs) == 0 || len(request.TagIDs) > MaxBulkTags {
+		return fmt.Errorf("%w: between 1 and %d tag IDs are required", ErrValidationFailed, MaxBulkTags)
+	}
+	for _, tagID := range request.TagIDs {
+		if err := v.ValidateID("tag ID", tagID); err != nil {
+			return err
+		}
+	}
+
+	return v.ValidateSelection(request.LibraryGameSelectionRequest)
+}
+
+func (v *CollectionsValidatorImpl) ValidateSelection(selection types.LibraryGameSelectionRequest) error {
+	total := len(selection.UserGameIDs) + len(selection.GameIDs)
+	if total == 0 || total > MaxBulkGames {
+		return fmt.Errorf("%w: between 1 and %d userGameIds or gameIds are required", ErrValidationFailed, MaxBulkGames)
+	}
+
+	for _, ids := range [][]int64{selection.UserGameIDs, selection.GameIDs} {
+		for _, id := range ids {
+			if id <= 0 {
+				return fmt.Errorf("%w: userGameIds and gameIds must be positive", ErrValidationFailed)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPlainText rejects input the sanitizer would change.
+// The escaped output isn't stored, so names like "Kids' games" keep their apostrophe.
+func (v *CollectionsValidatorImpl) checkPlainText(field string, value string) error {
+	sanitized, err := v.sanitizer.SanitizeString(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s content: %v", ErrValidationFailed, field, err)
+	}
+	if html.UnescapeString(sanitized) != value {
+		return fmt.Errorf("%w: %s must not contain HTML", ErrValidationFailed, field)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package collections
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Validating tag and collection names and bulk selections
+
+	Scenarios:
+	- Names are trimmed and keep punctuation such as apostrophes
+	- Empty, overlong and HTML names are rejected
+	- Bulk tag requests need at least one valid tag ID and one selected game
+	- Selections over MaxBulkGames are rejected
+*/
+
+func TestCollectionsValidator(t *testing.T) {
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		t.Fatalf("Failed to create sanitizer: %v", err)
+	}
+	validator, err := NewCollectionsValidator(sanitizer)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	/*
+		GIVEN a name with surrounding spaces and an apostrophe
+		WHEN ValidateName is called
+		THEN it should return the trimmed name unchanged otherwise
+	*/
+	t.Run("Names keep their punctuation", func(t *testing.T) {
+		// WHEN
+		name, err := validator.ValidateName("  Kids' games & co-op ")
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if name != "Kids' games & co-op" {
+			t.Errorf("Expected trimmed name, got '%s'", name)
+		}
+	})
+
+	invalidNames := []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty name", input: "   "},
+		{name: "Overlong name", input: strings.Repeat("a", MaxNameLength+1)},
+		{name: "HTML name", input: "<b>Favorites</b>"},
+	}
+
+	for _, testCase := range invalidNames {
+		/*
+			GIVEN an invalid name
+			WHEN ValidateName is called
+			THEN it should return ErrValidationFailed
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			_, err := validator.ValidateName(testCase.input)
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+		})
+	}
+
+	bulkRequests := []struct {
+		name      string
+		request   types.BulkTagRequest
+		expectErr bool
+	}{
+		{
+			name: "Valid bulk tag request",
+			request: types.BulkTagRequest{
+				TagIDs:                      []string{"0b0d6c3e-8f43-4b8e-9e4c-7f5d0b1b2c3d"},
+				LibraryGameSelectionRequest: types.LibraryGameSelectionRequest{GameIDs: []int64{1942}},
+			},
+		},
+		{
+			name: "Missing tag IDs",
+			request: types.BulkTagRequest{
+				LibraryGameSelectionRequest: types.LibraryGameSelectionRequest{UserGameIDs: []int64{7}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Malformed tag ID",
+			request: types.BulkTagRequest{
+				TagIDs:                      []string{"not-a-uuid"},
+				LibraryGameSelectionRequest: types.LibraryGameSelectionRequest{UserGameIDs: []int64{7}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "No games selected",
+			request: types.BulkTagRequest{
+				TagIDs: []string{"0b0d6c3e-8f43-4b8e-9e4c-7f5d0b1b2c3d"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Too many games selected",
+			request: types.BulkTagRequest{
+				TagIDs:                      []string{"0b0d6c3e-8f43-4b8e-9e4c-7f5d0b1b2c3d"},
+				LibraryGameSelectionRequest: types.LibraryGameSelectionRequest{UserGameIDs: make([]int64, MaxBulkGames+1)},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range bulkRequests {
+		/*
+			GIVEN a bulk tag request
+			WHEN ValidateBulkTagRequest is called
+			THEN only complete requests should pass
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			err := validator.ValidateBulkTagRequest(testCase.request)
+
+			// THEN
+			if testCase.expectErr && !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+			if !testCase.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
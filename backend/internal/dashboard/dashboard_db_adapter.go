@@ -46,11 +46,13 @@ func NewDashboardDbAdapter(appContext *appcontext.AppContext) (*DashboardDbAdapt
 // --- SQL QUERY CONSTANTS ---
 
 const (
-  // Get total games and last updated
+  // Get total games, favorite games and last updated
   getGameStatsQuery = `
-      SELECT 'Games' AS title, 'games' AS icon, COUNT(*) AS value, MAX(created_at) AS last_updated
+      SELECT 'Games' AS title, 'games' AS icon, COUNT(*) AS value,
+             COUNT(DISTINCT game_id) FILTER (WHERE favorite) AS secondary_value,
+             MAX(created_at) AS last_updated
       FROM user_games
-      WHERE user_id = $1
+      WHERE user_id = $1 AND deleted_at IS NULL
   `
 
   // Get total monthly online services costs and last updated
@@ -87,8 +89,8 @@ const (
         COUNT(s.id) AS secondary_value,
         MAX(pl.updated_at) AS last_updated
     FROM physical_locations pl
-    LEFT JOIN sublocations s ON pl.id = s.physical_location_id
-    WHERE pl.user_id = $1
+    LEFT JOIN sublocations s ON pl.id = s.physical_location_id AND s.deleted_at IS NULL
+    WHERE pl.user_id = $1 AND pl.deleted_at IS NULL
   `
 
   // Get all digital locations with details
@@ -109,7 +111,7 @@ const (
       COUNT(*) as count
     FROM digital_game_locations dgl
     JOIN user_games ug ON dgl.user_game_id = ug.id
-    WHERE ug.user_id = $1
+    WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
     GROUP BY dgl.digital_location_id
   ) stored_games ON dl.id = stored_games.digital_location_id
   WHERE dl.user_id = $1
@@ -128,26 +130,26 @@ const (
         COUNT(*) as count
       FROM physical_game_locations pgl
       JOIN user_games ug ON pgl.user_game_id = ug.id
-      WHERE ug.user_id = $1
+      WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
       GROUP BY pgl.sublocation_id
     ) stored_games ON s.id = stored_games.sublocation_id
-    WHERE s.user_id = $1
+    WHERE s.user_id = $1 AND s.deleted_at IS NULL AND pl.deleted_at IS NULL
   `
 
-  // Get platform distribution
+  // Get platform distribution, grouped by platform family (platforms not synced from IGDB yet by name)
   getPlatformListQuery = `
-      SELECT p.name AS platform, COUNT(*) AS item_count
+      SELECT COALESCE(p.platform_group, p.name) AS platform, COUNT(*) AS item_count
       FROM user_games ug
       JOIN platforms p ON ug.platform_id = p.id
-      WHERE ug.user_id = $1
-      GROUP BY p.name
+      WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+      GROUP BY COALESCE(p.platform_group, p.name)
   `
 
   // Get new items added this month
   getNewItemsThisMonthQuery = `
       SELECT COUNT(*) AS new_items
       FROM user_games
-      WHERE user_id = $1 AND DATE_TRUNC('month', created_at) = DATE_TRUNC('month', CURRENT_DATE)
+      WHERE user_id = $1 AND deleted_at IS NULL AND DATE_TRUNC('month', created_at) = DATE_TRUNC('month', CURRENT_DATE)
   `
 
   // Get monthly expenditures (for the last 12 months)
@@ -336,6 +338,7 @@ func (dda *DashboardDbAdapter) calculateMonthlyExpendituresDynamically(
       FROM one_time_purchases otp
       LEFT JOIN spending_categories sc ON otp.spending_category_id = sc.id
       WHERE otp.user_id = $1
+      AND otp.deleted_at IS NULL
       AND EXTRACT(YEAR FROM purchase_date) = EXTRACT(YEAR FROM $2::timestamp)
       AND EXTRACT(MONTH FROM purchase_date) = EXTRACT(MONTH FROM $2::timestamp)
       ORDER BY purchase_date DESC`,
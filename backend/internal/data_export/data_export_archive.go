@@ -0,0 +1,210 @@
+package data_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// DataExportFormatVersion is bumped whenever the archive layout or a dataset's shape changes
+const DataExportFormatVersion = 1
+
+// Archive layout
+const (
+	ExportManifestFile = "manifest.json"
+	exportJSONDir      = "json/"
+	exportCSVDir       = "csv/"
+)
+
+type exportDataset struct {
+	name  string
+	query string
+}
+
+// exportDatasets is everything a user owns, in the order it appears in the manifest
+var exportDatasets = []exportDataset{
+	{name: "profile", query: ExportProfileQuery},
+	{name: "games", query: ExportGamesQuery},
+	{name: "physical_locations", query: ExportPhysicalLocationsQuery},
+	{name: "sublocations", query: ExportSublocationsQuery},
+	{name: "digital_locations", query: ExportDigitalLocationsQuery},
+	{name: "subscriptions", query: ExportSubscriptionsQuery},
+	{name: "payments", query: ExportPaymentsQuery},
+	{name: "one_time_purchases", query: ExportOneTimePurchasesQuery},
+	{name: "wishlist", query: ExportWishlistQuery},
+	{name: "audit_log", query: ExportAuditLogQuery},
+}
+
+// ExportManifest describes an archive so it can be read back without guessing its shape
+type ExportManifest struct {
+	FormatVersion int                     `json:"formatVersion"`
+	ExportID      string                  `json:"exportId"`
+	UserID        string                  `json:"userId"`
+	ExportedAt    string                  `json:"exportedAt"`
+	Datasets      []ExportManifestDataset `json:"datasets"`
+}
+
+type ExportManifestDataset struct {
+	Name     string   `json:"name"`
+	Rows     int      `json:"rows"`
+	Columns  []string `json:"columns"`
+	JSONFile string   `json:"jsonFile"`
+	CSVFile  string   `json:"csvFile"`
+}
+
+// writeExportArchive writes a ZIP with a manifest plus a JSON and CSV file per dataset
+func writeExportArchive(
+	w io.Writer,
+	exportID string,
+	userID string,
+	exportedAt time.Time,
+	tables []models.DataExportTable,
+) error {
+	archive := zip.NewWriter(w)
+
+	manifest := ExportManifest{
+		FormatVersion: DataExportFormatVersion,
+		ExportID:      exportID,
+		UserID:        userID,
+		ExportedAt:    exportedAt.UTC().Format(time.RFC3339),
+		Datasets:      make([]ExportManifestDataset, 0, len(tables)),
+	}
+
+	for _, table := range tables {
+		dataset := ExportManifestDataset{
+			Name:     table.Name,
+			Rows:     len(table.Rows),
+			Columns:  table.Columns,
+			JSONFile: exportJSONDir + table.Name + ".json",
+			CSVFile:  exportCSVDir + table.Name + ".csv",
+		}
+
+		jsonFile, err := archive.Create(dataset.JSONFile)
+		if err != nil {
+			return fmt.Errorf("error adding %s: %w", dataset.JSONFile, err)
+		}
+		if err := writeTableJSON(jsonFile, table); err != nil {
+			return fmt.Errorf("error writing %s: %w", dataset.JSONFile, err)
+		}
+
+		csvFile, err := archive.Create(dataset.CSVFile)
+		if err != nil {
+			return fmt.Errorf("error adding %s: %w", dataset.CSVFile, err)
+		}
+		if err := writeTableCSV(csvFile, table); err != nil {
+			return fmt.Errorf("error writing %s: %w", dataset.CSVFile, err)
+		}
+
+		manifest.Datasets = append(manifest.Datasets, dataset)
+	}
+
+	manifestFile, err := archive.Create(ExportManifestFile)
+	if err != nil {
+		return fmt.Errorf("error adding manifest: %w", err)
+	}
+	encoder := json.NewEncoder(manifestFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return fmt.Errorf("error writing manifest: %w", err)
+	}
+
+	return archive.Close()
+}
+
+// writeTableJSON writes rows as an array of objects, keys keep the column order of the query
+func writeTableJSON(w io.Writer, table models.DataExportTable) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+
+	for rowIndex, row := range table.Rows {
+		if rowIndex > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n  {")
+
+		for columnIndex, column := range table.Columns {
+			if columnIndex > 0 {
+				buffer.WriteString(", ")
+			}
+
+			key, err := json.Marshal(column)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(row[columnIndex])
+			if err != nil {
+				return err
+			}
+
+			buffer.Write(key)
+			buffer.WriteString(": ")
+			buffer.Write(value)
+		}
+
+		buffer.WriteString("}")
+	}
+
+	if len(table.Rows) > 0 {
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]\n")
+
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+func writeTableCSV(w io.Writer, table models.DataExportTable) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(table.Columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i := range table.Columns {
+			record[i] = formatCSVValue(row[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatCSVValue renders a normalized value, text that a spreadsheet would run as a formula is quoted with a leading '
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && isFormulaPrefix(v[0]) {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return "'" + v
+			}
+		}
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isFormulaPrefix(first byte) bool {
+	switch first {
+	case '=', '+', '-', '@', '\t', '\r':
+		return true
+	default:
+		return false
+	}
+}
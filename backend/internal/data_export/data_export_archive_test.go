@@ -0,0 +1,93 @@
+package data_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+/*
+	Behavior:
+	- Writing a data export archive
+
+	Scenarios:
+	- The archive has a manifest plus a JSON and CSV file per dataset
+	- JSON keeps column order and value types
+	- CSV neutralizes values a spreadsheet would run as formulas
+*/
+
+func TestWriteExportArchive(t *testing.T) {
+	// GIVEN
+	tables := []models.DataExportTable{
+		{
+			Name:    "wishlist",
+			Columns: []string{"id", "game_name", "is_on_sale", "sale_price"},
+			Rows: [][]any{
+				{int64(1), "Hades", true, "19.99"},
+				{int64(2), "=HYPERLINK(\"x\")", false, nil},
+			},
+		},
+		{
+			Name:    "audit_log",
+			Columns: []string{"id", "action"},
+			Rows:    [][]any{},
+		},
+	}
+	exportedAt := time.Date(2024, 4, 14, 15, 0, 0, 0, time.UTC)
+
+	// WHEN
+	var buffer bytes.Buffer
+	if err := writeExportArchive(&buffer, "export-1", "user-1", exportedAt, tables); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// THEN
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("Expected a valid zip, got %v", err)
+	}
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		files[file.Name] = string(content)
+	}
+
+	var manifest ExportManifest
+	if err := json.Unmarshal([]byte(files[ExportManifestFile]), &manifest); err != nil {
+		t.Fatalf("Expected a valid manifest, got %v", err)
+	}
+	if manifest.FormatVersion != DataExportFormatVersion || manifest.ExportedAt != "2024-04-14T15:00:00Z" {
+		t.Errorf("Unexpected manifest: %+v", manifest)
+	}
+	if len(manifest.Datasets) != 2 || manifest.Datasets[0].Rows != 2 || manifest.Datasets[1].Rows != 0 {
+		t.Errorf("Unexpected manifest datasets: %+v", manifest.Datasets)
+	}
+
+	expectedJSON := "[\n" +
+		"  {\"id\": 1, \"game_name\": \"Hades\", \"is_on_sale\": true, \"sale_price\": \"19.99\"},\n" +
+		"  {\"id\": 2, \"game_name\": \"=HYPERLINK(\\\"x\\\")\", \"is_on_sale\": false, \"sale_price\": null}\n" +
+		"]\n"
+	if files["json/wishlist.json"] != expectedJSON {
+		t.Errorf("Unexpected JSON:\n%s", files["json/wishlist.json"])
+	}
+	if files["json/audit_log.json"] != "[]\n" {
+		t.Errorf("Expected empty JSON array, got %q", files["json/audit_log.json"])
+	}
+
+	expectedCSV := "id,game_name,is_on_sale,sale_price\n" +
+		"1,Hades,true,19.99\n" +
+		"2,\"'=HYPERLINK(\"\"x\"\")\",false,\n"
+	if files["csv/wishlist.csv"] != expectedCSV {
+		t.Errorf("Unexpected CSV:\n%s", files["csv/wishlist.csv"])
+	}
+	if files["csv/audit_log.csv"] != "id,action\n" {
+		t.Errorf("Expected header only CSV, got %q", files["csv/audit_log.csv"])
+	}
+}
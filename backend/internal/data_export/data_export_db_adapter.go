@@ -0,0 +1,218 @@
+package data_export
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type DataExportDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewDataExportDbAdapter(appContext *appcontext.AppContext) (*DataExportDbAdapter, error) {
+	appContext.Logger.Debug("Creating DataExportDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &DataExportDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// POST
+func (ea *DataExportDbAdapter) CreateDataExport(ctx context.Context, userID string) (models.DataExportDB, error) {
+	ea.logger.Debug("DataExportDbAdapter - CreateDataExport called", map[string]any{
+		"userID": userID,
+	})
+
+	var export models.DataExportDB
+	if err := ea.db.GetContext(ctx, &export, InsertDataExportQuery, userID); err != nil {
+		return models.DataExportDB{}, fmt.Errorf("error creating data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// GET
+// GetDataExport retrieves a single export owned by the user.
+// Returns ErrDataExportNotFound if the export doesn't exist or belongs to someone else.
+func (ea *DataExportDbAdapter) GetDataExport(
+	ctx context.Context,
+	userID string,
+	exportID string,
+) (models.DataExportDB, error) {
+	var export models.DataExportDB
+	if err := ea.db.GetContext(ctx, &export, GetDataExportQuery, exportID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DataExportDB{}, ErrDataExportNotFound
+		}
+		return models.DataExportDB{}, fmt.Errorf("error getting data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// GetActiveDataExport retrieves the user's pending or running export, if there is one
+func (ea *DataExportDbAdapter) GetActiveDataExport(
+	ctx context.Context,
+	userID string,
+) (models.DataExportDB, bool, error) {
+	var export models.DataExportDB
+	if err := ea.db.GetContext(ctx, &export, GetActiveDataExportQuery, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DataExportDB{}, false, nil
+		}
+		return models.DataExportDB{}, false, fmt.Errorf("error getting active data export: %w", err)
+	}
+
+	return export, true, nil
+}
+
+// GetUnfinishedDataExports retrieves pending and running exports across all users, oldest first
+func (ea *DataExportDbAdapter) GetUnfinishedDataExports(ctx context.Context) ([]models.DataExportDB, error) {
+	var exports []models.DataExportDB
+	if err := ea.db.SelectContext(ctx, &exports, GetUnfinishedDataExportsQuery); err != nil {
+		return nil, fmt.Errorf("error getting unfinished data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// GetExpiredDataExports retrieves completed exports whose download link has lapsed
+func (ea *DataExportDbAdapter) GetExpiredDataExports(ctx context.Context) ([]models.DataExportDB, error) {
+	var exports []models.DataExportDB
+	if err := ea.db.SelectContext(ctx, &exports, GetExpiredDataExportsQuery); err != nil {
+		return nil, fmt.Errorf("error getting expired data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+func (ea *DataExportDbAdapter) GetDataExportUser(ctx context.Context, userID string) (models.DataExportUserDB, error) {
+	var user models.DataExportUserDB
+	if err := ea.db.GetContext(ctx, &user, GetDataExportUserQuery, userID); err != nil {
+		return models.DataExportUserDB{}, fmt.Errorf("error getting data export user: %w", err)
+	}
+
+	return user, nil
+}
+
+// QueryDataset runs one of the export queries and keeps the result as plain columns and rows.
+// Values are normalized to strings, numbers, bools or nil so they encode the same way in JSON and CSV.
+func (ea *DataExportDbAdapter) QueryDataset(
+	ctx context.Context,
+	name string,
+	query string,
+	userID string,
+) (models.DataExportTable, error) {
+	rows, err := ea.db.QueryxContext(ctx, query, userID)
+	if err != nil {
+		return models.DataExportTable{}, fmt.Errorf("error exporting %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return models.DataExportTable{}, fmt.Errorf("error reading %s columns: %w", name, err)
+	}
+
+	table := models.DataExportTable{
+		Name:    name,
+		Columns: columns,
+		Rows:    [][]any{},
+	}
+
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return models.DataExportTable{}, fmt.Errorf("error scanning %s: %w", name, err)
+		}
+		for i, value := range values {
+			values[i] = normalizeExportValue(value)
+		}
+		table.Rows = append(table.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return models.DataExportTable{}, fmt.Errorf("error exporting %s: %w", name, err)
+	}
+
+	return table, nil
+}
+
+// PUT
+func (ea *DataExportDbAdapter) MarkDataExportRunning(ctx context.Context, exportID string) error {
+	if _, err := ea.db.ExecContext(ctx, MarkDataExportRunningQuery, exportID); err != nil {
+		return fmt.Errorf("error marking data export running: %w", err)
+	}
+
+	return nil
+}
+
+func (ea *DataExportDbAdapter) CompleteDataExport(
+	ctx context.Context,
+	exportID string,
+	blobKey string,
+	sizeBytes int64,
+	expiresAt time.Time,
+) error {
+	if _, err := ea.db.ExecContext(ctx, CompleteDataExportQuery, exportID, blobKey, sizeBytes, expiresAt); err != nil {
+		return fmt.Errorf("error completing data export: %w", err)
+	}
+
+	return nil
+}
+
+func (ea *DataExportDbAdapter) FailDataExport(ctx context.Context, exportID string, errorMessage string) error {
+	if _, err := ea.db.ExecContext(ctx, FailDataExportQuery, exportID, errorMessage); err != nil {
+		return fmt.Errorf("error failing data export: %w", err)
+	}
+
+	return nil
+}
+
+func (ea *DataExportDbAdapter) ExpireDataExport(ctx context.Context, exportID string) error {
+	if _, err := ea.db.ExecContext(ctx, ExpireDataExportQuery, exportID); err != nil {
+		return fmt.Errorf("error expiring data export: %w", err)
+	}
+
+	return nil
+}
+
+func normalizeExportValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return string(v)
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case string, bool, int64, int32, int, float64, float32:
+		return v
+	case driver.Valuer:
+		raw, err := v.Value()
+		if err != nil || raw == nil {
+			return nil
+		}
+		if _, isValuer := raw.(driver.Valuer); isValuer {
+			return fmt.Sprint(raw)
+		}
+		return normalizeExportValue(raw)
+	default:
+		return fmt.Sprint(v)
+	}
+}
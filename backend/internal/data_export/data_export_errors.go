@@ -0,0 +1,30 @@
+package data_export
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrDataExportNotFound = errors.New("data export not found")
+	ErrValidationFailed   = errors.New("validation failed")
+	ErrExportQueueFull    = errors.New("export queue is full")
+	ErrDatabaseError      = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrDataExportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrExportQueueFull):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,116 @@
+package data_export
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+)
+
+func RegisterDataExportRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	exportService services.DataExportService,
+) {
+	r.Post("/", RequestDataExport(appCtx, exportService))
+	r.Get("/{exportID}", GetDataExport(appCtx, exportService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// RequestDataExport handles POST requests to export all of a user's data.
+// The archive is built in the background and the download link is emailed when it is ready.
+func RequestDataExport(
+	appCtx *appcontext.AppContext,
+	exportService services.DataExportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		appCtx.Logger.Info("Requesting data export", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+		})
+
+		export, err := exportService.RequestDataExport(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"export": export,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusAccepted,
+			response,
+		)
+	}
+}
+
+// GetDataExport handles GET requests for an export's status and download link
+func GetDataExport(
+	appCtx *appcontext.AppContext,
+	exportService services.DataExportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		export, err := exportService.GetDataExport(r.Context(), userID, chi.URLParam(r, "exportID"))
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"export": export,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
@@ -0,0 +1,159 @@
+package data_export
+
+const (
+	InsertDataExportQuery = `
+		INSERT INTO user_data_exports (user_id, status)
+		VALUES ($1, 'pending')
+		RETURNING id, user_id, status, blob_key, size_bytes, error_message, created_at, completed_at, expires_at
+	`
+
+	GetDataExportQuery = `
+		SELECT id, user_id, status, blob_key, size_bytes, error_message, created_at, completed_at, expires_at
+		FROM user_data_exports
+		WHERE id = $1 AND user_id = $2
+	`
+
+	GetActiveDataExportQuery = `
+		SELECT id, user_id, status, blob_key, size_bytes, error_message, created_at, completed_at, expires_at
+		FROM user_data_exports
+		WHERE user_id = $1 AND status IN ('pending', 'running')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	GetUnfinishedDataExportsQuery = `
+		SELECT id, user_id, status, blob_key, size_bytes, error_message, created_at, completed_at, expires_at
+		FROM user_data_exports
+		WHERE status IN ('pending', 'running')
+		ORDER BY created_at
+	`
+
+	GetExpiredDataExportsQuery = `
+		SELECT id, user_id, status, blob_key, size_bytes, error_message, created_at, completed_at, expires_at
+		FROM user_data_exports
+		WHERE status = 'completed' AND expires_at < NOW()
+	`
+
+	MarkDataExportRunningQuery = `
+		UPDATE user_data_exports
+		SET status = 'running'
+		WHERE id = $1
+	`
+
+	CompleteDataExportQuery = `
+		UPDATE user_data_exports
+		SET status = 'completed', blob_key = $2, size_bytes = $3, expires_at = $4, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	FailDataExportQuery = `
+		UPDATE user_data_exports
+		SET status = 'failed', error_message = $2, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	ExpireDataExportQuery = `
+		UPDATE user_data_exports
+		SET status = 'expired', blob_key = NULL
+		WHERE id = $1
+	`
+
+	GetDataExportUserQuery = `
+		SELECT email, first_name
+		FROM users
+		WHERE id = $1
+	`
+)
+
+// Export datasets, every query takes the user ID as $1.
+// Tables the user owns are exported with all of their columns so new fields are picked up automatically.
+// Rows in the trash are left out.
+const (
+	ExportProfileQuery = `
+		SELECT id, email, first_name, last_name, created_at, updated_at
+		FROM users
+		WHERE id = $1
+	`
+
+	ExportGamesQuery = `
+		SELECT
+			ug.*,
+			g.name AS game_name,
+			p.name AS platform_name,
+			s.id AS sublocation_id,
+			s.name AS sublocation_name,
+			dl.id AS digital_location_id,
+			dl.name AS digital_location_name
+		FROM user_games ug
+		JOIN games g ON g.id = ug.game_id
+		LEFT JOIN platforms p ON p.id = ug.platform_id
+		LEFT JOIN physical_game_locations pgl ON pgl.user_game_id = ug.id
+		LEFT JOIN sublocations s ON s.id = pgl.sublocation_id
+		LEFT JOIN digital_game_locations dgl ON dgl.user_game_id = ug.id
+		LEFT JOIN digital_locations dl ON dl.id = dgl.digital_location_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		ORDER BY ug.id
+	`
+
+	ExportPhysicalLocationsQuery = `
+		SELECT pl.*
+		FROM physical_locations pl
+		WHERE pl.user_id = $1 AND pl.deleted_at IS NULL
+		ORDER BY pl.created_at
+	`
+
+	ExportSublocationsQuery = `
+		SELECT s.*, pl.name AS physical_location_name
+		FROM sublocations s
+		LEFT JOIN physical_locations pl ON pl.id = s.physical_location_id
+		WHERE s.user_id = $1 AND s.deleted_at IS NULL
+		ORDER BY s.created_at
+	`
+
+	ExportDigitalLocationsQuery = `
+		SELECT dl.*
+		FROM digital_locations dl
+		WHERE dl.user_id = $1
+		ORDER BY dl.created_at
+	`
+
+	ExportSubscriptionsQuery = `
+		SELECT dls.*, dl.name AS digital_location_name
+		FROM digital_location_subscriptions dls
+		JOIN digital_locations dl ON dl.id = dls.digital_location_id
+		WHERE dl.user_id = $1
+		ORDER BY dls.id
+	`
+
+	ExportPaymentsQuery = `
+		SELECT dlp.*, dl.name AS digital_location_name
+		FROM digital_location_payments dlp
+		JOIN digital_locations dl ON dl.id = dlp.digital_location_id
+		WHERE dl.user_id = $1
+		ORDER BY dlp.payment_date
+	`
+
+	ExportOneTimePurchasesQuery = `
+		SELECT otp.*, sc.name AS spending_category_name
+		FROM one_time_purchases otp
+		LEFT JOIN spending_categories sc ON sc.id = otp.spending_category_id
+		WHERE otp.user_id = $1 AND otp.deleted_at IS NULL
+		ORDER BY otp.purchase_date
+	`
+
+	ExportWishlistQuery = `
+		SELECT w.*, g.name AS game_name, p.name AS platform_name
+		FROM wishlist w
+		JOIN games g ON g.id = w.game_id
+		LEFT JOIN platforms p ON p.id = w.platform_id
+		WHERE w.user_id = $1
+		ORDER BY w.created_at
+	`
+
+	ExportAuditLogQuery = `
+		SELECT id, action, timestamp, details, created_at
+		FROM audit_logs
+		WHERE user_id = $1
+		ORDER BY timestamp
+	`
+)
@@ -0,0 +1,15 @@
+package data_export
+
+// ExportJob identifies a queued data export
+type ExportJob struct {
+	ExportID string
+	UserID   string
+}
+
+// LogFields identifies the job in the export queue's logs
+func (job ExportJob) LogFields() map[string]any {
+	return map[string]any{
+		"exportID": job.ExportID,
+		"userID":   job.UserID,
+	}
+}
@@ -0,0 +1,349 @@
+package data_export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	exportFailedMessage = "the export could not be completed, please request a new one"
+
+	// purgeInterval is how often expired archives are removed from the blob store
+	purgeInterval = time.Hour
+)
+
+// exportEmailQueue is the part of email.EmailQueue used to send the download link
+type exportEmailQueue interface {
+	EnqueueJob(ctx context.Context, jobType email.EmailJobType, userID, email string, data map[string]interface{}) error
+}
+
+type UserDataExportService struct {
+	dbAdapter  interfaces.DataExportDbAdapter
+	blobStore  interfaces.BlobStore
+	emailQueue exportEmailQueue
+	validator  interfaces.DataExportValidator
+	queue      *worker.JobQueue[ExportJob]
+	linkTTL    time.Duration
+	logger     interfaces.Logger
+	stopPurge  context.CancelFunc
+}
+
+type DataExportService interface {
+	RequestDataExport(ctx context.Context, userID string) (types.DataExportResponse, error)
+	GetDataExport(ctx context.Context, userID string, exportID string) (types.DataExportResponse, error)
+}
+
+// NewUserDataExportService creates the export service.
+// emailQueue may be nil when email isn't configured, exports are then only available from the status endpoint.
+func NewUserDataExportService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.DataExportDbAdapter,
+	blobStore interfaces.BlobStore,
+	emailQueue exportEmailQueue,
+	linkTTL time.Duration,
+) (*UserDataExportService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if blobStore == nil {
+		return nil, fmt.Errorf("blobStore is required")
+	}
+	if linkTTL <= 0 {
+		return nil, fmt.Errorf("linkTTL must be positive")
+	}
+
+	service := &UserDataExportService{
+		dbAdapter:  dbAdapter,
+		blobStore:  blobStore,
+		emailQueue: emailQueue,
+		validator:  NewDataExportValidator(),
+		linkTTL:    linkTTL,
+		logger:     appContext.Logger,
+	}
+	service.queue = worker.NewJobQueue("data export", 1, service.ProcessDataExport, appContext.Logger)
+
+	return service, nil
+}
+
+// Start starts the export worker, re-queues interrupted exports and begins purging expired archives
+func (es *UserDataExportService) Start(ctx context.Context) error {
+	if err := es.queue.Start(); err != nil {
+		return err
+	}
+
+	exports, err := es.dbAdapter.GetUnfinishedDataExports(ctx)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if err := es.queue.EnqueueJob(ExportJob{ExportID: export.ID, UserID: export.UserID}); err != nil {
+			es.logger.Error("Failed to resume data export", map[string]any{
+				"exportID": export.ID,
+				"error":    err,
+			})
+		}
+	}
+
+	purgeCtx, cancel := context.WithCancel(context.Background())
+	es.stopPurge = cancel
+	go worker.NewWorker(purgeInterval, es.PurgeExpiredExports, nil, es.logger).Start(purgeCtx)
+
+	return nil
+}
+
+// Stop stops the export worker and the purge job
+func (es *UserDataExportService) Stop() error {
+	if es.stopPurge != nil {
+		es.stopPurge()
+	}
+	return es.queue.Stop()
+}
+
+// POST
+// RequestDataExport queues a new export. A user only gets one export in flight at a time,
+// asking again while one is pending returns that export instead of starting another.
+func (es *UserDataExportService) RequestDataExport(
+	ctx context.Context,
+	userID string,
+) (types.DataExportResponse, error) {
+	es.logger.Info("UserDataExportService - RequestDataExport called", map[string]any{
+		"userID": userID,
+	})
+
+	if err := es.validator.ValidateUserID(userID); err != nil {
+		return types.DataExportResponse{}, err
+	}
+
+	active, found, err := es.dbAdapter.GetActiveDataExport(ctx, userID)
+	if err != nil {
+		return types.DataExportResponse{}, err
+	}
+	if found {
+		return TransformDataExportToResponse(active, ""), nil
+	}
+
+	export, err := es.dbAdapter.CreateDataExport(ctx, userID)
+	if err != nil {
+		return types.DataExportResponse{}, err
+	}
+
+	if err := es.queue.EnqueueJob(ExportJob{ExportID: export.ID, UserID: userID}); err != nil {
+		// Don't leave an export behind that nothing will ever pick up
+		if failErr := es.dbAdapter.FailDataExport(ctx, export.ID, err.Error()); failErr != nil {
+			es.logger.Error("Failed to mark unqueued data export as failed", map[string]any{
+				"exportID": export.ID,
+				"error":    failErr,
+			})
+		}
+		return types.DataExportResponse{}, fmt.Errorf("%w: try again later", ErrExportQueueFull)
+	}
+
+	return TransformDataExportToResponse(export, ""), nil
+}
+
+// GET
+// GetDataExport returns the export's status with a fresh download link once it is ready
+func (es *UserDataExportService) GetDataExport(
+	ctx context.Context,
+	userID string,
+	exportID string,
+) (types.DataExportResponse, error) {
+	if err := es.validator.ValidateUserID(userID); err != nil {
+		return types.DataExportResponse{}, err
+	}
+	if err := es.validator.ValidateExportID(exportID); err != nil {
+		return types.DataExportResponse{}, err
+	}
+
+	export, err := es.dbAdapter.GetDataExport(ctx, userID, exportID)
+	if err != nil {
+		return types.DataExportResponse{}, err
+	}
+
+	downloadURL := ""
+	if export.Status == models.DataExportCompleted && export.BlobKey.Valid &&
+		export.ExpiresAt.Valid && export.ExpiresAt.Time.After(time.Now()) {
+		downloadURL, err = es.blobStore.SignedURL(ctx, export.BlobKey.String, export.ExpiresAt.Time)
+		if err != nil {
+			return types.DataExportResponse{}, err
+		}
+	}
+
+	return TransformDataExportToResponse(export, downloadURL), nil
+}
+
+// ProcessDataExport gathers every dataset, stores the archive and emails the download link
+func (es *UserDataExportService) ProcessDataExport(ctx context.Context, job ExportJob) error {
+	export, err := es.dbAdapter.GetDataExport(ctx, job.UserID, job.ExportID)
+	if err != nil {
+		return err
+	}
+	if export.Status != models.DataExportPending && export.Status != models.DataExportRunning {
+		return nil
+	}
+
+	if err := es.dbAdapter.MarkDataExportRunning(ctx, export.ID); err != nil {
+		return err
+	}
+
+	tables := make([]models.DataExportTable, 0, len(exportDatasets))
+	for _, dataset := range exportDatasets {
+		table, err := es.dbAdapter.QueryDataset(ctx, dataset.name, dataset.query, export.UserID)
+		if err != nil {
+			return es.failExport(ctx, export.ID, err)
+		}
+		tables = append(tables, table)
+	}
+
+	exportedAt := time.Now()
+	blobKey := fmt.Sprintf("exports/%s/qko-data-export-%s.zip", export.ID, exportedAt.UTC().Format("2006-01-02"))
+
+	sizeBytes, err := es.storeArchive(ctx, blobKey, export, exportedAt, tables)
+	if err != nil {
+		return es.failExport(ctx, export.ID, err)
+	}
+
+	expiresAt := exportedAt.Add(es.linkTTL)
+	if err := es.dbAdapter.CompleteDataExport(ctx, export.ID, blobKey, sizeBytes, expiresAt); err != nil {
+		return es.failExport(ctx, export.ID, err)
+	}
+
+	es.logger.Info("Data export completed", map[string]any{
+		"exportID":  export.ID,
+		"userID":    export.UserID,
+		"sizeBytes": sizeBytes,
+	})
+
+	es.sendExportEmail(ctx, export, blobKey, expiresAt)
+
+	return nil
+}
+
+// PurgeExpiredExports deletes archives whose download link has expired
+func (es *UserDataExportService) PurgeExpiredExports(ctx context.Context) error {
+	exports, err := es.dbAdapter.GetExpiredDataExports(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		if export.BlobKey.Valid {
+			if err := es.blobStore.Delete(ctx, export.BlobKey.String); err != nil {
+				es.logger.Error("Failed to delete expired data export", map[string]any{
+					"exportID": export.ID,
+					"error":    err,
+				})
+				continue
+			}
+		}
+		if err := es.dbAdapter.ExpireDataExport(ctx, export.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeArchive builds the ZIP in a temp file so large exports aren't held in memory
+func (es *UserDataExportService) storeArchive(
+	ctx context.Context,
+	blobKey string,
+	export models.DataExportDB,
+	exportedAt time.Time,
+	tables []models.DataExportTable,
+) (int64, error) {
+	tempFile, err := os.CreateTemp("", "qko-export-*.zip")
+	if err != nil {
+		return 0, fmt.Errorf("error creating export archive: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if err := writeExportArchive(tempFile, export.ID, export.UserID, exportedAt, tables); err != nil {
+		return 0, err
+	}
+
+	sizeBytes, err := tempFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("error reading export archive: %w", err)
+	}
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("error reading export archive: %w", err)
+	}
+
+	if err := es.blobStore.Put(ctx, blobKey, tempFile); err != nil {
+		return 0, err
+	}
+
+	return sizeBytes, nil
+}
+
+// sendExportEmail queues the existing data export email, a failure here doesn't fail the export
+func (es *UserDataExportService) sendExportEmail(
+	ctx context.Context,
+	export models.DataExportDB,
+	blobKey string,
+	expiresAt time.Time,
+) {
+	if es.emailQueue == nil {
+		es.logger.Warn("Email is not configured, skipping data export email", map[string]any{
+			"exportID": export.ID,
+		})
+		return
+	}
+
+	user, err := es.dbAdapter.GetDataExportUser(ctx, export.UserID)
+	if err != nil {
+		es.logger.Error("Failed to look up user for data export email", map[string]any{
+			"exportID": export.ID,
+			"error":    err,
+		})
+		return
+	}
+
+	downloadURL, err := es.blobStore.SignedURL(ctx, blobKey, expiresAt)
+	if err != nil {
+		es.logger.Error("Failed to sign data export download link", map[string]any{
+			"exportID": export.ID,
+			"error":    err,
+		})
+		return
+	}
+
+	if err := es.emailQueue.EnqueueJob(ctx, email.EmailJobTypeDataExport, export.UserID, user.Email, map[string]interface{}{
+		"exportURL": downloadURL,
+		"userName":  user.FirstName,
+	}); err != nil {
+		es.logger.Error("Failed to queue data export email", map[string]any{
+			"exportID": export.ID,
+			"error":    err,
+		})
+	}
+}
+
+// failExport records a generic message for the user, the original error is returned for logging
+func (es *UserDataExportService) failExport(ctx context.Context, exportID string, cause error) error {
+	if errors.Is(cause, context.Canceled) {
+		return cause
+	}
+
+	if err := es.dbAdapter.FailDataExport(ctx, exportID, exportFailedMessage); err != nil {
+		es.logger.Error("Failed to mark data export as failed", map[string]any{
+			"exportID": exportID,
+			"error":    err,
+		})
+	}
+
+	return cause
+}
@@ -0,0 +1,27 @@
+package data_export
+
+import (
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformDataExportToResponse converts an export row to the status resource.
+// downloadURL is only set by the caller for completed exports that haven't expired.
+func TransformDataExportToResponse(export models.DataExportDB, downloadURL string) types.DataExportResponse {
+	response := types.DataExportResponse{
+		ID:           export.ID,
+		Status:       export.Status,
+		SizeBytes:    export.SizeBytes.Int64,
+		ErrorMessage: export.ErrorMessage.String,
+		DownloadURL:  downloadURL,
+		CreatedAt:    export.CreatedAt.Unix(),
+	}
+	if export.CompletedAt.Valid {
+		response.CompletedAt = export.CompletedAt.Time.Unix()
+	}
+	if export.ExpiresAt.Valid {
+		response.ExpiresAt = export.ExpiresAt.Time.Unix()
+	}
+
+	return response
+}
@@ -0,0 +1,28 @@
+package data_export
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+)
+
+type DataExportValidatorImpl struct{}
+
+func NewDataExportValidator() interfaces.DataExportValidator {
+	return &DataExportValidatorImpl{}
+}
+
+func (v *DataExportValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *DataExportValidatorImpl) ValidateExportID(exportID string) error {
+	if _, err := uuid.Parse(exportID); err != nil {
+		return fmt.Errorf("%w: invalid export ID", ErrValidationFailed)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package data_restore
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lokeam/qko-beta/internal/data_export"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// maxRestoreFileBytes caps each file once decompressed so a small upload can't expand without limit
+const maxRestoreFileBytes = 64 << 20
+
+// Export datasets a restore reads, the rest of the archive is ignored
+const (
+	restoreDatasetPhysicalLocations = "physical_locations"
+	restoreDatasetSublocations      = "sublocations"
+	restoreDatasetDigitalLocations  = "digital_locations"
+	restoreDatasetSubscriptions     = "subscriptions"
+	restoreDatasetPayments          = "payments"
+	restoreDatasetOneTimePurchases  = "one_time_purchases"
+	restoreDatasetGames             = "games"
+)
+
+// readRestoreArchive reads an archive written by the data export.
+// Archives from a newer format version than this build knows are rejected rather than half restored.
+func readRestoreArchive(content []byte) (models.DataRestoreArchive, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return models.DataRestoreArchive{}, fmt.Errorf("%w: not a zip file", ErrInvalidArchive)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	manifestFile, ok := files[data_export.ExportManifestFile]
+	if !ok {
+		return models.DataRestoreArchive{}, fmt.Errorf("%w: %s is missing", ErrInvalidArchive, data_export.ExportManifestFile)
+	}
+
+	var manifest data_export.ExportManifest
+	if err := decodeArchiveFile(manifestFile, &manifest); err != nil {
+		return models.DataRestoreArchive{}, err
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > data_export.DataExportFormatVersion {
+		return models.DataRestoreArchive{}, fmt.Errorf(
+			"%w: archive is version %d, this server reads up to version %d",
+			ErrUnsupportedFormatVersion,
+			manifest.FormatVersion,
+			data_export.DataExportFormatVersion,
+		)
+	}
+
+	datasetFiles := make(map[string]string, len(manifest.Datasets))
+	for _, dataset := range manifest.Datasets {
+		datasetFiles[dataset.Name] = dataset.JSONFile
+	}
+
+	result := models.DataRestoreArchive{FormatVersion: manifest.FormatVersion}
+	targets := []struct {
+		name   string
+		target any
+	}{
+		{name: restoreDatasetPhysicalLocations, target: &result.PhysicalLocations},
+		{name: restoreDatasetSublocations, target: &result.Sublocations},
+		{name: restoreDatasetDigitalLocations, target: &result.DigitalLocations},
+		{name: restoreDatasetSubscriptions, target: &result.Subscriptions},
+		{name: restoreDatasetPayments, target: &result.Payments},
+		{name: restoreDatasetOneTimePurchases, target: &result.OneTimePurchases},
+		{name: restoreDatasetGames, target: &result.Games},
+	}
+
+	for _, dataset := range targets {
+		// A dataset left out of the manifest restores as empty
+		fileName, listed := datasetFiles[dataset.name]
+		if !listed {
+			continue
+		}
+
+		file, ok := files[fileName]
+		if !ok {
+			return models.DataRestoreArchive{}, fmt.Errorf("%w: %s is listed in the manifest but missing", ErrInvalidArchive, fileName)
+		}
+		if err := decodeArchiveFile(file, dataset.target); err != nil {
+			return models.DataRestoreArchive{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// decodeArchiveFile unmarshals one JSON file from the archive into target
+func decodeArchiveFile(file *zip.File, target any) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: unable to open %s", ErrInvalidArchive, file.Name)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxRestoreFileBytes+1))
+	if err != nil {
+		return fmt.Errorf("%w: unable to read %s", ErrInvalidArchive, file.Name)
+	}
+	if len(content) > maxRestoreFileBytes {
+		return fmt.Errorf("%w: %s is too large", ErrInvalidArchive, file.Name)
+	}
+
+	if err := json.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("%w: %s is malformed: %v", ErrInvalidArchive, file.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package data_restore
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+/*
+	Behavior:
+	- Reading an export archive back for a restore
+
+	Scenarios:
+	- Datasets listed in the manifest are decoded, unknown columns are ignored
+	- Archives from a newer format version are rejected
+	- Archives without a manifest, or missing a listed file, are rejected
+*/
+
+func buildTestArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+const testManifest = `{
+	"formatVersion": 1,
+	"exportId": "export-1",
+	"userId": "user-1",
+	"exportedAt": "2024-04-14T15:00:00Z",
+	"datasets": [
+		{"name": "physical_locations", "rows": 1, "jsonFile": "json/physical_locations.json"},
+		{"name": "games", "rows": 1, "jsonFile": "json/games.json"},
+		{"name": "payments", "rows": 1, "jsonFile": "json/payments.json"}
+	]
+}`
+
+func TestReadRestoreArchive(t *testing.T) {
+	/*
+		GIVEN an archive written by the export
+		WHEN it is read
+		THEN the listed datasets are decoded and the rest are empty
+	*/
+	t.Run("Decodes listed datasets", func(t *testing.T) {
+		// GIVEN
+		content := buildTestArchive(t, map[string]string{
+			"manifest.json":                testManifest,
+			"json/physical_locations.json": `[{"id": "loc-1", "name": "Home", "location_type": "house", "bg_color": "red", "future_column": 1}]`,
+			"json/games.json":              `[{"id": 7, "game_id": 1942, "game_name": "The Witcher 3", "platform_id": 6, "game_type": "physical", "copy_number": 1, "sublocation_id": "sub-1"}]`,
+			"json/payments.json":           `[{"digital_location_id": "dl-1", "amount": "14.99", "payment_date": "2024-01-15T00:00:00Z", "payment_method": "visa"}]`,
+		})
+
+		// WHEN
+		archive, err := readRestoreArchive(content)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if archive.FormatVersion != 1 {
+			t.Errorf("Expected format version 1, got %d", archive.FormatVersion)
+		}
+		if len(archive.PhysicalLocations) != 1 || archive.PhysicalLocations[0].Name != "Home" {
+			t.Errorf("Unexpected physical locations: %+v", archive.PhysicalLocations)
+		}
+		if len(archive.Games) != 1 || archive.Games[0].SublocationID == nil || *archive.Games[0].SublocationID != "sub-1" {
+			t.Errorf("Unexpected games: %+v", archive.Games)
+		}
+		if len(archive.Payments) != 1 || archive.Payments[0].Amount.String() != "14.99" {
+			t.Errorf("Unexpected payments: %+v", archive.Payments)
+		}
+		if len(archive.Sublocations) != 0 || len(archive.DigitalLocations) != 0 {
+			t.Errorf("Expected unlisted datasets to be empty")
+		}
+	})
+
+	/*
+		GIVEN an archive from a newer version of the export
+		WHEN it is read
+		THEN it is rejected with ErrUnsupportedFormatVersion
+	*/
+	t.Run("Rejects newer format versions", func(t *testing.T) {
+		content := buildTestArchive(t, map[string]string{
+			"manifest.json": `{"formatVersion": 99, "datasets": []}`,
+		})
+
+		_, err := readRestoreArchive(content)
+		if !errors.Is(err, ErrUnsupportedFormatVersion) {
+			t.Errorf("Expected ErrUnsupportedFormatVersion, got %v", err)
+		}
+	})
+
+	/*
+		GIVEN archives that aren't complete exports
+		WHEN they are read
+		THEN they are rejected with ErrInvalidArchive
+	*/
+	t.Run("Rejects incomplete archives", func(t *testing.T) {
+		testCases := map[string][]byte{
+			"not a zip":        []byte("hello"),
+			"missing manifest": buildTestArchive(t, map[string]string{"json/games.json": "[]"}),
+			"missing dataset":  buildTestArchive(t, map[string]string{"manifest.json": testManifest}),
+		}
+
+		for name, content := range testCases {
+			if _, err := readRestoreArchive(content); !errors.Is(err, ErrInvalidArchive) {
+				t.Errorf("%s: expected ErrInvalidArchive, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,434 @@
+package data_restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type DataRestoreDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewDataRestoreDbAdapter(appContext *appcontext.AppContext) (*DataRestoreDbAdapter, error) {
+	appContext.Logger.Debug("Creating DataRestoreDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &DataRestoreDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// replaceModeQueries run before anything is restored when the mode is replace
+var replaceModeQueries = []string{
+	DeleteUserOneTimePurchasesQuery,
+	DeleteUserGamesQuery,
+	DeleteUserSublocationsQuery,
+	DeleteUserPhysicalLocationsQuery,
+	DeleteUserDigitalLocationsQuery,
+}
+
+// RestoreUserData rebuilds a user's locations, library and spend records in a single transaction.
+// Exported IDs are remapped to the IDs rows get in this database, so nothing in the archive has to line up with existing data.
+func (ra *DataRestoreDbAdapter) RestoreUserData(
+	ctx context.Context,
+	userID string,
+	mode string,
+	archive models.DataRestoreArchive,
+) ([]models.DataRestoreDatasetCount, error) {
+	ra.logger.Debug("DataRestoreDbAdapter - RestoreUserData called", map[string]any{
+		"userID": userID,
+		"mode":   mode,
+	})
+
+	tx, err := ra.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to start transaction: %v", ErrDatabaseError, err)
+	}
+	defer tx.Rollback()
+
+	if mode == models.DataRestoreModeReplace {
+		for _, query := range replaceModeQueries {
+			if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+				return nil, fmt.Errorf("%w: error clearing existing data: %v", ErrDatabaseError, err)
+			}
+		}
+	}
+
+	restorer := &userDataRestorer{
+		ctx:                 ctx,
+		tx:                  tx,
+		userID:              userID,
+		physicalLocationIDs: make(map[string]string),
+		sublocationIDs:      make(map[string]string),
+		digitalLocationIDs:  make(map[string]string),
+		userGames:           make(map[int64]restoredRow),
+	}
+
+	if err := restorer.restorePhysicalLocations(archive.PhysicalLocations); err != nil {
+		return nil, err
+	}
+	if err := restorer.restoreSublocations(archive.Sublocations); err != nil {
+		return nil, err
+	}
+	if err := restorer.restoreDigitalLocations(archive.DigitalLocations); err != nil {
+		return nil, err
+	}
+	if err := restorer.restoreSubscriptions(archive.Subscriptions); err != nil {
+		return nil, err
+	}
+	if err := restorer.restorePayments(archive.Payments); err != nil {
+		return nil, err
+	}
+	if err := restorer.restoreOneTimePurchases(archive.OneTimePurchases); err != nil {
+		return nil, err
+	}
+	if err := restorer.restoreGames(archive.Games); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%w: failed to commit transaction: %v", ErrDatabaseError, err)
+	}
+
+	return restorer.counts, nil
+}
+
+// restoredRow is the ID a row was given in this database and whether the restore created it
+type restoredRow struct {
+	ID      string `db:"id"`
+	Created bool   `db:"created"`
+}
+
+// userDataRestorer holds the old to new ID maps for a single restore.
+// Parents are restored before children so every reference can be remapped as it is read.
+type userDataRestorer struct {
+	ctx    context.Context
+	tx     *sqlx.Tx
+	userID string
+
+	physicalLocationIDs map[string]string
+	sublocationIDs      map[string]string
+	digitalLocationIDs  map[string]string
+	userGames           map[int64]restoredRow
+
+	counts []models.DataRestoreDatasetCount
+}
+
+func (r *userDataRestorer) insert(dataset string, index int, query string, args ...any) (restoredRow, error) {
+	var row restoredRow
+	if err := r.tx.QueryRowxContext(r.ctx, query, args...).StructScan(&row); err != nil {
+		return restoredRow{}, fmt.Errorf("%w: error restoring %s row %d: %v", ErrDatabaseError, dataset, index, err)
+	}
+	return row, nil
+}
+
+func (r *userDataRestorer) restorePhysicalLocations(locations []models.RestorePhysicalLocation) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetPhysicalLocations}
+
+	for i, location := range locations {
+		row, err := r.insert(count.Name, i, RestorePhysicalLocationQuery,
+			r.userID,
+			location.Name,
+			location.Label,
+			location.LocationType,
+			location.MapCoordinates,
+			location.BgColor,
+		)
+		if err != nil {
+			return err
+		}
+
+		r.physicalLocationIDs[location.ID] = row.ID
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func (r *userDataRestorer) restoreSublocations(sublocations []models.RestoreSublocation) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetSublocations}
+
+	for i, sublocation := range sublocations {
+		physicalLocationID, err := remapOptional(r.physicalLocationIDs, sublocation.PhysicalLocationID, count.Name, i, "physical location")
+		if err != nil {
+			return err
+		}
+
+		row, err := r.insert(count.Name, i, RestoreSublocationQuery,
+			r.userID,
+			physicalLocationID,
+			sublocation.Name,
+			sublocation.LocationType,
+		)
+		if err != nil {
+			return err
+		}
+
+		r.sublocationIDs[sublocation.ID] = row.ID
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func (r *userDataRestorer) restoreDigitalLocations(locations []models.RestoreDigitalLocation) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetDigitalLocations}
+
+	for i, location := range locations {
+		row, err := r.insert(count.Name, i, RestoreDigitalLocationQuery,
+			r.userID,
+			location.Name,
+			location.IsSubscription,
+			location.IsActive,
+			nullableNumber(location.DiskSizeValue),
+			location.DiskSizeUnit,
+			location.URL,
+			location.PaymentMethod,
+		)
+		if err != nil {
+			return err
+		}
+
+		r.digitalLocationIDs[location.ID] = row.ID
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func (r *userDataRestorer) restoreSubscriptions(subscriptions []models.RestoreSubscription) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetSubscriptions}
+
+	for i, subscription := range subscriptions {
+		digitalLocationID, err := remapRequired(r.digitalLocationIDs, subscription.DigitalLocationID, count.Name, i, "digital location")
+		if err != nil {
+			return err
+		}
+
+		row, err := r.insert(count.Name, i, RestoreSubscriptionQuery,
+			digitalLocationID,
+			subscription.BillingCycle,
+			nullableNumber(subscription.CostPerCycle),
+			subscription.AnchorDate,
+			subscription.LastPaymentDate,
+			subscription.PaymentMethod,
+		)
+		if err != nil {
+			return err
+		}
+
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func (r *userDataRestorer) restorePayments(payments []models.RestorePayment) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetPayments}
+
+	for i, payment := range payments {
+		digitalLocationID, err := remapRequired(r.digitalLocationIDs, payment.DigitalLocationID, count.Name, i, "digital location")
+		if err != nil {
+			return err
+		}
+
+		row, err := r.insert(count.Name, i, RestorePaymentQuery,
+			digitalLocationID,
+			nullableNumber(payment.Amount),
+			payment.PaymentDate,
+			payment.PaymentMethod,
+			payment.TransactionID,
+		)
+		if err != nil {
+			return err
+		}
+
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func (r *userDataRestorer) restoreOneTimePurchases(purchases []models.RestoreOneTimePurchase) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetOneTimePurchases}
+
+	for i, purchase := range purchases {
+		digitalLocationID, err := remapOptional(r.digitalLocationIDs, purchase.DigitalLocationID, count.Name, i, "digital location")
+		if err != nil {
+			return err
+		}
+
+		row, err := r.insert(count.Name, i, RestoreOneTimePurchaseQuery,
+			r.userID,
+			purchase.Title,
+			nullableNumber(purchase.Amount),
+			purchase.PurchaseDate,
+			purchase.PaymentMethod,
+			purchase.SpendingCategoryName,
+			digitalLocationID,
+			purchase.IsDigital,
+			purchase.IsWishlisted,
+		)
+		if err != nil {
+			return err
+		}
+
+		tally(&count, row.Created)
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+// restoreGames restores library copies and puts each one back where it was stored.
+// Copies that matched one the user already has keep their current locations.
+func (r *userDataRestorer) restoreGames(games []models.RestoreUserGame) error {
+	count := models.DataRestoreDatasetCount{Name: restoreDatasetGames}
+
+	for i, game := range games {
+		copyRow, seen := r.userGames[game.ID]
+		if !seen {
+			if _, err := r.tx.ExecContext(r.ctx, EnsureRestoreGameQuery, game.GameID, game.GameName); err != nil {
+				return fmt.Errorf("%w: error ensuring game %d exists: %v", ErrDatabaseError, game.GameID, err)
+			}
+
+			platformName := fmt.Sprintf("Platform %d", game.PlatformID)
+			if game.PlatformName != nil && *game.PlatformName != "" {
+				platformName = *game.PlatformName
+			}
+			if _, err := r.tx.ExecContext(
+				r.ctx,
+				EnsureRestorePlatformQuery,
+				game.PlatformID,
+				platformName,
+				platformCategory(platformName),
+				platformName,
+			); err != nil {
+				return fmt.Errorf("%w: error ensuring platform %d exists: %v", ErrDatabaseError, game.PlatformID, err)
+			}
+
+			row, err := r.insert(count.Name, i, RestoreUserGameQuery,
+				r.userID,
+				game.GameID,
+				game.PlatformID,
+				game.GameType,
+				game.CopyNumber,
+				game.IsUniqueCopy,
+				game.Favorite,
+				game.Condition,
+				game.HasOriginalCase,
+				game.HasManual,
+				game.AcquiredDate,
+				game.PlayStatus,
+				game.StartedAt,
+				game.FinishedAt,
+				game.CompletionNote,
+				game.PersonalRating,
+				game.Review,
+				game.PrivateNotes,
+				game.CopyNotes,
+			)
+			if err != nil {
+				return err
+			}
+
+			copyRow = row
+			r.userGames[game.ID] = row
+			tally(&count, row.Created)
+		}
+
+		if !copyRow.Created {
+			continue
+		}
+
+		if game.SublocationID != nil {
+			sublocationID, err := remapRequired(r.sublocationIDs, *game.SublocationID, count.Name, i, "sublocation")
+			if err != nil {
+				return err
+			}
+			if _, err := r.tx.ExecContext(r.ctx, RestorePhysicalGameLocationQuery, copyRow.ID, sublocationID); err != nil {
+				return fmt.Errorf("%w: error restoring games row %d location: %v", ErrDatabaseError, i, err)
+			}
+		}
+
+		if game.DigitalLocationID != nil {
+			digitalLocationID, err := remapRequired(r.digitalLocationIDs, *game.DigitalLocationID, count.Name, i, "digital location")
+			if err != nil {
+				return err
+			}
+			if _, err := r.tx.ExecContext(r.ctx, RestoreDigitalGameLocationQuery, copyRow.ID, digitalLocationID); err != nil {
+				return fmt.Errorf("%w: error restoring games row %d location: %v", ErrDatabaseError, i, err)
+			}
+		}
+	}
+
+	r.counts = append(r.counts, count)
+	return nil
+}
+
+func tally(count *models.DataRestoreDatasetCount, created bool) {
+	if created {
+		count.Created++
+	} else {
+		count.Merged++
+	}
+}
+
+// remapRequired swaps an exported ID for the ID its row was restored as
+func remapRequired(ids map[string]string, exportedID string, dataset string, index int, parent string) (string, error) {
+	newID, ok := ids[exportedID]
+	if !ok {
+		return "", fmt.Errorf("%w: %s row %d references %s '%s' which isn't in the archive", ErrInvalidArchive, dataset, index, parent, exportedID)
+	}
+	return newID, nil
+}
+
+// remapOptional is remapRequired for nullable references
+func remapOptional(ids map[string]string, exportedID *string, dataset string, index int, parent string) (*string, error) {
+	if exportedID == nil {
+		return nil, nil
+	}
+
+	newID, err := remapRequired(ids, *exportedID, dataset, index, parent)
+	if err != nil {
+		return nil, err
+	}
+	return &newID, nil
+}
+
+// nullableNumber passes a decimal to postgres as text, or NULL when the archive had none
+func nullableNumber(value json.Number) any {
+	if value == "" {
+		return nil
+	}
+	return value.String()
+}
+
+// platformCategory uses the same heuristic as the library when it first sees a platform,
+// the platform sync replaces it with the category IGDB gives the platform
+func platformCategory(platformName string) string {
+	switch {
+	case strings.Contains(strings.ToLower(platformName), "pc"):
+		return "pc"
+	case strings.Contains(strings.ToLower(platformName), "mobile"):
+		return "mobile"
+	default:
+		return "console"
+	}
+}
@@ -0,0 +1,185 @@
+package data_restore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Restoring an archive inside a single transaction with remapped IDs
+
+	Scenarios:
+	- Children are inserted against the IDs their parents were restored as
+	- Replace mode clears existing data first
+	- Copies that match an existing copy keep their current location
+	- A reference to a row that isn't in the archive rolls everything back
+*/
+
+func TestDataRestoreDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	sublocationID := "old-sub-1"
+
+	setupMockDB := func() (*DataRestoreDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &DataRestoreDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	restoredColumns := []string{"id", "created"}
+
+	archive := models.DataRestoreArchive{
+		FormatVersion: 1,
+		PhysicalLocations: []models.RestorePhysicalLocation{
+			{ID: "old-loc-1", Name: "Home", LocationType: "house", BgColor: "red"},
+		},
+		Sublocations: []models.RestoreSublocation{
+			{ID: sublocationID, PhysicalLocationID: stringPtr("old-loc-1"), Name: "Shelf", LocationType: "shelf"},
+		},
+		Games: []models.RestoreUserGame{
+			{ID: 7, GameID: 1942, GameName: "The Witcher 3", PlatformID: 48, PlatformName: stringPtr("PlayStation 4"), GameType: "physical", CopyNumber: 1, SublocationID: &sublocationID},
+		},
+	}
+
+	/*
+		GIVEN an archive with a location, a sublocation inside it and a game on that sublocation
+		WHEN it is restored in replace mode
+		THEN existing data is cleared and every child points at its parent's new ID
+	*/
+	t.Run("Replace mode remaps IDs", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		for _, table := range []string{"one_time_purchases", "user_games", "sublocations", "physical_locations", "digital_locations"} {
+			mock.ExpectExec("DELETE FROM " + table).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectQuery("INSERT INTO physical_locations").
+			WithArgs(userID, "Home", nil, "house", nil, "red").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("new-loc-1", true))
+		mock.ExpectQuery("INSERT INTO sublocations").
+			WithArgs(userID, "new-loc-1", "Shelf", "shelf").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("new-sub-1", true))
+		mock.ExpectExec("INSERT INTO games").WithArgs(int64(1942), "The Witcher 3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO platforms").WithArgs(int64(48), "PlayStation 4", "console", "PlayStation 4").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("INSERT INTO user_games").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("101", true))
+		mock.ExpectExec("INSERT INTO physical_game_locations").
+			WithArgs("101", "new-sub-1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		counts, err := adapter.RestoreUserData(context.Background(), userID, models.DataRestoreModeReplace, archive)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(counts) != 7 {
+			t.Fatalf("Expected a count for every dataset, got %d", len(counts))
+		}
+		if counts[0].Name != restoreDatasetPhysicalLocations || counts[0].Created != 1 {
+			t.Errorf("Unexpected physical location count: %+v", counts[0])
+		}
+		if counts[6].Name != restoreDatasetGames || counts[6].Created != 1 {
+			t.Errorf("Unexpected games count: %+v", counts[6])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a user who already owns the archived copy
+		WHEN the archive is merged in
+		THEN the copy is counted as merged and its location is left alone
+	*/
+	t.Run("Merge mode keeps existing copies where they are", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO physical_locations").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("existing-loc", false))
+		mock.ExpectQuery("INSERT INTO sublocations").
+			WithArgs(userID, "existing-loc", "Shelf", "shelf").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("existing-sub", false))
+		mock.ExpectExec("INSERT INTO games").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO platforms").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("INSERT INTO user_games").
+			WillReturnRows(sqlmock.NewRows(restoredColumns).AddRow("55", false))
+		mock.ExpectCommit()
+
+		// WHEN
+		counts, err := adapter.RestoreUserData(context.Background(), userID, models.DataRestoreModeMerge, archive)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if counts[6].Created != 0 || counts[6].Merged != 1 {
+			t.Errorf("Expected the copy to be merged, got %+v", counts[6])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a sublocation whose physical location isn't in the archive
+		WHEN it is restored
+		THEN the restore fails with ErrInvalidArchive and is rolled back
+	*/
+	t.Run("Unknown references roll back", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		brokenArchive := models.DataRestoreArchive{
+			Sublocations: []models.RestoreSublocation{
+				{ID: sublocationID, PhysicalLocationID: stringPtr("missing-loc"), Name: "Shelf", LocationType: "shelf"},
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.RestoreUserData(context.Background(), userID, models.DataRestoreModeMerge, brokenArchive)
+
+		// THEN
+		if !errors.Is(err, ErrInvalidArchive) {
+			t.Errorf("Expected ErrInvalidArchive, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
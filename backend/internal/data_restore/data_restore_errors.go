@@ -0,0 +1,30 @@
+package data_restore
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrValidationFailed         = errors.New("validation failed")
+	ErrInvalidArchive           = errors.New("invalid export archive")
+	ErrUnsupportedFormatVersion = errors.New("unsupported export format version")
+	ErrDatabaseError            = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInvalidArchive):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnsupportedFormatVersion):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,103 @@
+package data_restore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+)
+
+// maxRestoreBodyBytes is the largest export archive accepted for a restore
+const maxRestoreBodyBytes = 50 << 20
+
+func RegisterDataRestoreRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	restoreService services.DataRestoreService,
+) {
+	r.Post("/", RestoreUserData(appCtx, restoreService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// RestoreUserData handles POST requests that upload an export archive to restore from.
+// The body is the ZIP as downloaded, ?mode=replace clears existing data first, the default merges into it.
+func RestoreUserData(
+	appCtx *appcontext.AppContext,
+	restoreService services.DataRestoreService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		mode := strings.ToLower(r.URL.Query().Get("mode"))
+		if mode == "" {
+			mode = models.DataRestoreModeMerge
+		}
+
+		appCtx.Logger.Info("Restoring user data", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"mode":      mode,
+		})
+
+		archive, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRestoreBodyBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: archive is larger than %d MB", ErrValidationFailed, maxRestoreBodyBytes>>20))
+				return
+			}
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: unable to read request body", ErrValidationFailed))
+			return
+		}
+
+		restore, err := restoreService.RestoreUserData(r.Context(), userID, mode, archive)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"restore": restore,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
@@ -0,0 +1,201 @@
+package data_restore
+
+// Replace mode clears everything a restore rebuilds, in an order that satisfies the foreign keys.
+// Deleting user_games cascades to its location links, deleting digital_locations cascades to subscriptions and payments.
+const (
+	DeleteUserOneTimePurchasesQuery = `
+		DELETE FROM one_time_purchases
+		WHERE user_id = $1
+	`
+
+	DeleteUserGamesQuery = `
+		DELETE FROM user_games
+		WHERE user_id = $1
+	`
+
+	DeleteUserSublocationsQuery = `
+		DELETE FROM sublocations
+		WHERE user_id = $1
+	`
+
+	DeleteUserPhysicalLocationsQuery = `
+		DELETE FROM physical_locations
+		WHERE user_id = $1
+	`
+
+	DeleteUserDigitalLocationsQuery = `
+		DELETE FROM digital_locations
+		WHERE user_id = $1
+	`
+)
+
+// Each restore query inserts a row unless the user already has a matching one,
+// and returns the ID to remap to along with whether the row was created.
+// Parameters that only appear in a SELECT list are cast, Postgres would otherwise type them as text.
+const (
+	RestorePhysicalLocationQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM physical_locations
+			WHERE user_id = $1 AND name = $2 AND deleted_at IS NULL
+			ORDER BY created_at
+			LIMIT 1
+		),
+		inserted AS (
+			INSERT INTO physical_locations (user_id, name, label, location_type, map_coordinates, bg_color)
+			SELECT $1, $2, $3, $4, $5, $6
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestoreSublocationQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM sublocations
+			WHERE user_id = $1 AND physical_location_id IS NOT DISTINCT FROM $2 AND name = $3 AND deleted_at IS NULL
+			LIMIT 1
+		),
+		inserted AS (
+			INSERT INTO sublocations (user_id, physical_location_id, name, location_type)
+			SELECT $1, $2, $3, $4
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestoreDigitalLocationQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM digital_locations
+			WHERE user_id = $1 AND name = $2
+		),
+		inserted AS (
+			INSERT INTO digital_locations (user_id, name, is_subscription, is_active, disk_size_value, disk_size_unit, url, payment_method)
+			SELECT $1, $2, $3::boolean, $4::boolean, $5::numeric, $6, $7, $8
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestoreSubscriptionQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM digital_location_subscriptions
+			WHERE digital_location_id = $1
+		),
+		inserted AS (
+			INSERT INTO digital_location_subscriptions (digital_location_id, billing_cycle, cost_per_cycle, anchor_date, last_payment_date, payment_method)
+			SELECT $1, $2, $3::numeric, $4::date, $5::date, $6
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestorePaymentQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM digital_location_payments
+			WHERE digital_location_id = $1 AND amount = $2 AND payment_date = $3
+			LIMIT 1
+		),
+		inserted AS (
+			INSERT INTO digital_location_payments (digital_location_id, amount, payment_date, payment_method, transaction_id)
+			SELECT $1, $2, $3, $4, $5
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestoreOneTimePurchaseQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM one_time_purchases
+			WHERE user_id = $1 AND title = $2 AND amount = $3 AND purchase_date = $4 AND deleted_at IS NULL
+			LIMIT 1
+		),
+		inserted AS (
+			INSERT INTO one_time_purchases (
+				user_id, title, amount, purchase_date, payment_method,
+				spending_category_id, digital_location_id, is_digital, is_wishlisted
+			)
+			SELECT
+				$1, $2, $3, $4, $5,
+				(SELECT id FROM spending_categories WHERE name = $6),
+				$7::uuid, $8::boolean, $9::boolean
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+
+	RestoreUserGameQuery = `
+		WITH existing AS (
+			SELECT id
+			FROM user_games
+			WHERE user_id = $1 AND game_id = $2 AND platform_id = $3 AND game_type = $4 AND copy_number = $5 AND deleted_at IS NULL
+		),
+		inserted AS (
+			INSERT INTO user_games (
+				user_id, game_id, platform_id, game_type, copy_number, is_unique_copy, favorite,
+				condition, has_original_case, has_manual, acquired_date,
+				play_status, started_at, finished_at, completion_note,
+				personal_rating, review, private_notes, copy_notes
+			)
+			SELECT
+				$1, $2, $3, $4, $5, $6::boolean, $7::boolean,
+				$8, $9::boolean, $10::boolean, $11::timestamptz,
+				COALESCE($12, 'backlog'), $13::timestamptz, $14::timestamptz, $15,
+				$16::smallint, $17, $18, $19
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING id
+		)
+		SELECT id, true AS created FROM inserted
+		UNION ALL
+		SELECT id, false AS created FROM existing
+	`
+)
+
+// Games and platforms are shared IGDB data, another environment may not have them yet
+const (
+	EnsureRestoreGameQuery = `
+		INSERT INTO games (id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	EnsureRestorePlatformQuery = `
+		INSERT INTO platforms (id, name, category, model)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	RestorePhysicalGameLocationQuery = `
+		INSERT INTO physical_game_locations (user_game_id, sublocation_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_game_id, sublocation_id) DO NOTHING
+	`
+
+	RestoreDigitalGameLocationQuery = `
+		INSERT INTO digital_game_locations (user_game_id, digital_location_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_game_id, digital_location_id) DO NOTHING
+	`
+)
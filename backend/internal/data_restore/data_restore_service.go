@@ -0,0 +1,116 @@
+package data_restore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// userCacheInvalidator is the part of each domain's cache adapter a restore needs
+type userCacheInvalidator interface {
+	InvalidateUserCache(ctx context.Context, userID string) error
+}
+
+type UserDataRestoreService struct {
+	dbAdapter        interfaces.DataRestoreDbAdapter
+	analyticsService analytics.Service
+	userCaches       []userCacheInvalidator
+	validator        interfaces.DataRestoreValidator
+	logger           interfaces.Logger
+}
+
+type DataRestoreService interface {
+	RestoreUserData(ctx context.Context, userID string, mode string, archive []byte) (types.DataRestoreResponse, error)
+}
+
+// NewUserDataRestoreService creates the restore service.
+// userCaches are cleared for the user after every restore since any of the restored domains may have changed.
+func NewUserDataRestoreService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.DataRestoreDbAdapter,
+	analyticsService analytics.Service,
+	userCaches ...userCacheInvalidator,
+) (*UserDataRestoreService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if analyticsService == nil {
+		return nil, fmt.Errorf("analyticsService is required")
+	}
+
+	return &UserDataRestoreService{
+		dbAdapter:        dbAdapter,
+		analyticsService: analyticsService,
+		userCaches:       userCaches,
+		validator:        NewDataRestoreValidator(),
+		logger:           appContext.Logger,
+	}, nil
+}
+
+// POST
+// RestoreUserData rebuilds the user's data from an export archive.
+// Nothing is written unless the whole archive restores.
+func (rs *UserDataRestoreService) RestoreUserData(
+	ctx context.Context,
+	userID string,
+	mode string,
+	archive []byte,
+) (types.DataRestoreResponse, error) {
+	if err := rs.validator.ValidateUserID(userID); err != nil {
+		return types.DataRestoreResponse{}, err
+	}
+	if err := rs.validator.ValidateRestoreMode(mode); err != nil {
+		return types.DataRestoreResponse{}, err
+	}
+
+	restoreArchive, err := readRestoreArchive(archive)
+	if err != nil {
+		return types.DataRestoreResponse{}, err
+	}
+	if err := rs.validator.ValidateRestoreArchive(restoreArchive); err != nil {
+		return types.DataRestoreResponse{}, err
+	}
+
+	counts, err := rs.dbAdapter.RestoreUserData(ctx, userID, mode, restoreArchive)
+	if err != nil {
+		return types.DataRestoreResponse{}, err
+	}
+
+	rs.invalidateCaches(ctx, userID)
+
+	rs.logger.Info("User data restored", map[string]any{
+		"userID":        userID,
+		"mode":          mode,
+		"formatVersion": restoreArchive.FormatVersion,
+	})
+
+	return TransformDataRestoreResponse(mode, restoreArchive.FormatVersion, counts), nil
+}
+
+// invalidateCaches clears every cache that could hold the user's pre-restore data
+func (rs *UserDataRestoreService) invalidateCaches(ctx context.Context, userID string) {
+	for _, cache := range rs.userCaches {
+		if err := cache.InvalidateUserCache(ctx, userID); err != nil {
+			rs.logger.Error("Failed to invalidate cache after data restore", map[string]any{
+				"error":  err,
+				"userID": userID,
+			})
+		}
+	}
+
+	if err := rs.analyticsService.InvalidateDomains(ctx, userID, []string{
+		analytics.DomainGeneral,
+		analytics.DomainFinancial,
+		analytics.DomainStorage,
+		analytics.DomainInventory,
+	}); err != nil {
+		rs.logger.Warn("Failed to invalidate analytics cache after data restore", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package data_restore
+
+import (
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+func TransformDataRestoreResponse(
+	mode string,
+	formatVersion int,
+	counts []models.DataRestoreDatasetCount,
+) types.DataRestoreResponse {
+	response := types.DataRestoreResponse{
+		Mode:          mode,
+		FormatVersion: formatVersion,
+		Datasets:      make([]types.DataRestoreDatasetResponse, 0, len(counts)),
+	}
+
+	for _, count := range counts {
+		response.Datasets = append(response.Datasets, types.DataRestoreDatasetResponse{
+			Name:    count.Name,
+			Created: count.Created,
+			Merged:  count.Merged,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,75 @@
+package data_restore
+
+import (
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type DataRestoreValidatorImpl struct{}
+
+func NewDataRestoreValidator() interfaces.DataRestoreValidator {
+	return &DataRestoreValidatorImpl{}
+}
+
+func (v *DataRestoreValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *DataRestoreValidatorImpl) ValidateRestoreMode(mode string) error {
+	switch mode {
+	case models.DataRestoreModeMerge, models.DataRestoreModeReplace:
+		return nil
+	default:
+		return fmt.Errorf("%w: mode must be '%s' or '%s'", ErrValidationFailed, models.DataRestoreModeMerge, models.DataRestoreModeReplace)
+	}
+}
+
+// ValidateRestoreArchive checks the fields a restore needs to remap and insert rows.
+// Everything else is left to the database constraints.
+func (v *DataRestoreValidatorImpl) ValidateRestoreArchive(archive models.DataRestoreArchive) error {
+	for i, location := range archive.PhysicalLocations {
+		if location.ID == "" || location.Name == "" {
+			return fmt.Errorf("%w: %s row %d needs an id and name", ErrInvalidArchive, restoreDatasetPhysicalLocations, i)
+		}
+	}
+	for i, sublocation := range archive.Sublocations {
+		if sublocation.ID == "" || sublocation.Name == "" {
+			return fmt.Errorf("%w: %s row %d needs an id and name", ErrInvalidArchive, restoreDatasetSublocations, i)
+		}
+	}
+	for i, location := range archive.DigitalLocations {
+		if location.ID == "" || location.Name == "" {
+			return fmt.Errorf("%w: %s row %d needs an id and name", ErrInvalidArchive, restoreDatasetDigitalLocations, i)
+		}
+	}
+	for i, subscription := range archive.Subscriptions {
+		if subscription.DigitalLocationID == "" || subscription.CostPerCycle == "" {
+			return fmt.Errorf("%w: %s row %d needs a digital_location_id and cost_per_cycle", ErrInvalidArchive, restoreDatasetSubscriptions, i)
+		}
+	}
+	for i, payment := range archive.Payments {
+		if payment.DigitalLocationID == "" || payment.Amount == "" {
+			return fmt.Errorf("%w: %s row %d needs a digital_location_id and amount", ErrInvalidArchive, restoreDatasetPayments, i)
+		}
+	}
+	for i, purchase := range archive.OneTimePurchases {
+		if purchase.Title == "" || purchase.Amount == "" {
+			return fmt.Errorf("%w: %s row %d needs a title and amount", ErrInvalidArchive, restoreDatasetOneTimePurchases, i)
+		}
+	}
+	for i, game := range archive.Games {
+		if game.ID <= 0 || game.GameID <= 0 || game.PlatformID <= 0 || game.GameName == "" {
+			return fmt.Errorf("%w: %s row %d needs an id, game_id, game_name and platform_id", ErrInvalidArchive, restoreDatasetGames, i)
+		}
+		if game.GameType != "physical" && game.GameType != "digital" {
+			return fmt.Errorf("%w: %s row %d has game_type '%s'", ErrInvalidArchive, restoreDatasetGames, i, game.GameType)
+		}
+	}
+
+	return nil
+}
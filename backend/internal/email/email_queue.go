@@ -29,8 +29,16 @@ const (
 	EmailJobTypeDeletionConfirmation EmailJobType = "deletion_confirmation"
 	EmailJobTypeDataExport           EmailJobType = "data_export"
 	EmailJobTypeWelcomeBack          EmailJobType = "welcome_back"
+	EmailJobTypeLoanOverdue          EmailJobType = "loan_overdue"
+	EmailJobTypeWishlistSale         EmailJobType = "wishlist_sale"
+	EmailJobTypeReleaseReminder      EmailJobType = "release_reminder"
 )
 
+// JobEnqueuer is the part of EmailQueue services that send email depend on
+type JobEnqueuer interface {
+	EnqueueJob(ctx context.Context, jobType EmailJobType, userID, email string, data map[string]interface{}) error
+}
+
 // EmailQueue handles asynchronous email processing
 type EmailQueue struct {
 	appCtx       *appcontext.AppContext
@@ -241,6 +249,75 @@ func (eq *EmailQueue) processJob(job EmailJob) {
 		}
 		err = eq.emailService.SendWelcomeBackEmail(ctx, job.UserID, job.Email, userName)
 
+	case EmailJobTypeLoanOverdue:
+		userName, ok := job.Data["userName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid userName data")
+			break
+		}
+		gameName, ok := job.Data["gameName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid gameName data")
+			break
+		}
+		borrowerName, ok := job.Data["borrowerName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid borrowerName data")
+			break
+		}
+		dueDate, ok := job.Data["dueDate"].(time.Time)
+		if !ok {
+			err = fmt.Errorf("invalid dueDate data")
+			break
+		}
+		err = eq.emailService.SendLoanOverdueEmail(ctx, job.UserID, job.Email, userName, gameName, borrowerName, dueDate)
+
+	case EmailJobTypeWishlistSale:
+		userName, ok := job.Data["userName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid userName data")
+			break
+		}
+		gameName, ok := job.Data["gameName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid gameName data")
+			break
+		}
+		platformName, ok := job.Data["platformName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid platformName data")
+			break
+		}
+		price, ok := job.Data["price"].(float64)
+		if !ok {
+			err = fmt.Errorf("invalid price data")
+			break
+		}
+		regularPrice, ok := job.Data["regularPrice"].(float64)
+		if !ok {
+			err = fmt.Errorf("invalid regularPrice data")
+			break
+		}
+		targetPrice, ok := job.Data["targetPrice"].(float64)
+		if !ok {
+			err = fmt.Errorf("invalid targetPrice data")
+			break
+		}
+		err = eq.emailService.SendWishlistSaleEmail(ctx, job.UserID, job.Email, userName, gameName, platformName, price, regularPrice, targetPrice)
+
+	case EmailJobTypeReleaseReminder:
+		userName, ok := job.Data["userName"].(string)
+		if !ok {
+			err = fmt.Errorf("invalid userName data")
+			break
+		}
+		games, ok := job.Data["games"].([]ReleaseReminderGame)
+		if !ok || len(games) == 0 {
+			err = fmt.Errorf("invalid games data")
+			break
+		}
+		err = eq.emailService.SendReleaseReminderEmail(ctx, job.UserID, job.Email, userName, games)
+
 	default:
 		err = fmt.Errorf("unknown email job type: %s", job.Type)
 	}
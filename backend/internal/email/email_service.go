@@ -14,6 +14,13 @@ type EmailService interface {
 	SendDataExportEmail(ctx context.Context, userID, email, userName string, exportURL string) error
 	SendWelcomeBackEmail(ctx context.Context, userID, email string, userName string) error
 
+	// Library related emails
+	SendLoanOverdueEmail(ctx context.Context, userID, email, userName, gameName, borrowerName string, dueDate time.Time) error
+
+	// Wishlist related emails
+	SendWishlistSaleEmail(ctx context.Context, userID, email, userName, gameName, platformName string, price, regularPrice, targetPrice float64) error
+	SendReleaseReminderEmail(ctx context.Context, userID, email, userName string, games []ReleaseReminderGame) error
+
 	// Utility methods
 	SendEmail(ctx context.Context, to, subject, htmlContent string) error
 	Close() error
@@ -31,6 +38,13 @@ type EmailData struct {
 	TemplateName   string
 }
 
+// ReleaseReminderGame is a wishlisted game listed in a release reminder
+type ReleaseReminderGame struct {
+	GameName     string
+	PlatformName string
+	ReleaseDate  time.Time
+}
+
 // EmailTemplate defines email template structure
 type EmailTemplate struct {
 	Subject string
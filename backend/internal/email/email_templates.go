@@ -38,6 +38,9 @@ func (te *TemplateEngine) loadTemplates() error {
 		"deletion_confirmation.html",
 		"data_export.html",
 		"welcome_back.html",
+		"loan_overdue.html",
+		"wishlist_sale.html",
+		"release_reminder.html",
 	}
 
 	for _, filename := range templateFiles {
@@ -72,6 +75,21 @@ type TemplateData struct {
 	ExportURL              string
 	DeletionDate           time.Time
 	DeletionDateFormatted  string
+	GameName               string
+	BorrowerName           string
+	DueDateFormatted       string
+	PlatformName           string
+	PriceFormatted         string
+	RegularPriceFormatted  string
+	TargetPriceFormatted   string
+	Releases               []ReleaseTemplateData
+}
+
+// ReleaseTemplateData is a release listed in the release reminder template
+type ReleaseTemplateData struct {
+	GameName             string
+	PlatformName         string
+	ReleaseDateFormatted string
 }
 
 // renderTemplate renders a template with the given data
@@ -168,4 +186,73 @@ func (te *TemplateEngine) RenderWelcomeBack(
 		Name:   userName,
 	}
 	return te.renderTemplate("welcome_back.html", data)
-}
\ No newline at end of file
+}
+
+// RenderLoanOverdue renders the loan overdue email template
+func (te *TemplateEngine) RenderLoanOverdue(
+	userID,
+	email,
+	userName,
+	gameName,
+	borrowerName string,
+	dueDate time.Time,
+) (string, error) {
+	data := TemplateData{
+		UserID:           userID,
+		Email:            email,
+		Name:             userName,
+		GameName:         gameName,
+		BorrowerName:     borrowerName,
+		DueDateFormatted: dueDate.Format("January 2, 2006"),
+	}
+	return te.renderTemplate("loan_overdue.html", data)
+}
+
+// RenderWishlistSale renders the wishlist sale alert email template
+func (te *TemplateEngine) RenderWishlistSale(
+	userID,
+	email,
+	userName,
+	gameName,
+	platformName string,
+	price,
+	regularPrice,
+	targetPrice float64,
+) (string, error) {
+	data := TemplateData{
+		UserID:                userID,
+		Email:                 email,
+		Name:                  userName,
+		GameName:              gameName,
+		PlatformName:          platformName,
+		PriceFormatted:        fmt.Sprintf("%.2f", price),
+		RegularPriceFormatted: fmt.Sprintf("%.2f", regularPrice),
+		TargetPriceFormatted:  fmt.Sprintf("%.2f", targetPrice),
+	}
+	return te.renderTemplate("wishlist_sale.html", data)
+}
+
+// RenderReleaseReminder renders the wishlist release reminder email template
+func (te *TemplateEngine) RenderReleaseReminder(
+	userID,
+	email,
+	userName string,
+	games []ReleaseReminderGame,
+) (string, error) {
+	releases := make([]ReleaseTemplateData, len(games))
+	for i, game := range games {
+		releases[i] = ReleaseTemplateData{
+			GameName:             game.GameName,
+			PlatformName:         game.PlatformName,
+			ReleaseDateFormatted: game.ReleaseDate.Format("January 2, 2006"),
+		}
+	}
+
+	data := TemplateData{
+		UserID:   userID,
+		Email:    email,
+		Name:     userName,
+		Releases: releases,
+	}
+	return te.renderTemplate("release_reminder.html", data)
+}
@@ -201,6 +201,93 @@ func (res *ResendEmailService) SendWelcomeBackEmail(
 	return res.SendEmail(ctx, email, subject, htmlContent)
 }
 
+// SendLoanOverdueEmail reminds the user that a game they lent out is past its due date
+func (res *ResendEmailService) SendLoanOverdueEmail(
+	ctx context.Context,
+	userID,
+	email,
+	userName,
+	gameName,
+	borrowerName string,
+	dueDate time.Time,
+) error {
+	// Render email template
+	htmlContent, err := res.templateEngine.RenderLoanOverdue(
+		userID,
+		email,
+		userName,
+		gameName,
+		borrowerName,
+		dueDate,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to render loan overdue template: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s is overdue - QKO", gameName)
+
+	return res.SendEmail(ctx, email, subject, htmlContent)
+}
+
+// SendWishlistSaleEmail tells the user a wishlisted game dropped to their target price
+func (res *ResendEmailService) SendWishlistSaleEmail(
+	ctx context.Context,
+	userID,
+	email,
+	userName,
+	gameName,
+	platformName string,
+	price,
+	regularPrice,
+	targetPrice float64,
+) error {
+	// Render email template
+	htmlContent, err := res.templateEngine.RenderWishlistSale(
+		userID,
+		email,
+		userName,
+		gameName,
+		platformName,
+		price,
+		regularPrice,
+		targetPrice,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to render wishlist sale template: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s is down to %.2f - QKO", gameName, price)
+
+	return res.SendEmail(ctx, email, subject, htmlContent)
+}
+
+// SendReleaseReminderEmail tells the user which wishlisted games are about to release
+func (res *ResendEmailService) SendReleaseReminderEmail(
+	ctx context.Context,
+	userID,
+	email,
+	userName string,
+	games []ReleaseReminderGame,
+) error {
+	// Render email template
+	htmlContent, err := res.templateEngine.RenderReleaseReminder(
+		userID,
+		email,
+		userName,
+		games,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to render release reminder template: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s releases soon - QKO", games[0].GameName)
+	if len(games) > 1 {
+		subject = fmt.Sprintf("%d games on your wishlist release soon - QKO", len(games))
+	}
+
+	return res.SendEmail(ctx, email, subject, htmlContent)
+}
+
 // Close closes the email service
 func (res *ResendEmailService) Close() error {
 	// Resend client doesn't need explicit closing
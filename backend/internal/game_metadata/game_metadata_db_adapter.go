@@ -0,0 +1,186 @@
+package game_metadata
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type GameMetadataDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewGameMetadataDbAdapter(appContext *appcontext.AppContext) (*GameMetadataDbAdapter, error) {
+	appContext.Logger.Debug("Creating GameMetadataDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &GameMetadataDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetStaleGames retrieves up to limit games last refreshed before refreshedBefore, or never
+func (ga *GameMetadataDbAdapter) GetStaleGames(ctx context.Context, refreshedBefore time.Time, limit int) ([]models.StaleGameDB, error) {
+	var games []models.StaleGameDB
+	if err := ga.db.SelectContext(ctx, &games, GetStaleGamesQuery, refreshedBefore, limit); err != nil {
+		return nil, fmt.Errorf("error getting stale games: %w", err)
+	}
+
+	return games, nil
+}
+
+// PUT
+// UpdateGameMetadata writes a game's refreshed metadata along with its genres and themes
+func (ga *GameMetadataDbAdapter) UpdateGameMetadata(ctx context.Context, game models.GameMetadataToSave, refreshedAt time.Time) error {
+	return postgres.WithTransaction(ctx, ga.db, ga.logger, func(tx *sqlx.Tx) error {
+		return ga.saveGameMetadata(ctx, tx, game, refreshedAt)
+	})
+}
+
+// PUT
+// RemapGame moves copies, wishlist entries, barcodes, add-ons and price history from a game IGDB
+// merged away onto the game it was merged into, then deletes the old game
+func (ga *GameMetadataDbAdapter) RemapGame(
+	ctx context.Context,
+	oldGameID int64,
+	game models.GameMetadataToSave,
+	refreshedAt time.Time,
+) (models.GameRemapResult, error) {
+	var result models.GameRemapResult
+	err := postgres.WithTransaction(ctx, ga.db, ga.logger, func(tx *sqlx.Tx) error {
+		if err := ga.saveGameMetadata(ctx, tx, game, refreshedAt); err != nil {
+			return err
+		}
+
+		copies, err := tx.ExecContext(ctx, RemapUserGamesQuery, oldGameID, game.ID)
+		if err != nil {
+			return fmt.Errorf("error remapping copies: %w", err)
+		}
+		if result.Copies, err = copies.RowsAffected(); err != nil {
+			return fmt.Errorf("error counting remapped copies: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, DeleteDuplicateWishlistItemsQuery, oldGameID, game.ID); err != nil {
+			return fmt.Errorf("error removing duplicate wishlist items: %w", err)
+		}
+		wishlistItems, err := tx.ExecContext(ctx, RemapWishlistQuery, oldGameID, game.ID)
+		if err != nil {
+			return fmt.Errorf("error remapping wishlist items: %w", err)
+		}
+		if result.WishlistItems, err = wishlistItems.RowsAffected(); err != nil {
+			return fmt.Errorf("error counting remapped wishlist items: %w", err)
+		}
+
+		barcodes, err := tx.ExecContext(ctx, RemapBarcodesQuery, oldGameID, game.ID, game.Name)
+		if err != nil {
+			return fmt.Errorf("error remapping barcodes: %w", err)
+		}
+		if result.Barcodes, err = barcodes.RowsAffected(); err != nil {
+			return fmt.Errorf("error counting remapped barcodes: %w", err)
+		}
+
+		addons, err := tx.ExecContext(ctx, RemapGameAddonsQuery, oldGameID, game.ID)
+		if err != nil {
+			return fmt.Errorf("error remapping add-ons: %w", err)
+		}
+		if result.Addons, err = addons.RowsAffected(); err != nil {
+			return fmt.Errorf("error counting remapped add-ons: %w", err)
+		}
+
+		prices, err := tx.ExecContext(ctx, RemapPriceHistoryQuery, oldGameID, game.ID)
+		if err != nil {
+			return fmt.Errorf("error remapping price history: %w", err)
+		}
+		if result.PriceHistory, err = prices.RowsAffected(); err != nil {
+			return fmt.Errorf("error counting remapped price history: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, DeleteRemappedGameQuery, oldGameID); err != nil {
+			return fmt.Errorf("error deleting remapped game: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.GameRemapResult{}, err
+	}
+
+	return result, nil
+}
+
+// PUT
+// MarkGamesMissing records that IGDB no longer returns the games, they are checked again on the next refresh
+func (ga *GameMetadataDbAdapter) MarkGamesMissing(ctx context.Context, gameIDs []int64, checkedAt time.Time) error {
+	if _, err := ga.db.ExecContext(ctx, MarkGamesMissingQuery, pq.Array(gameIDs), checkedAt); err != nil {
+		return fmt.Errorf("error marking games missing from IGDB: %w", err)
+	}
+	return nil
+}
+
+// saveGameMetadata upserts the games row, then brings its genres and themes in line with IGDB
+func (ga *GameMetadataDbAdapter) saveGameMetadata(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	game models.GameMetadataToSave,
+	refreshedAt time.Time,
+) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		UpsertGameMetadataQuery,
+		game.ID,
+		game.Name,
+		game.Summary,
+		game.CoverURL,
+		game.FirstReleaseDate,
+		game.Rating,
+		refreshedAt,
+	); err != nil {
+		return fmt.Errorf("error saving game metadata: %w", err)
+	}
+
+	terms := []struct {
+		name        string
+		upsert      string
+		deleteStale string
+		insert      string
+		terms       []models.GameMetadataTerm
+	}{
+		{"genres", UpsertGenresQuery, DeleteStaleGameGenresQuery, InsertGameGenresQuery, game.Genres},
+		{"themes", UpsertThemesQuery, DeleteStaleGameThemesQuery, InsertGameThemesQuery, game.Themes},
+	}
+
+	for _, term := range terms {
+		ids := make([]int64, len(term.terms))
+		names := make([]string, len(term.terms))
+		for i, t := range term.terms {
+			ids[i], names[i] = t.ID, t.Name
+		}
+
+		if len(ids) > 0 {
+			if _, err := tx.ExecContext(ctx, term.upsert, pq.Array(ids), pq.Array(names)); err != nil {
+				return fmt.Errorf("error saving %s: %w", term.name, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, term.deleteStale, game.ID, pq.Array(ids)); err != nil {
+			return fmt.Errorf("error removing stale game %s: %w", term.name, err)
+		}
+		if len(ids) > 0 {
+			if _, err := tx.ExecContext(ctx, term.insert, game.ID, pq.Array(ids)); err != nil {
+				return fmt.Errorf("error saving game %s: %w", term.name, err)
+			}
+		}
+	}
+
+	return nil
+}
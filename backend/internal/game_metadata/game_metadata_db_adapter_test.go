@@ -0,0 +1,143 @@
+package game_metadata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Writing refreshed metadata and remapping merged games
+
+	Scenarios:
+	- RemapGame saves the new game, moves copies, wishlist items, barcodes and price history, then deletes the old game
+	- RemapGame moves the old game's add-ons before deleting it, so owned add-ons are kept
+*/
+
+func TestGameMetadataDbAdapter(t *testing.T) {
+	refreshedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	setupMockDB := func() (*GameMetadataDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &GameMetadataDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN a game IGDB merged into another one without genres or themes
+		WHEN RemapGame is called
+		THEN it should save the new game, move the old game's rows and price history onto it and delete the old game
+	*/
+	t.Run("RemapGame moves rows onto the new game", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		game := models.GameMetadataToSave{ID: 502, Name: "Hollow Knight"}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO games").
+			WithArgs(game.ID, game.Name, "", "", int64(0), float64(0), refreshedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM game_genres").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM game_themes").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(int64(500), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM wishlist").
+			WithArgs(int64(500), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE wishlist").
+			WithArgs(int64(500), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE barcodes").
+			WithArgs(int64(500), game.ID, game.Name).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE game_addons").
+			WithArgs(int64(500), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE game_price_history").
+			WithArgs(int64(500), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec("DELETE FROM games").
+			WithArgs(int64(500)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		result, err := adapter.RemapGame(context.Background(), 500, game, refreshedAt)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Copies != 2 || result.WishlistItems != 1 || result.Barcodes != 0 || result.PriceHistory != 4 {
+			t.Errorf("Expected 2 copies, 1 wishlist item and 4 prices remapped, got %+v", result)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a merged game with add-ons a user owns
+		WHEN RemapGame is called
+		THEN it should move the add-ons onto the new game before deleting the old one
+	*/
+	t.Run("RemapGame keeps owned add-ons", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		game := models.GameMetadataToSave{ID: 1026, Name: "The Witcher 3: Wild Hunt"}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO games").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM game_genres").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM game_themes").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(int64(1942), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM wishlist").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE wishlist").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE barcodes").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE game_addons SET parent_game_id = \\$2").
+			WithArgs(int64(1942), game.ID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("UPDATE game_price_history").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM games").
+			WithArgs(int64(1942)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		result, err := adapter.RemapGame(context.Background(), 1942, game, refreshedAt)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Copies != 1 || result.Addons != 2 {
+			t.Errorf("Expected 1 copy and 2 add-ons remapped, got %+v", result)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
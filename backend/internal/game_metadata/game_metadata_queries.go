@@ -0,0 +1,141 @@
+package game_metadata
+
+// Games due for a refresh, the ones never refreshed first
+const GetStaleGamesQuery = `
+	SELECT id, name, first_release_date
+	FROM games
+	WHERE metadata_refreshed_at IS NULL OR metadata_refreshed_at < $1
+	ORDER BY metadata_refreshed_at NULLS FIRST, id
+	LIMIT $2
+`
+
+// Saving metadata writes the games row, its genres and themes.
+// The upsert also creates the game a merged game is remapped to when we don't have it yet.
+// IGDB occasionally drops a cover or release date, the ones we have are kept.
+const (
+	UpsertGameMetadataQuery = `
+		INSERT INTO games (id, name, summary, cover_url, first_release_date, rating, metadata_refreshed_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5::BIGINT, 0), NULLIF($6::FLOAT, 0), $7, $7)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			name = EXCLUDED.name,
+			summary = EXCLUDED.summary,
+			cover_url = COALESCE(EXCLUDED.cover_url, games.cover_url),
+			first_release_date = COALESCE(EXCLUDED.first_release_date, games.first_release_date),
+			rating = EXCLUDED.rating,
+			metadata_refreshed_at = EXCLUDED.metadata_refreshed_at,
+			updated_at = EXCLUDED.updated_at,
+			igdb_missing_since = NULL
+	`
+
+	UpsertGenresQuery = `
+		INSERT INTO genres (id, name)
+		SELECT * FROM UNNEST($1::BIGINT[], $2::TEXT[])
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, updated_at = NOW()
+		WHERE genres.name IS DISTINCT FROM EXCLUDED.name
+	`
+
+	DeleteStaleGameGenresQuery = `
+		DELETE FROM game_genres
+		WHERE game_id = $1 AND genre_id <> ALL($2::BIGINT[])
+	`
+
+	InsertGameGenresQuery = `
+		INSERT INTO game_genres (game_id, genre_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+		ON CONFLICT (game_id, genre_id) DO NOTHING
+	`
+
+	UpsertThemesQuery = `
+		INSERT INTO themes (id, name)
+		SELECT * FROM UNNEST($1::BIGINT[], $2::TEXT[])
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, updated_at = NOW()
+		WHERE themes.name IS DISTINCT FROM EXCLUDED.name
+	`
+
+	DeleteStaleGameThemesQuery = `
+		DELETE FROM game_themes
+		WHERE game_id = $1 AND theme_id <> ALL($2::BIGINT[])
+	`
+
+	InsertGameThemesQuery = `
+		INSERT INTO game_themes (game_id, theme_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+		ON CONFLICT (game_id, theme_id) DO NOTHING
+	`
+)
+
+// Remapping moves everything from a game IGDB merged away ($1) onto the game it was merged into ($2).
+// Moved copies are numbered after the copies the user already has of the new game, so none collide.
+// A wishlist keeps one entry per game, the old entry goes when the user already wishlists the new game.
+const (
+	RemapUserGamesQuery = `
+		UPDATE user_games ug
+		SET
+			game_id = $2,
+			copy_number = ug.copy_number + COALESCE((
+				SELECT MAX(existing.copy_number)
+				FROM user_games existing
+				WHERE existing.user_id = ug.user_id AND existing.game_id = $2
+			), 0)
+		WHERE ug.game_id = $1
+	`
+
+	DeleteDuplicateWishlistItemsQuery = `
+		DELETE FROM wishlist old
+		WHERE old.game_id = $1
+		AND EXISTS (
+			SELECT 1 FROM wishlist w
+			WHERE w.user_id = old.user_id AND w.game_id = $2
+		)
+	`
+
+	RemapWishlistQuery = `
+		UPDATE wishlist
+		SET game_id = $2, updated_at = NOW()
+		WHERE game_id = $1
+	`
+
+	// Mappings that already exist for the new game keep their own confirmations, the old ones stay behind
+	RemapBarcodesQuery = `
+		UPDATE barcodes b
+		SET game_id = $2, game_name = $3, updated_at = NOW()
+		WHERE b.game_id = $1
+		AND NOT EXISTS (
+			SELECT 1 FROM barcodes existing
+			WHERE existing.code = b.code
+			AND existing.platform_id = b.platform_id
+			AND existing.game_id = $2
+		)
+	`
+
+	// Add-ons follow their game so the copies users own aren't deleted with the old game
+	RemapGameAddonsQuery = `
+		UPDATE game_addons
+		SET parent_game_id = $2, updated_at = NOW()
+		WHERE parent_game_id = $1
+	`
+
+	// Price history is kept so the wishlist price chart carries on for the new game
+	RemapPriceHistoryQuery = `
+		UPDATE game_price_history
+		SET game_id = $2
+		WHERE game_id = $1
+	`
+
+	DeleteRemappedGameQuery = `
+		DELETE FROM games
+		WHERE id = $1
+	`
+)
+
+// Games IGDB no longer returns are kept, the user's copies still point at them
+const MarkGamesMissingQuery = `
+	UPDATE games
+	SET
+		igdb_missing_since = COALESCE(igdb_missing_since, $2),
+		metadata_refreshed_at = $2
+	WHERE id = ANY($1)
+`
@@ -0,0 +1,263 @@
+package game_metadata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/igdb"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/search"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+)
+
+const (
+	// refreshInterval is how often the refresh job looks for stale games
+	refreshInterval = time.Hour
+
+	// staleAfter is how long a game's metadata is trusted before it is fetched again
+	staleAfter = 7 * 24 * time.Hour
+
+	// gamesPerRun caps how many games one run refreshes, the rest wait for the next run
+	gamesPerRun = 500
+
+	// igdbRequestInterval keeps the job under IGDB's limit of 4 requests a second,
+	// leaving room for searches made by users
+	igdbRequestInterval = 500 * time.Millisecond
+
+	// replacementSearchLimit is how many search results are checked for the game a missing one was merged into
+	replacementSearchLimit = 10
+)
+
+// GameMetadataRefreshService keeps the games table in line with IGDB.
+// Games IGDB no longer returns are looked up by name, a game with the same name and release date
+// is taken to be the one it was merged into and the user's copies are remapped to it.
+type GameMetadataRefreshService struct {
+	appContext      *appcontext.AppContext
+	dbAdapter       interfaces.GameMetadataDbAdapter
+	igdbAdapter     interfaces.IGDBAdapter
+	logger          interfaces.Logger
+	now             func() time.Time
+	requestInterval time.Duration
+	lastRequest     time.Time
+	stopRefresh     context.CancelFunc
+}
+
+func NewGameMetadataRefreshService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.GameMetadataDbAdapter,
+	igdbAdapter interfaces.IGDBAdapter,
+) (*GameMetadataRefreshService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if igdbAdapter == nil {
+		return nil, fmt.Errorf("igdbAdapter is required")
+	}
+
+	return &GameMetadataRefreshService{
+		appContext:      appContext,
+		dbAdapter:       dbAdapter,
+		igdbAdapter:     igdbAdapter,
+		logger:          appContext.Logger,
+		now:             time.Now,
+		requestInterval: igdbRequestInterval,
+	}, nil
+}
+
+// Start runs the refresh job
+func (rs *GameMetadataRefreshService) Start(ctx context.Context) error {
+	refreshCtx, cancel := context.WithCancel(context.Background())
+	rs.stopRefresh = cancel
+	go worker.NewWorker(refreshInterval, rs.RefreshStaleGames, nil, rs.logger).Start(refreshCtx)
+
+	return nil
+}
+
+// Stop stops the refresh job
+func (rs *GameMetadataRefreshService) Stop() error {
+	if rs.stopRefresh != nil {
+		rs.stopRefresh()
+	}
+	return nil
+}
+
+// RefreshStaleGames fetches the least recently refreshed games from IGDB, igdb.MaxLimit per request.
+// Hitting IGDB's rate limit ends the run early, the remaining games are picked up by the next one.
+func (rs *GameMetadataRefreshService) RefreshStaleGames(ctx context.Context) error {
+	games, err := rs.dbAdapter.GetStaleGames(ctx, rs.now().Add(-staleAfter), gamesPerRun)
+	if err != nil {
+		return err
+	}
+
+	var refreshed, remapped, missing int
+	for start := 0; start < len(games); start += igdb.MaxLimit {
+		batch := games[start:min(start+igdb.MaxLimit, len(games))]
+
+		counts, err := rs.refreshBatch(ctx, batch)
+		refreshed += counts.refreshed
+		remapped += counts.remapped
+		missing += counts.missing
+		if search.IsRateLimitError(err) {
+			rs.logger.Warn("IGDB rate limit reached, resuming the metadata refresh on the next run", map[string]any{
+				"refreshed": refreshed,
+			})
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(games) > 0 {
+		rs.logger.Info("Refreshed game metadata", map[string]any{
+			"stale":     len(games),
+			"refreshed": refreshed,
+			"remapped":  remapped,
+			"missing":   missing,
+		})
+	}
+
+	return nil
+}
+
+type refreshCounts struct {
+	refreshed int
+	remapped  int
+	missing   int
+}
+
+// refreshBatch updates the games IGDB returns and resolves the ones it doesn't.
+// A game that fails to save is logged and retried on a later run.
+func (rs *GameMetadataRefreshService) refreshBatch(ctx context.Context, batch []models.StaleGameDB) (refreshCounts, error) {
+	var counts refreshCounts
+
+	ids := make([]int64, len(batch))
+	for i, game := range batch {
+		ids[i] = game.ID
+	}
+
+	var fetched []*models.Game
+	err := rs.callIGDB(ctx, func() error {
+		var err error
+		fetched, err = rs.igdbAdapter.GetGamesByIDs(ctx, ids)
+		return err
+	})
+	if err != nil {
+		return counts, fmt.Errorf("error fetching games from IGDB: %w", err)
+	}
+
+	refreshedAt := rs.now()
+	found := make(map[int64]bool, len(fetched))
+	for _, game := range fetched {
+		if game == nil {
+			continue
+		}
+		found[game.ID] = true
+
+		if err := rs.dbAdapter.UpdateGameMetadata(ctx, TransformGameToMetadata(game), refreshedAt); err != nil {
+			rs.logger.Error("Failed to save refreshed game metadata", map[string]any{
+				"gameID": game.ID,
+				"error":  err,
+			})
+			continue
+		}
+		counts.refreshed++
+	}
+
+	// A failed search stops resolving the batch, the games already resolved are still recorded
+	var missingIDs []int64
+	var searchErr error
+	for _, game := range batch {
+		if found[game.ID] {
+			continue
+		}
+
+		replacement, err := rs.findReplacement(ctx, game)
+		if err != nil {
+			searchErr = err
+			break
+		}
+		if replacement == nil {
+			missingIDs = append(missingIDs, game.ID)
+			continue
+		}
+
+		result, err := rs.dbAdapter.RemapGame(ctx, game.ID, TransformGameToMetadata(replacement), refreshedAt)
+		if err != nil {
+			rs.logger.Error("Failed to remap merged game", map[string]any{
+				"gameID":        game.ID,
+				"replacementID": replacement.ID,
+				"error":         err,
+			})
+			continue
+		}
+		counts.remapped++
+
+		rs.logger.Info("Remapped game merged on IGDB", map[string]any{
+			"gameID":        game.ID,
+			"replacementID": replacement.ID,
+			"copies":        result.Copies,
+			"wishlistItems": result.WishlistItems,
+			"barcodes":      result.Barcodes,
+			"addons":        result.Addons,
+			"priceHistory":  result.PriceHistory,
+		})
+	}
+
+	if len(missingIDs) > 0 {
+		if err := rs.dbAdapter.MarkGamesMissing(ctx, missingIDs, refreshedAt); err != nil {
+			return counts, err
+		}
+		counts.missing += len(missingIDs)
+	}
+
+	return counts, searchErr
+}
+
+// findReplacement searches IGDB for a game with the same name and release date as one it no longer returns,
+// nil means the game is gone without a replacement
+func (rs *GameMetadataRefreshService) findReplacement(ctx context.Context, game models.StaleGameDB) (*models.Game, error) {
+	name := strings.TrimSpace(game.Name)
+	if name == "" {
+		return nil, nil
+	}
+
+	var candidates []*models.Game
+	err := rs.callIGDB(ctx, func() error {
+		var err error
+		candidates, err = rs.igdbAdapter.SearchGames(ctx, name, replacementSearchLimit, 0)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching IGDB for a replacement of game %d: %w", game.ID, err)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.ID == game.ID || !strings.EqualFold(strings.TrimSpace(candidate.Name), name) {
+			continue
+		}
+		if game.FirstReleaseDate.Valid && game.FirstReleaseDate.Int64 != 0 && candidate.FirstReleaseDate != game.FirstReleaseDate.Int64 {
+			continue
+		}
+		return candidate, nil
+	}
+	return nil, nil
+}
+
+// callIGDB spaces IGDB requests out by requestInterval and retries once with a fresh token on a 401
+func (rs *GameMetadataRefreshService) callIGDB(ctx context.Context, call func() error) error {
+	if wait := rs.lastRequest.Add(rs.requestInterval).Sub(time.Now()); wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	rs.lastRequest = time.Now()
+
+	return search.WithTokenRefresh(ctx, rs.appContext, rs.igdbAdapter, call)
+}
@@ -0,0 +1,156 @@
+package game_metadata
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+	"github.com/lokeam/qko-beta/internal/testutils/mocks"
+)
+
+/*
+	Behavior:
+	- Refreshing stale games from IGDB and resolving games IGDB no longer returns
+
+	Scenarios:
+	- Games IGDB returns get their metadata, genres and themes updated
+	- A missing game with a same name, same release date match is remapped to it
+	- A missing game without a match is marked missing
+	- Hitting the rate limit ends the run without failing it
+*/
+
+type fakeGameMetadataDbAdapter struct {
+	staleGames []models.StaleGameDB
+	updated    []models.GameMetadataToSave
+	remapped   map[int64]int64
+	missing    []int64
+}
+
+func (f *fakeGameMetadataDbAdapter) GetStaleGames(ctx context.Context, refreshedBefore time.Time, limit int) ([]models.StaleGameDB, error) {
+	return f.staleGames, nil
+}
+
+func (f *fakeGameMetadataDbAdapter) UpdateGameMetadata(ctx context.Context, game models.GameMetadataToSave, refreshedAt time.Time) error {
+	f.updated = append(f.updated, game)
+	return nil
+}
+
+func (f *fakeGameMetadataDbAdapter) RemapGame(
+	ctx context.Context,
+	oldGameID int64,
+	game models.GameMetadataToSave,
+	refreshedAt time.Time,
+) (models.GameRemapResult, error) {
+	if f.remapped == nil {
+		f.remapped = make(map[int64]int64)
+	}
+	f.remapped[oldGameID] = game.ID
+	return models.GameRemapResult{Copies: 1}, nil
+}
+
+func (f *fakeGameMetadataDbAdapter) MarkGamesMissing(ctx context.Context, gameIDs []int64, checkedAt time.Time) error {
+	f.missing = append(f.missing, gameIDs...)
+	return nil
+}
+
+func TestGameMetadataRefreshService(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	releaseDate := time.Date(2015, 5, 19, 0, 0, 0, 0, time.UTC).Unix()
+
+	newService := func(dbAdapter *fakeGameMetadataDbAdapter, igdbAdapter *mocks.MockIGDBAdapter) *GameMetadataRefreshService {
+		return &GameMetadataRefreshService{
+			dbAdapter:   dbAdapter,
+			igdbAdapter: igdbAdapter,
+			logger:      testutils.NewTestLogger(),
+			now:         func() time.Time { return now },
+		}
+	}
+
+	/*
+		GIVEN three stale games, IGDB returns one, one was merged and one is gone
+		WHEN RefreshStaleGames is called
+		THEN it should update the first, remap the second and mark the third missing
+	*/
+	t.Run("Refreshes, remaps and marks missing games", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeGameMetadataDbAdapter{
+			staleGames: []models.StaleGameDB{
+				{ID: 1942, Name: "The Witcher 3: Wild Hunt"},
+				{ID: 500, Name: "Hollow Knight", FirstReleaseDate: sql.NullInt64{Int64: releaseDate, Valid: true}},
+				{ID: 600, Name: "Delisted Game"},
+			},
+		}
+		igdbAdapter := &mocks.MockIGDBAdapter{
+			GetGamesByIDsFunc: func(ctx context.Context, ids []int64) ([]*models.Game, error) {
+				return []*models.Game{{
+					ID:         1942,
+					Name:       "The Witcher 3: Wild Hunt",
+					Summary:    "Geralt is back",
+					Genres:     []int64{12},
+					GenreNames: []string{"Role-playing (RPG)"},
+				}}, nil
+			},
+			SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
+				if query == "Hollow Knight" {
+					return []*models.Game{
+						{ID: 501, Name: "Hollow Knight", FirstReleaseDate: releaseDate + 1},
+						{ID: 502, Name: "hollow knight", FirstReleaseDate: releaseDate},
+					}, nil
+				}
+				return []*models.Game{{ID: 601, Name: "Delisted Game: Remastered"}}, nil
+			},
+		}
+
+		// WHEN
+		err := newService(dbAdapter, igdbAdapter).RefreshStaleGames(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.updated) != 1 || dbAdapter.updated[0].ID != 1942 {
+			t.Fatalf("Expected game 1942 to be updated, got %+v", dbAdapter.updated)
+		}
+		if genres := dbAdapter.updated[0].Genres; len(genres) != 1 || genres[0].ID != 12 || genres[0].Name != "Role-playing (RPG)" {
+			t.Errorf("Expected genre 12 to be saved, got %+v", genres)
+		}
+		if dbAdapter.remapped[500] != 502 {
+			t.Errorf("Expected game 500 to be remapped to 502, got %+v", dbAdapter.remapped)
+		}
+		if len(dbAdapter.missing) != 1 || dbAdapter.missing[0] != 600 {
+			t.Errorf("Expected game 600 to be marked missing, got %v", dbAdapter.missing)
+		}
+	})
+
+	/*
+		GIVEN IGDB turns the request down for going over its rate limit
+		WHEN RefreshStaleGames is called
+		THEN it should stop without an error or marking anything missing
+	*/
+	t.Run("Rate limit ends the run", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeGameMetadataDbAdapter{
+			staleGames: []models.StaleGameDB{{ID: 1942, Name: "The Witcher 3: Wild Hunt"}},
+		}
+		igdbAdapter := &mocks.MockIGDBAdapter{
+			GetGamesByIDsFunc: func(ctx context.Context, ids []int64) ([]*models.Game, error) {
+				return nil, errors.New("failed to execute query: IGDB API error (status 429): Too Many Requests")
+			},
+		}
+
+		// WHEN
+		err := newService(dbAdapter, igdbAdapter).RefreshStaleGames(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.updated) != 0 || len(dbAdapter.missing) != 0 {
+			t.Errorf("Expected nothing to be saved, got updated %+v and missing %v", dbAdapter.updated, dbAdapter.missing)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package game_metadata
+
+import "github.com/lokeam/qko-beta/internal/models"
+
+// TransformGameToMetadata takes the metadata we store from an IGDB game
+func TransformGameToMetadata(game *models.Game) models.GameMetadataToSave {
+	return models.GameMetadataToSave{
+		ID:               game.ID,
+		Name:             game.Name,
+		Summary:          game.Summary,
+		CoverURL:         game.CoverURL,
+		FirstReleaseDate: game.FirstReleaseDate,
+		Rating:           game.Rating,
+		Genres:           models.NewGameMetadataTerms(game.Genres, game.GenreNames),
+		Themes:           models.NewGameMetadataTerms(game.Themes, game.ThemeNames),
+	}
+}
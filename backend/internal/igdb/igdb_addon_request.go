@@ -0,0 +1,28 @@
+package igdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// GetGameAddons fetches a game's DLCs, expansions and standalone expansions.
+// A game IGDB doesn't know about comes back as nil.
+func (c *IGDBClient) GetGameAddons(ctx context.Context, gameID int64) (*types.IGDBGameAddonsResponse, error) {
+	if c == nil {
+		return nil, fmt.Errorf("IGDBClient is nil")
+	}
+
+	query := fmt.Sprintf("fields %s; where id = %d; limit 1;", GameAddonFields, gameID)
+
+	var responses []*types.IGDBGameAddonsResponse
+	if err := c.makeRequest(GamesEndpoint, query, &responses); err != nil {
+		return nil, fmt.Errorf("failed to get game addons: %w", err)
+	}
+	if len(responses) == 0 {
+		return nil, nil
+	}
+
+	return responses[0], nil
+}
@@ -44,3 +44,35 @@ const (
 const (
 	BASE_IGDB_API_URL = "https://api.igdb.com/v4"
 )
+
+// Platform endpoints, the platform list is small enough to page through whole on every sync
+const (
+	PlatformsEndpoint        = "platforms"
+	PlatformFamiliesEndpoint = "platform_families"
+
+	// MaxPageSize is the most results IGDB returns for a single request
+	MaxPageSize = 500
+
+	PlatformFields       = "id,name,abbreviation,generation,platform_family,platform_logo.url,platform_type"
+	PlatformFamilyFields = "id,name,slug"
+)
+
+// Platform types, see https://api-docs.igdb.com/#platform-type
+const (
+	PlatformTypeConsole         = 1
+	PlatformTypeArcade          = 2
+	PlatformTypePlatform        = 3
+	PlatformTypeOperatingSystem = 4
+	PlatformTypePortableConsole = 5
+	PlatformTypeComputer        = 6
+)
+
+// Add-on relations of a game, expanded so a single request returns every add-on with its details
+const (
+	GamesEndpoint = "games"
+
+	GameAddonFields = "id," +
+		"dlcs.id,dlcs.name,dlcs.cover.url,dlcs.first_release_date,dlcs.game_type.id,dlcs.game_type.type," +
+		"expansions.id,expansions.name,expansions.cover.url,expansions.first_release_date,expansions.game_type.id,expansions.game_type.type," +
+		"standalone_expansions.id,standalone_expansions.name,standalone_expansions.cover.url,standalone_expansions.first_release_date,standalone_expansions.game_type.id,standalone_expansions.game_type.type"
+)
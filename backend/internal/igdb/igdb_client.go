@@ -2,6 +2,7 @@ package igdb
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/lokeam/qko-beta/internal/appcontext"
 	"github.com/lokeam/qko-beta/internal/interfaces"
@@ -11,6 +12,7 @@ type IGDBClient struct {
 	baseURL        string
 	clientID       string
 	token          string
+	tokenMu        sync.RWMutex // the client is shared, a token refresh can land mid request
 	httpClient     *http.Client
 	logger         interfaces.Logger
 	appContext     *appcontext.AppContext
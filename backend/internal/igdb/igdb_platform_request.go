@@ -0,0 +1,40 @@
+package igdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// GetPlatforms fetches one page of IGDB platforms, ordered by ID so pages don't overlap
+func (c *IGDBClient) GetPlatforms(ctx context.Context, offset int) ([]*types.IGDBPlatformResponse, error) {
+	if c == nil {
+		return nil, fmt.Errorf("IGDBClient is nil")
+	}
+
+	query := fmt.Sprintf("fields %s; sort id asc; limit %d; offset %d;", PlatformFields, MaxPageSize, offset)
+
+	var responses []*types.IGDBPlatformResponse
+	if err := c.makeRequest(PlatformsEndpoint, query, &responses); err != nil {
+		return nil, fmt.Errorf("failed to get platforms: %w", err)
+	}
+
+	return responses, nil
+}
+
+// GetPlatformFamilies fetches one page of IGDB platform families, ordered by ID so pages don't overlap
+func (c *IGDBClient) GetPlatformFamilies(ctx context.Context, offset int) ([]*types.IGDBPlatformFamilyResponse, error) {
+	if c == nil {
+		return nil, fmt.Errorf("IGDBClient is nil")
+	}
+
+	query := fmt.Sprintf("fields %s; sort id asc; limit %d; offset %d;", PlatformFamilyFields, MaxPageSize, offset)
+
+	var responses []*types.IGDBPlatformFamilyResponse
+	if err := c.makeRequest(PlatformFamiliesEndpoint, query, &responses); err != nil {
+		return nil, fmt.Errorf("failed to get platform families: %w", err)
+	}
+
+	return responses, nil
+}
@@ -2,6 +2,7 @@ package igdb
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/lokeam/qko-beta/internal/interfaces"
@@ -16,7 +17,9 @@ type IGDBQuery struct {
 	search  string
 	fields  []string
 	where   []string
+	ids     []int64
 	limit   int
+	offset  int
 }
 
 type QueryBuilder struct {
@@ -81,6 +84,18 @@ func (qb *QueryBuilder) Where(condition string) *QueryBuilder {
 	return qb
 }
 
+// IDs restricts the query to specific IGDB game IDs, a search term is not required when set.
+// Returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) IDs(ids ...int64) *QueryBuilder {
+	if qb.logger != nil {
+		qb.logger.Debug("Query builder setting ids", map[string]any{
+			"ids": ids,
+		})
+	}
+	qb.query.ids = ids
+	return qb
+}
+
 // Limit sets max number of results returned by query
 // Returns QueryBuilder for method chaining.
 func (qb *QueryBuilder) Limit(limit int) *QueryBuilder {
@@ -93,6 +108,18 @@ func (qb *QueryBuilder) Limit(limit int) *QueryBuilder {
 	return qb
 }
 
+// Offset skips the first offset results, used to page through search results
+// Returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) Offset(offset int) *QueryBuilder {
+	if qb.logger != nil {
+		qb.logger.Debug("Query builder setting offset", map[string]any{
+			"offset": offset,
+		})
+	}
+	qb.query.offset = offset
+	return qb
+}
+
 // Build constructs the final IGDB API query string.
 // Returns an error if query is invalid
 func (qb *QueryBuilder) Build() (string, error) {
@@ -101,8 +128,8 @@ func (qb *QueryBuilder) Build() (string, error) {
 		return "", ErrNoFields
 	}
 
-	// Validate search term
-	if qb.query.search == "" {
+	// Validate search term, ID lookups don't need one
+	if qb.query.search == "" && len(qb.query.ids) == 0 {
 		return "", ErrInvalidSearchTerm
 	}
 
@@ -111,6 +138,11 @@ func (qb *QueryBuilder) Build() (string, error) {
 		return "", NewInvalidLimitError(qb.query.limit)
 	}
 
+	// Validate offset
+	if qb.query.offset < 0 {
+		return "", NewInvalidOffsetError(qb.query.offset)
+	}
+
 	// Validate where conditions
 	if len(qb.query.where) == 0 {
 		return "", ErrInvalidWhereCondition
@@ -151,8 +183,16 @@ func (qb *QueryBuilder) buildQueryParts() []string {
 	}
 
 	// Add where conditions
-	if len(qb.query.where) > 0 {
-		queryParts = append(queryParts, fmt.Sprintf("where %s;", strings.Join(qb.query.where, " & ")))
+	where := qb.query.where
+	if len(qb.query.ids) > 0 {
+		ids := make([]string, len(qb.query.ids))
+		for i := 0; i < len(qb.query.ids); i++ {
+			ids[i] = strconv.FormatInt(qb.query.ids[i], 10)
+		}
+		where = append(where, fmt.Sprintf("id = (%s)", strings.Join(ids, ",")))
+	}
+	if len(where) > 0 {
+		queryParts = append(queryParts, fmt.Sprintf("where %s;", strings.Join(where, " & ")))
 	}
 
 	// Add limit
@@ -160,5 +200,10 @@ func (qb *QueryBuilder) buildQueryParts() []string {
 		queryParts = append(queryParts, fmt.Sprintf("limit %d;", qb.query.limit))
 	}
 
+	// Add offset
+	if qb.query.offset > 0 {
+		queryParts = append(queryParts, fmt.Sprintf("offset %d;", qb.query.offset))
+	}
+
 	return queryParts
 }
\ No newline at end of file
@@ -60,6 +60,11 @@ func NewInvalidLimitError(limit int) error {
 		limit, MinLimit, MaxLimit))
 }
 
+// NewInvalidOffsetError creates a new error for negative offsets
+func NewInvalidOffsetError(offset int) error {
+	return NewIGDBQueryError(QueryValidateOperation, fmt.Errorf("invalid query offset: %d (must not be negative)", offset))
+}
+
 // Helper fn for error type checking - checks if the given error is a IGDBQueryError
 func IsIGDBQueryError(err error) bool {
 	var igdbQueryErr *IGDBQueryError
@@ -41,7 +41,7 @@ func (c *IGDBClient) makeRequest(endpoint string, query string, result interface
     }
 
     req.Header.Add("Client-ID", c.clientID)
-    req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
+    req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.currentToken()))
     req.Header.Add("Accept", "application/json")
 
     resp, err := c.httpClient.Do(req)
@@ -124,5 +124,14 @@ func (c *IGDBClient) ExecuteQuery(ctx context.Context, queryBuilder *QueryBuilde
 // This is needed because IGDB tokens expire and need to be refreshed
 // to maintain API access.
 func (c *IGDBClient) UpdateToken(token string) {
+    c.tokenMu.Lock()
+    defer c.tokenMu.Unlock()
     c.token = token
 }
+
+// currentToken reads the token a request authenticates with
+func (c *IGDBClient) currentToken() string {
+    c.tokenMu.RLock()
+    defer c.tokenMu.RUnlock()
+    return c.token
+}
@@ -0,0 +1,242 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/interfaces"
+)
+
+// DownloadPath is where the API serves files from a LocalBlobStore
+const DownloadPath = "/api/v1/downloads"
+
+var (
+	ErrBlobNotFound     = errors.New("blob not found")
+	ErrInvalidBlobKey   = errors.New("invalid blob key")
+	ErrInvalidSignature = errors.New("invalid download signature")
+	ErrLinkExpired      = errors.New("download link has expired")
+)
+
+// LocalBlobStore keeps blobs on the local filesystem and signs download links with an HMAC.
+// It is the default store, other backends only need to implement interfaces.BlobStore.
+type LocalBlobStore struct {
+	baseDir    string
+	baseURL    string
+	signingKey []byte
+	logger     interfaces.Logger
+}
+
+// NewLocalBlobStore creates the storage directory if needed.
+// Without a signing key a random one is generated, so links stop working after a restart.
+func NewLocalBlobStore(
+	baseDir string,
+	baseURL string,
+	signingKey string,
+	logger interfaces.Logger,
+) (*LocalBlobStore, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("blob storage directory is required")
+	}
+	if err := os.MkdirAll(baseDir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating blob storage directory: %w", err)
+	}
+
+	key := []byte(signingKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("error generating blob signing key: %w", err)
+		}
+		logger.Warn("No blob signing key configured, download links will not survive a restart", nil)
+	}
+
+	return &LocalBlobStore{
+		baseDir:    baseDir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: key,
+		logger:     logger,
+	}, nil
+}
+
+// Put writes to a temp file first so a half written blob is never served
+func (s *LocalBlobStore) Put(ctx context.Context, key string, content io.Reader) error {
+	blobPath, err := s.blobPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0o700); err != nil {
+		return fmt.Errorf("error creating blob directory: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(blobPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error creating blob: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), blobPath); err != nil {
+		return fmt.Errorf("error saving blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	blobPath, err := s.blobPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(blobPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("error opening blob: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete is a no-op for blobs that are already gone
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	blobPath, err := s.blobPath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(blobPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting blob: %w", err)
+	}
+
+	return nil
+}
+
+// SignedURL returns a link to DownloadPath that is valid until expiresAt
+func (s *LocalBlobStore) SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error) {
+	if _, err := s.blobPath(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + DownloadPath + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL checks a download link's signature and expiry
+func (s *LocalBlobStore) VerifySignedURL(key string, expires string, signature string, now time.Time) error {
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expiresAt {
+		return ErrLinkExpired
+	}
+
+	return nil
+}
+
+// ServeSignedDownload streams a blob for a valid signed link.
+// It is mounted outside of auth since the link itself is the credential.
+func (s *LocalBlobStore) ServeSignedDownload(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	key := query.Get("key")
+
+	if err := s.VerifySignedURL(key, query.Get("expires"), query.Get("signature"), time.Now()); err != nil {
+		status := http.StatusForbidden
+		if errors.Is(err, ErrLinkExpired) {
+			status = http.StatusGone
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	blob, err := s.Open(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		s.logger.Error("Failed to open blob for download", map[string]any{
+			"key":   key,
+			"error": err,
+		})
+		http.Error(w, "failed to open download", http.StatusInternalServerError)
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", contentTypeForKey(key))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(key)))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if _, err := io.Copy(w, blob); err != nil {
+		s.logger.Error("Failed to stream blob download", map[string]any{
+			"key":   key,
+			"error": err,
+		})
+	}
+}
+
+func (s *LocalBlobStore) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// blobPath maps a key to a path inside baseDir, keys may not escape it
+func (s *LocalBlobStore) blobPath(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidBlobKey
+	}
+
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidBlobKey
+	}
+
+	return filepath.Join(s.baseDir, filepath.FromSlash(cleaned)), nil
+}
+
+func contentTypeForKey(key string) string {
+	switch strings.ToLower(path.Ext(key)) {
+	case ".zip":
+		return "application/zip"
+	case ".json":
+		return "application/json"
+	case ".csv":
+		return "text/csv"
+	default:
+		return "application/octet-stream"
+	}
+}
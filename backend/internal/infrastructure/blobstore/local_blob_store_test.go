@@ -0,0 +1,150 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Storing blobs on the local filesystem
+	- Signing and verifying download links
+
+	Scenarios:
+	- Put then Open returns the same content, Delete removes it
+	- Keys that escape the storage directory are rejected
+	- A signed link verifies until it expires
+	- A tampered link is rejected
+	- ServeSignedDownload streams the blob for a valid link
+*/
+
+func newTestStore(t *testing.T) *LocalBlobStore {
+	t.Helper()
+
+	store, err := NewLocalBlobStore(t.TempDir(), "https://api.example.com/", "test-signing-key", testutils.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return store
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	key := "exports/123/archive.zip"
+
+	/*
+		GIVEN an empty store
+		WHEN a blob is written, read and deleted
+		THEN the content round trips and is gone afterwards
+	*/
+	t.Run("Put Open Delete round trip", func(t *testing.T) {
+		// GIVEN
+		store := newTestStore(t)
+
+		// WHEN
+		if err := store.Put(ctx, key, strings.NewReader("archive contents")); err != nil {
+			t.Fatalf("Expected no error on Put, got %v", err)
+		}
+		blob, err := store.Open(ctx, key)
+		if err != nil {
+			t.Fatalf("Expected no error on Open, got %v", err)
+		}
+		content, _ := io.ReadAll(blob)
+		blob.Close()
+
+		// THEN
+		if string(content) != "archive contents" {
+			t.Errorf("Expected stored content, got %q", string(content))
+		}
+		if err := store.Delete(ctx, key); err != nil {
+			t.Fatalf("Expected no error on Delete, got %v", err)
+		}
+		if _, err := store.Open(ctx, key); !errors.Is(err, ErrBlobNotFound) {
+			t.Errorf("Expected ErrBlobNotFound after delete, got %v", err)
+		}
+	})
+
+	/*
+		GIVEN keys that point outside of the storage directory
+		WHEN they are used
+		THEN they are rejected
+	*/
+	t.Run("Keys cannot escape the storage directory", func(t *testing.T) {
+		store := newTestStore(t)
+
+		for _, badKey := range []string{"../secret", "/etc/passwd", "exports/../../secret", "", "a\\b"} {
+			if err := store.Put(ctx, badKey, strings.NewReader("x")); !errors.Is(err, ErrInvalidBlobKey) {
+				t.Errorf("Expected ErrInvalidBlobKey for %q, got %v", badKey, err)
+			}
+		}
+	})
+
+	/*
+		GIVEN a signed link
+		WHEN it is verified before expiry, after expiry and after tampering
+		THEN only the untouched, unexpired link verifies
+	*/
+	t.Run("Signed links verify until they expire", func(t *testing.T) {
+		// GIVEN
+		store := newTestStore(t)
+		now := time.Now()
+		signedURL, err := store.SignedURL(ctx, key, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(signedURL, "https://api.example.com"+DownloadPath+"?") {
+			t.Errorf("Unexpected signed URL %s", signedURL)
+		}
+		parsed, _ := url.Parse(signedURL)
+		query := parsed.Query()
+
+		// WHEN / THEN
+		if err := store.VerifySignedURL(query.Get("key"), query.Get("expires"), query.Get("signature"), now); err != nil {
+			t.Errorf("Expected valid link, got %v", err)
+		}
+		if err := store.VerifySignedURL(query.Get("key"), query.Get("expires"), query.Get("signature"), now.Add(2*time.Hour)); !errors.Is(err, ErrLinkExpired) {
+			t.Errorf("Expected ErrLinkExpired, got %v", err)
+		}
+		if err := store.VerifySignedURL("exports/456/archive.zip", query.Get("expires"), query.Get("signature"), now); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expected ErrInvalidSignature for another key, got %v", err)
+		}
+	})
+
+	/*
+		GIVEN a stored blob and its signed link
+		WHEN the link is requested
+		THEN the blob is streamed as an attachment
+	*/
+	t.Run("ServeSignedDownload streams the blob", func(t *testing.T) {
+		// GIVEN
+		store := newTestStore(t)
+		if err := store.Put(ctx, key, strings.NewReader("zip bytes")); err != nil {
+			t.Fatalf("Expected no error on Put, got %v", err)
+		}
+		signedURL, _ := store.SignedURL(ctx, key, time.Now().Add(time.Hour))
+
+		// WHEN
+		recorder := httptest.NewRecorder()
+		store.ServeSignedDownload(recorder, httptest.NewRequest(http.MethodGet, signedURL, nil))
+
+		// THEN
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", recorder.Code)
+		}
+		if recorder.Body.String() != "zip bytes" {
+			t.Errorf("Expected blob content, got %q", recorder.Body.String())
+		}
+		if recorder.Header().Get("Content-Type") != "application/zip" {
+			t.Errorf("Expected application/zip, got %s", recorder.Header().Get("Content-Type"))
+		}
+	})
+}
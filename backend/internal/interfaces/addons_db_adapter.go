@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type AddonsDbAdapter interface {
+	GetAddonsSyncedAt(ctx context.Context, userID string, gameID int64) (sql.NullTime, error)
+	SaveGameAddons(ctx context.Context, gameID int64, addons []models.GameAddon, syncedAt time.Time) error
+	GetGameAddons(ctx context.Context, userID string, gameID int64) ([]models.GameAddonDB, error)
+	GetGameEditions(ctx context.Context, userID string, gameID int64) ([]models.GameEditionDB, error)
+	CreateOwnedAddon(ctx context.Context, userID string, addon models.OwnedAddonToSave) (models.OwnedAddonDB, error)
+	DeleteOwnedAddon(ctx context.Context, userID string, ownedAddonID int64) error
+	UpdateEdition(ctx context.Context, userID string, edition models.EditionToSave) (models.GameEditionDB, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+type AddonsValidator interface {
+	ValidateUserID(userID string) error
+	ValidateGameID(gameID int64) error
+	ValidateOwnedAddonID(ownedAddonID int64) error
+	ValidateCreateOwnedAddonRequest(request types.CreateOwnedAddonRequest, now time.Time) error
+	ValidateUpdateEditionRequest(userGameID int64, request types.UpdateEditionRequest) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type BarcodesDbAdapter interface {
+	GetBarcodeMappings(ctx context.Context, userID string, code string) ([]models.BarcodeMappingDB, error)
+	GetTopBarcodeMappings(ctx context.Context, userID string, codes []string) ([]models.BarcodeMappingDB, error)
+	SaveBarcodeMapping(ctx context.Context, userID string, mapping models.BarcodeMappingToSave) (models.BarcodeMappingDB, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/lokeam/qko-beta/internal/types"
+
+type BarcodesValidator interface {
+	ValidateUserID(userID string) error
+	ValidateCode(code string) (string, error)
+	ValidateMappingRequest(request types.BarcodeMappingRequest) error
+	ValidateScanSessionRequest(request types.BarcodeScanSessionRequest) error
+}
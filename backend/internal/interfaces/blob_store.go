@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStore keeps generated files such as data export archives.
+// Implementations hand out time limited download links so files never need to be proxied by the API.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error)
+}
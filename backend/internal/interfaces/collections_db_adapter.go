@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type CollectionsDbAdapter interface {
+	// Tags
+	GetTags(ctx context.Context, userID string) ([]models.UserTagDB, error)
+	CreateTag(ctx context.Context, userID string, name string) (models.UserTagDB, error)
+	UpdateTag(ctx context.Context, userID string, tagID string, name string) (models.UserTagDB, error)
+	DeleteTag(ctx context.Context, userID string, tagID string) error
+	TagGames(ctx context.Context, userID string, tagIDs []string, selection models.LibraryGameSelection) (int64, error)
+	UntagGames(ctx context.Context, userID string, tagIDs []string, selection models.LibraryGameSelection) (int64, error)
+
+	// Collections
+	GetCollections(ctx context.Context, userID string) ([]models.CollectionDB, error)
+	GetCollection(ctx context.Context, userID string, collectionID string) (models.CollectionDB, []models.CollectionGameDB, error)
+	CreateCollection(ctx context.Context, userID string, name string, description string) (models.CollectionDB, error)
+	UpdateCollection(ctx context.Context, userID string, collectionID string, name string, description string) (models.CollectionDB, error)
+	DeleteCollection(ctx context.Context, userID string, collectionID string) error
+	AddCollectionGames(ctx context.Context, userID string, collectionID string, selection models.LibraryGameSelection) (int64, error)
+	RemoveCollectionGames(ctx context.Context, userID string, collectionID string, selection models.LibraryGameSelection) (int64, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/lokeam/qko-beta/internal/types"
+
+type CollectionsValidator interface {
+	ValidateUserID(userID string) error
+	ValidateID(field string, id string) error
+	ValidateName(name string) (string, error)
+	ValidateDescription(description string) (string, error)
+	ValidateBulkTagRequest(request types.BulkTagRequest) error
+	ValidateSelection(selection types.LibraryGameSelectionRequest) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type DataExportDbAdapter interface {
+	CreateDataExport(ctx context.Context, userID string) (models.DataExportDB, error)
+	GetDataExport(ctx context.Context, userID string, exportID string) (models.DataExportDB, error)
+	GetActiveDataExport(ctx context.Context, userID string) (models.DataExportDB, bool, error)
+	GetUnfinishedDataExports(ctx context.Context) ([]models.DataExportDB, error)
+	GetExpiredDataExports(ctx context.Context) ([]models.DataExportDB, error)
+	GetDataExportUser(ctx context.Context, userID string) (models.DataExportUserDB, error)
+	QueryDataset(ctx context.Context, name string, query string, userID string) (models.DataExportTable, error)
+
+	MarkDataExportRunning(ctx context.Context, exportID string) error
+	CompleteDataExport(ctx context.Context, exportID string, blobKey string, sizeBytes int64, expiresAt time.Time) error
+	FailDataExport(ctx context.Context, exportID string, errorMessage string) error
+	ExpireDataExport(ctx context.Context, exportID string) error
+}
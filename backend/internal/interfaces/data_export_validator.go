@@ -0,0 +1,6 @@
+package interfaces
+
+type DataExportValidator interface {
+	ValidateUserID(userID string) error
+	ValidateExportID(exportID string) error
+}
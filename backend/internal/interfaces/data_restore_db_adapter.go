@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type DataRestoreDbAdapter interface {
+	RestoreUserData(
+		ctx context.Context,
+		userID string,
+		mode string,
+		archive models.DataRestoreArchive,
+	) ([]models.DataRestoreDatasetCount, error)
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/lokeam/qko-beta/internal/models"
+
+type DataRestoreValidator interface {
+	ValidateUserID(userID string) error
+	ValidateRestoreMode(mode string) error
+	ValidateRestoreArchive(archive models.DataRestoreArchive) error
+}
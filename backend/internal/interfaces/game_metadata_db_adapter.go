@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type GameMetadataDbAdapter interface {
+	GetStaleGames(ctx context.Context, refreshedBefore time.Time, limit int) ([]models.StaleGameDB, error)
+	UpdateGameMetadata(ctx context.Context, game models.GameMetadataToSave, refreshedAt time.Time) error
+	RemapGame(ctx context.Context, oldGameID int64, game models.GameMetadataToSave, refreshedAt time.Time) (models.GameRemapResult, error)
+	MarkGamesMissing(ctx context.Context, gameIDs []int64, checkedAt time.Time) error
+}
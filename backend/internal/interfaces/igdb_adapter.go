@@ -11,6 +11,8 @@ type IGDBAdapter interface {
 		ctx context.Context,
 		query string,
 		limit int,
+		offset int,
 	) ([]*models.Game, error)
+	GetGamesByIDs(ctx context.Context, ids []int64) ([]*models.Game, error)
 	UpdateToken(token string) error
 }
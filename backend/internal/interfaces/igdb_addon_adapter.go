@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type IGDBAddonAdapter interface {
+	GetGameAddons(ctx context.Context, gameID int64) ([]*models.GameAddon, error)
+	UpdateToken(token string) error
+}
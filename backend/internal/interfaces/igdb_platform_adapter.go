@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+type IGDBPlatformAdapter interface {
+	GetPlatforms(ctx context.Context) ([]*types.IGDBPlatformResponse, error)
+	GetPlatformFamilies(ctx context.Context) ([]*types.IGDBPlatformFamilyResponse, error)
+	UpdateToken(token string) error
+}
@@ -0,0 +1,6 @@
+package interfaces
+
+// IGDBTokenUpdater is any IGDB adapter that can take a refreshed Twitch token
+type IGDBTokenUpdater interface {
+	UpdateToken(token string) error
+}
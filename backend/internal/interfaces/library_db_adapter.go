@@ -10,12 +10,21 @@ import (
 type LibraryDbAdapter interface {
 	GetSingleLibraryGame(ctx context.Context, userID string, gameID int64) (types.LibraryGameItemBFFResponseFINAL, error)
 	GetUserLibraryItems(ctx context.Context, userID string) ([]models.GameToSave, error)
-	UpdateLibraryGame(ctx context.Context, game models.GameToSave) error
+	UpdateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
+	UpdateLibraryGamePlayStatus(ctx context.Context, userID string, status models.GamePlayStatusToSave) error
+	UpdateLibraryGameReview(ctx context.Context, userID string, review models.GameReviewToSave) error
+	SetLibraryGameFavorite(ctx context.Context, userID string, gameID int64, favorite bool) error
 	CreateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
 	DeleteLibraryGame(ctx context.Context, userID string, gameID int64) error
 	DeleteGameVersions(ctx context.Context, userID string, gameID int64, request types.BatchDeleteLibraryGameRequest) (types.BatchDeleteLibraryGameResponse, error)
 	IsGameInLibrary(ctx context.Context, userID string, gameID int64) (bool, error)
 
+	// QueryLibraryGames returns up to query.Limit+1 games so callers can tell if another page exists
+	QueryLibraryGames(ctx context.Context, userID string, query models.LibraryQuery) ([]models.LibraryGameListItemDB, error)
+	SearchLibraryGames(ctx context.Context, userID string, search models.LibrarySearch) ([]models.LibrarySearchResultDB, error)
+	GetLibraryGenres(ctx context.Context, userID string) ([]models.LibraryGameTermCountDB, error)
+	GetLibraryThemes(ctx context.Context, userID string) ([]models.LibraryGameTermCountDB, error)
+
 	// REFACTORED RESPONSE WITH HELPER METHODS
 	GetLibraryRefactoredBFFResponse(ctx context.Context,userID string) (types.LibraryBFFRefactoredResponse, error)
 	GetPhysicalLocations(ctx context.Context, userID string) ([]models.PhysicalLocationDB, error)
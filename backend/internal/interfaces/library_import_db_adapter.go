@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type LibraryImportDbAdapter interface {
+	CreateImportJob(ctx context.Context, userID string, source string, dryRun bool, rows []models.LibraryImportRowToSave) (models.LibraryImportJobDB, error)
+	GetImportJob(ctx context.Context, userID string, jobID string) (models.LibraryImportJobDB, error)
+	GetImportRows(ctx context.Context, jobID string) ([]models.LibraryImportRowDB, error)
+	GetPendingImportRows(ctx context.Context, jobID string) ([]models.LibraryImportRowDB, error)
+	GetUnfinishedImportJobs(ctx context.Context) ([]models.LibraryImportJobDB, error)
+	GetImportLocations(ctx context.Context, userID string) ([]models.LibraryImportLocationDB, error)
+	GetReviewRows(ctx context.Context, userID string) ([]models.LibraryImportRowDB, error)
+	GetReviewRow(ctx context.Context, userID string, rowID int64) (models.LibraryImportRowDB, error)
+
+	MarkImportJobRunning(ctx context.Context, jobID string) error
+	SaveImportRowResult(ctx context.Context, jobID string, rowID int64, result models.LibraryImportRowResult) error
+	CompleteImportJob(ctx context.Context, jobID string, status string, errorMessage string) error
+	ResolveReviewRow(ctx context.Context, jobID string, rowID int64, result models.LibraryImportRowResult) error
+	DismissReviewRow(ctx context.Context, userID string, rowID int64) error
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+type LibraryImportValidator interface {
+	ValidateUserID(userID string) error
+	ValidateJobID(jobID string) error
+	ValidateSource(source string) error
+	ValidateRowID(rowID int64) error
+}
@@ -1,9 +1,18 @@
 package interfaces
 
-import "github.com/lokeam/qko-beta/internal/models"
+import (
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
 
 type LibraryValidator interface {
-	ValidateLibraryGame(game models.GameToSave) error
+	ValidateLibraryGame(game models.GameToSave) (models.GameToSave, error)
 	ValidateUserID(userID string) error
 	ValidateGameID(gameID int64) error
+	ValidateGameCondition(condition string) error
+	ValidatePlayStatus(playStatus string) error
+	ValidateGamePlayStatus(status models.GamePlayStatusToSave) (models.GamePlayStatusToSave, error)
+	ValidateGameReview(review models.GameReviewToSave) (models.GameReviewToSave, error)
+	ValidateLibraryQuery(request types.LibraryQueryRequest) error
+	ValidateLibrarySearch(request types.LibrarySearchRequest) error
 }
\ No newline at end of file
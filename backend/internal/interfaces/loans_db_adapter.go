@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type LoansDbAdapter interface {
+	GetLoans(ctx context.Context, userID string, status string, now time.Time) ([]models.GameLoanDB, error)
+	CreateLoan(ctx context.Context, userID string, loan models.LoanToSave) (models.GameLoanDB, error)
+	ReturnLoan(ctx context.Context, userID string, loanID string, returnedAt time.Time) (models.GameLoanDB, error)
+
+	// Overdue reminders, across every user
+	GetDueLoanReminders(ctx context.Context, now time.Time) ([]models.LoanReminderDB, error)
+	MarkLoanReminderSent(ctx context.Context, loanID string, sentAt time.Time) error
+}
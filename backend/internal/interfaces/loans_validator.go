@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+type LoansValidator interface {
+	ValidateUserID(userID string) error
+	ValidateLoanID(loanID string) error
+	ValidateStatus(status string) (string, error)
+	ValidateCreateLoanRequest(request types.CreateLoanRequest, now time.Time) error
+	ValidateReturnLoanRequest(request types.ReturnLoanRequest, now time.Time) error
+}
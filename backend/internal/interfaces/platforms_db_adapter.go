@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type PlatformsDbAdapter interface {
+	SavePlatformTaxonomy(
+		ctx context.Context,
+		families []models.PlatformFamilyToSave,
+		platforms []models.PlatformToSave,
+		syncedAt time.Time,
+	) error
+}
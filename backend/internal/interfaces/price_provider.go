@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// PriceProvider looks up current prices for wishlisted games.
+// Games the provider has no price for are left out of the result.
+type PriceProvider interface {
+	Name() string
+	GetPrices(ctx context.Context, queries []models.PriceQuery) ([]models.GamePrice, error)
+}
@@ -13,4 +13,5 @@ type SpendTrackingDbAdapter interface {
 	UpdateOneTimePurchase(ctx context.Context, userID string, request models.SpendTrackingOneTimePurchaseDB) (models.SpendTrackingOneTimePurchaseDB, error)
 	GetSingleSpendTrackingItem(ctx context.Context, userID string, itemID string) (models.SpendTrackingOneTimePurchaseDB, error)
 	DeleteSpendTrackingItems(ctx context.Context, userID string, itemIDs []string) (int64, error)
+	GetLinkedGameIDs(ctx context.Context, userID string, itemIDs []string) ([]int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type TrashDbAdapter interface {
+	GetTrash(ctx context.Context, userID string) (models.TrashDB, error)
+	RestoreGame(ctx context.Context, userID string, gameID int64) (models.TrashRestoreResult, error)
+	RestorePhysicalLocation(ctx context.Context, userID string, locationID string) (models.TrashRestoreResult, error)
+	RestoreSublocation(ctx context.Context, userID string, sublocationID string) (models.TrashRestoreResult, error)
+	RestorePurchase(ctx context.Context, userID string, purchaseID int) (models.TrashRestoreResult, error)
+
+	// Retention purge, across every user
+	PurgeTrash(ctx context.Context, cutoff time.Time) (models.TrashPurgeResult, error)
+}
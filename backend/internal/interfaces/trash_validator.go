@@ -0,0 +1,9 @@
+package interfaces
+
+type TrashValidator interface {
+	ValidateUserID(userID string) error
+	ValidateItemType(itemType string) error
+	ValidateGameID(itemID string) (int64, error)
+	ValidateLocationID(itemID string) error
+	ValidatePurchaseID(itemID string) (int, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type WishlistCacheWrapper interface {
+	GetCachedWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, bool, error)
+	SetCachedWishlistItems(ctx context.Context, userID string, items []models.WishlistItemDB) error
+
+	InvalidateUserCache(ctx context.Context, userID string) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type WishlistDbAdapter interface {
+	GetWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, error)
+	GetSingleWishlistItem(ctx context.Context, userID string, gameID int64) (models.WishlistItemDB, error)
+	IsGameInWishlist(ctx context.Context, userID string, gameID int64) (bool, error)
+	CreateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) error
+	UpdateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) error
+	DeleteWishlistItem(ctx context.Context, userID string, gameID int64) error
+	GetPriceHistory(ctx context.Context, userID string, gameID int64) ([]models.GamePriceHistoryDB, error)
+	GetUpcomingReleases(ctx context.Context, userID string, from int64, to int64) ([]models.WishlistReleaseDB, error)
+	GetWishlistSettings(ctx context.Context, userID string) (models.WishlistSettingsDB, error)
+	UpdateWishlistSettings(ctx context.Context, userID string, settings models.WishlistSettingsDB) error
+	PurchaseWishlistItem(ctx context.Context, userID string, purchase models.WishlistPurchaseToSave) (models.WishlistPurchaseResult, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type WishlistPriceDbAdapter interface {
+	GetPriceQueries(ctx context.Context, checkedBefore time.Time, limit int) ([]models.PriceQuery, error)
+	SaveGamePrices(ctx context.Context, provider string, checked []models.PriceQuery, prices []models.GamePrice, checkedAt time.Time) ([]string, error)
+	GetDueSaleAlerts(ctx context.Context) ([]models.WishlistSaleAlertDB, error)
+	MarkSaleAlertSent(ctx context.Context, wishlistID int64, sentAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type WishlistReleaseDbAdapter interface {
+	GetDueReleaseReminders(ctx context.Context, now time.Time, defaultDays int) ([]models.WishlistReleaseReminderDB, error)
+	MarkReleaseRemindersSent(ctx context.Context, wishlistIDs []int64) error
+}
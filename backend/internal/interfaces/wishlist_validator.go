@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/lokeam/qko-beta/internal/models"
+
+type WishlistValidator interface {
+	ValidateWishlistItem(item models.WishlistItemToSave) error
+	ValidateUserID(userID string) error
+	ValidateGameID(gameID int64) error
+	ValidateReleaseReminderDays(days int) error
+	ValidateUpcomingDays(days int) error
+	ValidateMonthlyBudget(budget float64) error
+	ValidatePurchase(purchase models.WishlistPurchaseToSave) error
+}
@@ -0,0 +1,101 @@
+package library
+
+import (
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// FilterLibraryBFFResponse narrows library items and recently added items to the games matching every filter.
+// Play status and favorite apply to the whole game, a game matches the copy-level filters when at least one of its copies does.
+func FilterLibraryBFFResponse(
+	response types.LibraryBFFRefactoredResponse,
+	filters types.LibraryBFFFilters,
+) types.LibraryBFFRefactoredResponse {
+	if !hasLibraryBFFFilters(filters) {
+		return response
+	}
+
+	return types.LibraryBFFRefactoredResponse{
+		LibraryItems:  filterLibraryGames(response.LibraryItems, filters),
+		RecentlyAdded: filterLibraryGames(response.RecentlyAdded, filters),
+	}
+}
+
+func hasLibraryBFFFilters(filters types.LibraryBFFFilters) bool {
+	return len(filters.PlayStatuses) > 0 || filters.Favorite != nil || hasCopyFilters(filters)
+}
+
+func hasCopyFilters(filters types.LibraryBFFFilters) bool {
+	return len(filters.Conditions) > 0 ||
+		filters.HasOriginalCase != nil ||
+		filters.HasManual != nil
+}
+
+func filterLibraryGames(
+	games []types.SingleLibraryGameBFFResponse,
+	filters types.LibraryBFFFilters,
+) []types.SingleLibraryGameBFFResponse {
+	result := make([]types.SingleLibraryGameBFFResponse, 0, len(games))
+	for _, game := range games {
+		if gameMatchesFilters(game, filters) {
+			result = append(result, game)
+		}
+	}
+	return result
+}
+
+func gameMatchesFilters(
+	game types.SingleLibraryGameBFFResponse,
+	filters types.LibraryBFFFilters,
+) bool {
+	if len(filters.PlayStatuses) > 0 && !containsString(filters.PlayStatuses, game.PlayStatus) {
+		return false
+	}
+	if filters.Favorite != nil && game.Favorite != *filters.Favorite {
+		return false
+	}
+	if !hasCopyFilters(filters) {
+		return true
+	}
+
+	for _, location := range game.PhysicalLocations {
+		for _, version := range location.GamePlatformVersions {
+			if copyMatchesFilters(version, filters) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func copyMatchesFilters(
+	version types.PlatformVersionResponse,
+	filters types.LibraryBFFFilters,
+) bool {
+	if len(filters.Conditions) > 0 && !containsString(filters.Conditions, version.Condition) {
+		return false
+	}
+	if filters.HasOriginalCase != nil && !boolPointerEquals(version.HasOriginalCase, *filters.HasOriginalCase) {
+		return false
+	}
+	if filters.HasManual != nil && !boolPointerEquals(version.HasManual, *filters.HasManual) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+// boolPointerEquals treats an unset value as false
+func boolPointerEquals(value *bool, target bool) bool {
+	if value == nil {
+		return !target
+	}
+	return *value == target
+}
@@ -0,0 +1,135 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Narrowing the library BFF response by per-copy condition and completeness
+
+	Scenarios:
+	- No filters returns the response unchanged
+	- Condition filter keeps games with at least one matching physical copy
+	- Completeness filter treats unknown values as false
+	- Play status filter applies to the whole game
+	- Favorite filter applies to the whole game
+	- Digital-only games never match copy filters
+*/
+
+func TestFilterLibraryBFFResponse(t *testing.T) {
+	hasManual := true
+	noManual := false
+	onlyFavorites := true
+
+	sealedGame := types.SingleLibraryGameBFFResponse{
+		ID:         1,
+		PlayStatus: "completed",
+		PhysicalLocations: []types.LibraryBFFSinglePhysicalLocationResponse{
+			{
+				GamePlatformVersions: []types.PlatformVersionResponse{
+					{PlatformId: 48, Condition: "sealed", HasManual: &hasManual},
+				},
+			},
+		},
+	}
+	looseGame := types.SingleLibraryGameBFFResponse{
+		ID:         2,
+		PlayStatus: "backlog",
+		PhysicalLocations: []types.LibraryBFFSinglePhysicalLocationResponse{
+			{
+				GamePlatformVersions: []types.PlatformVersionResponse{
+					{PlatformId: 48, Condition: "loose"},
+				},
+			},
+		},
+	}
+	digitalGame := types.SingleLibraryGameBFFResponse{
+		ID:         3,
+		PlayStatus: "playing",
+		Favorite:   true,
+		DigitalLocations: []types.LibraryBFFSingleDigitalLocationResponse{
+			{
+				GamePlatformVersions: []types.PlatformVersionResponse{{PlatformId: 6}},
+			},
+		},
+	}
+
+	response := types.LibraryBFFRefactoredResponse{
+		LibraryItems:  []types.SingleLibraryGameBFFResponse{sealedGame, looseGame, digitalGame},
+		RecentlyAdded: []types.SingleLibraryGameBFFResponse{looseGame},
+	}
+
+	testCases := []struct {
+		name                  string
+		filters               types.LibraryBFFFilters
+		expectedLibraryIDs    []int64
+		expectedRecentlyAdded []int64
+	}{
+		{
+			name:                  "No filters returns the response unchanged",
+			filters:               types.LibraryBFFFilters{},
+			expectedLibraryIDs:    []int64{1, 2, 3},
+			expectedRecentlyAdded: []int64{2},
+		},
+		{
+			name:                  "Condition filter keeps games with a matching copy",
+			filters:               types.LibraryBFFFilters{Conditions: []string{"sealed", "mint"}},
+			expectedLibraryIDs:    []int64{1},
+			expectedRecentlyAdded: []int64{},
+		},
+		{
+			name:                  "Completeness filter treats unknown values as false",
+			filters:               types.LibraryBFFFilters{HasManual: &noManual},
+			expectedLibraryIDs:    []int64{2},
+			expectedRecentlyAdded: []int64{2},
+		},
+		{
+			name:                  "Play status filter applies to the whole game",
+			filters:               types.LibraryBFFFilters{PlayStatuses: []string{"playing", "completed"}},
+			expectedLibraryIDs:    []int64{1, 3},
+			expectedRecentlyAdded: []int64{},
+		},
+		{
+			name:                  "Favorite filter applies to the whole game",
+			filters:               types.LibraryBFFFilters{Favorite: &onlyFavorites},
+			expectedLibraryIDs:    []int64{3},
+			expectedRecentlyAdded: []int64{},
+		},
+		{
+			name: "Play status and copy filters combine",
+			filters: types.LibraryBFFFilters{
+				PlayStatuses: []string{"playing", "completed"},
+				Conditions:   []string{"sealed"},
+			},
+			expectedLibraryIDs:    []int64{1},
+			expectedRecentlyAdded: []int64{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			result := FilterLibraryBFFResponse(response, testCase.filters)
+
+			// THEN
+			assertGameIDs(t, "libraryItems", result.LibraryItems, testCase.expectedLibraryIDs)
+			assertGameIDs(t, "recentlyAdded", result.RecentlyAdded, testCase.expectedRecentlyAdded)
+		})
+	}
+}
+
+func assertGameIDs(t *testing.T, label string, games []types.SingleLibraryGameBFFResponse, expected []int64) {
+	t.Helper()
+
+	if len(games) != len(expected) {
+		t.Fatalf("Expected %d %s, got %d", len(expected), label, len(games))
+	}
+	for i, game := range games {
+		if game.ID != expected[i] {
+			t.Errorf("Expected %s[%d] to have ID %d, got %d", label, i, expected[i], game.ID)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package library
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Reporting what each copy of a game cost
+	- Reading purchase IDs sent with a library update
+
+	Scenarios:
+	- Copies with linked purchases get a cost basis and purchase IDs, the game gets a total
+	- A game without linked purchases is left unchanged
+	- Purchase IDs are deduplicated and must use the "one-" format
+*/
+
+func TestApplyCostBasisToResponse(t *testing.T) {
+	newResponse := func() types.LibraryGameItemBFFResponseFINAL {
+		return types.LibraryGameItemBFFResponseFINAL{
+			ID: 1942,
+			GamesByPlatformAndLocation: []types.LibraryGamesByPlatformAndLocationItemFINAL{
+				{UserGameID: 10, PlatformID: 48},
+				{UserGameID: 11, PlatformID: 6},
+			},
+		}
+	}
+
+	/*
+		GIVEN a game whose PS4 copy has two linked purchases
+		WHEN ApplyCostBasisToResponse is called
+		THEN only that copy should get a cost basis and the game a total
+	*/
+	t.Run("Linked copies get a cost basis", func(t *testing.T) {
+		// GIVEN
+		response := newResponse()
+		costBases := []models.UserGameCostBasisDB{
+			{UserGameID: 10, CostBasis: 34.99, PurchaseIDs: pq.Int64Array{16, 21}},
+		}
+
+		// WHEN
+		ApplyCostBasisToResponse(&response, costBases)
+
+		// THEN
+		linked := response.GamesByPlatformAndLocation[0]
+		if linked.CostBasis == nil || *linked.CostBasis != 34.99 {
+			t.Errorf("Expected cost basis 34.99, got %v", linked.CostBasis)
+		}
+		if len(linked.PurchaseIDs) != 2 || linked.PurchaseIDs[0] != "one-16" {
+			t.Errorf("Expected purchase IDs [one-16 one-21], got %v", linked.PurchaseIDs)
+		}
+		if response.GamesByPlatformAndLocation[1].CostBasis != nil {
+			t.Errorf("Expected no cost basis on the unlinked copy")
+		}
+		if response.TotalCostBasis == nil || *response.TotalCostBasis != 34.99 {
+			t.Errorf("Expected total cost basis 34.99, got %v", response.TotalCostBasis)
+		}
+	})
+
+	/*
+		GIVEN a game without linked purchases
+		WHEN ApplyCostBasisToResponse is called
+		THEN the response should be left without any cost basis
+	*/
+	t.Run("Unlinked games are unchanged", func(t *testing.T) {
+		// GIVEN
+		response := newResponse()
+
+		// WHEN
+		ApplyCostBasisToResponse(&response, nil)
+
+		// THEN
+		if response.TotalCostBasis != nil {
+			t.Errorf("Expected no total cost basis, got %v", *response.TotalCostBasis)
+		}
+	})
+}
+
+func TestParsePurchaseIDs(t *testing.T) {
+	/*
+		GIVEN frontend purchase IDs with a repeat
+		WHEN parsePurchaseIDs is called
+		THEN it should return each numeric ID once
+	*/
+	t.Run("Repeated IDs are dropped", func(t *testing.T) {
+		// WHEN
+		ids, err := parsePurchaseIDs([]string{"one-16", "one-21", "one-16"})
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(ids) != 2 || ids[0] != 16 || ids[1] != 21 {
+			t.Errorf("Expected [16 21], got %v", ids)
+		}
+	})
+
+	for _, purchaseID := range []string{"16", "one-", "one-abc", "one--4", "sub-16"} {
+		/*
+			GIVEN a malformed purchase ID
+			WHEN parsePurchaseIDs is called
+			THEN it should return ErrValidationFailed
+		*/
+		t.Run("Rejects "+purchaseID, func(t *testing.T) {
+			// WHEN
+			_, err := parsePurchaseIDs([]string{purchaseID})
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package library
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,6 +98,19 @@ func (la *LibraryDbAdapter) GetSingleLibraryGame(
 		return types.LibraryGameItemBFFResponseFINAL{}, fmt.Errorf("error getting locations data for single game: %w", err)
 	}
 
+	// Get what each copy cost
+	var costBases []models.UserGameCostBasisDB
+	err = la.db.SelectContext(
+		ctx,
+		&costBases,
+		GetLibraryGameCostBasisQuery,
+		userID,
+		gameID,
+	)
+	if err != nil {
+		return types.LibraryGameItemBFFResponseFINAL{}, fmt.Errorf("error getting cost basis for single game: %w", err)
+	}
+
 	// Transform locations to BFF response format
 	var bffLocations []types.LibraryGamesByPlatformAndLocationItemFINAL
 	for _, loc := range locations {
@@ -104,64 +120,202 @@ func (la *LibraryDbAdapter) GetSingleLibraryGame(
 	}
 
 	// Transform game to BFF response format
-	return TransformGameDBToResponse(game, bffLocations), nil
+	response := TransformGameDBToResponse(game, bffLocations)
+	ApplyCostBasisToResponse(&response, costBases)
+
+	return response, nil
 }
 
 // PUT
-// UpdateLibraryGame updates a game in the user's library.
-// It updates the game details in the games table and any associated platform locations.
+// UpdateLibraryGame syncs the platform versions of a game in the user's library.
+// Versions in the request are updated in place (copy details and location) or added,
+// versions missing from the request are removed.
 // Returns:
 // - nil if the operation was successful
-// - ErrGameNotFound if the game doesn't exist in the user's library
+// - ErrValidationFailed if a location isn't one of the user's, or its sublocation is in the trash
 // - Another error if a database error occurred
-func (la *LibraryDbAdapter) UpdateLibraryGame(ctx context.Context, game models.GameToSave) error {
+func (la *LibraryDbAdapter) UpdateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error {
 	la.logger.Info("LibraryDbAdapter - UpdateLibraryGame called", map[string]any{
+		"userID": userID,
 		"gameID": game.GameID,
 	})
 
-	// Update game in database
-	_, err := la.db.ExecContext(
-		ctx,
-		UpdateLibraryGameQuery,
-		game.GameID,
-		game.GameName,
-		game.GameCoverURL,
-		game.GameType.DisplayText,
-		game.GameType.NormalizedText,
-	)
-	if err != nil {
-		return fmt.Errorf("error updating game in library: %w", err)
-	}
+	return postgres.WithTransaction(ctx, la.db, la.logger, func(tx *sqlx.Tx) error {
+		keptUserGameIDs := make([]int64, 0, len(game.PlatformLocations))
 
-	// Delete existing locations
-	_, err = la.db.ExecContext(
-		ctx,
-		DeleteLibraryLocationsQuery,
-		game.GameID,
-	)
-	if err != nil {
-		return fmt.Errorf("error deleting existing locations: %w", err)
-	}
+		for i, location := range game.PlatformLocations {
+			// STEP 1: Ensure platform exists
+			_, err := tx.ExecContext(
+				ctx,
+				EnsurePlatformExistsQuery,
+				location.PlatformID,
+				location.PlatformName,
+				getPlatformCategory(location.PlatformName),
+				getPlatformModel(location.PlatformName),
+			)
+			if err != nil {
+				return fmt.Errorf("error ensuring platform exists at index %d: %w", i, err)
+			}
+
+			// STEP 2: Update the existing copy or add a new one
+			var userGameID int64
+			err = tx.QueryRowContext(
+				ctx,
+				GetUserGameCopyIDQuery,
+				userID,
+				game.GameID,
+				location.PlatformID,
+				location.Type,
+			).Scan(&userGameID)
+
+			switch {
+			case err == nil:
+				_, err = tx.ExecContext(
+					ctx,
+					UpdateUserGameCopyDetailsQuery,
+					userGameID,
+					location.CopyDetails.Condition,
+					location.CopyDetails.HasOriginalCase,
+					location.CopyDetails.HasManual,
+					location.CopyDetails.AcquiredDate,
+					location.CopyDetails.Notes,
+				)
+				if err != nil {
+					return fmt.Errorf("error updating user game at index %d: %w", i, err)
+				}
+			case errors.Is(err, sql.ErrNoRows):
+				err = tx.QueryRowContext(
+					ctx,
+					InsertUserGameCopyQuery,
+					userID,
+					game.GameID,
+					location.PlatformID,
+					location.Type,
+					location.CopyDetails.Condition,
+					location.CopyDetails.HasOriginalCase,
+					location.CopyDetails.HasManual,
+					location.CopyDetails.AcquiredDate,
+					location.CopyDetails.Notes,
+					FirstCopyNumber,
+				).Scan(&userGameID)
+				if err != nil {
+					return fmt.Errorf("error inserting user game at index %d: %w", i, err)
+				}
+			default:
+				return fmt.Errorf("error finding user game at index %d: %w", i, err)
+			}
+
+			// STEP 3: Replace location mapping
+			if _, err := tx.ExecContext(ctx, DeleteUserGamePhysicalLocationQuery, userGameID); err != nil {
+				return fmt.Errorf("error clearing physical location at index %d: %w", i, err)
+			}
+			if _, err := tx.ExecContext(ctx, DeleteUserGameDigitalLocationQuery, userGameID); err != nil {
+				return fmt.Errorf("error clearing digital location at index %d: %w", i, err)
+			}
+
+			var inserted sql.Result
+			if location.Type == "physical" {
+				inserted, err = tx.ExecContext(ctx, InsertUserGamePhysicalLocationQuery, userGameID, location.Location.SublocationID, userID)
+			} else {
+				inserted, err = tx.ExecContext(ctx, InsertUserGameDigitalLocationQuery, userGameID, location.Location.DigitalLocationID, userID)
+			}
+			if err != nil {
+				return fmt.Errorf("error inserting game location at index %d: %w", i, err)
+			}
+			rowsAffected, err := inserted.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error getting rows affected at index %d: %w", i, err)
+			}
+			if rowsAffected == 0 {
+				return fmt.Errorf("%w: location not found at index %d", ErrValidationFailed, i)
+			}
+
+			// STEP 4: Replace purchase links, a nil list keeps the current ones
+			if location.PurchaseIDs != nil {
+				if err := replaceUserGamePurchases(ctx, tx, userID, userGameID, location.PurchaseIDs); err != nil {
+					return fmt.Errorf("%w at index %d", err, i)
+				}
+			}
+
+			keptUserGameIDs = append(keptUserGameIDs, userGameID)
+		}
 
-	// Insert new locations
-	for _, location := range game.PlatformLocations {
-		_, err = la.db.ExecContext(
+		// STEP 5: Trash versions that are no longer part of the request
+		_, err := tx.ExecContext(
 			ctx,
-			InsertLibraryLocationQuery,
+			TrashRemovedUserGameVersionsQuery,
+			userID,
 			game.GameID,
-			location.PlatformID,
-			location.PlatformName,
-			location.Type,
-			location.Location.SublocationID,
+			pq.Array(keptUserGameIDs),
 		)
 		if err != nil {
-			return fmt.Errorf("error inserting new location: %w", err)
+			return fmt.Errorf("error trashing old game versions: %w", err)
 		}
+
+		return nil
+	})
+}
+
+// replaceUserGamePurchases swaps the one-time purchases linked to a copy.
+// Returns ErrValidationFailed if any purchase isn't the user's.
+func replaceUserGamePurchases(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	userID string,
+	userGameID int64,
+	purchaseIDs []string,
+) error {
+	numericIDs, err := parsePurchaseIDs(purchaseIDs)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, DeleteUserGamePurchasesQuery, userGameID); err != nil {
+		return fmt.Errorf("error clearing purchase links: %w", err)
+	}
+	if len(numericIDs) == 0 {
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx, LinkUserGamePurchasesQuery, userGameID, userID, pq.Array(numericIDs))
+	if err != nil {
+		return fmt.Errorf("error linking purchases: %w", err)
+	}
+
+	linked, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking linked purchases: %w", err)
+	}
+	if linked != int64(len(numericIDs)) {
+		return fmt.Errorf("%w: one or more purchases not found", ErrValidationFailed)
 	}
 
 	return nil
 }
 
+// FirstCopyNumber is the copy number a game added or edited in the library gets on each platform
+const FirstCopyNumber = 1
+
+// purchaseIDPrefix is how spend tracking exposes one_time_purchases IDs to the frontend
+const purchaseIDPrefix = "one-"
+
+// parsePurchaseIDs converts frontend purchase IDs ("one-16") to one_time_purchases IDs, dropping repeats
+func parsePurchaseIDs(purchaseIDs []string) ([]int64, error) {
+	seen := make(map[int64]bool, len(purchaseIDs))
+	numericIDs := make([]int64, 0, len(purchaseIDs))
+	for _, purchaseID := range purchaseIDs {
+		id, err := strconv.ParseInt(strings.TrimPrefix(purchaseID, purchaseIDPrefix), 10, 64)
+		if err != nil || !strings.HasPrefix(purchaseID, purchaseIDPrefix) || id <= 0 {
+			return nil, fmt.Errorf("%w: invalid purchase ID '%s'", ErrValidationFailed, purchaseID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			numericIDs = append(numericIDs, id)
+		}
+	}
+	return numericIDs, nil
+}
+
 // POST
 func (la *LibraryDbAdapter) CreateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error {
 	la.logger.Info("LibraryDbAdapter - CreateLibraryGame called", map[string]any{
@@ -187,6 +341,11 @@ func (la *LibraryDbAdapter) CreateLibraryGame(ctx context.Context, userID string
 					return fmt.Errorf("error ensuring game exists: %w", err)
 			}
 
+			// STEP 1b: Normalize the game's genres and themes
+			if err := SaveGameTerms(ctx, tx, game.GameID, game.GameGenres, game.GameThemes); err != nil {
+					return err
+			}
+
 			// STEP 2: For each platform version the user wants to add
 			for i, location := range game.PlatformLocations {
 					la.logger.Info("Processing platform", map[string]any{
@@ -213,11 +372,20 @@ func (la *LibraryDbAdapter) CreateLibraryGame(ctx context.Context, userID string
 
 					// STEP 2b: Add game+platform to user's library
 					var userGameID int
-					err = tx.QueryRowContext(ctx, `
-							INSERT INTO user_games (user_id, game_id, platform_id, game_type)
-							VALUES ($1, $2, $3, $4)
-							RETURNING id
-					`, userID, game.GameID, location.PlatformID, location.Type).Scan(&userGameID)
+					err = tx.QueryRowContext(
+							ctx,
+							InsertUserGameCopyQuery,
+							userID,
+							game.GameID,
+							location.PlatformID,
+							location.Type,
+							location.CopyDetails.Condition,
+							location.CopyDetails.HasOriginalCase,
+							location.CopyDetails.HasManual,
+							location.CopyDetails.AcquiredDate,
+							location.CopyDetails.Notes,
+							FirstCopyNumber,
+					).Scan(&userGameID)
 					if err != nil {
 							if strings.Contains(err.Error(), "unique constraint") {
 									la.logger.Info("Game already exists, getting ID", map[string]any{
@@ -227,7 +395,7 @@ func (la *LibraryDbAdapter) CreateLibraryGame(ctx context.Context, userID string
 									})
 									err = tx.QueryRowContext(ctx, `
 											SELECT id FROM user_games
-											WHERE user_id = $1 AND game_id = $2 AND platform_id = $3
+											WHERE user_id = $1 AND game_id = $2 AND platform_id = $3 AND deleted_at IS NULL
 									`, userID, game.GameID, location.PlatformID).Scan(&userGameID)
 									if err != nil {
 											la.logger.Error("Failed to find existing user game", map[string]any{
@@ -283,9 +451,45 @@ func (la *LibraryDbAdapter) CreateLibraryGame(ctx context.Context, userID string
 	})
 }
 
-// DeleteLibraryGame removes a game from a user's library.
-// It will automatically remove any associated physical or digital location mappings
-// due to ON DELETE CASCADE constraints in the database.
+// SaveGameTerms adds the game's genres and themes, along with any we didn't have yet.
+// Other paths that add copies, like wishlist purchases, call it inside their own transaction.
+func SaveGameTerms(ctx context.Context, tx *sqlx.Tx, gameID int64, genres, themes []models.GameMetadataTerm) error {
+	terms := []struct {
+		name        string
+		terms       []models.GameMetadataTerm
+		ensureQuery string
+		linkQuery   string
+	}{
+		{"genres", genres, EnsureGenresExistQuery, LinkGameGenresQuery},
+		{"themes", themes, EnsureThemesExistQuery, LinkGameThemesQuery},
+	}
+
+	for _, kind := range terms {
+		if len(kind.terms) == 0 {
+			continue
+		}
+
+		ids := make([]int64, len(kind.terms))
+		names := make([]string, len(kind.terms))
+		for i, term := range kind.terms {
+			ids[i] = term.ID
+			names[i] = term.Name
+		}
+
+		if _, err := tx.ExecContext(ctx, kind.ensureQuery, pq.Array(ids), pq.Array(names)); err != nil {
+			return fmt.Errorf("error saving %s: %w", kind.name, err)
+		}
+		if _, err := tx.ExecContext(ctx, kind.linkQuery, gameID, pq.Array(ids)); err != nil {
+			return fmt.Errorf("error linking game %s: %w", kind.name, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteLibraryGame moves every copy of a game to the user's trash.
+// Location, purchase, tag and loan mappings are kept so the game can be restored
+// until the trash purge job removes it.
 // Returns:
 // - nil if the operation was successful
 // - ErrGameNotFound if the game doesn't exist in the user's library
@@ -322,10 +526,10 @@ func (la *LibraryDbAdapter) DeleteLibraryGame(ctx context.Context, userID string
 			return ErrGameNotFound
 		}
 
-		// Delete the game from user's library
+		// Move the game to the user's trash
 		_, err = tx.ExecContext(
 			ctx,
-			CascadingDeleteLibraryGameQuery,
+			TrashLibraryGameQuery,
 			userID,
 			gameID,
 		)
@@ -346,6 +550,110 @@ func (la *LibraryDbAdapter) DeleteLibraryGame(ctx context.Context, userID string
 	})
 }
 
+// UpdateLibraryGamePlayStatus sets the play status on every copy of a game in the user's library
+// Returns ErrGameNotFound if the user has no copies of the game
+func (la *LibraryDbAdapter) UpdateLibraryGamePlayStatus(
+	ctx context.Context,
+	userID string,
+	status models.GamePlayStatusToSave,
+) error {
+	la.logger.Info("LibraryDbAdapter - UpdateLibraryGamePlayStatus called", map[string]any{
+		"userID":     userID,
+		"gameID":     status.GameID,
+		"playStatus": status.PlayStatus,
+	})
+
+	result, err := la.db.ExecContext(
+		ctx,
+		UpdateUserGamePlayStatusQuery,
+		userID,
+		status.GameID,
+		status.PlayStatus,
+		status.StartedAt,
+		status.FinishedAt,
+		status.CompletionNote,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating play status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrGameNotFound
+	}
+
+	return nil
+}
+
+// UpdateLibraryGameReview sets the personal rating, review and private notes on every copy of a game
+// Returns ErrGameNotFound if the user has no copies of the game
+func (la *LibraryDbAdapter) UpdateLibraryGameReview(
+	ctx context.Context,
+	userID string,
+	review models.GameReviewToSave,
+) error {
+	la.logger.Info("LibraryDbAdapter - UpdateLibraryGameReview called", map[string]any{
+		"userID": userID,
+		"gameID": review.GameID,
+	})
+
+	result, err := la.db.ExecContext(
+		ctx,
+		UpdateUserGameReviewQuery,
+		userID,
+		review.GameID,
+		review.PersonalRating,
+		review.Review,
+		review.PrivateNotes,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating review: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrGameNotFound
+	}
+
+	return nil
+}
+
+// SetLibraryGameFavorite sets the favorite flag on every copy of a game
+// Returns ErrGameNotFound if the user has no copies of the game
+func (la *LibraryDbAdapter) SetLibraryGameFavorite(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+	favorite bool,
+) error {
+	la.logger.Info("LibraryDbAdapter - SetLibraryGameFavorite called", map[string]any{
+		"userID":   userID,
+		"gameID":   gameID,
+		"favorite": favorite,
+	})
+
+	result, err := la.db.ExecContext(ctx, UpdateUserGameFavoriteQuery, userID, gameID, favorite)
+	if err != nil {
+		return fmt.Errorf("error updating favorite: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrGameNotFound
+	}
+
+	return nil
+}
+
 // Helper fn to determine if game exists in user library
 func (la *LibraryDbAdapter) IsGameInLibrary(ctx context.Context, userID string, gameID int64) (bool, error) {
 	la.logger.Debug("LibraryDbAdapter - IsGameInLibrary called", map[string]any{
@@ -363,7 +671,8 @@ func (la *LibraryDbAdapter) IsGameInLibrary(ctx context.Context, userID string,
 	return exists, nil
 }
 
-// Helper function to determine platform category
+// Helper function to determine platform category for a platform the platform sync hasn't added yet,
+// the next sync replaces it with the category IGDB gives the platform
 func getPlatformCategory(platformName string) string {
 	switch {
 	case strings.Contains(strings.ToLower(platformName), "pc"):
@@ -375,9 +684,9 @@ func getPlatformCategory(platformName string) string {
 	}
 }
 
-// Helper function to determine platform model
+// Helper function to determine platform model, the platform sync replaces it with IGDB's abbreviation
 func getPlatformModel(platformName string) string {
-	return platformName // For now, use the platform name as the model
+	return platformName
 }
 
 func (la *LibraryDbAdapter) GetLibraryBFFResponse(
@@ -615,6 +924,13 @@ func (la *LibraryDbAdapter) GetGamesMetadata(
 					&game.GameTypeNormalizedText,
 					&game.Favorite,
 					&game.CreatedAt,
+					&game.PlayStatus,
+					&game.StartedAt,
+					&game.FinishedAt,
+					&game.CompletionNote,
+					&game.PersonalRating,
+					&game.Review,
+					&game.PrivateNotes,
 					&game.IsInWishlist,
 					pq.Array(&genreNames),
 			)
@@ -639,6 +955,163 @@ func (la *LibraryDbAdapter) GetGamesMetadata(
 	return games, nil
 }
 
+// QueryLibraryGames returns one page of a user's library, plus one extra row when another page exists
+func (la *LibraryDbAdapter) QueryLibraryGames(
+	ctx context.Context,
+	userID string,
+	query models.LibraryQuery,
+) ([]models.LibraryGameListItemDB, error) {
+	la.logger.Debug("QueryLibraryGames called", map[string]any{
+		"userID": userID,
+		"sortBy": query.SortBy,
+		"limit":  query.Limit,
+	})
+
+	sqlQuery, err := renderQueryLibraryGames(query.SortBy, query.SortDescending)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := la.db.QueryxContext(
+		ctx,
+		sqlQuery,
+		userID,
+		nullableInt64Array(query.PlatformIDs),
+		nullableInt64Array(query.GenreIDs),
+		nullableInt64Array(query.ThemeIDs),
+		nullableString(query.GameType),
+		nullableString(query.LocationID),
+		query.Favorite,
+		query.AfterSortValue,
+		query.AfterGameID,
+		query.Limit+1,
+		nullableStringArray(query.TagIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying library games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []models.LibraryGameListItemDB
+	for rows.Next() {
+		var game models.LibraryGameListItemDB
+		var genreNames []string
+
+		err := rows.Scan(
+			&game.ID,
+			&game.Name,
+			&game.CoverURL,
+			&game.FirstReleaseDate,
+			&game.Rating,
+			&game.Favorite,
+			&game.PlayStatus,
+			&game.PersonalRating,
+			&game.DateAdded,
+			&game.TotalPhysicalVersions,
+			&game.TotalDigitalVersions,
+			pq.Array(&genreNames),
+			&game.SortValue,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning library game: %w", err)
+		}
+
+		game.GenreNames = genreNames
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating library games: %w", err)
+	}
+
+	la.logger.Debug("QueryLibraryGames success", map[string]any{
+		"userID":    userID,
+		"gameCount": len(games),
+	})
+
+	return games, nil
+}
+
+// SearchLibraryGames returns the user's games matching a search, best match first
+func (la *LibraryDbAdapter) SearchLibraryGames(
+	ctx context.Context,
+	userID string,
+	search models.LibrarySearch,
+) ([]models.LibrarySearchResultDB, error) {
+	la.logger.Debug("SearchLibraryGames called", map[string]any{
+		"userID": userID,
+		"limit":  search.Limit,
+	})
+
+	var results []models.LibrarySearchResultDB
+	err := la.db.SelectContext(
+		ctx,
+		&results,
+		SearchLibraryGamesQuery,
+		userID,
+		search.Query,
+		nullableInt64Array(search.PlatformIDs),
+		nullableString(search.LocationID),
+		nameHeadlineOptions,
+		summaryHeadlineOptions,
+		search.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error searching library games: %w", err)
+	}
+
+	la.logger.Debug("SearchLibraryGames success", map[string]any{
+		"userID":      userID,
+		"resultCount": len(results),
+	})
+
+	return results, nil
+}
+
+// GetLibraryGenres returns the genres of the user's games with how many games have each
+func (la *LibraryDbAdapter) GetLibraryGenres(ctx context.Context, userID string) ([]models.LibraryGameTermCountDB, error) {
+	var genres []models.LibraryGameTermCountDB
+	if err := la.db.SelectContext(ctx, &genres, GetLibraryGenresQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting library genres: %w", err)
+	}
+
+	return genres, nil
+}
+
+// GetLibraryThemes returns the themes of the user's games with how many games have each
+func (la *LibraryDbAdapter) GetLibraryThemes(ctx context.Context, userID string) ([]models.LibraryGameTermCountDB, error) {
+	var themes []models.LibraryGameTermCountDB
+	if err := la.db.SelectContext(ctx, &themes, GetLibraryThemesQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting library themes: %w", err)
+	}
+
+	return themes, nil
+}
+
+// nullableInt64Array passes an empty filter as NULL so the query skips it
+func nullableInt64Array(values []int64) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return pq.Array(values)
+}
+
+// nullableStringArray passes an empty filter as NULL so the query skips it
+func nullableStringArray(values []string) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return pq.Array(values)
+}
+
+// nullableString passes an empty filter as NULL so the query skips it
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
 func (la *LibraryDbAdapter) GetLibraryRefactoredBFFResponse(
 	ctx context.Context,
 	userID string,
@@ -746,10 +1219,10 @@ func (la *LibraryDbAdapter) DeleteGameVersions(
 				return fmt.Errorf("error getting game versions: %w", err)
 			}
 
-			// Delete all user_game entries for this game (cascades to location tables)
+			// Trash all user_game entries for this game, location mappings are kept for a restore
 			result, err := tx.ExecContext(
 				ctx,
-				CascadingDeleteLibraryGameQuery,
+				TrashLibraryGameQuery,
 				userID,
 				gameID,
 			)
@@ -804,10 +1277,10 @@ func (la *LibraryDbAdapter) DeleteGameVersions(
 				}
 			}
 
-			// Delete specific versions
+			// Trash specific versions
 			result, err := tx.ExecContext(
 				ctx,
-				DeleteSpecificGameVersionsQuery,
+				TrashSpecificGameVersionsQuery,
 				userID,
 				gameID,
 				pq.Array(platformIDs),
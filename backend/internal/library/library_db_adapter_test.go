@@ -3,10 +3,13 @@ package library
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/lokeam/qko-beta/internal/appcontext"
 	"github.com/lokeam/qko-beta/internal/models"
 	"github.com/lokeam/qko-beta/internal/testutils"
@@ -27,6 +30,10 @@ import (
 	- GetLibraryItems handles database errors
 	- CreateLibraryGame successfully adds a new game
 	- CreateLibraryGame handles existing games
+	- CreateLibraryGame saves the game's genres and themes
+	- UpdateLibraryGame gives a new copy the fields shared by every copy of the game
+	- UpdateLibraryGame rejects a location that isn't the user's
+	- GetLibraryGenres returns genres with game counts
 	- DeleteLibraryGame successfully removes a game
 	- IsGameInLibrary correctly identifies if a game is in library
 */
@@ -170,6 +177,10 @@ func TestLibraryDbAdapter(t *testing.T) {
 		}
 		defer adapter.db.Close()
 
+		hasOriginalCase := true
+		hasManual := false
+		acquiredDate := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
 		gameToSave := models.GameToSave{
 			GameID:               gameID,
 			GameName:             "Test Game",
@@ -188,6 +199,12 @@ func TestLibraryDbAdapter(t *testing.T) {
 					Location: models.GameToSaveLocationDetails{
 						SublocationID: "shelf-1",
 					},
+					CopyDetails: models.GameToSaveCopyDetails{
+						Condition:       "mint",
+						HasOriginalCase: &hasOriginalCase,
+						HasManual:       &hasManual,
+						AcquiredDate:    &acquiredDate,
+					},
 				},
 			},
 		}
@@ -210,9 +227,14 @@ func TestLibraryDbAdapter(t *testing.T) {
 		// Insert user game
 		mock.ExpectQuery("INSERT INTO user_games").
 			WithArgs(userID, gameToSave.GameID, gameToSave.PlatformLocations[0].PlatformID,
-				gameToSave.PlatformLocations[0].Type).
+				gameToSave.PlatformLocations[0].Type, "mint", true, false, sqlmock.AnyArg(), "", FirstCopyNumber).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
+		// Insert physical location
+		mock.ExpectExec("INSERT INTO physical_game_locations").
+			WithArgs(1, "shelf-1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
 		// Commit transaction
 		mock.ExpectCommit()
 
@@ -281,14 +303,19 @@ func TestLibraryDbAdapter(t *testing.T) {
 		// Insert user game (will fail with unique constraint, then select existing)
 		mock.ExpectQuery("INSERT INTO user_games").
 			WithArgs(userID, gameToSave.GameID, gameToSave.PlatformLocations[0].PlatformID,
-				gameToSave.PlatformLocations[0].Type).
-			WillReturnError(sql.ErrNoRows)
+				gameToSave.PlatformLocations[0].Type, "", nil, nil, nil, "", FirstCopyNumber).
+			WillReturnError(errors.New(`duplicate key value violates unique constraint "user_games_user_id_game_id_platform_id_game_type_copy_number_key"`))
 
 		// Select existing user game
 		mock.ExpectQuery("SELECT id FROM user_games").
 			WithArgs(userID, gameToSave.GameID, gameToSave.PlatformLocations[0].PlatformID).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
+		// Insert physical location
+		mock.ExpectExec("INSERT INTO physical_game_locations").
+			WithArgs(1, "shelf-1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
 		// Commit transaction
 		mock.ExpectCommit()
 
@@ -375,27 +402,567 @@ func TestLibraryDbAdapter(t *testing.T) {
 			},
 		}
 
-		// Set up mock expectations
-		// Update game
-		mock.ExpectExec("UPDATE games SET").
-			WithArgs(gameToSave.GameID, gameToSave.GameName, gameToSave.GameCoverURL,
-				gameToSave.GameType.DisplayText, gameToSave.GameType.NormalizedText).
+		// Set up mock expectations for transaction
+		mock.ExpectBegin()
+
+		// Ensure platform exists
+		mock.ExpectExec("INSERT INTO platforms").
+			WithArgs(int64(2), "Steam", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 0))
+
+		// Existing digital copy found
+		mock.ExpectQuery("SELECT id FROM user_games").
+			WithArgs(userID, gameID, int64(2), "digital").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+		// Update copy details
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(int64(7), "", nil, nil, nil, "").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Replace location mapping
+		mock.ExpectExec("DELETE FROM physical_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM digital_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO digital_game_locations").
+			WithArgs(int64(7), "steam-lib", userID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		// Delete existing locations
-		mock.ExpectExec("DELETE FROM user_games").
-			WithArgs(gameToSave.GameID).
+		// Trash versions no longer in the request
+		mock.ExpectExec("UPDATE user_games SET deleted_at = NOW\\(\\)").
+			WithArgs(userID, gameID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectCommit()
+
+		// Execute
+		err = adapter.UpdateLibraryGame(context.Background(), userID, gameToSave)
+
+		// Verify
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN an update that moves a copy to a sublocation the user doesn't own
+		WHEN the adapter updates the game
+		THEN no location is linked and the transaction is rolled back with ErrValidationFailed
+	*/
+	t.Run("UpdateLibraryGame - Rejects a location that isn't the user's", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		gameToSave := models.GameToSave{
+			GameID:   gameID,
+			GameName: "Test Game",
+			PlatformLocations: []models.GameToSaveLocation{
+				{
+					PlatformID:   48,
+					PlatformName: "PlayStation 4",
+					Type:         "physical",
+					Location: models.GameToSaveLocationDetails{
+						SublocationID: "someone-elses-shelf",
+					},
+				},
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO platforms").
+			WithArgs(int64(48), "PlayStation 4", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectQuery("SELECT id FROM user_games").
+			WithArgs(userID, gameID, int64(48), "physical").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(int64(7), "", nil, nil, nil, "").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM physical_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM digital_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO physical_game_locations (.+) FROM sublocations s WHERE (.+) s.deleted_at IS NULL").
+			WithArgs(int64(7), "someone-elses-shelf", userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		// Execute
+		err = adapter.UpdateLibraryGame(context.Background(), userID, gameToSave)
+
+		// Verify
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a completed, rated, favorite game the user owns a Switch copy of
+		WHEN the adapter adds a Steam copy of it
+		THEN the new copy takes its play status, review and favorite from the existing copy
+	*/
+	t.Run("UpdateLibraryGame - A new copy keeps the game's shared fields", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		gameToSave := models.GameToSave{
+			GameID:   gameID,
+			GameName: "Completed Test Game",
+			PlatformLocations: []models.GameToSaveLocation{
+				{
+					PlatformID:   130,
+					PlatformName: "Nintendo Switch",
+					Type:         "digital",
+					Location: models.GameToSaveLocationDetails{
+						DigitalLocationID: "eshop",
+					},
+				},
+				{
+					PlatformID:   2,
+					PlatformName: "Steam",
+					Type:         "digital",
+					Location: models.GameToSaveLocationDetails{
+						DigitalLocationID: "steam-lib",
+					},
+				},
+			},
+		}
+
+		mock.ExpectBegin()
+
+		// Existing Switch copy is kept
+		mock.ExpectExec("INSERT INTO platforms").
+			WithArgs(int64(130), "Nintendo Switch", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectQuery("SELECT id FROM user_games").
+			WithArgs(userID, gameID, int64(130), "digital").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(int64(7), "", nil, nil, nil, "").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM physical_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM digital_game_locations").
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO digital_game_locations").
+			WithArgs(int64(7), "eshop", userID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// New Steam copy reads the shared fields from the Switch copy
+		mock.ExpectExec("INSERT INTO platforms").
+			WithArgs(int64(2), "Steam", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectQuery("SELECT id FROM user_games").
+			WithArgs(userID, gameID, int64(2), "digital").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(`WITH shared AS \(\s*SELECT play_status, started_at, finished_at, completion_note, personal_rating, review, private_notes, favorite\s+FROM user_games\s+`+
+			`WHERE user_id = \$1 AND game_id = \$2 AND deleted_at IS NULL.*`+
+			`INSERT INTO user_games.*COALESCE\(\(SELECT play_status FROM shared\), 'backlog'\),\s*`+
+			`\(SELECT started_at FROM shared\),\s*\(SELECT finished_at FROM shared\),\s*\(SELECT completion_note FROM shared\),\s*`+
+			`\(SELECT personal_rating FROM shared\),\s*\(SELECT review FROM shared\),\s*\(SELECT private_notes FROM shared\),\s*`+
+			`COALESCE\(\(SELECT favorite FROM shared\), false\)`).
+			WithArgs(userID, gameID, int64(2), "digital", "", nil, nil, nil, "", FirstCopyNumber).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+		mock.ExpectExec("DELETE FROM physical_game_locations").
+			WithArgs(int64(8)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DELETE FROM digital_game_locations").
+			WithArgs(int64(8)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO digital_game_locations").
+			WithArgs(int64(8), "steam-lib", userID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		// Insert new location
-		mock.ExpectExec("INSERT INTO user_games").
-			WithArgs(gameToSave.GameID, gameToSave.PlatformLocations[0].PlatformID,
-				gameToSave.PlatformLocations[0].PlatformName, gameToSave.PlatformLocations[0].Type,
-				gameToSave.PlatformLocations[0].Location.SublocationID).
+		// Both copies are kept
+		mock.ExpectExec("UPDATE user_games SET deleted_at = NOW\\(\\)").
+			WithArgs(userID, gameID, pq.Array([]int64{7, 8})).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectCommit()
+
+		// Execute
+		err = adapter.UpdateLibraryGame(context.Background(), userID, gameToSave)
+
+		// Verify
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a play status update for a game in the user's library
+		WHEN the adapter updates the play status
+		THEN every copy of the game is updated
+	*/
+	t.Run("UpdateLibraryGamePlayStatus - Successfully updates every copy", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		startedAt := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+		finishedAt := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+		status := models.GamePlayStatusToSave{
+			GameID:         gameID,
+			PlayStatus:     models.PlayStatusCompleted,
+			StartedAt:      &startedAt,
+			FinishedAt:     &finishedAt,
+			CompletionNote: "Rolled credits",
+		}
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, "completed", &startedAt, &finishedAt, "Rolled credits").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		// Execute
+		err = adapter.UpdateLibraryGamePlayStatus(context.Background(), userID, status)
+
+		// Verify
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a play status update for a game the user does not own
+		WHEN the adapter updates the play status
+		THEN ErrGameNotFound is returned
+	*/
+	t.Run("UpdateLibraryGamePlayStatus - Returns not found when no copies exist", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		status := models.GamePlayStatusToSave{
+			GameID:     gameID,
+			PlayStatus: models.PlayStatusPlaying,
+		}
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, "playing", nil, nil, "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// Execute
+		err = adapter.UpdateLibraryGamePlayStatus(context.Background(), userID, status)
+
+		// Verify
+		if !errors.Is(err, ErrGameNotFound) {
+			t.Errorf("Expected ErrGameNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a rating, review and private notes for a game in the user's library
+		WHEN the adapter updates the review
+		THEN every copy of the game is updated
+	*/
+	t.Run("UpdateLibraryGameReview - Successfully updates every copy", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		rating := 4
+		review := models.GameReviewToSave{
+			GameID:         gameID,
+			PersonalRating: &rating,
+			Review:         "Great soundtrack",
+			PrivateNotes:   "Lent the manual to Sam",
+		}
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, &rating, "Great soundtrack", "Lent the manual to Sam").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		// Execute
+		err = adapter.UpdateLibraryGameReview(context.Background(), userID, review)
+
+		// Verify
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a review for a game the user does not own
+		WHEN the adapter updates the review
+		THEN ErrGameNotFound is returned
+	*/
+	t.Run("UpdateLibraryGameReview - Returns not found when no copies exist", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		review := models.GameReviewToSave{GameID: gameID}
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, nil, "", "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// Execute
+		err = adapter.UpdateLibraryGameReview(context.Background(), userID, review)
+
+		// Verify
+		if !errors.Is(err, ErrGameNotFound) {
+			t.Errorf("Expected ErrGameNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a game in the user's library
+		WHEN the adapter marks it as a favorite
+		THEN every copy of the game is updated
+	*/
+	t.Run("SetLibraryGameFavorite - Successfully updates every copy", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, true).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		// Execute
+		err = adapter.SetLibraryGameFavorite(context.Background(), userID, gameID, true)
+
+		// Verify
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a game the user does not own
+		WHEN the adapter unmarks it as a favorite
+		THEN ErrGameNotFound is returned
+	*/
+	t.Run("SetLibraryGameFavorite - Returns not found when no copies exist", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		mock.ExpectExec("UPDATE user_games").
+			WithArgs(userID, gameID, false).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// Execute
+		err = adapter.SetLibraryGameFavorite(context.Background(), userID, gameID, false)
+
+		// Verify
+		if !errors.Is(err, ErrGameNotFound) {
+			t.Errorf("Expected ErrGameNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a second page request sorted by name with a platform filter
+		WHEN the adapter queries the library
+		THEN unset filters are passed as NULL and one extra row is requested
+	*/
+	t.Run("QueryLibraryGames - Returns a page with unset filters passed as NULL", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		afterValue := "metroid"
+		afterID := int64(99)
+		query := models.LibraryQuery{
+			SortBy:         LibrarySortName,
+			Limit:          2,
+			PlatformIDs:    []int64{48},
+			AfterSortValue: &afterValue,
+			AfterGameID:    &afterID,
+		}
+		dateAdded := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectQuery("SELECT (.+) FROM \\(").
+			WithArgs(userID, sqlmock.AnyArg(), nil, nil, nil, nil, nil, &afterValue, &afterID, 3, nil).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "cover_url", "first_release_date", "rating", "favorite", "play_status", "personal_rating",
+				"date_added", "total_physical_versions", "total_digital_versions", "genre_names", "sort_value",
+			}).
+				AddRow(gameID, "Okami", "", int64(1145836800), 90.5, true, "completed", int64(5), dateAdded, 1, 0, "{Adventure}", "okami").
+				AddRow(int64(124), "Persona 5", "", int64(1474502400), 93.0, false, "backlog", nil, dateAdded, 0, 1, "{}", "persona 5"))
+
+		// Execute
+		games, err := adapter.QueryLibraryGames(context.Background(), userID, query)
+
+		// Verify
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(games) != 2 {
+			t.Fatalf("Expected 2 games, got %d", len(games))
+		}
+		if games[0].SortValue != "okami" || len(games[0].GenreNames) != 1 {
+			t.Errorf("Expected sort value and genres to be scanned, got %+v", games[0])
+		}
+		if games[0].PersonalRating.Int64 != 5 || games[1].PersonalRating.Valid {
+			t.Errorf("Expected personal ratings 5 and unset, got %+v and %+v", games[0].PersonalRating, games[1].PersonalRating)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a search with no filters
+		WHEN the adapter searches the library
+		THEN unset filters are passed as NULL along with the headline options
+	*/
+	t.Run("SearchLibraryGames - Returns ranked matches with unset filters passed as NULL", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		search := models.LibrarySearch{Query: "zelda", Limit: 20}
+
+		mock.ExpectQuery("WITH search AS").
+			WithArgs(userID, "zelda", nil, nil, nameHeadlineOptions, summaryHeadlineOptions, 20).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "name", "cover_url", "first_release_date", "rating", "favorite", "play_status",
+				"total_physical_versions", "total_digital_versions", "platform_names",
+				"name_highlight", "summary_highlight", "text_match", "score",
+			}).
+				AddRow(gameID, "The Legend of Zelda", "", int64(509328000), 88.0, false, "backlog",
+					1, 0, "{NES}", "The Legend of \x01Zelda\x02", "", true, 1.1))
+
+		// Execute
+		results, err := adapter.SearchLibraryGames(context.Background(), userID, search)
+
+		// Verify
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].ID != gameID {
+			t.Fatalf("Expected game %d, got %+v", gameID, results)
+		}
+		if len(results[0].PlatformNames) != 1 || !results[0].TextMatch {
+			t.Errorf("Expected platform names and text match to be scanned, got %+v", results[0])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a game added along with its IGDB genres and themes
+		WHEN the adapter adds the game to the user's library
+		THEN the genres and themes are saved and linked to the game before the copy is added
+	*/
+	t.Run("CreateLibraryGame - Saves the game's genres and themes", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		gameToSave := models.GameToSave{
+			GameID:     gameID,
+			GameName:   "Test Game",
+			GameGenres: []models.GameMetadataTerm{{ID: 12, Name: "Role-playing (RPG)"}},
+			GameThemes: []models.GameMetadataTerm{{ID: 17, Name: "Fantasy"}},
+			PlatformLocations: []models.GameToSaveLocation{
+				{
+					PlatformID:   130,
+					PlatformName: "Nintendo Switch",
+					Type:         "digital",
+					Location: models.GameToSaveLocationDetails{
+						DigitalLocationID: "eshop",
+					},
+				},
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO games").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO genres").
+			WithArgs(pq.Array([]int64{12}), pq.Array([]string{"Role-playing (RPG)"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO game_genres").
+			WithArgs(gameID, pq.Array([]int64{12})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO themes").
+			WithArgs(pq.Array([]int64{17}), pq.Array([]string{"Fantasy"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO game_themes").
+			WithArgs(gameID, pq.Array([]int64{17})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO platforms").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("INSERT INTO user_games").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO digital_game_locations").
+			WithArgs(1, "eshop").
 			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		// Execute
-		err = adapter.UpdateLibraryGame(context.Background(), gameToSave)
+		err = adapter.CreateLibraryGame(context.Background(), userID, gameToSave)
 
 		// Verify
 		if err != nil {
@@ -405,4 +972,38 @@ func TestLibraryDbAdapter(t *testing.T) {
 			t.Errorf("Unfulfilled expectations: %v", err)
 		}
 	})
-}
\ No newline at end of file
+
+	/*
+		GIVEN a user whose games have genres
+		WHEN the adapter lists the library's genres
+		THEN each genre is returned with how many of the user's games have it
+	*/
+	t.Run("GetLibraryGenres - Returns genres with game counts", func(t *testing.T) {
+		// Setup
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Error setting up mock DB: %v", err)
+		}
+		defer adapter.db.Close()
+
+		mock.ExpectQuery("SELECT gen.id, gen.name, COUNT\\(DISTINCT ug.game_id\\) AS game_count").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "game_count"}).
+				AddRow(12, "Role-playing (RPG)", 4).
+				AddRow(31, "Adventure", 2))
+
+		// Execute
+		genres, err := adapter.GetLibraryGenres(context.Background(), userID)
+
+		// Verify
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(genres) != 2 || genres[0].ID != 12 || genres[0].GameCount != 4 {
+			t.Errorf("Expected 2 genres led by RPG with 4 games, got %+v", genres)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -2,8 +2,10 @@ package library
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -38,12 +40,20 @@ func RegisterLibraryRoutes(
 	analyticsService analytics.Service,
 ) {
 	// Base routes
+	r.Get("/", QueryLibraryGames(appCtx, libraryService))
+	r.Get("/search", SearchLibraryGames(appCtx, libraryService))
+	r.Get("/genres", GetLibraryGenres(appCtx, libraryService))
+	r.Get("/themes", GetLibraryThemes(appCtx, libraryService))
 	r.Post("/", CreateLibraryGame(appCtx, libraryService, analyticsService))
 
 	// Nested routes with ID
 	r.Route("/games/{gameID}", func(r chi.Router) {
 		r.Put("/", UpdateLibraryGame(appCtx, libraryService, analyticsService))
 		r.Delete("/", DeleteGameFromLibrary(appCtx, libraryService, analyticsService))
+		r.Put("/play-status", UpdateLibraryGamePlayStatus(appCtx, libraryService, analyticsService))
+		r.Put("/review", UpdateLibraryGameReview(appCtx, libraryService))
+		r.Put("/favorite", SetLibraryGameFavorite(appCtx, libraryService, true))
+		r.Delete("/favorite", SetLibraryGameFavorite(appCtx, libraryService, false))
 	})
 
 	// BFF route
@@ -67,6 +77,271 @@ func handleError(
 	)
 }
 
+// helper fn to read optional BFF filters from the query string
+// e.g. /library/bff?condition=sealed,mint&has_manual=true&play_status=backlog,playing&favorite=true
+func parseLibraryBFFFilters(r *http.Request) (types.LibraryBFFFilters, error) {
+	query := r.URL.Query()
+	filters := types.LibraryBFFFilters{
+		Conditions:   parseListParam(query.Get("condition")),
+		PlayStatuses: parseListParam(query.Get("play_status")),
+	}
+
+	hasOriginalCase, err := parseOptionalBoolParam(query.Get("has_original_case"))
+	if err != nil {
+		return types.LibraryBFFFilters{}, fmt.Errorf("%w: has_original_case must be true or false", ErrValidationFailed)
+	}
+	filters.HasOriginalCase = hasOriginalCase
+
+	hasManual, err := parseOptionalBoolParam(query.Get("has_manual"))
+	if err != nil {
+		return types.LibraryBFFFilters{}, fmt.Errorf("%w: has_manual must be true or false", ErrValidationFailed)
+	}
+	filters.HasManual = hasManual
+
+	favorite, err := parseOptionalBoolParam(query.Get("favorite"))
+	if err != nil {
+		return types.LibraryBFFFilters{}, fmt.Errorf("%w: favorite must be true or false", ErrValidationFailed)
+	}
+	filters.Favorite = favorite
+
+	return filters, nil
+}
+
+// parseListParam splits a comma separated query param into lowercase values
+func parseListParam(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// helper fn to read paging, sort and filter params for the library query endpoint
+// e.g. /library?sort=rating&order=desc&limit=25&platform_id=48,167&favorite=true&tag_id=...&cursor=...
+func parseLibraryQueryRequest(r *http.Request) (types.LibraryQueryRequest, error) {
+	query := r.URL.Query()
+	request := types.LibraryQueryRequest{
+		Cursor:     query.Get("cursor"),
+		SortBy:     strings.ToLower(strings.TrimSpace(query.Get("sort"))),
+		SortOrder:  strings.ToLower(strings.TrimSpace(query.Get("order"))),
+		GameType:   strings.ToLower(strings.TrimSpace(query.Get("game_type"))),
+		LocationID: strings.TrimSpace(query.Get("location_id")),
+		TagIDs:     parseListParam(query.Get("tag_id")),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 {
+			return types.LibraryQueryRequest{}, fmt.Errorf("%w: limit must be a positive number", ErrValidationFailed)
+		}
+		request.Limit = parsedLimit
+	}
+
+	var err error
+	if request.PlatformIDs, err = parseInt64ListParam(query.Get("platform_id")); err != nil {
+		return types.LibraryQueryRequest{}, fmt.Errorf("%w: platform_id must be a list of numbers", ErrValidationFailed)
+	}
+	if request.GenreIDs, err = parseInt64ListParam(query.Get("genre_id")); err != nil {
+		return types.LibraryQueryRequest{}, fmt.Errorf("%w: genre_id must be a list of numbers", ErrValidationFailed)
+	}
+	if request.ThemeIDs, err = parseInt64ListParam(query.Get("theme_id")); err != nil {
+		return types.LibraryQueryRequest{}, fmt.Errorf("%w: theme_id must be a list of numbers", ErrValidationFailed)
+	}
+	if request.Favorite, err = parseOptionalBoolParam(query.Get("favorite")); err != nil {
+		return types.LibraryQueryRequest{}, fmt.Errorf("%w: favorite must be true or false", ErrValidationFailed)
+	}
+
+	return request, nil
+}
+
+// parseInt64ListParam splits a comma separated query param into numbers
+func parseInt64ListParam(value string) ([]int64, error) {
+	var values []int64
+	for _, item := range parseListParam(value) {
+		parsed, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
+func parseOptionalBoolParam(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// QueryLibraryGames handles GET requests for a sorted, filtered page of the library
+func QueryLibraryGames(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		queryRequest, err := parseLibraryQueryRequest(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		page, err := libraryService.QueryLibraryGames(r.Context(), userID, queryRequest)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"library": page,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// parseLibrarySearchRequest reads ?q= along with the limit, platform_id and location_id params
+func parseLibrarySearchRequest(r *http.Request) (types.LibrarySearchRequest, error) {
+	query := r.URL.Query()
+	request := types.LibrarySearchRequest{
+		Query:      query.Get("q"),
+		LocationID: strings.TrimSpace(query.Get("location_id")),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 {
+			return types.LibrarySearchRequest{}, fmt.Errorf("%w: limit must be a positive number", ErrValidationFailed)
+		}
+		request.Limit = parsedLimit
+	}
+
+	var err error
+	if request.PlatformIDs, err = parseInt64ListParam(query.Get("platform_id")); err != nil {
+		return types.LibrarySearchRequest{}, fmt.Errorf("%w: platform_id must be a list of numbers", ErrValidationFailed)
+	}
+
+	return request, nil
+}
+
+// SearchLibraryGames handles GET requests that search the user's own library
+func SearchLibraryGames(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		searchRequest, err := parseLibrarySearchRequest(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		results, err := libraryService.SearchLibraryGames(r.Context(), userID, searchRequest)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"search": results,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetLibraryGenres handles GET requests for the genres of the user's games
+func GetLibraryGenres(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+) http.HandlerFunc {
+	return getLibraryGameTerms(appCtx, "genres", libraryService.GetLibraryGenres)
+}
+
+// GetLibraryThemes handles GET requests for the themes of the user's games
+func GetLibraryThemes(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+) http.HandlerFunc {
+	return getLibraryGameTerms(appCtx, "themes", libraryService.GetLibraryThemes)
+}
+
+// helper fn to respond with genre or theme counts under the given key
+func getLibraryGameTerms(
+	appCtx *appcontext.AppContext,
+	key string,
+	getTerms func(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		terms, err := getTerms(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			key: terms,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
 // GetAllLibraryItemsBFF handles GET requests for the /library/bff page
 func GetAllLibraryItemsBFF(
 	appCtx *appcontext.AppContext,
@@ -89,8 +364,14 @@ func GetAllLibraryItemsBFF(
 			"userID":    userID,
 		})
 
+		filters, err := parseLibraryBFFFilters(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
 		//libraryItemsPayload, err := libraryService.GetAllLibraryItemsBFF(r.Context(), userID)
-		libraryItemsPayload, err := libraryService.GetLibraryRefactoredBFFResponse(r.Context(), userID)
+		libraryItemsPayload, err := libraryService.GetLibraryRefactoredBFFResponse(r.Context(), userID, filters)
 		if err != nil {
 			handleError(w, appCtx.Logger, requestID, err)
 			return
@@ -249,7 +530,7 @@ func UpdateLibraryGame(
 		libraryGame.GameID = gameIDint64
 
 		// Get the current game state
-		game, err := libraryService.GetSingleLibraryGame(
+		currentGame, err := libraryService.GetSingleLibraryGame(
 			r.Context(),
 			userID,
 			gameIDint64,
@@ -259,12 +540,27 @@ func UpdateLibraryGame(
 			return
 		}
 
+		// Update requests only carry versions, keep the game details we already have
+		libraryGame.GameName = currentGame.Name
+		libraryGame.GameCoverURL = currentGame.CoverURL
+
 		// Update the game
 		if err := libraryService.UpdateLibraryGame(r.Context(), userID, libraryGame); err != nil {
 			handleError(w, appCtx.Logger, requestID, err)
 			return
 		}
 
+		// Get the updated game state
+		game, err := libraryService.GetSingleLibraryGame(
+			r.Context(),
+			userID,
+			gameIDint64,
+		)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
 		// Invalidate analytics cache for inventory domain
 		if err := analyticsService.InvalidateDomain(r.Context(), userID, analytics.DomainInventory); err != nil {
 			appCtx.Logger.Warn("Failed to invalidate analytics cache", map[string]any{
@@ -289,6 +585,188 @@ func UpdateLibraryGame(
 	}
 }
 
+// UpdateLibraryGamePlayStatus handles PUT requests for setting the play status of a library game
+func UpdateLibraryGamePlayStatus(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+	analyticsService analytics.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID := httputils.GetUserID(r)
+
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := strconv.ParseInt(chi.URLParam(r, "gameID"), 10, 64)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid ID: must be a number", ErrValidationFailed))
+			return
+		}
+
+		appCtx.Logger.Info("Updating library game play status", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+		})
+
+		var playStatusRequest types.UpdateLibraryGamePlayStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&playStatusRequest); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		requestAdapter := NewLibraryRequestAdapter()
+		playStatus := requestAdapter.AdaptPlayStatusRequestToModel(playStatusRequest)
+		playStatus.GameID = gameID
+
+		result, err := libraryService.UpdateLibraryGamePlayStatus(r.Context(), userID, playStatus)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		// Backlog size and completion rate live in the general analytics domain
+		if err := analyticsService.InvalidateDomain(r.Context(), userID, analytics.DomainGeneral); err != nil {
+			appCtx.Logger.Warn("Failed to invalidate analytics cache", map[string]any{
+				"requestID": requestID,
+				"userID":    userID,
+				"error":     err,
+			})
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"library": map[string]any{
+				"playStatus": result,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// UpdateLibraryGameReview handles PUT requests for the user's own rating, review and private notes on a library game
+func UpdateLibraryGameReview(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID := httputils.GetUserID(r)
+
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := strconv.ParseInt(chi.URLParam(r, "gameID"), 10, 64)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid ID: must be a number", ErrValidationFailed))
+			return
+		}
+
+		appCtx.Logger.Info("Updating library game review", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+		})
+
+		var reviewRequest types.UpdateLibraryGameReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&reviewRequest); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		requestAdapter := NewLibraryRequestAdapter()
+		review := requestAdapter.AdaptReviewRequestToModel(reviewRequest)
+		review.GameID = gameID
+
+		result, err := libraryService.UpdateLibraryGameReview(r.Context(), userID, review)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"library": map[string]any{
+				"review": result,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// SetLibraryGameFavorite handles PUT (favorite) and DELETE (unfavorite) requests for a library game
+func SetLibraryGameFavorite(
+	appCtx *appcontext.AppContext,
+	libraryService services.LibraryService,
+	favorite bool,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID := httputils.GetUserID(r)
+
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := strconv.ParseInt(chi.URLParam(r, "gameID"), 10, 64)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid ID: must be a number", ErrValidationFailed))
+			return
+		}
+
+		appCtx.Logger.Info("Setting library game favorite", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+			"favorite":  favorite,
+		})
+
+		result, err := libraryService.SetLibraryGameFavorite(r.Context(), userID, gameID, favorite)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"library": map[string]any{
+				"favorite": result,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
 // DeleteGameFromLibrary handles DELETE requests for deleting a game from the library
 // Supports both single game deletion (no request body) and batch version deletion (with request body)
 func DeleteGameFromLibrary(
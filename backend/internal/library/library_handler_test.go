@@ -52,7 +52,7 @@ type MockLibraryService struct {
 	InvalidateUserCacheError error
 }
 
-func (m *MockLibraryService) GetLibraryRefactoredBFFResponse(ctx context.Context, userID string) (types.LibraryBFFRefactoredResponse, error) {
+func (m *MockLibraryService) GetLibraryRefactoredBFFResponse(ctx context.Context, userID string, filters types.LibraryBFFFilters) (types.LibraryBFFRefactoredResponse, error) {
 	return m.GetLibraryRefactoredBFFResponseResult, m.GetLibraryRefactoredBFFResponseError
 }
 
@@ -82,6 +82,34 @@ func (m *MockLibraryService) UpdateLibraryGame(ctx context.Context, userID strin
 	return nil
 }
 
+func (m *MockLibraryService) UpdateLibraryGamePlayStatus(ctx context.Context, userID string, status models.GamePlayStatusToSave) (types.LibraryGamePlayStatusResponse, error) {
+	return types.LibraryGamePlayStatusResponse{}, nil
+}
+
+func (m *MockLibraryService) QueryLibraryGames(ctx context.Context, userID string, request types.LibraryQueryRequest) (types.LibraryQueryResponse, error) {
+	return types.LibraryQueryResponse{}, nil
+}
+
+func (m *MockLibraryService) SearchLibraryGames(ctx context.Context, userID string, request types.LibrarySearchRequest) (types.LibrarySearchResponse, error) {
+	return types.LibrarySearchResponse{}, nil
+}
+
+func (m *MockLibraryService) GetLibraryGenres(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (m *MockLibraryService) GetLibraryThemes(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (m *MockLibraryService) UpdateLibraryGameReview(ctx context.Context, userID string, review models.GameReviewToSave) (types.LibraryGameReviewResponse, error) {
+	return types.LibraryGameReviewResponse{}, nil
+}
+
+func (m *MockLibraryService) SetLibraryGameFavorite(ctx context.Context, userID string, gameID int64, favorite bool) (types.LibraryGameFavoriteResponse, error) {
+	return types.LibraryGameFavoriteResponse{}, nil
+}
+
 func (m *MockLibraryService) DeleteGameVersions(ctx context.Context, userID string, gameID int64, request types.BatchDeleteLibraryGameRequest) (types.BatchDeleteLibraryGameResponse, error) {
 	return types.BatchDeleteLibraryGameResponse{}, nil
 }
@@ -4,7 +4,7 @@ const (
 	CheckIfGameIsInLibraryQuery = `
 		SELECT EXISTS(
 			SELECT 1 FROM user_games
-			WHERE user_id = $1 AND game_id = $2
+			WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
 		)
 	`
 
@@ -19,7 +19,7 @@ const (
 			ug.created_at
 		FROM games g
 		JOIN user_games ug ON g.id = ug.game_id
-		WHERE ug.user_id = $1 AND g.id = $2
+		WHERE ug.user_id = $1 AND g.id = $2 AND ug.deleted_at IS NULL
 		ORDER BY g.id, ug.id
 	`
 
@@ -34,15 +34,17 @@ const (
 			ug.created_at
 		FROM games g
 		JOIN user_games ug ON g.id = ug.game_id
-		WHERE ug.user_id = $1
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
 		ORDER BY g.id, ug.id
 	`
 
 	GetLibraryLocationsQuery = `
 		SELECT
+			ug.id as user_game_id,
 			ug.game_id,
 			p.id as platform_id,
 			p.name as platform_name,
+			COALESCE(p.platform_group, p.name) as platform_group,
 			p.category,
 			ug.created_at,
 			pl.id as parent_location_id,
@@ -51,43 +53,221 @@ const (
 			pl.bg_color as parent_location_bg_color,
 			sl.id as sublocation_id,
 			sl.name as sublocation_name,
-			sl.location_type as sublocation_type
+			sl.location_type as sublocation_type,
+			ug.condition,
+			ug.has_original_case,
+			ug.has_manual,
+			ug.acquired_date,
+			ug.copy_notes,
+			EXISTS (
+				SELECT 1 FROM game_loans gl
+				WHERE gl.user_game_id = ug.id AND gl.returned_at IS NULL
+			) as is_on_loan
 		FROM user_games ug
 		JOIN platforms p ON ug.platform_id = p.id
 		LEFT JOIN physical_game_locations pgl ON ug.id = pgl.user_game_id
 		LEFT JOIN sublocations sl ON pgl.sublocation_id = sl.id
 		LEFT JOIN physical_locations pl ON sl.physical_location_id = pl.id
-		WHERE ug.user_id = $1
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+	`
+
+	// Genres and themes come from IGDB with the game, names we already have are kept as the metadata refresh keeps them current
+	EnsureGenresExistQuery = `
+		INSERT INTO genres (id, name)
+		SELECT * FROM UNNEST($1::BIGINT[], $2::TEXT[])
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	EnsureThemesExistQuery = `
+		INSERT INTO themes (id, name)
+		SELECT * FROM UNNEST($1::BIGINT[], $2::TEXT[])
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	LinkGameGenresQuery = `
+		INSERT INTO game_genres (game_id, genre_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+		ON CONFLICT (game_id, genre_id) DO NOTHING
+	`
+
+	LinkGameThemesQuery = `
+		INSERT INTO game_themes (game_id, theme_id)
+		SELECT $1, UNNEST($2::BIGINT[])
+		ON CONFLICT (game_id, theme_id) DO NOTHING
+	`
+
+	EnsurePlatformExistsQuery = `
+		INSERT INTO platforms (id, name, category, model)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	// Play status, rating, review, private notes and favorite are shared by every copy of a game, so a new copy takes them from the user's oldest active copy.
+	// Wishlist purchases add copies through this query too, library edits always add copy FirstCopyNumber.
+	InsertUserGameCopyQuery = `
+		WITH shared AS (
+			SELECT play_status, started_at, finished_at, completion_note, personal_rating, review, private_notes, favorite
+			FROM user_games
+			WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
+			ORDER BY created_at, id
+			LIMIT 1
+		)
+		INSERT INTO user_games (
+			user_id,
+			game_id,
+			platform_id,
+			game_type,
+			condition,
+			has_original_case,
+			has_manual,
+			acquired_date,
+			copy_notes,
+			copy_number,
+			play_status,
+			started_at,
+			finished_at,
+			completion_note,
+			personal_rating,
+			review,
+			private_notes,
+			favorite
+		)
+		VALUES (
+			$1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, NULLIF($9, ''), $10,
+			COALESCE((SELECT play_status FROM shared), 'backlog'),
+			(SELECT started_at FROM shared),
+			(SELECT finished_at FROM shared),
+			(SELECT completion_note FROM shared),
+			(SELECT personal_rating FROM shared),
+			(SELECT review FROM shared),
+			(SELECT private_notes FROM shared),
+			COALESCE((SELECT favorite FROM shared), false)
+		)
+		RETURNING id
+	`
+
+	GetUserGameCopyIDQuery = `
+		SELECT id FROM user_games
+		WHERE user_id = $1 AND game_id = $2 AND platform_id = $3 AND game_type = $4
+		AND deleted_at IS NULL
+		ORDER BY copy_number
+		LIMIT 1
 	`
 
-	UpdateLibraryGameQuery = `
-		UPDATE games
-		SET name = $2,
-				cover_url = $3,
-				game_type_display_text = $4,
-				game_type_normalized_text = $5
+	UpdateUserGameCopyDetailsQuery = `
+		UPDATE user_games
+		SET condition = NULLIF($2, ''),
+			has_original_case = $3,
+			has_manual = $4,
+			acquired_date = $5,
+			copy_notes = NULLIF($6, '')
 		WHERE id = $1
 	`
 
-	DeleteLibraryLocationsQuery = `
+	DeleteUserGamePhysicalLocationQuery = `
 		DELETE FROM physical_game_locations
-		WHERE user_game_id IN (
-			SELECT id FROM user_games WHERE game_id = $1
-		)
+		WHERE user_game_id = $1
 	`
 
-	// Note: Due to ON DELETE CASCADE in our schema, this will automatically
-	// remove related records from physical_game_locations and digital_game_locations
-	CascadingDeleteLibraryGameQuery = `
-		DELETE FROM user_games
-		WHERE user_id = $1 AND game_id = $2
+	DeleteUserGameDigitalLocationQuery = `
+		DELETE FROM digital_game_locations
+		WHERE user_game_id = $1
 	`
 
-	InsertLibraryLocationQuery = `
+	// Locations that aren't the user's insert nothing, the caller checks the row count
+	InsertUserGamePhysicalLocationQuery = `
 		INSERT INTO physical_game_locations (user_game_id, sublocation_id)
-		SELECT id, $5
-		FROM user_games
-		WHERE game_id = $1 AND platform_id = $2 AND platform_name = $3 AND game_type = $4
+		SELECT $1, s.id
+		FROM sublocations s
+		WHERE s.id = $2 AND s.user_id = $3 AND s.deleted_at IS NULL
+	`
+
+	InsertUserGameDigitalLocationQuery = `
+		INSERT INTO digital_game_locations (user_game_id, digital_location_id)
+		SELECT $1, dl.id
+		FROM digital_locations dl
+		WHERE dl.id = $2 AND dl.user_id = $3
+	`
+
+	// Links to trashed purchases are kept so restoring the purchase restores the link
+	DeleteUserGamePurchasesQuery = `
+		DELETE FROM one_time_purchase_games otpg
+		USING one_time_purchases otp
+		WHERE otpg.user_game_id = $1
+		AND otp.id = otpg.purchase_id
+		AND otp.deleted_at IS NULL
+	`
+
+	// Purchases that aren't the user's are skipped, the caller compares the row count
+	LinkUserGamePurchasesQuery = `
+		INSERT INTO one_time_purchase_games (purchase_id, user_game_id)
+		SELECT otp.id, $1
+		FROM one_time_purchases otp
+		WHERE otp.user_id = $2 AND otp.id = ANY($3::int[]) AND otp.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`
+
+	// Each purchase is split evenly between the copies it's linked to, trashed copies and purchases don't count
+	GetLibraryGameCostBasisQuery = `
+		SELECT
+			otpg.user_game_id,
+			ROUND(SUM(otp.amount / link_counts.copies), 2) as cost_basis,
+			ARRAY_AGG(otp.id ORDER BY otp.id) as purchase_ids
+		FROM one_time_purchase_games otpg
+		JOIN one_time_purchases otp ON otp.id = otpg.purchase_id
+		JOIN (
+			SELECT link.purchase_id, COUNT(*) as copies
+			FROM one_time_purchase_games link
+			JOIN user_games linked ON linked.id = link.user_game_id
+			WHERE linked.deleted_at IS NULL
+			GROUP BY link.purchase_id
+		) link_counts ON link_counts.purchase_id = otpg.purchase_id
+		JOIN user_games ug ON ug.id = otpg.user_game_id
+		WHERE ug.user_id = $1 AND ug.game_id = $2
+		AND ug.deleted_at IS NULL
+		AND otp.deleted_at IS NULL
+		GROUP BY otpg.user_game_id
+	`
+
+	// Copies dropped from an update go to the trash like any other removed copy
+	TrashRemovedUserGameVersionsQuery = `
+		UPDATE user_games
+		SET deleted_at = NOW()
+		WHERE user_id = $1 AND game_id = $2 AND NOT (id = ANY($3))
+		AND deleted_at IS NULL
+	`
+
+	// Play status is shared by every copy of a game, so update all of the user's rows for it
+	UpdateUserGamePlayStatusQuery = `
+		UPDATE user_games
+		SET play_status = $3,
+			started_at = $4,
+			finished_at = $5,
+			completion_note = NULLIF($6, '')
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
+	`
+
+	// Favorite is shared by every copy of a game
+	UpdateUserGameFavoriteQuery = `
+		UPDATE user_games
+		SET favorite = $3
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
+	`
+
+	// Rating, review and private notes are shared by every copy of a game, like play status
+	UpdateUserGameReviewQuery = `
+		UPDATE user_games
+		SET personal_rating = $3,
+			review = NULLIF($4, ''),
+			private_notes = NULLIF($5, '')
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
+	`
+
+	// Trashed copies keep their location, purchase, tag and loan rows so a restore can bring them back
+	TrashLibraryGameQuery = `
+		UPDATE user_games
+		SET deleted_at = NOW()
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
 	`
 
 	GetPhysicalLocationsRefactoredQuery = `
@@ -95,6 +275,7 @@ const (
 				ug.game_id,
 				p.id as platform_id,
 				p.name as platform_name,
+				COALESCE(p.platform_group, p.name) as platform_group,
 				p.category,
 				ug.created_at,
 				pl.id as parent_location_id,
@@ -103,13 +284,22 @@ const (
 				pl.bg_color as parent_location_bg_color,
 				sl.id as sublocation_id,
 				sl.name as sublocation_name,
-				sl.location_type as sublocation_type
+				sl.location_type as sublocation_type,
+				ug.condition,
+				ug.has_original_case,
+				ug.has_manual,
+				ug.acquired_date,
+				ug.copy_notes,
+				EXISTS (
+					SELECT 1 FROM game_loans gl
+					WHERE gl.user_game_id = ug.id AND gl.returned_at IS NULL
+				) as is_on_loan
 		FROM user_games ug
 		JOIN platforms p ON ug.platform_id = p.id
 		LEFT JOIN physical_game_locations pgl ON ug.id = pgl.user_game_id
 		LEFT JOIN sublocations sl ON pgl.sublocation_id = sl.id
 		LEFT JOIN physical_locations pl ON sl.physical_location_id = pl.id
-		WHERE ug.user_id = $1 AND ug.game_type = 'physical'
+		WHERE ug.user_id = $1 AND ug.game_type = 'physical' AND ug.deleted_at IS NULL
 		ORDER BY ug.game_id, p.id
 	`
 
@@ -118,15 +308,17 @@ const (
 				ug.game_id,
 				p.id as platform_id,
 				p.name as platform_name,
+				COALESCE(p.platform_group, p.name) as platform_group,
 				p.category,
 				ug.created_at,
 				dl.id as digital_location_id,
-				dl.name as digital_location_name
+				dl.name as digital_location_name,
+				ug.copy_notes
 		FROM user_games ug
 		JOIN platforms p ON ug.platform_id = p.id
 		LEFT JOIN digital_game_locations dgl ON ug.id = dgl.user_game_id
 		LEFT JOIN digital_locations dl ON dgl.digital_location_id = dl.id
-		WHERE ug.user_id = $1 AND ug.game_type = 'digital'
+		WHERE ug.user_id = $1 AND ug.game_type = 'digital' AND ug.deleted_at IS NULL
 		ORDER BY ug.game_id, p.id
 	`
 
@@ -141,14 +333,21 @@ const (
 					LOWER(ug.game_type) as game_type_normalized_text,
 					ug.favorite as favorite,
 					ug.created_at,
+					ug.play_status,
+					ug.started_at,
+					ug.finished_at,
+					ug.completion_note,
+					ug.personal_rating,
+					ug.review,
+					ug.private_notes,
 					EXISTS(SELECT 1 FROM wishlist w WHERE w.user_id = $1 AND w.game_id = g.id) as is_in_wishlist,
 					COALESCE(ARRAY_AGG(DISTINCT gen.name) FILTER (WHERE gen.name IS NOT NULL), ARRAY[]::text[]) as genre_names
 			FROM games g
 			JOIN user_games ug ON g.id = ug.game_id
 			LEFT JOIN game_genres gg ON g.id = gg.game_id
 			LEFT JOIN genres gen ON gg.genre_id = gen.id
-			WHERE ug.user_id = $1
-			GROUP BY g.id, g.name, g.cover_url, g.first_release_date, g.rating, ug.game_type, ug.favorite, ug.created_at, ug.id
+			WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+			GROUP BY g.id, g.name, g.cover_url, g.first_release_date, g.rating, ug.game_type, ug.favorite, ug.created_at, ug.play_status, ug.started_at, ug.finished_at, ug.completion_note, ug.personal_rating, ug.review, ug.private_notes, ug.id
 			ORDER BY g.id, ug.id
 	`
 
@@ -166,14 +365,16 @@ const (
 		JOIN platforms p ON ug.platform_id = p.id
 		LEFT JOIN physical_game_locations pgl ON ug.id = pgl.user_game_id
 		LEFT JOIN digital_game_locations dgl ON ug.id = dgl.user_game_id
-		WHERE ug.user_id = $1 AND ug.game_id = $2
+		WHERE ug.user_id = $1 AND ug.game_id = $2 AND ug.deleted_at IS NULL
 	`
 
-	// Delete specific user_game entries (this will cascade to location tables)
-	DeleteSpecificGameVersionsQuery = `
-		DELETE FROM user_games
+	// Trash specific user_game entries, their location rows are kept for a restore
+	TrashSpecificGameVersionsQuery = `
+		UPDATE user_games
+		SET deleted_at = NOW()
 		WHERE user_id = $1
 		AND game_id = $2
+		AND deleted_at IS NULL
 		AND platform_id = ANY($3)
 		AND (
 			(game_type = 'physical' AND id IN (
@@ -194,6 +395,171 @@ const (
 	CountDeletedGameVersionsQuery = `
 		SELECT COUNT(*)
 		FROM user_games
-		WHERE user_id = $1 AND game_id = $2
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
+	`
+)
+
+// Paginated library query
+// Rendered with fmt.Sprintf using a whitelisted librarySortOption, never with user input:
+// %[1]s sort expression, %[2]s sort value type, %[3]s keyset comparator, %[4]s sort direction
+//
+// Optional filters are passed as NULL when unset:
+// $2 platform IDs, $3 genre IDs, $4 theme IDs, $5 game type, $6 location ID, $7 favorite
+// $8 and $9 are the sort value and game ID of the last item on the previous page, $10 the page size
+// $11 tag IDs, a game matches when any of its copies has one of the tags
+const QueryLibraryGamesTemplate = `
+	SELECT
+		g.id,
+		g.name,
+		COALESCE(g.cover_url, '') as cover_url,
+		COALESCE(g.first_release_date, 0) as first_release_date,
+		COALESCE(g.rating, 0) as rating,
+		lg.favorite,
+		lg.play_status,
+		lg.personal_rating,
+		lg.date_added,
+		lg.total_physical_versions,
+		lg.total_digital_versions,
+		COALESCE(
+			(SELECT ARRAY_AGG(gen.name ORDER BY gen.name)
+			FROM game_genres gg
+			JOIN genres gen ON gg.genre_id = gen.id
+			WHERE gg.game_id = g.id),
+			ARRAY[]::text[]
+		) as genre_names,
+		(%[1]s)::text as sort_value
+	FROM (
+		SELECT
+			ug.game_id,
+			MIN(ug.created_at) as date_added,
+			BOOL_OR(ug.favorite) as favorite,
+			(ARRAY_AGG(ug.play_status ORDER BY ug.created_at, ug.id))[1] as play_status,
+			(ARRAY_AGG(ug.personal_rating ORDER BY ug.created_at, ug.id))[1] as personal_rating,
+			COUNT(*) FILTER (WHERE ug.game_type = 'physical') as total_physical_versions,
+			COUNT(*) FILTER (WHERE ug.game_type = 'digital') as total_digital_versions
+		FROM user_games ug
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY ug.game_id
+		HAVING ($2::bigint[] IS NULL OR BOOL_OR(ug.platform_id = ANY($2::bigint[])))
+			AND ($5::text IS NULL OR BOOL_OR(ug.game_type = $5::text))
+			AND ($7::boolean IS NULL OR BOOL_OR(ug.favorite) = $7::boolean)
+	) lg
+	JOIN games g ON g.id = lg.game_id
+	WHERE ($3::bigint[] IS NULL OR EXISTS (
+			SELECT 1 FROM game_genres gg
+			WHERE gg.game_id = g.id AND gg.genre_id = ANY($3::bigint[])
+		))
+		AND ($4::bigint[] IS NULL OR EXISTS (
+			SELECT 1 FROM game_themes gt
+			WHERE gt.game_id = g.id AND gt.theme_id = ANY($4::bigint[])
+		))
+		AND ($6::uuid IS NULL OR EXISTS (
+			SELECT 1
+			FROM user_games lug
+			LEFT JOIN physical_game_locations pgl ON lug.id = pgl.user_game_id
+			LEFT JOIN sublocations sl ON pgl.sublocation_id = sl.id
+			LEFT JOIN digital_game_locations dgl ON lug.id = dgl.user_game_id
+			WHERE lug.user_id = $1
+			AND lug.game_id = g.id
+			AND lug.deleted_at IS NULL
+			AND (
+				pgl.sublocation_id = $6::uuid
+				OR sl.physical_location_id = $6::uuid
+				OR dgl.digital_location_id = $6::uuid
+			)
+		))
+		AND ($11::uuid[] IS NULL OR EXISTS (
+			SELECT 1
+			FROM user_games tug
+			JOIN user_game_tags ugt ON ugt.user_game_id = tug.id
+			WHERE tug.user_id = $1
+			AND tug.game_id = g.id
+			AND tug.deleted_at IS NULL
+			AND ugt.tag_id = ANY($11::uuid[])
+		))
+		AND ($9::bigint IS NULL OR (%[1]s, g.id) %[3]s ($8::text::%[2]s, $9::bigint))
+	ORDER BY %[1]s %[4]s, g.id %[4]s
+	LIMIT $10
+`
+
+// SearchLibraryGamesQuery ranks the user's games against a search string ($2).
+// Full-text matches on name and summary score by ts_rank_cd, trigram similarity on the name
+// catches typos and partial titles. $5 and $6 are the ts_headline options for name and summary.
+const SearchLibraryGamesQuery = `
+	WITH search AS (
+		SELECT websearch_to_tsquery('english', $2) AS query
+	)
+	SELECT
+		g.id,
+		g.name,
+		COALESCE(g.cover_url, '') as cover_url,
+		COALESCE(g.first_release_date, 0) as first_release_date,
+		COALESCE(g.rating, 0) as rating,
+		lg.favorite,
+		lg.play_status,
+		lg.total_physical_versions,
+		lg.total_digital_versions,
+		lg.platform_names,
+		ts_headline('english', g.name, s.query, $5) as name_highlight,
+		ts_headline('english', COALESCE(g.summary, ''), s.query, $6) as summary_highlight,
+		g.search_vector @@ s.query as text_match,
+		ts_rank_cd(g.search_vector, s.query)
+			+ GREATEST(similarity(g.name, $2), word_similarity($2, g.name)) as score
+	FROM (
+		SELECT
+			ug.game_id,
+			BOOL_OR(ug.favorite) as favorite,
+			(ARRAY_AGG(ug.play_status ORDER BY ug.created_at, ug.id))[1] as play_status,
+			COUNT(*) FILTER (WHERE ug.game_type = 'physical') as total_physical_versions,
+			COUNT(*) FILTER (WHERE ug.game_type = 'digital') as total_digital_versions,
+			ARRAY_AGG(DISTINCT p.name ORDER BY p.name) as platform_names
+		FROM user_games ug
+		JOIN platforms p ON p.id = ug.platform_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY ug.game_id
+		HAVING ($3::bigint[] IS NULL OR BOOL_OR(ug.platform_id = ANY($3::bigint[])))
+	) lg
+	JOIN games g ON g.id = lg.game_id
+	CROSS JOIN search s
+	WHERE (g.search_vector @@ s.query OR g.name % $2 OR $2 <% g.name)
+		AND ($4::uuid IS NULL OR EXISTS (
+			SELECT 1
+			FROM user_games lug
+			LEFT JOIN physical_game_locations pgl ON lug.id = pgl.user_game_id
+			LEFT JOIN sublocations sl ON pgl.sublocation_id = sl.id
+			LEFT JOIN digital_game_locations dgl ON lug.id = dgl.user_game_id
+			WHERE lug.user_id = $1
+			AND lug.game_id = g.id
+			AND lug.deleted_at IS NULL
+			AND (
+				pgl.sublocation_id = $4::uuid
+				OR sl.physical_location_id = $4::uuid
+				OR dgl.digital_location_id = $4::uuid
+			)
+		))
+	ORDER BY score DESC, g.id
+	LIMIT $7
+`
+
+// Genres and themes of the user's games, a game counts once however many copies the user has
+const (
+	GetLibraryGenresQuery = `
+		SELECT gen.id, gen.name, COUNT(DISTINCT ug.game_id) AS game_count
+		FROM user_games ug
+		JOIN game_genres gg ON gg.game_id = ug.game_id
+		JOIN genres gen ON gen.id = gg.genre_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY gen.id, gen.name
+		ORDER BY game_count DESC, gen.name
+	`
+
+	GetLibraryThemesQuery = `
+		SELECT th.id, th.name, COUNT(DISTINCT ug.game_id) AS game_count
+		FROM user_games ug
+		JOIN game_themes gt ON gt.game_id = ug.game_id
+		JOIN themes th ON th.id = gt.theme_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NULL
+		GROUP BY th.id, th.name
+		ORDER BY game_count DESC, th.name
 	`
-)
\ No newline at end of file
+)
@@ -0,0 +1,83 @@
+package library
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Sort keys accepted by GET /library
+const (
+	LibrarySortName           = "name"
+	LibrarySortReleaseDate    = "release_date"
+	LibrarySortRating         = "rating"
+	LibrarySortDateAdded      = "date_added"
+	LibrarySortPersonalRating = "personal_rating"
+)
+
+const (
+	DefaultLibraryQueryLimit = 50
+	MaxLibraryQueryLimit     = 100
+)
+
+// librarySortOption is the SQL for one sort key, only ever rendered from this whitelist
+type librarySortOption struct {
+	expression string
+	valueType  string
+	// defaultDescending puts newest, highest rated first when no order is given
+	defaultDescending bool
+}
+
+var librarySortOptions = map[string]librarySortOption{
+	LibrarySortName:           {expression: "LOWER(g.name)", valueType: "text"},
+	LibrarySortReleaseDate:    {expression: "COALESCE(g.first_release_date, 0)", valueType: "bigint", defaultDescending: true},
+	LibrarySortRating:         {expression: "COALESCE(g.rating, 0)", valueType: "float8", defaultDescending: true},
+	LibrarySortDateAdded:      {expression: "lg.date_added", valueType: "timestamptz", defaultDescending: true},
+	LibrarySortPersonalRating: {expression: "COALESCE(lg.personal_rating, 0)", valueType: "integer", defaultDescending: true},
+}
+
+// renderQueryLibraryGames fills QueryLibraryGamesTemplate for a whitelisted sort
+func renderQueryLibraryGames(sortBy string, descending bool) (string, error) {
+	option, ok := librarySortOptions[sortBy]
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported sort '%s'", ErrValidationFailed, sortBy)
+	}
+
+	comparator, direction := ">", "ASC"
+	if descending {
+		comparator, direction = "<", "DESC"
+	}
+
+	return fmt.Sprintf(QueryLibraryGamesTemplate, option.expression, option.valueType, comparator, direction), nil
+}
+
+// libraryCursor is the keyset position of the last item on a page.
+// Sort and order are included so a cursor can't be replayed against a different ordering.
+type libraryCursor struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d"`
+	SortValue  string `json:"v"`
+	GameID     int64  `json:"id"`
+}
+
+func encodeLibraryCursor(cursor libraryCursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("error encoding library cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func decodeLibraryCursor(encoded string) (libraryCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return libraryCursor{}, fmt.Errorf("%w: malformed cursor", ErrValidationFailed)
+	}
+
+	var cursor libraryCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.GameID <= 0 {
+		return libraryCursor{}, fmt.Errorf("%w: malformed cursor", ErrValidationFailed)
+	}
+
+	return cursor, nil
+}
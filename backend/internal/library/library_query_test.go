@@ -0,0 +1,82 @@
+package library
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+/*
+	Behavior:
+	- Rendering the paginated library query for a sort key
+	- Encoding and decoding keyset cursors
+
+	Scenarios:
+	- Whitelisted sort renders its expression, type and direction
+	- Unknown sort is rejected
+	- Cursor survives a round trip
+	- Malformed cursor is rejected as a validation error
+*/
+
+func TestLibraryQuery(t *testing.T) {
+	t.Run("Whitelisted sort renders its expression, type and direction", func(t *testing.T) {
+		// WHEN
+		query, err := renderQueryLibraryGames(LibrarySortDateAdded, true)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(query, "(lg.date_added, g.id) < ($8::text::timestamptz, $9::bigint)") {
+			t.Errorf("Expected descending keyset condition on date added, got %s", query)
+		}
+		if !strings.Contains(query, "ORDER BY lg.date_added DESC, g.id DESC") {
+			t.Errorf("Expected descending order on date added, got %s", query)
+		}
+	})
+
+	t.Run("Unknown sort is rejected", func(t *testing.T) {
+		// WHEN
+		_, err := renderQueryLibraryGames("g.name; DROP TABLE games", false)
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+
+	t.Run("Cursor survives a round trip", func(t *testing.T) {
+		// GIVEN
+		cursor := libraryCursor{
+			SortBy:     LibrarySortName,
+			Descending: false,
+			SortValue:  "the legend of zelda",
+			GameID:     1025,
+		}
+
+		// WHEN
+		encoded, err := encodeLibraryCursor(cursor)
+		if err != nil {
+			t.Fatalf("Expected no error encoding, got %v", err)
+		}
+		decoded, err := decodeLibraryCursor(encoded)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error decoding, got %v", err)
+		}
+		if decoded != cursor {
+			t.Errorf("Expected %+v, got %+v", cursor, decoded)
+		}
+	})
+
+	t.Run("Malformed cursor is rejected as a validation error", func(t *testing.T) {
+		// WHEN
+		_, err := decodeLibraryCursor("not-a-cursor!")
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package library
 
 import (
+	"strings"
+	"time"
+
 	"github.com/lokeam/qko-beta/internal/models"
 	"github.com/lokeam/qko-beta/internal/types"
 )
@@ -24,10 +27,27 @@ func (a *LibraryRequestAdapter) AdaptCreateRequestToLibraryGameModel(
 			NormalizedText:     req.GameType.NormalizedText,
 		},
 		GameThemeNames:       req.GameThemeNames,
+		GameGenres:           a.transformGameTerms(req.GameGenres),
+		GameThemes:           a.transformGameTerms(req.GameThemes),
 		PlatformLocations:    a.transformPlatformLocations(req.GamesByPlatformAndLocation),
 	}
 }
 
+func (a *LibraryRequestAdapter) transformGameTerms(terms []types.LibraryRequestGameTerm) []models.GameMetadataTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	result := make([]models.GameMetadataTerm, len(terms))
+	for i, term := range terms {
+		result[i] = models.GameMetadataTerm{
+			ID:   term.ID,
+			Name: strings.TrimSpace(term.Name),
+		}
+	}
+	return result
+}
+
 func (a *LibraryRequestAdapter) AdaptUpdateRequestToLibraryGameModel(
 	req types.UpdateLibraryGameRequest,
 ) models.GameToSave {
@@ -36,6 +56,27 @@ func (a *LibraryRequestAdapter) AdaptUpdateRequestToLibraryGameModel(
 	}
 }
 
+func (a *LibraryRequestAdapter) AdaptPlayStatusRequestToModel(
+	req types.UpdateLibraryGamePlayStatusRequest,
+) models.GamePlayStatusToSave {
+	return models.GamePlayStatusToSave{
+		PlayStatus:     strings.ToLower(strings.TrimSpace(req.PlayStatus)),
+		StartedAt:      unixToTimePointer(req.StartedAt),
+		FinishedAt:     unixToTimePointer(req.FinishedAt),
+		CompletionNote: strings.TrimSpace(req.CompletionNote),
+	}
+}
+
+func (a *LibraryRequestAdapter) AdaptReviewRequestToModel(
+	req types.UpdateLibraryGameReviewRequest,
+) models.GameReviewToSave {
+	return models.GameReviewToSave{
+		PersonalRating: req.PersonalRating,
+		Review:         strings.TrimSpace(req.Review),
+		PrivateNotes:   strings.TrimSpace(req.PrivateNotes),
+	}
+}
+
 func (a *LibraryRequestAdapter) transformPlatformLocations(
 	locations []types.LibraryRequestGameLocation,
 ) []models.GameToSaveLocation {
@@ -50,7 +91,32 @@ func (a *LibraryRequestAdapter) transformPlatformLocations(
 				SublocationID:     locations[i].Location.SublocationID,
 				DigitalLocationID: locations[i].Location.DigitalLocationID,
 			},
+			CopyDetails: a.transformCopyDetails(locations[i]),
+			PurchaseIDs: locations[i].PurchaseIDs,
 		}
 	}
 	return platformLocations
 }
+
+func (a *LibraryRequestAdapter) transformCopyDetails(
+	location types.LibraryRequestGameLocation,
+) models.GameToSaveCopyDetails {
+	copyDetails := models.GameToSaveCopyDetails{
+		Condition:       strings.ToLower(strings.TrimSpace(location.Condition)),
+		HasOriginalCase: location.HasOriginalCase,
+		HasManual:       location.HasManual,
+		Notes:           strings.TrimSpace(location.Notes),
+	}
+
+	copyDetails.AcquiredDate = unixToTimePointer(location.AcquiredDate)
+
+	return copyDetails
+}
+
+func unixToTimePointer(timestamp *int64) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	converted := time.Unix(*timestamp, 0).UTC()
+	return &converted
+}
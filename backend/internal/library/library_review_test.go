@@ -0,0 +1,217 @@
+package library
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+)
+
+/*
+	Behavior:
+	- Validating the user's own rating, review and private notes
+	- Sanitizing free text before it is stored
+
+	Scenarios:
+	- Ratings outside 1 to 5 stars are rejected, no rating is allowed
+	- HTML is stripped from reviews, notes, copy notes and completion notes
+	- Completion notes are limited by characters, not bytes
+	- Overlong reviews are rejected
+	- Stored text is decoded again in the response
+*/
+
+func TestValidateGameReview(t *testing.T) {
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		t.Fatalf("Failed to create sanitizer: %v", err)
+	}
+	validator := NewLibraryValidator(sanitizer)
+
+	intPointer := func(value int) *int {
+		return &value
+	}
+
+	/*
+		GIVEN a review containing markup
+		WHEN ValidateGameReview is called
+		THEN the markup should be stripped from the review and notes
+	*/
+	t.Run("HTML is stripped from review text", func(t *testing.T) {
+		// GIVEN
+		review := models.GameReviewToSave{
+			GameID:         1942,
+			PersonalRating: intPointer(5),
+			Review:         "<script>alert(1)</script>Best <b>RPG</b> ever",
+			PrivateNotes:   "<i>Replay</i> on hard",
+		}
+
+		// WHEN
+		sanitized, err := validator.ValidateGameReview(review)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sanitized.Review != "Best RPG ever" {
+			t.Errorf("Expected markup stripped from review, got '%s'", sanitized.Review)
+		}
+		if sanitized.PrivateNotes != "Replay on hard" {
+			t.Errorf("Expected markup stripped from notes, got '%s'", sanitized.PrivateNotes)
+		}
+	})
+
+	/*
+		GIVEN a review without a rating
+		WHEN ValidateGameReview is called
+		THEN it should be accepted so the rating can be cleared
+	*/
+	t.Run("Rating is optional", func(t *testing.T) {
+		// WHEN
+		_, err := validator.ValidateGameReview(models.GameReviewToSave{GameID: 1942})
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	invalidReviews := []struct {
+		name   string
+		review models.GameReviewToSave
+	}{
+		{name: "Rating below one star", review: models.GameReviewToSave{GameID: 1942, PersonalRating: intPointer(0)}},
+		{name: "Rating above five stars", review: models.GameReviewToSave{GameID: 1942, PersonalRating: intPointer(6)}},
+		{name: "Overlong review", review: models.GameReviewToSave{GameID: 1942, Review: strings.Repeat("a", MaxReviewLength+1)}},
+		{name: "Missing game", review: models.GameReviewToSave{PersonalRating: intPointer(3)}},
+	}
+
+	for _, testCase := range invalidReviews {
+		/*
+			GIVEN an invalid review
+			WHEN ValidateGameReview is called
+			THEN it should return an error
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			_, err := validator.ValidateGameReview(testCase.review)
+
+			// THEN
+			if err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+
+	/*
+		GIVEN a physical copy with notes containing markup
+		WHEN ValidateLibraryGame is called
+		THEN the returned game should carry sanitized notes and the input should be unchanged
+	*/
+	t.Run("Copy notes are sanitized", func(t *testing.T) {
+		// GIVEN
+		game := models.GameToSave{
+			GameID:   1942,
+			GameName: "The Witcher 3",
+			PlatformLocations: []models.GameToSaveLocation{
+				{
+					PlatformID:   48,
+					PlatformName: "PlayStation 4",
+					Type:         "physical",
+					Location:     models.GameToSaveLocationDetails{SublocationID: "shelf-1"},
+					CopyDetails:  models.GameToSaveCopyDetails{Notes: "<b>Signed</b> by the devs"},
+				},
+			},
+		}
+
+		// WHEN
+		validated, err := validator.ValidateLibraryGame(game)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if validated.PlatformLocations[0].CopyDetails.Notes != "Signed by the devs" {
+			t.Errorf("Expected sanitized copy notes, got '%s'", validated.PlatformLocations[0].CopyDetails.Notes)
+		}
+		if game.PlatformLocations[0].CopyDetails.Notes != "<b>Signed</b> by the devs" {
+			t.Errorf("Expected the input game to be unchanged, got '%s'", game.PlatformLocations[0].CopyDetails.Notes)
+		}
+	})
+
+	/*
+		GIVEN a completion note containing markup
+		WHEN ValidateGamePlayStatus is called
+		THEN the returned status should carry the sanitized note
+	*/
+	t.Run("Completion notes are sanitized", func(t *testing.T) {
+		// GIVEN
+		status := models.GamePlayStatusToSave{
+			GameID:         1942,
+			PlayStatus:     models.PlayStatusCompleted,
+			CompletionNote: "<script>alert(1)</script>Rolled <b>credits</b>",
+		}
+
+		// WHEN
+		validated, err := validator.ValidateGamePlayStatus(status)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if validated.CompletionNote != "Rolled credits" {
+			t.Errorf("Expected markup stripped from completion note, got '%s'", validated.CompletionNote)
+		}
+	})
+
+	/*
+		GIVEN completion notes of multi-byte characters at and over the limit
+		WHEN ValidateGamePlayStatus is called
+		THEN only the note over the character limit should be rejected
+	*/
+	t.Run("Completion note length counts characters", func(t *testing.T) {
+		// GIVEN
+		atLimit := models.GamePlayStatusToSave{
+			GameID:         1942,
+			PlayStatus:     models.PlayStatusCompleted,
+			CompletionNote: strings.Repeat("é", MaxCompletionNoteLength),
+		}
+		overLimit := atLimit
+		overLimit.CompletionNote += "é"
+
+		// WHEN
+		_, atLimitErr := validator.ValidateGamePlayStatus(atLimit)
+		_, overLimitErr := validator.ValidateGamePlayStatus(overLimit)
+
+		// THEN
+		if atLimitErr != nil {
+			t.Errorf("Expected no error at the limit, got %v", atLimitErr)
+		}
+		if overLimitErr == nil {
+			t.Error("Expected an error over the limit, got nil")
+		}
+	})
+
+	/*
+		GIVEN a stored review with an escaped apostrophe
+		WHEN TransformReviewToResponse is called
+		THEN the apostrophe should be decoded for display
+	*/
+	t.Run("Response decodes stored text", func(t *testing.T) {
+		// GIVEN
+		review := models.GameReviewToSave{GameID: 1942, Review: "Geralt&#39;s best"}
+
+		// WHEN
+		response := TransformReviewToResponse(review)
+
+		// THEN
+		if response.Review != "Geralt's best" {
+			t.Errorf("Expected decoded review, got '%s'", response.Review)
+		}
+
+		status := TransformPlayStatusToResponse(models.GamePlayStatusToSave{GameID: 1942, CompletionNote: "Geralt&#39;s ending"})
+		if status.CompletionNote != "Geralt's ending" {
+			t.Errorf("Expected decoded completion note, got '%s'", status.CompletionNote)
+		}
+	})
+}
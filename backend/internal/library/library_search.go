@@ -0,0 +1,38 @@
+package library
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	DefaultLibrarySearchLimit = 20
+	MaxLibrarySearchLimit     = 50
+
+	MinLibrarySearchLength = 2
+	MaxLibrarySearchLength = 100
+)
+
+// How a search result matched, text matches hit the full-text index and fuzzy ones only the name trigrams
+const (
+	LibrarySearchMatchText  = "text"
+	LibrarySearchMatchFuzzy = "fuzzy"
+)
+
+// ts_headline wraps matches in control characters that can't appear in game data,
+// so highlights can be HTML escaped before the markers become <mark> tags
+const (
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+
+	nameHeadlineOptions    = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	summaryHeadlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+		", MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=\" ... \""
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// renderHighlight escapes a ts_headline result and turns its markers into <mark> tags
+func renderHighlight(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
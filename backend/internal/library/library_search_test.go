@@ -0,0 +1,127 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Validating library searches
+	- Turning ts_headline output into safe highlights
+
+	Scenarios:
+	- Searches shorter than 2 or longer than 100 characters are rejected
+	- Malformed location IDs and out of range limits are rejected
+	- Highlights are HTML escaped before matches are wrapped in <mark> tags
+	- Results without a full-text match are reported as fuzzy
+*/
+
+func TestLibrarySearch(t *testing.T) {
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		t.Fatalf("Failed to create sanitizer: %v", err)
+	}
+	validator := NewLibraryValidator(sanitizer)
+
+	searches := []struct {
+		name      string
+		request   types.LibrarySearchRequest
+		expectErr bool
+	}{
+		{name: "Valid search with filters", request: types.LibrarySearchRequest{Query: "zelda", Limit: 10, PlatformIDs: []int64{48}}},
+		{name: "Single character", request: types.LibrarySearchRequest{Query: " z "}, expectErr: true},
+		{name: "Too long", request: types.LibrarySearchRequest{Query: strings.Repeat("a", MaxLibrarySearchLength+1)}, expectErr: true},
+		{name: "Limit above maximum", request: types.LibrarySearchRequest{Query: "zelda", Limit: MaxLibrarySearchLimit + 1}, expectErr: true},
+		{name: "Malformed location", request: types.LibrarySearchRequest{Query: "zelda", LocationID: "shelf"}, expectErr: true},
+	}
+
+	/*
+		GIVEN searches with valid and invalid params
+		WHEN ValidateLibrarySearch is called
+		THEN only the valid search should pass
+	*/
+	for _, tc := range searches {
+		t.Run(tc.name, func(t *testing.T) {
+			// WHEN
+			err := validator.ValidateLibrarySearch(tc.request)
+
+			// THEN
+			if tc.expectErr && err == nil {
+				t.Error("Expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+
+	/*
+		GIVEN a headline of a game name containing markup
+		WHEN the result is transformed
+		THEN the markup should be escaped and only the match wrapped in <mark>
+	*/
+	t.Run("Highlights are escaped before marking matches", func(t *testing.T) {
+		// GIVEN
+		results := []models.LibrarySearchResultDB{{
+			ID:            1,
+			Name:          "<b>Zelda</b>",
+			NameHighlight: "<b>" + highlightStart + "Zelda" + highlightStop + "</b>",
+			TextMatch:     true,
+		}}
+
+		// WHEN
+		items := TransformLibrarySearchResults(results)
+
+		// THEN
+		expected := "&lt;b&gt;<mark>Zelda</mark>&lt;/b&gt;"
+		if items[0].NameHighlight != expected {
+			t.Errorf("Expected highlight %q, got %q", expected, items[0].NameHighlight)
+		}
+		if items[0].MatchType != LibrarySearchMatchText {
+			t.Errorf("Expected match type '%s', got '%s'", LibrarySearchMatchText, items[0].MatchType)
+		}
+	})
+
+	/*
+		GIVEN a result that only matched on name similarity
+		WHEN the result is transformed
+		THEN it should be reported as a fuzzy match with an empty platform list
+	*/
+	t.Run("Similarity only matches are fuzzy", func(t *testing.T) {
+		// WHEN
+		items := TransformLibrarySearchResults([]models.LibrarySearchResultDB{{ID: 2, Name: "Zelda", NameHighlight: "Zelda"}})
+
+		// THEN
+		if items[0].MatchType != LibrarySearchMatchFuzzy {
+			t.Errorf("Expected match type '%s', got '%s'", LibrarySearchMatchFuzzy, items[0].MatchType)
+		}
+		if items[0].PlatformNames == nil {
+			t.Error("Expected an empty platform list, got nil")
+		}
+	})
+
+	/*
+		GIVEN an invalid search
+		WHEN the service is asked to search
+		THEN it should return ErrValidationFailed before reaching the database
+	*/
+	t.Run("Service rejects invalid searches", func(t *testing.T) {
+		// GIVEN
+		service := &GameLibraryService{validator: validator}
+
+		// WHEN
+		_, err := service.SearchLibraryGames(context.Background(), "test-user-id", types.LibrarySearchRequest{Query: "z"})
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
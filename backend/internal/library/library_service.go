@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/lokeam/qko-beta/internal/appcontext"
 	"github.com/lokeam/qko-beta/internal/interfaces"
 	"github.com/lokeam/qko-beta/internal/models"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
 	"github.com/lokeam/qko-beta/internal/types"
 )
 
@@ -24,6 +26,9 @@ type LibraryService interface {
 	CreateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
 
 	UpdateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
+	UpdateLibraryGamePlayStatus(ctx context.Context, userID string, status models.GamePlayStatusToSave) (types.LibraryGamePlayStatusResponse, error)
+	UpdateLibraryGameReview(ctx context.Context, userID string, review models.GameReviewToSave) (types.LibraryGameReviewResponse, error)
+	SetLibraryGameFavorite(ctx context.Context, userID string, gameID int64, favorite bool) (types.LibraryGameFavoriteResponse, error)
 	DeleteLibraryGame(ctx context.Context, userID string, gameID int64) error
 
 	// IsGameInLibraryBFF checks if a game is in a user's library, first checks cache then db as fallback
@@ -36,7 +41,14 @@ type LibraryService interface {
 	InvalidateUserCache(ctx context.Context, userID string) error
 
 	// REFACTORED RESPONSE
-	GetLibraryRefactoredBFFResponse(ctx context.Context, userID string) (types.LibraryBFFRefactoredResponse, error)
+	GetLibraryRefactoredBFFResponse(ctx context.Context, userID string, filters types.LibraryBFFFilters) (types.LibraryBFFRefactoredResponse, error)
+
+	// QueryLibraryGames returns one sorted, filtered page of the user's library
+	QueryLibraryGames(ctx context.Context, userID string, request types.LibraryQueryRequest) (types.LibraryQueryResponse, error)
+
+	// GetLibraryGenres and GetLibraryThemes list what the user's games are tagged with on IGDB, with game counts
+	GetLibraryGenres(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error)
+	GetLibraryThemes(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error)
 }
 
 func NewGameLibraryService(
@@ -55,11 +67,16 @@ func NewGameLibraryService(
 		return nil, fmt.Errorf("dashboardCacheWrapper is required")
 	}
 
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		return nil, fmt.Errorf("creating sanitizer: %w", err)
+	}
+
 	return &GameLibraryService{
 		dbAdapter: dbAdapter,
 		cacheWrapper: cacheWrapper,
 		dashboardCacheWrapper: dashboardCacheWrapper,
-		validator: NewLibraryValidator(),
+		validator: NewLibraryValidator(sanitizer),
 		logger: appContext.Logger,
 	}, nil
 }
@@ -73,11 +90,12 @@ func (ls *GameLibraryService) CreateLibraryGame(ctx context.Context, userID stri
 
 	// Validate inputs
 	if err := ls.validator.ValidateUserID(userID); err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
+		return fmt.Errorf("%w: invalid user ID: %v", ErrValidationFailed, err)
 	}
 
-	if err := ls.validator.ValidateLibraryGame(game); err != nil {
-		return fmt.Errorf("invalid game: %w", err)
+	game, err := ls.validator.ValidateLibraryGame(game)
+	if err != nil {
+		return fmt.Errorf("%w: invalid game: %v", ErrValidationFailed, err)
 	}
 
 	// Add to db
@@ -235,7 +253,8 @@ func (ls *GameLibraryService) UpdateLibraryGame(ctx context.Context, userID stri
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	if err := ls.validator.ValidateLibraryGame(game); err != nil {
+	game, err := ls.validator.ValidateLibraryGame(game)
+	if err != nil {
 		return fmt.Errorf("invalid game: %w", err)
 	}
 
@@ -249,7 +268,7 @@ func (ls *GameLibraryService) UpdateLibraryGame(ctx context.Context, userID stri
 	}
 
 	// Update game in database
-	if err := ls.dbAdapter.UpdateLibraryGame(ctx, game); err != nil {
+	if err := ls.dbAdapter.UpdateLibraryGame(ctx, userID, game); err != nil {
 		return fmt.Errorf("error updating game in library: %w", err)
 	}
 
@@ -279,6 +298,150 @@ func (ls *GameLibraryService) UpdateLibraryGame(ctx context.Context, userID stri
 	return nil
 }
 
+// UpdateLibraryGamePlayStatus sets the play status, dates and completion note for every copy of a game
+func (ls *GameLibraryService) UpdateLibraryGamePlayStatus(
+	ctx context.Context,
+	userID string,
+	status models.GamePlayStatusToSave,
+) (types.LibraryGamePlayStatusResponse, error) {
+	ls.logger.Info("GameLibraryService - UpdateLibraryGamePlayStatus called", map[string]any{
+		"userID":     userID,
+		"gameID":     status.GameID,
+		"playStatus": status.PlayStatus,
+	})
+
+	// Validate inputs
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryGamePlayStatusResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	status, err := ls.validator.ValidateGamePlayStatus(status)
+	if err != nil {
+		return types.LibraryGamePlayStatusResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	// Update play status in database
+	if err := ls.dbAdapter.UpdateLibraryGamePlayStatus(ctx, userID, status); err != nil {
+		return types.LibraryGamePlayStatusResponse{}, err
+	}
+
+	// Invalidate library cache
+	if err := ls.cacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate user cache", map[string]any{
+			"error": err,
+			"userID": userID,
+		})
+	}
+	if err := ls.cacheWrapper.InvalidateGameCache(ctx, userID, status.GameID); err != nil {
+		ls.logger.Error("Failed to invalidate game cache", map[string]any{
+			"error": err,
+			"userID": userID,
+			"gameID": status.GameID,
+		})
+	}
+
+	// Invalidate dashboard cache to refresh statistics
+	if err := ls.dashboardCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate dashboard cache after updating play status", map[string]any{
+			"error": err,
+			"userID": userID,
+		})
+	}
+
+	return TransformPlayStatusToResponse(status), nil
+}
+
+// UpdateLibraryGameReview sets the personal rating, review and private notes for every copy of a game
+func (ls *GameLibraryService) UpdateLibraryGameReview(
+	ctx context.Context,
+	userID string,
+	review models.GameReviewToSave,
+) (types.LibraryGameReviewResponse, error) {
+	ls.logger.Info("GameLibraryService - UpdateLibraryGameReview called", map[string]any{
+		"userID": userID,
+		"gameID": review.GameID,
+	})
+
+	// Validate inputs
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryGameReviewResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	review, err := ls.validator.ValidateGameReview(review)
+	if err != nil {
+		return types.LibraryGameReviewResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	// Update review in database
+	if err := ls.dbAdapter.UpdateLibraryGameReview(ctx, userID, review); err != nil {
+		return types.LibraryGameReviewResponse{}, err
+	}
+
+	// Invalidate library cache
+	if err := ls.cacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate user cache", map[string]any{
+			"error": err,
+			"userID": userID,
+		})
+	}
+	if err := ls.cacheWrapper.InvalidateGameCache(ctx, userID, review.GameID); err != nil {
+		ls.logger.Error("Failed to invalidate game cache", map[string]any{
+			"error": err,
+			"userID": userID,
+			"gameID": review.GameID,
+		})
+	}
+
+	return TransformReviewToResponse(review), nil
+}
+
+// SetLibraryGameFavorite marks or unmarks every copy of a game as a favorite
+func (ls *GameLibraryService) SetLibraryGameFavorite(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+	favorite bool,
+) (types.LibraryGameFavoriteResponse, error) {
+	ls.logger.Info("GameLibraryService - SetLibraryGameFavorite called", map[string]any{
+		"userID":   userID,
+		"gameID":   gameID,
+		"favorite": favorite,
+	})
+
+	// Validate inputs
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryGameFavoriteResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	if err := ls.validator.ValidateGameID(gameID); err != nil {
+		return types.LibraryGameFavoriteResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	// Update favorite in database
+	if err := ls.dbAdapter.SetLibraryGameFavorite(ctx, userID, gameID, favorite); err != nil {
+		return types.LibraryGameFavoriteResponse{}, err
+	}
+
+	// Invalidate library cache
+	if err := ls.cacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate user cache", map[string]any{"error": err})
+	}
+	if err := ls.cacheWrapper.InvalidateGameCache(ctx, userID, gameID); err != nil {
+		ls.logger.Error("Failed to invalidate game cache", map[string]any{
+			"error":  err,
+			"userID": userID,
+			"gameID": gameID,
+		})
+	}
+
+	// Invalidate dashboard cache to refresh the favorites count
+	if err := ls.dashboardCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate dashboard cache after updating favorite", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+
+	return types.LibraryGameFavoriteResponse{GameID: gameID, Favorite: favorite}, nil
+}
+
 func (ls *GameLibraryService) IsGameInLibraryBFF(
 	ctx context.Context,
 	userID string,
@@ -354,11 +517,24 @@ func (ls *GameLibraryService) GetAllLibraryItemsBFF(
 func (ls *GameLibraryService) GetLibraryRefactoredBFFResponse(
 	ctx context.Context,
 	userID string,
+	filters types.LibraryBFFFilters,
 ) (types.LibraryBFFRefactoredResponse, error) {
 	if userID == "" {
 			return types.LibraryBFFRefactoredResponse{}, errors.New("user ID is required")
 	}
 
+	for _, condition := range filters.Conditions {
+			if err := ls.validator.ValidateGameCondition(condition); err != nil {
+					return types.LibraryBFFRefactoredResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+			}
+	}
+
+	for _, playStatus := range filters.PlayStatuses {
+			if err := ls.validator.ValidatePlayStatus(playStatus); err != nil {
+					return types.LibraryBFFRefactoredResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+			}
+	}
+
 	// Try to get from cache first (if cache supports new structure)
 	// For now, always get from database
 	response, err := ls.dbAdapter.GetLibraryRefactoredBFFResponse(ctx, userID)
@@ -366,12 +542,156 @@ func (ls *GameLibraryService) GetLibraryRefactoredBFFResponse(
 			return types.LibraryBFFRefactoredResponse{}, err
 	}
 
+	return FilterLibraryBFFResponse(response, filters), nil
+}
+
+
+
+
+
+// QueryLibraryGames returns one page of the user's library using keyset pagination
+func (ls *GameLibraryService) QueryLibraryGames(
+	ctx context.Context,
+	userID string,
+	request types.LibraryQueryRequest,
+) (types.LibraryQueryResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryQueryResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	if err := ls.validator.ValidateLibraryQuery(request); err != nil {
+		return types.LibraryQueryResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	query := models.LibraryQuery{
+		SortBy:      request.SortBy,
+		Limit:       request.Limit,
+		PlatformIDs: request.PlatformIDs,
+		GenreIDs:    request.GenreIDs,
+		ThemeIDs:    request.ThemeIDs,
+		GameType:    request.GameType,
+		LocationID:  request.LocationID,
+		Favorite:    request.Favorite,
+		TagIDs:      request.TagIDs,
+	}
+	if query.SortBy == "" {
+		query.SortBy = LibrarySortName
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultLibraryQueryLimit
+	}
+	query.SortDescending = librarySortOptions[query.SortBy].defaultDescending
+	if request.SortOrder != "" {
+		query.SortDescending = request.SortOrder == "desc"
+	}
+
+	if request.Cursor != "" {
+		cursor, err := decodeLibraryCursor(request.Cursor)
+		if err != nil {
+			return types.LibraryQueryResponse{}, err
+		}
+		if cursor.SortBy != query.SortBy || cursor.Descending != query.SortDescending {
+			return types.LibraryQueryResponse{}, fmt.Errorf("%w: cursor does not match the requested sort", ErrValidationFailed)
+		}
+		query.AfterSortValue = &cursor.SortValue
+		query.AfterGameID = &cursor.GameID
+	}
+
+	games, err := ls.dbAdapter.QueryLibraryGames(ctx, userID, query)
+	if err != nil {
+		return types.LibraryQueryResponse{}, err
+	}
+
+	// The adapter returns one extra row when there is another page
+	hasMore := len(games) > query.Limit
+	if hasMore {
+		games = games[:query.Limit]
+	}
+
+	response := types.LibraryQueryResponse{
+		Items:   TransformLibraryGameListItems(games),
+		HasMore: hasMore,
+		Limit:   query.Limit,
+	}
+
+	if hasMore {
+		last := games[len(games)-1]
+		nextCursor, err := encodeLibraryCursor(libraryCursor{
+			SortBy:     query.SortBy,
+			Descending: query.SortDescending,
+			SortValue:  last.SortValue,
+			GameID:     last.ID,
+		})
+		if err != nil {
+			return types.LibraryQueryResponse{}, err
+		}
+		response.NextCursor = nextCursor
+	}
+
 	return response, nil
 }
 
+// SearchLibraryGames ranks the user's own games against a search string, typos in titles still match
+func (ls *GameLibraryService) SearchLibraryGames(
+	ctx context.Context,
+	userID string,
+	request types.LibrarySearchRequest,
+) (types.LibrarySearchResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LibrarySearchResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	if err := ls.validator.ValidateLibrarySearch(request); err != nil {
+		return types.LibrarySearchResponse{}, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
 
+	search := models.LibrarySearch{
+		Query:       strings.TrimSpace(request.Query),
+		Limit:       request.Limit,
+		PlatformIDs: request.PlatformIDs,
+		LocationID:  request.LocationID,
+	}
+	if search.Limit == 0 {
+		search.Limit = DefaultLibrarySearchLimit
+	}
 
+	results, err := ls.dbAdapter.SearchLibraryGames(ctx, userID, search)
+	if err != nil {
+		return types.LibrarySearchResponse{}, err
+	}
+
+	return types.LibrarySearchResponse{
+		Query: search.Query,
+		Items: TransformLibrarySearchResults(results),
+		Limit: search.Limit,
+	}, nil
+}
+
+// GetLibraryGenres lists the genres of the user's games, most common first
+func (ls *GameLibraryService) GetLibraryGenres(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	genres, err := ls.dbAdapter.GetLibraryGenres(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformLibraryGameTerms(genres), nil
+}
+
+// GetLibraryThemes lists the themes of the user's games, most common first
+func (ls *GameLibraryService) GetLibraryThemes(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
 
+	themes, err := ls.dbAdapter.GetLibraryThemes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformLibraryGameTerms(themes), nil
+}
 
 // InvalidateUserCache invalidates all cache entries for a specific user
 func (ls *GameLibraryService) InvalidateUserCache(ctx context.Context, userID string) error {
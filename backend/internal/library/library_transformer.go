@@ -1,8 +1,10 @@
 package library
 
 import (
+	"database/sql"
 	"fmt"
 	"html"
+	"math"
 	"time"
 
 	"github.com/lokeam/qko-beta/internal/models"
@@ -15,6 +17,7 @@ func TransformLocationDBToResponse(db models.GameLocationDatabaseEntry) types.Li
 		ID: db.GameID,
 		PlatformID: db.PlatformID,
 		PlatformName: db.PlatformName,
+		PlatformGroup: db.PlatformGroup,
 		IsPC: db.Category == "pc",
 		IsMobile: db.Category == "mobile",
 		DateAdded: db.CreatedAt.Unix(),
@@ -25,6 +28,13 @@ func TransformLocationDBToResponse(db models.GameLocationDatabaseEntry) types.Li
 		SublocationID: db.SublocationID.String,
 		SublocationName: db.SublocationName.String,
 		SublocationType: db.SublocationType.String,
+		Condition: db.Condition.String,
+		HasOriginalCase: nullBoolToPointer(db.HasOriginalCase),
+		HasManual: nullBoolToPointer(db.HasManual),
+		AcquiredDate: nullTimeToUnix(db.AcquiredDate),
+		UserGameID: db.UserGameID,
+		IsOnLoan: db.IsOnLoan,
+		CopyNotes: html.UnescapeString(db.CopyNotes.String),
 	}
 }
 
@@ -41,6 +51,41 @@ func TransformGameDBToResponse(db models.LibraryGameDB, locations []types.Librar
 	}
 }
 
+// ApplyCostBasisToResponse adds what each copy cost to a single game response
+func ApplyCostBasisToResponse(
+	response *types.LibraryGameItemBFFResponseFINAL,
+	costBases []models.UserGameCostBasisDB,
+) {
+	if len(costBases) == 0 {
+		return
+	}
+
+	byUserGame := make(map[int64]models.UserGameCostBasisDB, len(costBases))
+	total := 0.0
+	for _, costBasis := range costBases {
+		byUserGame[costBasis.UserGameID] = costBasis
+		total += costBasis.CostBasis
+	}
+
+	for i, location := range response.GamesByPlatformAndLocation {
+		costBasis, ok := byUserGame[location.UserGameID]
+		if !ok {
+			continue
+		}
+
+		amount := costBasis.CostBasis
+		response.GamesByPlatformAndLocation[i].CostBasis = &amount
+		purchaseIDs := make([]string, len(costBasis.PurchaseIDs))
+		for j, purchaseID := range costBasis.PurchaseIDs {
+			purchaseIDs[j] = fmt.Sprintf("%s%d", purchaseIDPrefix, purchaseID)
+		}
+		response.GamesByPlatformAndLocation[i].PurchaseIDs = purchaseIDs
+	}
+
+	total = math.Round(total*100) / 100
+	response.TotalCostBasis = &total
+}
+
 // -- REFACTORED LIBRARY RESPONSE TRANSFORMERS --
 // transformToRefactoredResponse transforms database results to the refactored BFF response
 func (la *LibraryDbAdapter) TransformToRefactoredResponse(
@@ -90,6 +135,13 @@ func (la *LibraryDbAdapter) TransformToRefactoredResponse(
 							NormalizedText: game.GameTypeNormalizedText,
 					},
 					Favorite:              game.Favorite,
+					PlayStatus:            game.PlayStatus,
+					StartedAt:             nullTimeToUnix(game.StartedAt),
+					FinishedAt:            nullTimeToUnix(game.FinishedAt),
+					CompletionNote:        html.UnescapeString(game.CompletionNote.String),
+					PersonalRating:        nullInt64ToIntPointer(game.PersonalRating),
+					Review:                html.UnescapeString(game.Review.String),
+					PrivateNotes:          html.UnescapeString(game.PrivateNotes.String),
 					TotalPhysicalVersions: totalPhysicalVersions,
 					TotalDigitalVersions:  totalDigitalVersions,
 					PhysicalLocations:     physicalLocations,
@@ -135,8 +187,15 @@ func (la *LibraryDbAdapter) TransformPhysicalLocations(
 			platformVersions := make([]types.PlatformVersionResponse, len(group))
 			for i, platform := range group {
 					platformVersions[i] = types.PlatformVersionResponse{
-							PlatformName: platform.PlatformName,
-							PlatformId:   platform.PlatformID,
+							PlatformName:    platform.PlatformName,
+							PlatformId:      platform.PlatformID,
+							PlatformGroup:   platform.PlatformGroup,
+							Condition:       platform.Condition.String,
+							HasOriginalCase: nullBoolToPointer(platform.HasOriginalCase),
+							HasManual:       nullBoolToPointer(platform.HasManual),
+							AcquiredDate:    nullTimeToUnix(platform.AcquiredDate),
+							IsOnLoan:        platform.IsOnLoan,
+							CopyNotes:       html.UnescapeString(platform.CopyNotes.String),
 					}
 			}
 
@@ -181,6 +240,8 @@ func (la *LibraryDbAdapter) TransformDigitalLocations(
 					platformVersions[i] = types.PlatformVersionResponse{
 							PlatformName: platform.PlatformName,
 							PlatformId:   platform.PlatformID,
+							PlatformGroup: platform.PlatformGroup,
+							CopyNotes:    html.UnescapeString(platform.CopyNotes.String),
 					}
 			}
 
@@ -192,4 +253,129 @@ func (la *LibraryDbAdapter) TransformDigitalLocations(
 	}
 
 	return result
-}
\ No newline at end of file
+}
+// nullBoolToPointer keeps "unknown" distinct from false for optional copy details
+func nullBoolToPointer(value sql.NullBool) *bool {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Bool
+	return &result
+}
+
+// nullInt64ToIntPointer converts an optional small number such as a rating, nil when unset
+func nullInt64ToIntPointer(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int64)
+	return &result
+}
+
+// nullTimeToUnix converts an optional timestamp to unix seconds, 0 when unset
+func nullTimeToUnix(value sql.NullTime) int64 {
+	if !value.Valid {
+		return 0
+	}
+	return value.Time.Unix()
+}
+
+// TransformPlayStatusToResponse converts a saved play status to its response format
+func TransformPlayStatusToResponse(status models.GamePlayStatusToSave) types.LibraryGamePlayStatusResponse {
+	response := types.LibraryGamePlayStatusResponse{
+		GameID:         status.GameID,
+		PlayStatus:     status.PlayStatus,
+		CompletionNote: html.UnescapeString(status.CompletionNote),
+	}
+	if status.StartedAt != nil {
+		response.StartedAt = status.StartedAt.Unix()
+	}
+	if status.FinishedAt != nil {
+		response.FinishedAt = status.FinishedAt.Unix()
+	}
+	return response
+}
+
+// TransformLibraryGameListItems converts a page of library query results to response format
+func TransformLibraryGameListItems(games []models.LibraryGameListItemDB) []types.LibraryGameListItemResponse {
+	items := make([]types.LibraryGameListItemResponse, 0, len(games))
+	for _, game := range games {
+		genreNames := game.GenreNames
+		if genreNames == nil {
+			genreNames = []string{}
+		}
+
+		items = append(items, types.LibraryGameListItemResponse{
+			ID:                    game.ID,
+			Name:                  game.Name,
+			CoverURL:              game.CoverURL,
+			FirstReleaseDate:      game.FirstReleaseDate,
+			Rating:                game.Rating,
+			GenreNames:            genreNames,
+			Favorite:              game.Favorite,
+			PlayStatus:            game.PlayStatus,
+			PersonalRating:        nullInt64ToIntPointer(game.PersonalRating),
+			DateAdded:             game.DateAdded.Unix(),
+			TotalPhysicalVersions: game.TotalPhysicalVersions,
+			TotalDigitalVersions:  game.TotalDigitalVersions,
+		})
+	}
+	return items
+}
+
+func TransformLibrarySearchResults(results []models.LibrarySearchResultDB) []types.LibrarySearchResultResponse {
+	items := make([]types.LibrarySearchResultResponse, 0, len(results))
+	for _, result := range results {
+		platformNames := []string(result.PlatformNames)
+		if platformNames == nil {
+			platformNames = []string{}
+		}
+
+		matchType := LibrarySearchMatchFuzzy
+		if result.TextMatch {
+			matchType = LibrarySearchMatchText
+		}
+
+		items = append(items, types.LibrarySearchResultResponse{
+			ID:                    result.ID,
+			Name:                  result.Name,
+			CoverURL:              result.CoverURL,
+			FirstReleaseDate:      result.FirstReleaseDate,
+			Rating:                result.Rating,
+			Favorite:              result.Favorite,
+			PlayStatus:            result.PlayStatus,
+			PlatformNames:         platformNames,
+			TotalPhysicalVersions: result.TotalPhysicalVersions,
+			TotalDigitalVersions:  result.TotalDigitalVersions,
+			NameHighlight:         renderHighlight(result.NameHighlight),
+			SummaryHighlight:      renderHighlight(result.SummaryHighlight),
+			MatchType:             matchType,
+			Score:                 result.Score,
+		})
+	}
+	return items
+}
+
+// TransformReviewToResponse converts a saved review to its response format.
+// Text is stored sanitized, so entities such as apostrophes are decoded for display.
+func TransformReviewToResponse(review models.GameReviewToSave) types.LibraryGameReviewResponse {
+	return types.LibraryGameReviewResponse{
+		GameID:         review.GameID,
+		PersonalRating: review.PersonalRating,
+		Review:         html.UnescapeString(review.Review),
+		PrivateNotes:   html.UnescapeString(review.PrivateNotes),
+	}
+}
+
+// TransformLibraryGameTerms converts genre or theme counts to the response format
+func TransformLibraryGameTerms(terms []models.LibraryGameTermCountDB) []types.LibraryGameTermResponse {
+	items := make([]types.LibraryGameTermResponse, 0, len(terms))
+	for _, term := range terms {
+		items = append(items, types.LibraryGameTermResponse{
+			ID:        term.ID,
+			Name:      term.Name,
+			GameCount: term.GameCount,
+		})
+	}
+	return items
+}
@@ -3,9 +3,14 @@ package library
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/lokeam/qko-beta/internal/interfaces"
 	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
 )
 
 type ValidationError struct {
@@ -17,44 +22,288 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
-type LibraryValidatorImpl struct{}
+const (
+	MaxCompletionNoteLength = 1000
+	MaxCopyNotesLength      = 1000
+	MaxReviewLength         = 5000
+	MaxPrivateNotesLength   = 2000
+	MaxGameTermNameLength   = 255
+)
+
+type LibraryValidatorImpl struct {
+	sanitizer interfaces.Sanitizer
+}
 
-func NewLibraryValidator() interfaces.LibraryValidator {
-	return &LibraryValidatorImpl{}
+func NewLibraryValidator(sanitizer interfaces.Sanitizer) interfaces.LibraryValidator {
+	return &LibraryValidatorImpl{sanitizer: sanitizer}
 }
 
-func (v *LibraryValidatorImpl) ValidateLibraryGame(game models.GameToSave) error {
+// ValidateLibraryGame checks a game and its copies, returning it with copy notes sanitized
+func (v *LibraryValidatorImpl) ValidateLibraryGame(game models.GameToSave) (models.GameToSave, error) {
 	if game.GameID <= 0 {
-		return errors.New("game ID must be positive")
+		return models.GameToSave{}, errors.New("game ID must be positive")
 	}
 
 	if game.GameName == "" {
-		return errors.New("game name is required")
+		return models.GameToSave{}, errors.New("game name is required")
 	}
 
 	if len(game.PlatformLocations) == 0 {
-		return errors.New("at least one platform location is required")
+		return models.GameToSave{}, errors.New("at least one platform location is required")
+	}
+
+	if err := ValidateGameTerms("genre", game.GameGenres); err != nil {
+		return models.GameToSave{}, err
+	}
+	if err := ValidateGameTerms("theme", game.GameThemes); err != nil {
+		return models.GameToSave{}, err
 	}
 
-	for i, location := range game.PlatformLocations {
+	// Copy so sanitizing notes doesn't change the caller's slice
+	locations := make([]models.GameToSaveLocation, len(game.PlatformLocations))
+	copy(locations, game.PlatformLocations)
+
+	for i, location := range locations {
 		if location.PlatformID <= 0 {
-			return fmt.Errorf("platform ID must be positive at index %d", i)
+			return models.GameToSave{}, fmt.Errorf("platform ID must be positive at index %d", i)
 		}
 
 		if location.PlatformName == "" {
-			return fmt.Errorf("platform name is required at index %d", i)
+			return models.GameToSave{}, fmt.Errorf("platform name is required at index %d", i)
 		}
 
 		if location.Type != "physical" && location.Type != "digital" {
-			return fmt.Errorf("invalid location type '%s' at index %d", location.Type, i)
+			return models.GameToSave{}, fmt.Errorf("invalid location type '%s' at index %d", location.Type, i)
 		}
 
 		if location.Type == "physical" && location.Location.SublocationID == "" {
-			return fmt.Errorf("sublocation ID is required for physical location at index %d", i)
+			return models.GameToSave{}, fmt.Errorf("sublocation ID is required for physical location at index %d", i)
 		}
 
 		if location.Type == "digital" && location.Location.DigitalLocationID == "" {
-			return fmt.Errorf("digital location ID is required for digital location at index %d", i)
+			return models.GameToSave{}, fmt.Errorf("digital location ID is required for digital location at index %d", i)
+		}
+
+		if err := v.validateCopyDetails(location); err != nil {
+			return models.GameToSave{}, fmt.Errorf("%w at index %d", err, i)
+		}
+
+		if _, err := parsePurchaseIDs(location.PurchaseIDs); err != nil {
+			return models.GameToSave{}, fmt.Errorf("%w at index %d", err, i)
+		}
+
+		notes, err := v.sanitizeText("copy notes", location.CopyDetails.Notes, MaxCopyNotesLength)
+		if err != nil {
+			return models.GameToSave{}, fmt.Errorf("%w at index %d", err, i)
+		}
+		locations[i].CopyDetails.Notes = notes
+	}
+
+	game.PlatformLocations = locations
+	return game, nil
+}
+
+// ValidateGameTerms checks the genres or themes sent along with a new game
+func ValidateGameTerms(kind string, terms []models.GameMetadataTerm) error {
+	for i, term := range terms {
+		if term.ID <= 0 {
+			return fmt.Errorf("%s ID must be positive at index %d", kind, i)
+		}
+		if term.Name == "" {
+			return fmt.Errorf("%s name is required at index %d", kind, i)
+		}
+		if utf8.RuneCountInString(term.Name) > MaxGameTermNameLength {
+			return fmt.Errorf("%s name must be at most %d characters at index %d", kind, MaxGameTermNameLength, i)
+		}
+	}
+	return nil
+}
+
+// validateCopyDetails checks condition and completeness, which only apply to physical copies
+func (v *LibraryValidatorImpl) validateCopyDetails(location models.GameToSaveLocation) error {
+	details := location.CopyDetails
+
+	if location.Type == "digital" {
+		if details.Condition != "" || details.HasOriginalCase != nil || details.HasManual != nil {
+			return errors.New("condition, case and manual only apply to physical copies")
+		}
+	}
+
+	if details.Condition != "" {
+		if err := v.ValidateGameCondition(details.Condition); err != nil {
+			return err
+		}
+	}
+
+	if details.AcquiredDate != nil && details.AcquiredDate.After(time.Now()) {
+		return errors.New("acquired date cannot be in the future")
+	}
+
+	return nil
+}
+
+func (v *LibraryValidatorImpl) ValidateGameCondition(condition string) error {
+	for _, validCondition := range models.GameConditions {
+		if condition == validCondition {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid condition '%s', must be one of: %s", condition, strings.Join(models.GameConditions, ", "))
+}
+
+func (v *LibraryValidatorImpl) ValidatePlayStatus(playStatus string) error {
+	for _, validStatus := range models.PlayStatuses {
+		if playStatus == validStatus {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid play status '%s', must be one of: %s", playStatus, strings.Join(models.PlayStatuses, ", "))
+}
+
+// ValidateGamePlayStatus checks that the dates and note make sense for the requested status,
+// returning the status with its completion note sanitized
+func (v *LibraryValidatorImpl) ValidateGamePlayStatus(status models.GamePlayStatusToSave) (models.GamePlayStatusToSave, error) {
+	if err := v.ValidateGameID(status.GameID); err != nil {
+		return models.GamePlayStatusToSave{}, err
+	}
+
+	if err := v.ValidatePlayStatus(status.PlayStatus); err != nil {
+		return models.GamePlayStatusToSave{}, err
+	}
+
+	if status.PlayStatus == models.PlayStatusBacklog && (status.StartedAt != nil || status.FinishedAt != nil) {
+		return models.GamePlayStatusToSave{}, errors.New("backlog games cannot have a started or finished date")
+	}
+
+	if status.FinishedAt != nil &&
+		status.PlayStatus != models.PlayStatusCompleted &&
+		status.PlayStatus != models.PlayStatusHundredPercent &&
+		status.PlayStatus != models.PlayStatusDropped {
+		return models.GamePlayStatusToSave{}, fmt.Errorf("finished date does not apply to play status '%s'", status.PlayStatus)
+	}
+
+	now := time.Now()
+	if status.StartedAt != nil && status.StartedAt.After(now) {
+		return models.GamePlayStatusToSave{}, errors.New("started date cannot be in the future")
+	}
+	if status.FinishedAt != nil && status.FinishedAt.After(now) {
+		return models.GamePlayStatusToSave{}, errors.New("finished date cannot be in the future")
+	}
+	if status.StartedAt != nil && status.FinishedAt != nil && status.FinishedAt.Before(*status.StartedAt) {
+		return models.GamePlayStatusToSave{}, errors.New("finished date cannot be before started date")
+	}
+
+	sanitizedNote, err := v.sanitizeText("completion note", status.CompletionNote, MaxCompletionNoteLength)
+	if err != nil {
+		return models.GamePlayStatusToSave{}, err
+	}
+
+	status.CompletionNote = sanitizedNote
+	return status, nil
+}
+
+// ValidateGameReview checks the rating range, returning the review with its text sanitized
+func (v *LibraryValidatorImpl) ValidateGameReview(review models.GameReviewToSave) (models.GameReviewToSave, error) {
+	if err := v.ValidateGameID(review.GameID); err != nil {
+		return models.GameReviewToSave{}, err
+	}
+
+	if review.PersonalRating != nil &&
+		(*review.PersonalRating < models.MinPersonalRating || *review.PersonalRating > models.MaxPersonalRating) {
+		return models.GameReviewToSave{}, fmt.Errorf("personal rating must be between %d and %d", models.MinPersonalRating, models.MaxPersonalRating)
+	}
+
+	sanitizedReview, err := v.sanitizeText("review", review.Review, MaxReviewLength)
+	if err != nil {
+		return models.GameReviewToSave{}, err
+	}
+	sanitizedNotes, err := v.sanitizeText("private notes", review.PrivateNotes, MaxPrivateNotesLength)
+	if err != nil {
+		return models.GameReviewToSave{}, err
+	}
+
+	review.Review = sanitizedReview
+	review.PrivateNotes = sanitizedNotes
+	return review, nil
+}
+
+// sanitizeText strips HTML from free text, the sanitized value is what gets stored
+func (v *LibraryValidatorImpl) sanitizeText(field string, value string, maxLength int) (string, error) {
+	if utf8.RuneCountInString(value) > maxLength {
+		return "", fmt.Errorf("%s cannot exceed %d characters", field, maxLength)
+	}
+
+	sanitized, err := v.sanitizer.SanitizeString(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s content: %v", field, err)
+	}
+	return sanitized, nil
+}
+
+// ValidateLibraryQuery checks paging, sort and filter params, zero values fall back to defaults
+func (v *LibraryValidatorImpl) ValidateLibraryQuery(request types.LibraryQueryRequest) error {
+	if request.Limit < 0 || request.Limit > MaxLibraryQueryLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLibraryQueryLimit)
+	}
+
+	if request.SortBy != "" {
+		if _, ok := librarySortOptions[request.SortBy]; !ok {
+			return fmt.Errorf("invalid sort '%s', must be one of: %s, %s, %s, %s, %s",
+				request.SortBy, LibrarySortName, LibrarySortReleaseDate, LibrarySortRating, LibrarySortDateAdded, LibrarySortPersonalRating)
+		}
+	}
+
+	if request.SortOrder != "" && request.SortOrder != "asc" && request.SortOrder != "desc" {
+		return fmt.Errorf("invalid order '%s', must be asc or desc", request.SortOrder)
+	}
+
+	if request.GameType != "" && request.GameType != "physical" && request.GameType != "digital" {
+		return fmt.Errorf("invalid game type '%s', must be physical or digital", request.GameType)
+	}
+
+	if request.LocationID != "" {
+		if _, err := uuid.Parse(request.LocationID); err != nil {
+			return fmt.Errorf("invalid location ID '%s'", request.LocationID)
+		}
+	}
+
+	for _, tagID := range request.TagIDs {
+		if _, err := uuid.Parse(tagID); err != nil {
+			return fmt.Errorf("invalid tag ID '%s'", tagID)
+		}
+	}
+
+	for _, ids := range [][]int64{request.PlatformIDs, request.GenreIDs, request.ThemeIDs} {
+		for _, id := range ids {
+			if id <= 0 {
+				return errors.New("platform, genre and theme IDs must be positive")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateLibrarySearch checks the search string, limit and filters, a zero limit falls back to the default
+func (v *LibraryValidatorImpl) ValidateLibrarySearch(request types.LibrarySearchRequest) error {
+	queryLength := utf8.RuneCountInString(strings.TrimSpace(request.Query))
+	if queryLength < MinLibrarySearchLength || queryLength > MaxLibrarySearchLength {
+		return fmt.Errorf("search must be between %d and %d characters", MinLibrarySearchLength, MaxLibrarySearchLength)
+	}
+
+	if request.Limit < 0 || request.Limit > MaxLibrarySearchLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLibrarySearchLimit)
+	}
+
+	if request.LocationID != "" {
+		if _, err := uuid.Parse(request.LocationID); err != nil {
+			return fmt.Errorf("invalid location ID '%s'", request.LocationID)
+		}
+	}
+
+	for _, platformID := range request.PlatformIDs {
+		if platformID <= 0 {
+			return errors.New("platform IDs must be positive")
 		}
 	}
 
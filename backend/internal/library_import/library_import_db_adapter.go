@@ -0,0 +1,331 @@
+package library_import
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type LibraryImportDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewLibraryImportDbAdapter(appContext *appcontext.AppContext) (*LibraryImportDbAdapter, error) {
+	appContext.Logger.Debug("Creating LibraryImportDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &LibraryImportDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// POST
+// CreateImportJob stores a pending job together with every parsed row in one transaction
+func (ia *LibraryImportDbAdapter) CreateImportJob(
+	ctx context.Context,
+	userID string,
+	source string,
+	dryRun bool,
+	rows []models.LibraryImportRowToSave,
+) (models.LibraryImportJobDB, error) {
+	ia.logger.Debug("LibraryImportDbAdapter - CreateImportJob called", map[string]any{
+		"userID": userID,
+		"source": source,
+		"dryRun": dryRun,
+		"rows":   len(rows),
+	})
+
+	var job models.LibraryImportJobDB
+	err := postgres.WithTransaction(ctx, ia.db, ia.logger, func(tx *sqlx.Tx) error {
+		if err := tx.GetContext(ctx, &job, InsertImportJobQuery, userID, source, dryRun, len(rows)); err != nil {
+			return fmt.Errorf("error creating import job: %w", err)
+		}
+
+		for _, row := range rows {
+			if _, err := tx.ExecContext(
+				ctx,
+				InsertImportRowQuery,
+				job.ID,
+				row.RowNumber,
+				row.Title,
+				row.IGDBID,
+				row.Platform,
+				row.GameType,
+				row.Location,
+				row.ExternalID,
+			); err != nil {
+				return fmt.Errorf("error saving import row %d: %w", row.RowNumber, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.LibraryImportJobDB{}, err
+	}
+
+	return job, nil
+}
+
+// GET
+// GetImportJob retrieves a single job owned by the user.
+// Returns ErrImportJobNotFound if the job doesn't exist or belongs to someone else.
+func (ia *LibraryImportDbAdapter) GetImportJob(
+	ctx context.Context,
+	userID string,
+	jobID string,
+) (models.LibraryImportJobDB, error) {
+	ia.logger.Debug("LibraryImportDbAdapter - GetImportJob called", map[string]any{
+		"userID": userID,
+		"jobID":  jobID,
+	})
+
+	var job models.LibraryImportJobDB
+	if err := ia.db.GetContext(ctx, &job, GetImportJobQuery, jobID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LibraryImportJobDB{}, ErrImportJobNotFound
+		}
+		return models.LibraryImportJobDB{}, fmt.Errorf("error getting import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetImportRows retrieves every row of a job in file order
+func (ia *LibraryImportDbAdapter) GetImportRows(
+	ctx context.Context,
+	jobID string,
+) ([]models.LibraryImportRowDB, error) {
+	var rows []models.LibraryImportRowDB
+	if err := ia.db.SelectContext(ctx, &rows, GetImportRowsQuery, jobID); err != nil {
+		return nil, fmt.Errorf("error getting import rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetPendingImportRows retrieves the rows of a job that haven't been processed yet
+func (ia *LibraryImportDbAdapter) GetPendingImportRows(
+	ctx context.Context,
+	jobID string,
+) ([]models.LibraryImportRowDB, error) {
+	var rows []models.LibraryImportRowDB
+	if err := ia.db.SelectContext(ctx, &rows, GetPendingImportRowsQuery, jobID); err != nil {
+		return nil, fmt.Errorf("error getting pending import rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetUnfinishedImportJobs retrieves pending and running jobs across all users, oldest first
+func (ia *LibraryImportDbAdapter) GetUnfinishedImportJobs(
+	ctx context.Context,
+) ([]models.LibraryImportJobDB, error) {
+	var jobs []models.LibraryImportJobDB
+	if err := ia.db.SelectContext(ctx, &jobs, GetUnfinishedImportJobsQuery); err != nil {
+		return nil, fmt.Errorf("error getting unfinished import jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetImportLocations retrieves the user's sublocations and active digital locations
+func (ia *LibraryImportDbAdapter) GetImportLocations(
+	ctx context.Context,
+	userID string,
+) ([]models.LibraryImportLocationDB, error) {
+	var locations []models.LibraryImportLocationDB
+	if err := ia.db.SelectContext(ctx, &locations, GetImportLocationsQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting import locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetReviewRows retrieves every row across the user's imports that is waiting for review
+func (ia *LibraryImportDbAdapter) GetReviewRows(
+	ctx context.Context,
+	userID string,
+) ([]models.LibraryImportRowDB, error) {
+	var rows []models.LibraryImportRowDB
+	if err := ia.db.SelectContext(ctx, &rows, GetReviewRowsQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting import review rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetReviewRow retrieves a single row waiting for review.
+// Returns ErrImportRowNotFound if the row doesn't exist, belongs to someone else or was already reviewed.
+func (ia *LibraryImportDbAdapter) GetReviewRow(
+	ctx context.Context,
+	userID string,
+	rowID int64,
+) (models.LibraryImportRowDB, error) {
+	var row models.LibraryImportRowDB
+	if err := ia.db.GetContext(ctx, &row, GetReviewRowQuery, userID, rowID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.LibraryImportRowDB{}, ErrImportRowNotFound
+		}
+		return models.LibraryImportRowDB{}, fmt.Errorf("error getting import review row: %w", err)
+	}
+
+	return row, nil
+}
+
+// PUT
+func (ia *LibraryImportDbAdapter) MarkImportJobRunning(ctx context.Context, jobID string) error {
+	if _, err := ia.db.ExecContext(ctx, MarkImportJobRunningQuery, jobID); err != nil {
+		return fmt.Errorf("error marking import job running: %w", err)
+	}
+
+	return nil
+}
+
+// SaveImportRowResult records a row's outcome and bumps the job's progress counters.
+// Rows that were already processed are left alone so resumed jobs don't double count.
+func (ia *LibraryImportDbAdapter) SaveImportRowResult(
+	ctx context.Context,
+	jobID string,
+	rowID int64,
+	result models.LibraryImportRowResult,
+) error {
+	return postgres.WithTransaction(ctx, ia.db, ia.logger, func(tx *sqlx.Tx) error {
+		updated, err := tx.ExecContext(
+			ctx,
+			UpdateImportRowResultQuery,
+			jobID,
+			rowID,
+			result.Status,
+			result.MatchedGameID,
+			result.MatchedGameName,
+			result.MatchedPlatformID,
+			result.MatchedPlatformName,
+			result.MatchedLocationID,
+			result.Message,
+		)
+		if err != nil {
+			return fmt.Errorf("error saving import row result: %w", err)
+		}
+
+		rowsAffected, err := updated.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking import row update: %w", err)
+		}
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		succeeded, failed, review := 0, 0, 0
+		switch result.Status {
+		case models.LibraryImportRowFailed:
+			failed = 1
+		case models.LibraryImportRowNeedsReview:
+			review = 1
+		default:
+			succeeded = 1
+		}
+
+		if _, err := tx.ExecContext(ctx, IncrementImportJobCountersQuery, jobID, succeeded, failed, review); err != nil {
+			return fmt.Errorf("error updating import job progress: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ResolveReviewRow records the outcome of a reviewed row and moves it out of the job's review count
+func (ia *LibraryImportDbAdapter) ResolveReviewRow(
+	ctx context.Context,
+	jobID string,
+	rowID int64,
+	result models.LibraryImportRowResult,
+) error {
+	return postgres.WithTransaction(ctx, ia.db, ia.logger, func(tx *sqlx.Tx) error {
+		updated, err := tx.ExecContext(
+			ctx,
+			ResolveReviewRowQuery,
+			jobID,
+			rowID,
+			result.Status,
+			result.MatchedGameID,
+			result.MatchedGameName,
+			result.MatchedPlatformID,
+			result.MatchedPlatformName,
+			result.MatchedLocationID,
+			result.Message,
+		)
+		if err != nil {
+			return fmt.Errorf("error saving import review result: %w", err)
+		}
+
+		rowsAffected, err := updated.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking import review update: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrImportRowNotFound
+		}
+
+		succeeded, failed := 1, 0
+		if result.Status != models.LibraryImportRowImported {
+			succeeded, failed = 0, 1
+		}
+
+		if _, err := tx.ExecContext(ctx, MoveReviewRowCountersQuery, jobID, succeeded, failed); err != nil {
+			return fmt.Errorf("error updating import job progress: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DELETE
+// DismissReviewRow takes a row out of the review queue without importing it.
+// Returns ErrImportRowNotFound if the row isn't waiting for review.
+func (ia *LibraryImportDbAdapter) DismissReviewRow(
+	ctx context.Context,
+	userID string,
+	rowID int64,
+) error {
+	return postgres.WithTransaction(ctx, ia.db, ia.logger, func(tx *sqlx.Tx) error {
+		var jobID string
+		if err := tx.GetContext(ctx, &jobID, DismissReviewRowQuery, userID, rowID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrImportRowNotFound
+			}
+			return fmt.Errorf("error dismissing import review row: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, MoveReviewRowCountersQuery, jobID, 0, 1); err != nil {
+			return fmt.Errorf("error updating import job progress: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CompleteImportJob moves a job to its final status
+func (ia *LibraryImportDbAdapter) CompleteImportJob(
+	ctx context.Context,
+	jobID string,
+	status string,
+	errorMessage string,
+) error {
+	if _, err := ia.db.ExecContext(ctx, CompleteImportJobQuery, jobID, status, errorMessage); err != nil {
+		return fmt.Errorf("error completing import job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package library_import
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Reading import jobs for a user
+	- Recording row results and job progress
+
+	Scenarios:
+	- GetImportJob returns ErrImportJobNotFound for unknown jobs
+	- SaveImportRowResult bumps the failed counter for failed rows
+	- SaveImportRowResult leaves counters alone for rows that were already processed
+*/
+
+func TestLibraryImportDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	jobID := "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c001"
+
+	setupMockDB := func() (*LibraryImportDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &LibraryImportDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN a job ID that doesn't belong to the user
+		WHEN GetImportJob is called
+		THEN it should return ErrImportJobNotFound
+	*/
+	t.Run("GetImportJob returns not found", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectQuery("SELECT (.+) FROM library_import_jobs").
+			WithArgs(jobID, userID).
+			WillReturnError(sql.ErrNoRows)
+
+		// WHEN
+		_, err = adapter.GetImportJob(context.Background(), userID, jobID)
+
+		// THEN
+		if !errors.Is(err, ErrImportJobNotFound) {
+			t.Errorf("Expected ErrImportJobNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a pending row that failed to match
+		WHEN SaveImportRowResult is called
+		THEN the row and the job's failed counter should both be updated
+	*/
+	t.Run("SaveImportRowResult counts failed rows", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		result := models.LibraryImportRowResult{
+			Status:  models.LibraryImportRowFailed,
+			Message: "no IGDB game found matching 'Unknown'",
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE library_import_rows").
+			WithArgs(jobID, int64(7), result.Status, int64(0), "", int64(0), "", "", result.Message).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE library_import_jobs").
+			WithArgs(jobID, 0, 1, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		err = adapter.SaveImportRowResult(context.Background(), jobID, 7, result)
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a row that was already processed before a restart
+		WHEN SaveImportRowResult is called again
+		THEN the job counters should not be touched
+	*/
+	t.Run("SaveImportRowResult skips processed rows", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE library_import_rows").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		// WHEN
+		err = adapter.SaveImportRowResult(context.Background(), jobID, 7, models.LibraryImportRowResult{
+			Status: models.LibraryImportRowImported,
+		})
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
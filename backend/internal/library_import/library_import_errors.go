@@ -0,0 +1,36 @@
+package library_import
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrImportJobNotFound = errors.New("import job not found")
+	ErrImportRowNotFound = errors.New("import row not found")
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrImportQueueFull   = errors.New("import queue is full")
+	ErrDatabaseError     = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrImportJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrImportRowNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnsupportedFormat):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrImportQueueFull):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
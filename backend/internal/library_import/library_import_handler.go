@@ -0,0 +1,336 @@
+package library_import
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// maxImportBodyBytes is comfortably above MaxImportRows worth of CSV
+const maxImportBodyBytes = 5 << 20
+
+// maxLauncherImportBodyBytes allows for Playnite exports, which carry a lot of metadata per game
+const maxLauncherImportBodyBytes = 25 << 20
+
+func RegisterLibraryImportRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) {
+	r.Post("/", CreateLibraryImport(appCtx, importService))
+	r.Get("/review", GetLibraryImportReviewQueue(appCtx, importService))
+	r.Post("/review/{rowID}/resolve", ResolveLibraryImportRow(appCtx, importService))
+	r.Post("/review/{rowID}/dismiss", DismissLibraryImportRow(appCtx, importService))
+	r.Get("/{jobID}", GetLibraryImport(appCtx, importService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to pick the parser from ?format= or the Content-Type header
+func parseImportSource(r *http.Request) (string, error) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); format != "" {
+		return format, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", fmt.Errorf("%w: Content-Type must be text/csv or application/json", ErrUnsupportedFormat)
+	}
+
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return models.LibraryImportSourceCSV, nil
+	case "application/json":
+		return models.LibraryImportSourceJSON, nil
+	default:
+		return "", fmt.Errorf("%w: Content-Type must be text/csv or application/json", ErrUnsupportedFormat)
+	}
+}
+
+// helper fn to read the {rowID} path parameter of the review routes
+func parseRowID(r *http.Request) (int64, error) {
+	rowID, err := strconv.ParseInt(chi.URLParam(r, "rowID"), 10, 64)
+	if err != nil || rowID <= 0 {
+		return 0, fmt.Errorf("%w: invalid import row ID", ErrValidationFailed)
+	}
+	return rowID, nil
+}
+
+// CreateLibraryImport handles POST requests that upload a CSV or JSON library import,
+// or a launcher export with ?format=playnite, gog or steam.
+// The rows are processed in the background, the response is the job to poll.
+func CreateLibraryImport(
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		dryRun := false
+		if rawDryRun := r.URL.Query().Get("dry_run"); rawDryRun != "" {
+			parsed, err := strconv.ParseBool(rawDryRun)
+			if err != nil {
+				handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: dry_run must be true or false", ErrValidationFailed))
+				return
+			}
+			dryRun = parsed
+		}
+
+		source, err := parseImportSource(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		appCtx.Logger.Info("Creating library import", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"source":    source,
+			"dryRun":    dryRun,
+		})
+
+		// Oversized bodies are cut off here and surface as a parse error
+		maxBodyBytes := int64(maxImportBodyBytes)
+		if models.IsLauncherImportSource(source) {
+			maxBodyBytes = maxLauncherImportBodyBytes
+		}
+		body := http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+		var rows []models.LibraryImportRowToSave
+		switch source {
+		case models.LibraryImportSourceCSV:
+			rows, err = ParseCSVImport(body)
+		case models.LibraryImportSourceJSON:
+			rows, err = ParseJSONImport(body)
+		case models.LibraryImportSourcePlaynite:
+			rows, err = ParsePlayniteImport(body)
+		case models.LibraryImportSourceGOG:
+			rows, err = ParseGOGGalaxyImport(body)
+		case models.LibraryImportSourceSteam:
+			rows, err = ParseSteamImport(body)
+		default:
+			err = fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, source)
+		}
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		job, err := importService.CreateImportJob(r.Context(), userID, source, dryRun, rows)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"import": job,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusAccepted,
+			response,
+		)
+	}
+}
+
+// GetLibraryImport handles GET requests for an import's progress and per-row report
+func GetLibraryImport(
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		job, err := importService.GetImportJob(r.Context(), userID, chi.URLParam(r, "jobID"))
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"import": job,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetLibraryImportReviewQueue handles GET requests for launcher import rows waiting for review
+func GetLibraryImportReviewQueue(
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		rows, err := importService.GetReviewQueue(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"review": rows,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// ResolveLibraryImportRow handles POST requests that import a review row with the user's chosen match
+func ResolveLibraryImportRow(
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		rowID, err := parseRowID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		// An empty body accepts the suggested match
+		var request types.ResolveLibraryImportRowRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		row, err := importService.ResolveReviewRow(r.Context(), userID, rowID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"row": row,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// DismissLibraryImportRow handles POST requests that drop a review row without importing it
+func DismissLibraryImportRow(
+	appCtx *appcontext.AppContext,
+	importService services.LibraryImportService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		rowID, err := parseRowID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		if err := importService.DismissReviewRow(r.Context(), userID, rowID); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"id":      rowID,
+			"message": "import row dismissed",
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
@@ -0,0 +1,311 @@
+package library_import
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/locations/digital"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// MaxLauncherImportRows is higher than MaxImportRows, launcher exports can't be split by hand
+const MaxLauncherImportRows = 5000
+
+// pcPlatformID is IGDB's "PC (Microsoft Windows)", where launcher games are assumed to live
+const pcPlatformID = "6"
+
+// launcherStoreServices maps the store names launchers use to DigitalServicesCatalog IDs.
+// Keys are lowercase with spaces, dots and dashes removed.
+var launcherStoreServices = map[string]string{
+	"steam":          "steam",
+	"gog":            "gog",
+	"epic":           "epicgames",
+	"epicgames":      "epicgames",
+	"uplay":          "ubisoft",
+	"ubisoft":        "ubisoft",
+	"ubisoftconnect": "ubisoft",
+	"origin":         "ea",
+	"ea":             "ea",
+	"eaapp":          "ea",
+	"battlenet":      "blizzard",
+	"itch":           "itchio",
+	"itchio":         "itchio",
+	"amazon":         "primegaming",
+	"amazongames":    "primegaming",
+	"psn":            "playstation",
+	"playstation":    "playstation",
+	"xbox":           "xboxgamepass",
+	"xboxone":        "xboxgamepass",
+}
+
+// launcherLocationName is the digital location a launcher entry attaches to.
+// Known stores use the catalog name, anything else is kept as given so the user can match it by hand.
+func launcherLocationName(store string) string {
+	key := strings.NewReplacer(" ", "", ".", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(store)))
+	if service, ok := launcherCatalogService(launcherStoreServices[key]); ok {
+		return service.Name
+	}
+	return strings.TrimSpace(store)
+}
+
+func launcherCatalogService(serviceID string) (types.DigitalServiceItem, bool) {
+	for _, service := range digital.DigitalServicesCatalog {
+		if service.ID == serviceID {
+			return service, true
+		}
+	}
+	return types.DigitalServiceItem{}, false
+}
+
+// launcherCatalogServiceByName finds the catalog entry a launcher location name came from
+func launcherCatalogServiceByName(name string) (types.DigitalServiceItem, bool) {
+	for _, service := range digital.DigitalServicesCatalog {
+		if strings.EqualFold(service.Name, name) {
+			return service, true
+		}
+	}
+	return types.DigitalServiceItem{}, false
+}
+
+// ParsePlayniteImport reads a Playnite library export, a JSON array of games.
+// Source and platforms may be objects with a Name or plain strings depending on the exporter.
+func ParsePlayniteImport(reader io.Reader) ([]models.LibraryImportRowToSave, error) {
+	var games []struct {
+		ID        string            `json:"Id"`
+		GameID    string            `json:"GameId"`
+		Name      string            `json:"Name"`
+		Source    json.RawMessage   `json:"Source"`
+		Platforms []json.RawMessage `json:"Platforms"`
+	}
+	if err := json.NewDecoder(reader).Decode(&games); err != nil {
+		return nil, fmt.Errorf("%w: invalid Playnite export, expected a JSON array of games", ErrValidationFailed)
+	}
+
+	rows := make([]models.LibraryImportRowToSave, 0, len(games))
+	for _, game := range games {
+		if len(rows) >= MaxLauncherImportRows {
+			return nil, fmt.Errorf("%w: launcher imports are limited to %d games", ErrValidationFailed, MaxLauncherImportRows)
+		}
+
+		platform := ""
+		if len(game.Platforms) > 0 {
+			platform = playnitePlatform(game.Platforms[0])
+		}
+
+		externalID := game.GameID
+		if externalID == "" {
+			externalID = game.ID
+		}
+
+		rows = append(rows, models.LibraryImportRowToSave{
+			RowNumber:  len(rows) + 1,
+			Title:      strings.TrimSpace(game.Name),
+			Platform:   platform,
+			GameType:   "digital",
+			Location:   launcherLocationName(playniteName(game.Source)),
+			ExternalID: externalID,
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: Playnite export has no games", ErrValidationFailed)
+	}
+
+	return rows, nil
+}
+
+// playniteName reads a Playnite field that is either {"Name": "..."} or a string
+func playniteName(raw json.RawMessage) string {
+	var named struct {
+		Name string `json:"Name"`
+	}
+	if err := json.Unmarshal(raw, &named); err == nil {
+		return named.Name
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return name
+	}
+	return ""
+}
+
+// playnitePlatform converts a Playnite platform to something matchPlatform understands.
+// PC platforms become IGDB IDs, console names lose the manufacturer Playnite puts in front.
+func playnitePlatform(raw json.RawMessage) string {
+	var platform struct {
+		Name            string `json:"Name"`
+		SpecificationID string `json:"SpecificationId"`
+	}
+	if err := json.Unmarshal(raw, &platform); err != nil {
+		platform.Name = playniteName(raw)
+	}
+
+	switch strings.ToLower(platform.SpecificationID) {
+	case "pc_windows":
+		return pcPlatformID
+	case "pc_linux":
+		return "3"
+	case "macintosh":
+		return "14"
+	}
+
+	name := strings.TrimSpace(platform.Name)
+	if strings.HasPrefix(strings.ToLower(name), "pc") {
+		return pcPlatformID
+	}
+	for _, manufacturer := range []string{"Sony ", "Microsoft "} {
+		name = strings.TrimPrefix(name, manufacturer)
+	}
+	return name
+}
+
+// GOG Galaxy export columns, matched like the CSV import's headers
+const (
+	columnGOGTitle      = "title"
+	columnGOGName       = "name"
+	columnGOGReleaseKey = "releasekey"
+)
+
+// ParseGOGGalaxyImport reads a GOG Galaxy library CSV.
+// The store comes from the release key prefix, "steam_620" is Portal 2 owned on Steam.
+// Comma and tab separated files are both accepted.
+func ParseGOGGalaxyImport(reader io.Reader) ([]models.LibraryImportRowToSave, error) {
+	buffered := bufio.NewReader(reader)
+	firstLine, err := buffered.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("%w: unable to read GOG Galaxy export", ErrValidationFailed)
+	}
+	if newline := bytes.IndexByte(firstLine, '\n'); newline >= 0 {
+		firstLine = firstLine[:newline]
+	}
+
+	csvReader := csv.NewReader(buffered)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	if bytes.Count(firstLine, []byte("\t")) > bytes.Count(firstLine, []byte(",")) {
+		csvReader.Comma = '\t'
+	}
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: import file is empty", ErrValidationFailed)
+		}
+		return nil, fmt.Errorf("%w: invalid CSV header: %v", ErrValidationFailed, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ReplaceAll(normalizeColumnName(name), "_", "")] = i
+	}
+
+	titleColumn := columnGOGTitle
+	if _, ok := columns[titleColumn]; !ok {
+		titleColumn = columnGOGName
+	}
+	if _, ok := columns[titleColumn]; !ok {
+		return nil, fmt.Errorf("%w: GOG Galaxy export must have a '%s' column", ErrValidationFailed, columnGOGTitle)
+	}
+
+	var rows []models.LibraryImportRowToSave
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV on line %d: %v", ErrValidationFailed, line, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) >= MaxLauncherImportRows {
+			return nil, fmt.Errorf("%w: launcher imports are limited to %d games", ErrValidationFailed, MaxLauncherImportRows)
+		}
+
+		releaseKey := csvField(record, columns, columnGOGReleaseKey)
+		store, _, _ := strings.Cut(releaseKey, "_")
+
+		// Console stores don't say which console, those rows need a platform from review
+		platform := pcPlatformID
+		if service := launcherStoreServices[strings.ToLower(store)]; service == "playstation" || service == "xboxgamepass" {
+			platform = ""
+		}
+
+		rows = append(rows, models.LibraryImportRowToSave{
+			RowNumber:  len(rows) + 1,
+			Title:      csvField(record, columns, titleColumn),
+			Platform:   platform,
+			GameType:   "digital",
+			Location:   launcherLocationName(store),
+			ExternalID: releaseKey,
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: GOG Galaxy export has no games", ErrValidationFailed)
+	}
+
+	return rows, nil
+}
+
+// ParseSteamImport reads the owned games response from the Steam Web API.
+// Names are only present when the export was made with include_appinfo, entries without one go to review.
+func ParseSteamImport(reader io.Reader) ([]models.LibraryImportRowToSave, error) {
+	type steamGame struct {
+		AppID int64  `json:"appid"`
+		Name  string `json:"name"`
+	}
+
+	var export struct {
+		Response struct {
+			Games []steamGame `json:"games"`
+		} `json:"response"`
+		Games []steamGame `json:"games"`
+	}
+	if err := json.NewDecoder(reader).Decode(&export); err != nil {
+		return nil, fmt.Errorf("%w: invalid Steam export, expected the owned games JSON", ErrValidationFailed)
+	}
+
+	games := export.Response.Games
+	if len(games) == 0 {
+		games = export.Games
+	}
+	if len(games) == 0 {
+		return nil, fmt.Errorf("%w: Steam export has no games", ErrValidationFailed)
+	}
+	if len(games) > MaxLauncherImportRows {
+		return nil, fmt.Errorf("%w: launcher imports are limited to %d games", ErrValidationFailed, MaxLauncherImportRows)
+	}
+
+	location := launcherLocationName(models.LibraryImportSourceSteam)
+
+	rows := make([]models.LibraryImportRowToSave, 0, len(games))
+	for i, game := range games {
+		externalID := ""
+		if game.AppID > 0 {
+			externalID = strconv.FormatInt(game.AppID, 10)
+		}
+
+		rows = append(rows, models.LibraryImportRowToSave{
+			RowNumber:  i + 1,
+			Title:      strings.TrimSpace(game.Name),
+			Platform:   pcPlatformID,
+			GameType:   "digital",
+			Location:   location,
+			ExternalID: externalID,
+		})
+	}
+
+	return rows, nil
+}
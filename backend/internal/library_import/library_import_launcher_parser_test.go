@@ -0,0 +1,187 @@
+package library_import
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+/*
+	Behavior:
+	- Parsing Playnite, GOG Galaxy and Steam exports into digital import rows
+
+	Scenarios:
+	- Playnite sources map to catalog locations, unknown sources are kept as given
+	- Playnite PC platforms become IGDB IDs and console names lose their manufacturer
+	- GOG Galaxy release keys decide the store, tab separated files are detected
+	- GOG Galaxy console stores leave the platform for review
+	- Steam owned games keep the app ID, both response shapes are accepted
+	- Exports without games are rejected
+*/
+
+func TestParsePlayniteImport(t *testing.T) {
+	/*
+		GIVEN a Playnite export with object and string sources and platforms
+		WHEN ParsePlayniteImport is called
+		THEN locations should use the catalog name and platforms should be normalized
+	*/
+	t.Run("Sources and platforms are normalized", func(t *testing.T) {
+		// GIVEN
+		input := `[
+			{"Id": "a1", "GameId": "1145360", "Name": " Hades ", "Source": {"Name": "Steam"},
+				"Platforms": [{"Name": "PC (Windows)", "SpecificationId": "pc_windows"}]},
+			{"Id": "b2", "Name": "Bloodborne", "Source": "PlayStation",
+				"Platforms": ["Sony PlayStation 4"]},
+			{"Id": "c3", "Name": "Celeste", "Source": {"Name": "Humble"}}
+		]`
+
+		// WHEN
+		rows, err := ParsePlayniteImport(strings.NewReader(input))
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Expected 3 rows, got %d", len(rows))
+		}
+
+		if rows[0].Title != "Hades" || rows[0].Platform != "6" || rows[0].Location != "Steam" ||
+			rows[0].GameType != "digital" || rows[0].ExternalID != "1145360" {
+			t.Errorf("Unexpected first row: %+v", rows[0])
+		}
+		if rows[1].Platform != "PlayStation 4" || rows[1].Location != "PlayStation Plus" || rows[1].ExternalID != "b2" {
+			t.Errorf("Unexpected second row: %+v", rows[1])
+		}
+		if rows[2].RowNumber != 3 || rows[2].Platform != "" || rows[2].Location != "Humble" {
+			t.Errorf("Unexpected third row: %+v", rows[2])
+		}
+	})
+
+	/*
+		GIVEN an empty Playnite export
+		WHEN ParsePlayniteImport is called
+		THEN a validation error should be returned
+	*/
+	t.Run("Empty export is rejected", func(t *testing.T) {
+		// WHEN
+		_, err := ParsePlayniteImport(strings.NewReader("[]"))
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
+
+func TestParseGOGGalaxyImport(t *testing.T) {
+	/*
+		GIVEN a tab separated GOG Galaxy export with several stores
+		WHEN ParseGOGGalaxyImport is called
+		THEN the release key prefix should pick each row's location
+	*/
+	t.Run("Release keys decide the store", func(t *testing.T) {
+		// GIVEN
+		input := "releaseKey\ttitle\tplatformList\n" +
+			"steam_620\tPortal 2\tPC\n" +
+			"gog_1207658924\tThe Witcher\tPC\n" +
+			"epic_fn\tFortnite\tPC\n" +
+			"psn_CUSA00900\tBloodborne\tPS4\n"
+
+		// WHEN
+		rows, err := ParseGOGGalaxyImport(strings.NewReader(input))
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(rows) != 4 {
+			t.Fatalf("Expected 4 rows, got %d", len(rows))
+		}
+
+		expectedLocations := []string{"Steam", "GOG", "Epic Games", "PlayStation Plus"}
+		for i, expected := range expectedLocations {
+			if rows[i].Location != expected {
+				t.Errorf("Expected row %d location '%s', got '%s'", i+1, expected, rows[i].Location)
+			}
+		}
+		if rows[0].Title != "Portal 2" || rows[0].ExternalID != "steam_620" || rows[0].Platform != "6" {
+			t.Errorf("Unexpected first row: %+v", rows[0])
+		}
+		if rows[3].Platform != "" {
+			t.Errorf("Expected console row to have no platform, got '%s'", rows[3].Platform)
+		}
+	})
+
+	/*
+		GIVEN a GOG Galaxy export without a title column
+		WHEN ParseGOGGalaxyImport is called
+		THEN a validation error should be returned
+	*/
+	t.Run("Missing title column is rejected", func(t *testing.T) {
+		// WHEN
+		_, err := ParseGOGGalaxyImport(strings.NewReader("releaseKey,platformList\nsteam_620,PC\n"))
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
+
+func TestParseSteamImport(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "Web API response",
+			input: `{"response": {"game_count": 2, "games": [{"appid": 620, "name": "Portal 2"}, {"appid": 440}]}}`,
+		},
+		{
+			name:  "Bare games list",
+			input: `{"games": [{"appid": 620, "name": "Portal 2"}, {"appid": 440}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		/*
+			GIVEN a Steam owned games export
+			WHEN ParseSteamImport is called
+			THEN every game should become a Steam row with its app ID
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			rows, err := ParseSteamImport(strings.NewReader(testCase.input))
+
+			// THEN
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(rows) != 2 {
+				t.Fatalf("Expected 2 rows, got %d", len(rows))
+			}
+			if rows[0].Title != "Portal 2" || rows[0].ExternalID != "620" || rows[0].Location != "Steam" || rows[0].Platform != "6" {
+				t.Errorf("Unexpected first row: %+v", rows[0])
+			}
+			if rows[1].Title != "" || rows[1].ExternalID != "440" {
+				t.Errorf("Unexpected second row: %+v", rows[1])
+			}
+		})
+	}
+
+	/*
+		GIVEN a Steam export without games
+		WHEN ParseSteamImport is called
+		THEN a validation error should be returned
+	*/
+	t.Run("Empty export is rejected", func(t *testing.T) {
+		// WHEN
+		_, err := ParseSteamImport(strings.NewReader(`{"response": {}}`))
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package library_import
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// MaxImportRows caps a single upload, larger libraries can be split across several imports
+const MaxImportRows = 1000
+
+// CSV columns, matched case-insensitively. Spaces and dashes in headers are read as underscores.
+const (
+	columnTitle    = "title"
+	columnIGDBID   = "igdb_id"
+	columnPlatform = "platform"
+	columnType     = "type"
+	columnLocation = "location"
+)
+
+// ParseCSVImport reads an import file with a header row.
+// Either a title or an igdb_id column is required, unknown columns are ignored.
+func ParseCSVImport(reader io.Reader) ([]models.LibraryImportRowToSave, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: import file is empty", ErrValidationFailed)
+		}
+		return nil, fmt.Errorf("%w: invalid CSV header: %v", ErrValidationFailed, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[normalizeColumnName(name)] = i
+	}
+
+	_, hasTitle := columns[columnTitle]
+	_, hasIGDBID := columns[columnIGDBID]
+	if !hasTitle && !hasIGDBID {
+		return nil, fmt.Errorf("%w: CSV must have a '%s' or '%s' column", ErrValidationFailed, columnTitle, columnIGDBID)
+	}
+
+	var rows []models.LibraryImportRowToSave
+	for line := 2; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV on line %d: %v", ErrValidationFailed, line, err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) >= MaxImportRows {
+			return nil, fmt.Errorf("%w: imports are limited to %d rows", ErrValidationFailed, MaxImportRows)
+		}
+
+		row := models.LibraryImportRowToSave{
+			RowNumber: len(rows) + 1,
+			Title:     csvField(record, columns, columnTitle),
+			Platform:  csvField(record, columns, columnPlatform),
+			GameType:  strings.ToLower(csvField(record, columns, columnType)),
+			Location:  csvField(record, columns, columnLocation),
+		}
+
+		if rawID := csvField(record, columns, columnIGDBID); rawID != "" {
+			igdbID, err := strconv.ParseInt(rawID, 10, 64)
+			if err != nil || igdbID <= 0 {
+				return nil, fmt.Errorf("%w: invalid igdb_id '%s' on line %d", ErrValidationFailed, rawID, line)
+			}
+			row.IGDBID = igdbID
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: import file has no rows", ErrValidationFailed)
+	}
+
+	return rows, nil
+}
+
+// ParseJSONImport reads a {"rows": [...]} import body
+func ParseJSONImport(reader io.Reader) ([]models.LibraryImportRowToSave, error) {
+	var request types.LibraryImportRequest
+	if err := json.NewDecoder(reader).Decode(&request); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body", ErrValidationFailed)
+	}
+
+	if len(request.Rows) == 0 {
+		return nil, fmt.Errorf("%w: import has no rows", ErrValidationFailed)
+	}
+	if len(request.Rows) > MaxImportRows {
+		return nil, fmt.Errorf("%w: imports are limited to %d rows", ErrValidationFailed, MaxImportRows)
+	}
+
+	rows := make([]models.LibraryImportRowToSave, 0, len(request.Rows))
+	for i, requestRow := range request.Rows {
+		if requestRow.IGDBID < 0 {
+			return nil, fmt.Errorf("%w: invalid igdb_id on row %d", ErrValidationFailed, i+1)
+		}
+
+		rows = append(rows, models.LibraryImportRowToSave{
+			RowNumber: i + 1,
+			Title:     strings.TrimSpace(requestRow.Title),
+			IGDBID:    requestRow.IGDBID,
+			Platform:  strings.TrimSpace(requestRow.Platform),
+			GameType:  strings.ToLower(strings.TrimSpace(requestRow.Type)),
+			Location:  strings.TrimSpace(requestRow.Location),
+		})
+	}
+
+	return rows, nil
+}
+
+// normalizeColumnName also strips the byte order mark spreadsheet exports put before the first header
+func normalizeColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+func csvField(record []string, columns map[string]int, column string) string {
+	index, ok := columns[column]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
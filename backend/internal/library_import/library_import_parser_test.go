@@ -0,0 +1,153 @@
+package library_import
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/*
+	Behavior:
+	- Parsing CSV and JSON library imports into rows
+
+	Scenarios:
+	- CSV headers are matched case-insensitively, in any order
+	- CSV blank lines are skipped and row numbers stay contiguous
+	- CSV without a title or igdb_id column is rejected
+	- CSV with a non-numeric igdb_id is rejected with its line number
+	- CSV over MaxImportRows is rejected
+	- JSON rows are trimmed and the type is lowercased
+	- JSON without rows is rejected
+*/
+
+func TestParseCSVImport(t *testing.T) {
+	/*
+		GIVEN a CSV with mixed case headers in a custom order
+		WHEN ParseCSVImport is called
+		THEN every column should land on the right field
+	*/
+	t.Run("Headers are matched case-insensitively", func(t *testing.T) {
+		// GIVEN
+		input := "\ufeffLocation,IGDB ID,Title,Platform,Type\n" +
+			"Shelf A,,Chrono Trigger,SNES,Physical\n" +
+			"\n" +
+			"Steam,1942,,PC (Microsoft Windows),digital\n"
+
+		// WHEN
+		rows, err := ParseCSVImport(strings.NewReader(input))
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+
+		first := rows[0]
+		if first.RowNumber != 1 || first.Title != "Chrono Trigger" || first.Platform != "SNES" ||
+			first.GameType != "physical" || first.Location != "Shelf A" || first.IGDBID != 0 {
+			t.Errorf("Unexpected first row: %+v", first)
+		}
+
+		second := rows[1]
+		if second.RowNumber != 2 || second.IGDBID != 1942 || second.Title != "" || second.GameType != "digital" {
+			t.Errorf("Unexpected second row: %+v", second)
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			name:          "Missing title and igdb_id columns",
+			input:         "platform,location\nSNES,Shelf A\n",
+			expectedError: "must have a 'title' or 'igdb_id' column",
+		},
+		{
+			name:          "Non-numeric igdb_id",
+			input:         "title,igdb_id\nChrono Trigger,abc\n",
+			expectedError: "invalid igdb_id 'abc' on line 2",
+		},
+		{
+			name:          "Header only",
+			input:         "title,platform\n",
+			expectedError: "import file has no rows",
+		},
+		{
+			name:          "Empty file",
+			input:         "",
+			expectedError: "import file is empty",
+		},
+		{
+			name:          "Too many rows",
+			input:         "title\n" + strings.Repeat("Game\n", MaxImportRows+1),
+			expectedError: fmt.Sprintf("limited to %d rows", MaxImportRows),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			_, err := ParseCSVImport(strings.NewReader(testCase.input))
+
+			// THEN
+			if !errors.Is(err, ErrValidationFailed) {
+				t.Fatalf("Expected ErrValidationFailed, got %v", err)
+			}
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("Expected error containing %q, got %q", testCase.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseJSONImport(t *testing.T) {
+	/*
+		GIVEN a JSON import with padded values
+		WHEN ParseJSONImport is called
+		THEN values should be trimmed and numbered in order
+	*/
+	t.Run("Rows are trimmed and numbered", func(t *testing.T) {
+		// GIVEN
+		input := `{"rows": [
+			{"title": "  Hades ", "platform": "Nintendo Switch", "type": "DIGITAL", "location": "eShop"},
+			{"igdb_id": 1942, "location": "Shelf A"}
+		]}`
+
+		// WHEN
+		rows, err := ParseJSONImport(strings.NewReader(input))
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+		if rows[0].RowNumber != 1 || rows[0].Title != "Hades" || rows[0].GameType != "digital" {
+			t.Errorf("Unexpected first row: %+v", rows[0])
+		}
+		if rows[1].RowNumber != 2 || rows[1].IGDBID != 1942 || rows[1].Location != "Shelf A" {
+			t.Errorf("Unexpected second row: %+v", rows[1])
+		}
+	})
+
+	/*
+		GIVEN a JSON import without rows
+		WHEN ParseJSONImport is called
+		THEN it should return a validation error
+	*/
+	t.Run("No rows", func(t *testing.T) {
+		// WHEN
+		_, err := ParseJSONImport(strings.NewReader(`{"rows": []}`))
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
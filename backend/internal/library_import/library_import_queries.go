@@ -0,0 +1,143 @@
+package library_import
+
+const (
+	InsertImportJobQuery = `
+		INSERT INTO library_import_jobs (user_id, source, dry_run, status, total_rows)
+		VALUES ($1, $2, $3, 'pending', $4)
+		RETURNING id, user_id, source, dry_run, status, total_rows, processed_rows,
+			succeeded_rows, failed_rows, review_rows, error_message, created_at, started_at, completed_at
+	`
+
+	InsertImportRowQuery = `
+		INSERT INTO library_import_rows (job_id, row_number, title, igdb_id, platform, game_type, location, external_id)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, 0), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''))
+	`
+
+	GetImportJobQuery = `
+		SELECT id, user_id, source, dry_run, status, total_rows, processed_rows,
+			succeeded_rows, failed_rows, review_rows, error_message, created_at, started_at, completed_at
+		FROM library_import_jobs
+		WHERE id = $1 AND user_id = $2
+	`
+
+	GetImportRowsQuery = `
+		SELECT id, job_id, row_number, title, igdb_id, platform, game_type, location, external_id, status,
+			matched_game_id, matched_game_name, matched_platform_id, matched_platform_name,
+			matched_location_id, message
+		FROM library_import_rows
+		WHERE job_id = $1
+		ORDER BY row_number
+	`
+
+	GetPendingImportRowsQuery = `
+		SELECT id, job_id, row_number, title, igdb_id, platform, game_type, location, external_id, status,
+			matched_game_id, matched_game_name, matched_platform_id, matched_platform_name,
+			matched_location_id, message
+		FROM library_import_rows
+		WHERE job_id = $1 AND status = 'pending'
+		ORDER BY row_number
+	`
+
+	// Jobs interrupted by a restart are picked back up, only their pending rows are re-run
+	GetUnfinishedImportJobsQuery = `
+		SELECT id, user_id, source, dry_run, status, total_rows, processed_rows,
+			succeeded_rows, failed_rows, review_rows, error_message, created_at, started_at, completed_at
+		FROM library_import_jobs
+		WHERE status IN ('pending', 'running')
+		ORDER BY created_at
+	`
+
+	MarkImportJobRunningQuery = `
+		UPDATE library_import_jobs
+		SET status = 'running', started_at = COALESCE(started_at, NOW())
+		WHERE id = $1
+	`
+
+	// Only pending rows are updated so a row is never counted twice
+	UpdateImportRowResultQuery = `
+		UPDATE library_import_rows
+		SET status = $3,
+			matched_game_id = NULLIF($4, 0),
+			matched_game_name = NULLIF($5, ''),
+			matched_platform_id = NULLIF($6, 0),
+			matched_platform_name = NULLIF($7, ''),
+			matched_location_id = NULLIF($8, '')::uuid,
+			message = NULLIF($9, '')
+		WHERE id = $2 AND job_id = $1 AND status = 'pending'
+	`
+
+	IncrementImportJobCountersQuery = `
+		UPDATE library_import_jobs
+		SET processed_rows = processed_rows + 1,
+			succeeded_rows = succeeded_rows + $2,
+			failed_rows = failed_rows + $3,
+			review_rows = review_rows + $4
+		WHERE id = $1
+	`
+
+	CompleteImportJobQuery = `
+		UPDATE library_import_jobs
+		SET status = $2, error_message = NULLIF($3, ''), completed_at = NOW()
+		WHERE id = $1
+	`
+
+	GetImportLocationsQuery = `
+		SELECT id::text as id, name, 'physical' as game_type
+		FROM sublocations
+		WHERE user_id = $1 AND deleted_at IS NULL
+		UNION ALL
+		SELECT id::text as id, name, 'digital' as game_type
+		FROM digital_locations
+		WHERE user_id = $1 AND is_active = true
+	`
+
+	// The review queue only holds rows from real imports, dry run reports are never acted on
+	GetReviewRowsQuery = `
+		SELECT r.id, r.job_id, r.row_number, r.title, r.igdb_id, r.platform, r.game_type, r.location,
+			r.external_id, r.status, r.matched_game_id, r.matched_game_name, r.matched_platform_id,
+			r.matched_platform_name, r.matched_location_id, r.message
+		FROM library_import_rows r
+		JOIN library_import_jobs j ON j.id = r.job_id
+		WHERE j.user_id = $1 AND j.dry_run = false AND r.status = 'needs_review'
+		ORDER BY j.created_at, r.row_number
+	`
+
+	GetReviewRowQuery = `
+		SELECT r.id, r.job_id, r.row_number, r.title, r.igdb_id, r.platform, r.game_type, r.location,
+			r.external_id, r.status, r.matched_game_id, r.matched_game_name, r.matched_platform_id,
+			r.matched_platform_name, r.matched_location_id, r.message
+		FROM library_import_rows r
+		JOIN library_import_jobs j ON j.id = r.job_id
+		WHERE j.user_id = $1 AND j.dry_run = false AND r.status = 'needs_review' AND r.id = $2
+	`
+
+	// Only rows still waiting for review are updated so a row is never resolved twice
+	ResolveReviewRowQuery = `
+		UPDATE library_import_rows
+		SET status = $3,
+			matched_game_id = NULLIF($4, 0),
+			matched_game_name = NULLIF($5, ''),
+			matched_platform_id = NULLIF($6, 0),
+			matched_platform_name = NULLIF($7, ''),
+			matched_location_id = NULLIF($8, '')::uuid,
+			message = NULLIF($9, '')
+		WHERE id = $2 AND job_id = $1 AND status = 'needs_review'
+	`
+
+	DismissReviewRowQuery = `
+		UPDATE library_import_rows r
+		SET status = 'dismissed'
+		FROM library_import_jobs j
+		WHERE j.id = r.job_id AND j.user_id = $1 AND j.dry_run = false AND r.id = $2 AND r.status = 'needs_review'
+		RETURNING r.job_id
+	`
+
+	// Moves a reviewed row out of the job's review count and into succeeded or failed
+	MoveReviewRowCountersQuery = `
+		UPDATE library_import_jobs
+		SET review_rows = review_rows - 1,
+			succeeded_rows = succeeded_rows + $2,
+			failed_rows = failed_rows + $3
+		WHERE id = $1
+	`
+)
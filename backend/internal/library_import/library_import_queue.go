@@ -0,0 +1,15 @@
+package library_import
+
+// ImportJob identifies a queued library import
+type ImportJob struct {
+	JobID  string
+	UserID string
+}
+
+// LogFields identifies the job in the import queue's logs
+func (job ImportJob) LogFields() map[string]any {
+	return map[string]any{
+		"jobID":  job.JobID,
+		"userID": job.UserID,
+	}
+}
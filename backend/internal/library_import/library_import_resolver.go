@@ -0,0 +1,273 @@
+package library_import
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/igdb"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/search"
+)
+
+// titleSearchLimit is how many IGDB results are considered when matching a title
+const titleSearchLimit = 10
+
+// gameResolver matches import rows to IGDB games.
+// Lookups are cached for the lifetime of a job so repeated titles only hit IGDB once.
+type gameResolver struct {
+	appContext  *appcontext.AppContext
+	adapter     interfaces.IGDBAdapter
+	logger      interfaces.Logger
+	gamesByID   map[int64]*models.Game
+	gamesByName map[string][]*models.Game
+
+	// exactTitles only accepts a title match, launcher rows that don't have one go to review
+	// instead of trusting IGDB's ranking
+	exactTitles bool
+}
+
+func newGameResolver(appContext *appcontext.AppContext, adapter interfaces.IGDBAdapter) *gameResolver {
+	return &gameResolver{
+		appContext:  appContext,
+		adapter:     adapter,
+		logger:      appContext.Logger,
+		gamesByID:   make(map[int64]*models.Game),
+		gamesByName: make(map[string][]*models.Game),
+	}
+}
+
+// prefetchByIDs loads every IGDB ID in the job up front, igdb.MaxLimit IDs per request
+func (gr *gameResolver) prefetchByIDs(ctx context.Context, rows []models.LibraryImportRowDB) error {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, row := range rows {
+		if row.IGDBID.Valid && !seen[row.IGDBID.Int64] {
+			seen[row.IGDBID.Int64] = true
+			ids = append(ids, row.IGDBID.Int64)
+		}
+	}
+
+	for start := 0; start < len(ids); start += igdb.MaxLimit {
+		end := min(start+igdb.MaxLimit, len(ids))
+
+		var games []*models.Game
+		err := search.WithTokenRefresh(ctx, gr.appContext, gr.adapter, func() error {
+			var err error
+			games, err = gr.adapter.GetGamesByIDs(ctx, ids[start:end])
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching games from IGDB: %w", err)
+		}
+
+		for _, game := range games {
+			gr.gamesByID[game.ID] = game
+		}
+	}
+
+	return nil
+}
+
+// resolveGame finds the game for a row, preferring the IGDB ID over the title.
+// The returned message explains why a row couldn't be matched.
+func (gr *gameResolver) resolveGame(ctx context.Context, row models.LibraryImportRowDB) (*models.Game, string, error) {
+	if row.IGDBID.Valid {
+		game, ok := gr.gamesByID[row.IGDBID.Int64]
+		if !ok {
+			return nil, fmt.Sprintf("no IGDB game found with ID %d", row.IGDBID.Int64), nil
+		}
+		return game, "", nil
+	}
+
+	title := strings.TrimSpace(row.Title.String)
+	if title == "" {
+		return nil, "title or igdb_id is required", nil
+	}
+
+	games, err := gr.searchTitle(ctx, title)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var game *models.Game
+	if gr.exactTitles {
+		game = pickExactTitleMatch(games, title)
+	} else {
+		game = pickTitleMatch(games, title)
+	}
+	if game == nil {
+		return nil, fmt.Sprintf("no IGDB game found matching '%s'", title), nil
+	}
+
+	return game, "", nil
+}
+
+// suggestGame is IGDB's best guess for a title that had no exact match, nil if the search came back empty
+func (gr *gameResolver) suggestGame(title string) *models.Game {
+	return pickTitleMatch(gr.gamesByName[strings.ToLower(strings.TrimSpace(title))], title)
+}
+
+// searchTitle returns IGDB's candidates for a title, searching each title only once per job
+func (gr *gameResolver) searchTitle(ctx context.Context, title string) ([]*models.Game, error) {
+	cacheKey := strings.ToLower(title)
+	if games, ok := gr.gamesByName[cacheKey]; ok {
+		return games, nil
+	}
+
+	var games []*models.Game
+	err := search.WithTokenRefresh(ctx, gr.appContext, gr.adapter, func() error {
+		var err error
+		games, err = gr.adapter.SearchGames(ctx, title, titleSearchLimit, 0)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching IGDB: %w", err)
+	}
+
+	gr.gamesByName[cacheKey] = games
+	return games, nil
+}
+
+// gameByID fetches a single game, used when the user picks the match for a review row
+func (gr *gameResolver) gameByID(ctx context.Context, gameID int64) (*models.Game, error) {
+	if game, ok := gr.gamesByID[gameID]; ok {
+		return game, nil
+	}
+
+	var games []*models.Game
+	err := search.WithTokenRefresh(ctx, gr.appContext, gr.adapter, func() error {
+		var err error
+		games, err = gr.adapter.GetGamesByIDs(ctx, []int64{gameID})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching game from IGDB: %w", err)
+	}
+
+	for _, game := range games {
+		if game != nil && game.ID == gameID {
+			gr.gamesByID[gameID] = game
+			return game, nil
+		}
+	}
+	return nil, nil
+}
+
+// pickTitleMatch prefers an exact, case-insensitive name match and otherwise trusts IGDB's ranking
+func pickTitleMatch(games []*models.Game, title string) *models.Game {
+	for _, game := range games {
+		if game != nil && strings.EqualFold(strings.TrimSpace(game.Name), title) {
+			return game
+		}
+	}
+	for _, game := range games {
+		if game != nil {
+			return game
+		}
+	}
+	return nil
+}
+
+// pickExactTitleMatch only accepts a game whose name matches once case, punctuation and
+// trademark symbols are ignored, "DOOM Eternal" matches "Doom: Eternal™" but not "Doom 64"
+func pickExactTitleMatch(games []*models.Game, title string) *models.Game {
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return nil
+	}
+
+	for _, game := range games {
+		if game != nil && normalizeTitle(game.Name) == normalized {
+			return game
+		}
+	}
+	return nil
+}
+
+// normalizeTitle lowercases a title and reduces punctuation and symbols to single spaces
+func normalizeTitle(title string) string {
+	var builder strings.Builder
+	space := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == '™' || r == '®' || r == '©':
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && builder.Len() > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteRune(r)
+			space = false
+		default:
+			space = true
+		}
+	}
+	return builder.String()
+}
+
+// matchPlatform finds the row's platform among the platforms the game was released on.
+// The platform may be an IGDB platform ID or a name, a game on a single platform needs neither.
+func matchPlatform(game *models.Game, platform string) (models.PlatformInfo, string) {
+	platform = strings.TrimSpace(platform)
+	if platform == "" {
+		if len(game.Platforms) == 1 {
+			return game.Platforms[0], ""
+		}
+		return models.PlatformInfo{}, fmt.Sprintf("platform is required, '%s' is available on %d platforms", game.Name, len(game.Platforms))
+	}
+
+	if platformID, err := strconv.ParseInt(platform, 10, 64); err == nil {
+		for _, candidate := range game.Platforms {
+			if candidate.ID == platformID {
+				return candidate, ""
+			}
+		}
+	}
+
+	for _, candidate := range game.Platforms {
+		if strings.EqualFold(candidate.Name, platform) {
+			return candidate, ""
+		}
+	}
+
+	return models.PlatformInfo{}, fmt.Sprintf("'%s' is not available on platform '%s'", game.Name, platform)
+}
+
+// matchLocation finds the row's location by ID or name among the user's locations.
+// gameType narrows the search when given, otherwise it is taken from the matched location.
+func matchLocation(locations []models.LibraryImportLocationDB, location string, gameType string) (models.LibraryImportLocationDB, string) {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return models.LibraryImportLocationDB{}, "location is required"
+	}
+	if gameType != "" && gameType != "physical" && gameType != "digital" {
+		return models.LibraryImportLocationDB{}, fmt.Sprintf("invalid type '%s', must be physical or digital", gameType)
+	}
+
+	var matches []models.LibraryImportLocationDB
+	for _, candidate := range locations {
+		if gameType != "" && candidate.GameType != gameType {
+			continue
+		}
+		if strings.EqualFold(candidate.ID, location) {
+			return candidate, ""
+		}
+		if strings.EqualFold(candidate.Name, location) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return models.LibraryImportLocationDB{}, fmt.Sprintf("no location found matching '%s'", location)
+	case 1:
+		return matches[0], ""
+	default:
+		return models.LibraryImportLocationDB{}, fmt.Sprintf("location '%s' is ambiguous, use its ID instead", location)
+	}
+}
@@ -0,0 +1,150 @@
+package library_import
+
+import (
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+/*
+	Behavior:
+	- Matching an import row's title, platform and location
+
+	Scenarios:
+	- An exact title match wins over IGDB's ranking
+	- Launcher imports only accept a title match that ignores case, punctuation and trademarks
+	- Platforms match by ID or name, single platform games need neither
+	- Locations match by ID or name, narrowed by type
+	- Ambiguous location names are rejected
+*/
+
+func TestPickTitleMatch(t *testing.T) {
+	games := []*models.Game{
+		{ID: 1, Name: "Doom Eternal"},
+		{ID: 2, Name: "DOOM"},
+	}
+
+	if game := pickTitleMatch(games, "doom"); game == nil || game.ID != 2 {
+		t.Errorf("Expected exact match with ID 2, got %+v", game)
+	}
+	if game := pickTitleMatch(games, "doom 3"); game == nil || game.ID != 1 {
+		t.Errorf("Expected first result with ID 1, got %+v", game)
+	}
+	if game := pickTitleMatch(nil, "doom"); game != nil {
+		t.Errorf("Expected no match, got %+v", game)
+	}
+}
+
+func TestPickExactTitleMatch(t *testing.T) {
+	games := []*models.Game{
+		{ID: 1, Name: "Doom 64"},
+		{ID: 2, Name: "Doom: Eternal™"},
+	}
+
+	if game := pickExactTitleMatch(games, "DOOM Eternal"); game == nil || game.ID != 2 {
+		t.Errorf("Expected normalized match with ID 2, got %+v", game)
+	}
+	if game := pickExactTitleMatch(games, "Doom 3"); game != nil {
+		t.Errorf("Expected no match, got %+v", game)
+	}
+	if game := pickExactTitleMatch(games, "™"); game != nil {
+		t.Errorf("Expected no match for an empty title, got %+v", game)
+	}
+	if normalized := normalizeTitle("  The Witcher® 3: Wild Hunt "); normalized != "the witcher 3 wild hunt" {
+		t.Errorf("Expected 'the witcher 3 wild hunt', got '%s'", normalized)
+	}
+}
+
+func TestMatchPlatform(t *testing.T) {
+	multiPlatformGame := &models.Game{
+		Name: "Hades",
+		Platforms: []models.PlatformInfo{
+			{ID: 6, Name: "PC (Microsoft Windows)"},
+			{ID: 130, Name: "Nintendo Switch"},
+		},
+	}
+	singlePlatformGame := &models.Game{
+		Name:      "Chrono Trigger",
+		Platforms: []models.PlatformInfo{{ID: 19, Name: "Super Nintendo Entertainment System"}},
+	}
+
+	testCases := []struct {
+		name            string
+		game            *models.Game
+		platform        string
+		expectedID      int64
+		expectedFailure bool
+	}{
+		{name: "Match by ID", game: multiPlatformGame, platform: "130", expectedID: 130},
+		{name: "Match by name", game: multiPlatformGame, platform: "nintendo switch", expectedID: 130},
+		{name: "Single platform needs no platform", game: singlePlatformGame, platform: "", expectedID: 19},
+		{name: "Multi platform needs a platform", game: multiPlatformGame, platform: "", expectedFailure: true},
+		{name: "Unknown platform", game: multiPlatformGame, platform: "PlayStation 5", expectedFailure: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			platform, message := matchPlatform(testCase.game, testCase.platform)
+
+			// THEN
+			if testCase.expectedFailure {
+				if message == "" {
+					t.Errorf("Expected a failure message, matched %+v", platform)
+				}
+				return
+			}
+			if message != "" {
+				t.Fatalf("Expected a match, got %q", message)
+			}
+			if platform.ID != testCase.expectedID {
+				t.Errorf("Expected platform %d, got %d", testCase.expectedID, platform.ID)
+			}
+		})
+	}
+}
+
+func TestMatchLocation(t *testing.T) {
+	locations := []models.LibraryImportLocationDB{
+		{ID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c001", Name: "Shelf A", GameType: "physical"},
+		{ID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c002", Name: "Shelf A", GameType: "physical"},
+		{ID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c003", Name: "Steam", GameType: "digital"},
+		{ID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c004", Name: "Closet", GameType: "physical"},
+	}
+
+	testCases := []struct {
+		name            string
+		location        string
+		gameType        string
+		expectedID      string
+		expectedFailure bool
+	}{
+		{name: "Match by ID", location: "6F1C8A52-0D7E-4BDE-9D0A-6F61C0A8C002", expectedID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c002"},
+		{name: "Match by name infers type", location: "steam", expectedID: "6f1c8a52-0d7e-4bde-9d0a-6f61c0a8c003"},
+		{name: "Type narrows the match", location: "Steam", gameType: "physical", expectedFailure: true},
+		{name: "Ambiguous name", location: "Shelf A", expectedFailure: true},
+		{name: "Missing location", location: "", expectedFailure: true},
+		{name: "Invalid type", location: "Closet", gameType: "cartridge", expectedFailure: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			location, message := matchLocation(locations, testCase.location, testCase.gameType)
+
+			// THEN
+			if testCase.expectedFailure {
+				if message == "" {
+					t.Errorf("Expected a failure message, matched %+v", location)
+				}
+				return
+			}
+			if message != "" {
+				t.Fatalf("Expected a match, got %q", message)
+			}
+			if location.ID != testCase.expectedID {
+				t.Errorf("Expected location %s, got %s", testCase.expectedID, location.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,582 @@
+package library_import
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/library"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	importFailedMessage    = "the import could not be completed, please try again"
+	importRowFailedMessage = "this row could not be added to your library, please try again"
+)
+
+type GameLibraryImportService struct {
+	appContext       *appcontext.AppContext
+	dbAdapter        interfaces.LibraryImportDbAdapter
+	igdbAdapter      interfaces.IGDBAdapter
+	libraryService   services.LibraryService
+	digitalService   services.DigitalService
+	analyticsService analytics.Service
+	validator        interfaces.LibraryImportValidator
+	queue            *worker.JobQueue[ImportJob]
+	logger           interfaces.Logger
+}
+
+type LibraryImportService interface {
+	CreateImportJob(ctx context.Context, userID string, source string, dryRun bool, rows []models.LibraryImportRowToSave) (types.LibraryImportJobResponse, error)
+	GetImportJob(ctx context.Context, userID string, jobID string) (types.LibraryImportJobResponse, error)
+	GetReviewQueue(ctx context.Context, userID string) ([]types.LibraryImportRowResponse, error)
+	ResolveReviewRow(ctx context.Context, userID string, rowID int64, request types.ResolveLibraryImportRowRequest) (types.LibraryImportRowResponse, error)
+	DismissReviewRow(ctx context.Context, userID string, rowID int64) error
+}
+
+func NewGameLibraryImportService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.LibraryImportDbAdapter,
+	igdbAdapter interfaces.IGDBAdapter,
+	libraryService services.LibraryService,
+	digitalService services.DigitalService,
+	analyticsService analytics.Service,
+) (*GameLibraryImportService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if igdbAdapter == nil {
+		return nil, fmt.Errorf("igdbAdapter is required")
+	}
+	if libraryService == nil {
+		return nil, fmt.Errorf("libraryService is required")
+	}
+	if digitalService == nil {
+		return nil, fmt.Errorf("digitalService is required")
+	}
+	if analyticsService == nil {
+		return nil, fmt.Errorf("analyticsService is required")
+	}
+
+	service := &GameLibraryImportService{
+		appContext:       appContext,
+		dbAdapter:        dbAdapter,
+		igdbAdapter:      igdbAdapter,
+		libraryService:   libraryService,
+		digitalService:   digitalService,
+		analyticsService: analyticsService,
+		validator:        NewLibraryImportValidator(),
+		logger:           appContext.Logger,
+	}
+	service.queue = worker.NewJobQueue("library import", 2, service.ProcessImportJob, appContext.Logger)
+
+	return service, nil
+}
+
+// Start starts the import workers and re-queues jobs that were interrupted by a restart
+func (is *GameLibraryImportService) Start(ctx context.Context) error {
+	if err := is.queue.Start(); err != nil {
+		return err
+	}
+
+	jobs, err := is.dbAdapter.GetUnfinishedImportJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := is.queue.EnqueueJob(ImportJob{JobID: job.ID, UserID: job.UserID}); err != nil {
+			is.logger.Error("Failed to resume library import job", map[string]any{
+				"jobID": job.ID,
+				"error": err,
+			})
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the import workers
+func (is *GameLibraryImportService) Stop() error {
+	return is.queue.Stop()
+}
+
+// POST
+// CreateImportJob stores the parsed rows and hands the job to the background queue
+func (is *GameLibraryImportService) CreateImportJob(
+	ctx context.Context,
+	userID string,
+	source string,
+	dryRun bool,
+	rows []models.LibraryImportRowToSave,
+) (types.LibraryImportJobResponse, error) {
+	is.logger.Info("GameLibraryImportService - CreateImportJob called", map[string]any{
+		"userID": userID,
+		"source": source,
+		"dryRun": dryRun,
+		"rows":   len(rows),
+	})
+
+	if err := is.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+	if err := is.validator.ValidateSource(source); err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+	maxRows := MaxImportRows
+	if models.IsLauncherImportSource(source) {
+		maxRows = MaxLauncherImportRows
+	}
+	if len(rows) == 0 || len(rows) > maxRows {
+		return types.LibraryImportJobResponse{}, fmt.Errorf("%w: imports must have between 1 and %d rows", ErrValidationFailed, maxRows)
+	}
+
+	job, err := is.dbAdapter.CreateImportJob(ctx, userID, source, dryRun, rows)
+	if err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+
+	if err := is.queue.EnqueueJob(ImportJob{JobID: job.ID, UserID: userID}); err != nil {
+		// Don't leave a job behind that nothing will ever pick up
+		if completeErr := is.dbAdapter.CompleteImportJob(ctx, job.ID, models.LibraryImportJobFailed, err.Error()); completeErr != nil {
+			is.logger.Error("Failed to mark unqueued library import as failed", map[string]any{
+				"jobID": job.ID,
+				"error": completeErr,
+			})
+		}
+		return types.LibraryImportJobResponse{}, fmt.Errorf("%w: try again later", ErrImportQueueFull)
+	}
+
+	return TransformImportJobToResponse(job, nil), nil
+}
+
+// GET
+// GetImportJob returns the job's progress along with the per-row report
+func (is *GameLibraryImportService) GetImportJob(
+	ctx context.Context,
+	userID string,
+	jobID string,
+) (types.LibraryImportJobResponse, error) {
+	if err := is.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+	if err := is.validator.ValidateJobID(jobID); err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+
+	job, err := is.dbAdapter.GetImportJob(ctx, userID, jobID)
+	if err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+
+	rows, err := is.dbAdapter.GetImportRows(ctx, jobID)
+	if err != nil {
+		return types.LibraryImportJobResponse{}, err
+	}
+
+	return TransformImportJobToResponse(job, rows), nil
+}
+
+// ProcessImportJob matches every pending row of a job and, unless it is a dry run, adds it to the library.
+// Rows are saved one at a time so progress is visible while the job runs and survives a restart.
+func (is *GameLibraryImportService) ProcessImportJob(ctx context.Context, queued ImportJob) error {
+	job, err := is.dbAdapter.GetImportJob(ctx, queued.UserID, queued.JobID)
+	if err != nil {
+		return err
+	}
+	if job.Status == models.LibraryImportJobCompleted || job.Status == models.LibraryImportJobFailed {
+		return nil
+	}
+
+	if err := is.dbAdapter.MarkImportJobRunning(ctx, job.ID); err != nil {
+		return err
+	}
+
+	rows, err := is.dbAdapter.GetPendingImportRows(ctx, job.ID)
+	if err != nil {
+		return is.failJob(ctx, job.ID, err)
+	}
+
+	locations, err := is.dbAdapter.GetImportLocations(ctx, job.UserID)
+	if err != nil {
+		return is.failJob(ctx, job.ID, err)
+	}
+
+	// Launcher exports are matched strictly, anything uncertain is left for the user to review
+	launcher := models.IsLauncherImportSource(job.Source)
+	if launcher {
+		locations = is.ensureLauncherLocations(ctx, job, rows, locations)
+	}
+
+	resolver := newGameResolver(is.appContext, is.igdbAdapter)
+	resolver.exactTitles = launcher
+	if err := resolver.prefetchByIDs(ctx, rows); err != nil {
+		return is.failJob(ctx, job.ID, err)
+	}
+
+	imported := 0
+	for _, row := range rows {
+		result := is.processRow(ctx, job, row, resolver, locations)
+		if launcher && result.Status == models.LibraryImportRowFailed {
+			result = reviewRow(row, result, resolver)
+		}
+
+		// Shutting down mid-row, leave the row pending so it is retried on restart
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := is.dbAdapter.SaveImportRowResult(ctx, job.ID, row.ID, result); err != nil {
+			return is.failJob(ctx, job.ID, err)
+		}
+		if result.Status == models.LibraryImportRowImported {
+			imported++
+		}
+	}
+
+	if err := is.dbAdapter.CompleteImportJob(ctx, job.ID, models.LibraryImportJobCompleted, ""); err != nil {
+		return err
+	}
+
+	if imported > 0 {
+		if err := is.analyticsService.InvalidateDomains(ctx, job.UserID, []string{
+			analytics.DomainGeneral,
+			analytics.DomainInventory,
+			analytics.DomainStorage,
+		}); err != nil {
+			is.logger.Warn("Failed to invalidate analytics cache after library import", map[string]any{
+				"jobID":  job.ID,
+				"userID": job.UserID,
+				"error":  err,
+			})
+		}
+	}
+
+	is.logger.Info("Library import job completed", map[string]any{
+		"jobID":    job.ID,
+		"userID":   job.UserID,
+		"dryRun":   job.DryRun,
+		"rows":     len(rows),
+		"imported": imported,
+	})
+
+	return nil
+}
+
+// processRow resolves a single row, a failure here only fails the row, never the job
+func (is *GameLibraryImportService) processRow(
+	ctx context.Context,
+	job models.LibraryImportJobDB,
+	row models.LibraryImportRowDB,
+	resolver *gameResolver,
+	locations []models.LibraryImportLocationDB,
+) models.LibraryImportRowResult {
+	game, message, err := resolver.resolveGame(ctx, row)
+	if err != nil {
+		is.logger.Error("Failed to resolve library import row", map[string]any{
+			"jobID":     job.ID,
+			"rowNumber": row.RowNumber,
+			"error":     err,
+		})
+		return failedRow("could not reach IGDB to match this row")
+	}
+	if game == nil {
+		return failedRow(message)
+	}
+
+	result := models.LibraryImportRowResult{
+		MatchedGameID:   game.ID,
+		MatchedGameName: game.Name,
+	}
+
+	platform, message := matchPlatform(game, row.Platform.String)
+	if message != "" {
+		result.Status, result.Message = models.LibraryImportRowFailed, message
+		return result
+	}
+	result.MatchedPlatformID, result.MatchedPlatformName = platform.ID, platform.Name
+
+	location, message := matchLocation(locations, row.Location.String, row.GameType.String)
+	if message != "" {
+		result.Status, result.Message = models.LibraryImportRowFailed, message
+		return result
+	}
+	result.MatchedLocationID = location.ID
+
+	if job.DryRun {
+		result.Status = models.LibraryImportRowMatched
+		if location.ID == "" {
+			result.Message = fmt.Sprintf("a '%s' digital location will be created", location.Name)
+		}
+		return result
+	}
+
+	if err := is.libraryService.CreateLibraryGame(ctx, job.UserID, buildGameToSave(game, platform, location)); err != nil {
+		is.logger.Error("Failed to add library import row to the library", map[string]any{
+			"jobID":     job.ID,
+			"rowNumber": row.RowNumber,
+			"gameID":    game.ID,
+			"error":     err,
+		})
+		result.Status, result.Message = models.LibraryImportRowFailed, importRowErrorMessage(err)
+		return result
+	}
+
+	result.Status = models.LibraryImportRowImported
+	return result
+}
+
+// ensureLauncherLocations creates the store locations a launcher import needs that the user doesn't have yet.
+// Only non-subscription catalog services are created, a subscription needs billing details we don't have.
+// Dry runs get a placeholder without an ID so rows still match and report what would be created.
+func (is *GameLibraryImportService) ensureLauncherLocations(
+	ctx context.Context,
+	job models.LibraryImportJobDB,
+	rows []models.LibraryImportRowDB,
+	locations []models.LibraryImportLocationDB,
+) []models.LibraryImportLocationDB {
+	for _, row := range rows {
+		name := row.Location.String
+		if name == "" {
+			continue
+		}
+		if hasDigitalLocation(locations, name) {
+			continue
+		}
+
+		service, ok := launcherCatalogServiceByName(name)
+		if !ok || service.IsSubscriptionService {
+			continue
+		}
+
+		if job.DryRun {
+			locations = append(locations, models.LibraryImportLocationDB{Name: service.Name, GameType: "digital"})
+			continue
+		}
+
+		created, err := is.digitalService.CreateDigitalLocation(ctx, job.UserID, types.DigitalLocationRequest{
+			Name:          service.Name,
+			IsActive:      true,
+			URL:           service.URL,
+			PaymentMethod: "generic",
+		})
+		if err != nil {
+			// Rows for this store fall through to the review queue with "no location found"
+			is.logger.Warn("Failed to create digital location for launcher import", map[string]any{
+				"jobID":   job.ID,
+				"service": service.ID,
+				"error":   err,
+			})
+			continue
+		}
+
+		is.logger.Info("Created digital location for launcher import", map[string]any{
+			"jobID":      job.ID,
+			"service":    service.ID,
+			"locationID": created.ID,
+		})
+		locations = append(locations, models.LibraryImportLocationDB{ID: created.ID, Name: created.Name, GameType: "digital"})
+	}
+
+	return locations
+}
+
+// GET
+// GetReviewQueue returns every launcher import row the user still has to resolve or dismiss
+func (is *GameLibraryImportService) GetReviewQueue(ctx context.Context, userID string) ([]types.LibraryImportRowResponse, error) {
+	if err := is.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := is.dbAdapter.GetReviewRows(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]types.LibraryImportRowResponse, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, TransformImportRowToResponse(row))
+	}
+
+	return response, nil
+}
+
+// POST
+// ResolveReviewRow imports a review row with the game, platform and location the user picked.
+// Anything left out of the request falls back to the row's suggestion and original values.
+func (is *GameLibraryImportService) ResolveReviewRow(
+	ctx context.Context,
+	userID string,
+	rowID int64,
+	request types.ResolveLibraryImportRowRequest,
+) (types.LibraryImportRowResponse, error) {
+	if err := is.validator.ValidateUserID(userID); err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+	if err := is.validator.ValidateRowID(rowID); err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+
+	row, err := is.dbAdapter.GetReviewRow(ctx, userID, rowID)
+	if err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+
+	gameID := request.IGDBID
+	if gameID == 0 {
+		gameID = row.MatchedGameID.Int64
+	}
+	if gameID <= 0 {
+		return types.LibraryImportRowResponse{}, fmt.Errorf("%w: igdb_id is required, this row has no suggested game", ErrValidationFailed)
+	}
+
+	game, err := newGameResolver(is.appContext, is.igdbAdapter).gameByID(ctx, gameID)
+	if err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+	if game == nil {
+		return types.LibraryImportRowResponse{}, fmt.Errorf("%w: no IGDB game found with ID %d", ErrValidationFailed, gameID)
+	}
+
+	platformName := request.Platform
+	if platformName == "" {
+		platformName = row.Platform.String
+	}
+	platform, message := matchPlatform(game, platformName)
+	if message != "" {
+		return types.LibraryImportRowResponse{}, fmt.Errorf("%w: %s", ErrValidationFailed, message)
+	}
+
+	locations, err := is.dbAdapter.GetImportLocations(ctx, userID)
+	if err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+	locationName := request.Location
+	if locationName == "" {
+		locationName = row.Location.String
+	}
+	location, message := matchLocation(locations, locationName, row.GameType.String)
+	if message != "" {
+		return types.LibraryImportRowResponse{}, fmt.Errorf("%w: %s", ErrValidationFailed, message)
+	}
+
+	// A failed import leaves the row in the queue so it can be retried or dismissed
+	if err := is.libraryService.CreateLibraryGame(ctx, userID, buildGameToSave(game, platform, location)); err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+
+	result := models.LibraryImportRowResult{
+		Status:              models.LibraryImportRowImported,
+		MatchedGameID:       game.ID,
+		MatchedGameName:     game.Name,
+		MatchedPlatformID:   platform.ID,
+		MatchedPlatformName: platform.Name,
+		MatchedLocationID:   location.ID,
+	}
+	if err := is.dbAdapter.ResolveReviewRow(ctx, row.JobID, row.ID, result); err != nil {
+		return types.LibraryImportRowResponse{}, err
+	}
+
+	if err := is.analyticsService.InvalidateDomains(ctx, userID, []string{
+		analytics.DomainGeneral,
+		analytics.DomainInventory,
+		analytics.DomainStorage,
+	}); err != nil {
+		is.logger.Warn("Failed to invalidate analytics cache after resolving import row", map[string]any{
+			"rowID":  row.ID,
+			"userID": userID,
+			"error":  err,
+		})
+	}
+
+	row.Status = result.Status
+	row.MatchedGameID = sql.NullInt64{Int64: game.ID, Valid: true}
+	row.MatchedGameName = sql.NullString{String: game.Name, Valid: true}
+	row.MatchedPlatformID = sql.NullInt64{Int64: platform.ID, Valid: true}
+	row.MatchedPlatformName = sql.NullString{String: platform.Name, Valid: true}
+	row.MatchedLocationID = sql.NullString{String: location.ID, Valid: true}
+	row.Message = sql.NullString{}
+
+	return TransformImportRowToResponse(row), nil
+}
+
+// POST
+// DismissReviewRow removes a row from the review queue without importing it
+func (is *GameLibraryImportService) DismissReviewRow(ctx context.Context, userID string, rowID int64) error {
+	if err := is.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := is.validator.ValidateRowID(rowID); err != nil {
+		return err
+	}
+
+	return is.dbAdapter.DismissReviewRow(ctx, userID, rowID)
+}
+
+// failJob records a job level error, the original error is returned for logging
+func (is *GameLibraryImportService) failJob(ctx context.Context, jobID string, cause error) error {
+	if errors.Is(cause, context.Canceled) {
+		return cause
+	}
+
+	// The cause may be a raw db or IGDB error, so users only see a generic message
+	if err := is.dbAdapter.CompleteImportJob(ctx, jobID, models.LibraryImportJobFailed, importFailedMessage); err != nil {
+		is.logger.Error("Failed to mark library import job as failed", map[string]any{
+			"jobID": jobID,
+			"error": err,
+		})
+	}
+
+	return cause
+}
+
+func hasDigitalLocation(locations []models.LibraryImportLocationDB, name string) bool {
+	for _, location := range locations {
+		if location.GameType == "digital" && strings.EqualFold(location.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewRow sends a launcher row that couldn't be imported to the review queue.
+// When the title had no exact match IGDB's best guess is kept as the suggested game.
+func reviewRow(row models.LibraryImportRowDB, result models.LibraryImportRowResult, resolver *gameResolver) models.LibraryImportRowResult {
+	result.Status = models.LibraryImportRowNeedsReview
+	if result.MatchedGameID == 0 {
+		if suggestion := resolver.suggestGame(row.Title.String); suggestion != nil {
+			result.MatchedGameID, result.MatchedGameName = suggestion.ID, suggestion.Name
+		}
+	}
+	return result
+}
+
+// importRowErrorMessage turns a library error into a row message users can act on.
+// Anything else may be a raw db error, so users only see a generic message.
+func importRowErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, library.ErrValidationFailed):
+		return "the matched game, platform or location isn't valid for your library"
+	case errors.Is(err, library.ErrDuplicateGame):
+		return "this game is already in your library"
+	case errors.Is(err, library.ErrGameNotFound):
+		return "the matched game could not be found"
+	default:
+		return importRowFailedMessage
+	}
+}
+
+func failedRow(message string) models.LibraryImportRowResult {
+	return models.LibraryImportRowResult{
+		Status:  models.LibraryImportRowFailed,
+		Message: message,
+	}
+}
@@ -0,0 +1,37 @@
+package library_import
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/library"
+)
+
+/*
+	Behavior:
+	- Reporting why an import row couldn't be added to the library
+
+	Scenarios:
+	- Known library errors get a message users can act on
+	- Any other error gets a generic message, never the raw error
+*/
+
+func TestImportRowErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"validation", fmt.Errorf("%w: invalid game: game name is required", library.ErrValidationFailed), "the matched game, platform or location isn't valid for your library"},
+		{"duplicate", library.ErrDuplicateGame, "this game is already in your library"},
+		{"not found", library.ErrGameNotFound, "the matched game could not be found"},
+		{"database", errors.New(`pq: duplicate key value violates unique constraint "user_games_pkey"`), importRowFailedMessage},
+	}
+
+	for _, test := range tests {
+		if message := importRowErrorMessage(test.err); message != test.expected {
+			t.Errorf("%s: expected '%s', got '%s'", test.name, test.expected, message)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package library_import
+
+import (
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformImportJobToResponse converts a job and its rows to the status resource.
+// Rows are left out while the job is only being polled for progress.
+func TransformImportJobToResponse(job models.LibraryImportJobDB, rows []models.LibraryImportRowDB) types.LibraryImportJobResponse {
+	response := types.LibraryImportJobResponse{
+		ID:            job.ID,
+		Source:        job.Source,
+		DryRun:        job.DryRun,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SucceededRows: job.SucceededRows,
+		FailedRows:    job.FailedRows,
+		ReviewRows:    job.ReviewRows,
+		ErrorMessage:  job.ErrorMessage.String,
+		CreatedAt:     job.CreatedAt.Unix(),
+	}
+	if job.StartedAt.Valid {
+		response.StartedAt = job.StartedAt.Time.Unix()
+	}
+	if job.CompletedAt.Valid {
+		response.CompletedAt = job.CompletedAt.Time.Unix()
+	}
+
+	if len(rows) > 0 {
+		response.Rows = make([]types.LibraryImportRowResponse, 0, len(rows))
+		for _, row := range rows {
+			response.Rows = append(response.Rows, TransformImportRowToResponse(row))
+		}
+	}
+
+	return response
+}
+
+// TransformImportRowToResponse converts a single row for the job report and the review queue
+func TransformImportRowToResponse(row models.LibraryImportRowDB) types.LibraryImportRowResponse {
+	return types.LibraryImportRowResponse{
+		ID:                  row.ID,
+		JobID:               row.JobID,
+		RowNumber:           row.RowNumber,
+		Title:               row.Title.String,
+		IGDBID:              row.IGDBID.Int64,
+		Platform:            row.Platform.String,
+		Type:                row.GameType.String,
+		Location:            row.Location.String,
+		ExternalID:          row.ExternalID.String,
+		Status:              row.Status,
+		MatchedGameID:       row.MatchedGameID.Int64,
+		MatchedGameName:     row.MatchedGameName.String,
+		MatchedPlatformID:   row.MatchedPlatformID.Int64,
+		MatchedPlatformName: row.MatchedPlatformName.String,
+		MatchedLocationID:   row.MatchedLocationID.String,
+		Message:             row.Message.String,
+	}
+}
+
+// buildGameToSave turns a matched import row into the same shape the add game form sends
+func buildGameToSave(
+	game *models.Game,
+	platform models.PlatformInfo,
+	location models.LibraryImportLocationDB,
+) models.GameToSave {
+	platformLocation := models.GameToSaveLocation{
+		PlatformID:   platform.ID,
+		PlatformName: platform.Name,
+		Type:         location.GameType,
+	}
+	if location.GameType == "physical" {
+		platformLocation.Location.SublocationID = location.ID
+	} else {
+		platformLocation.Location.DigitalLocationID = location.ID
+	}
+
+	return models.GameToSave{
+		GameID:               game.ID,
+		GameName:             game.Name,
+		GameCoverURL:         game.CoverURL,
+		GameFirstReleaseDate: game.FirstReleaseDate,
+		GameType: models.GameToSaveIGDBType{
+			DisplayText:    game.GameType.DisplayText,
+			NormalizedText: game.GameType.NormalizedText,
+		},
+		GameThemeNames:    game.ThemeNames,
+		GameGenres:        models.NewGameMetadataTerms(game.Genres, game.GenreNames),
+		GameThemes:        models.NewGameMetadataTerms(game.Themes, game.ThemeNames),
+		PlatformLocations: []models.GameToSaveLocation{platformLocation},
+		GameRating:        game.Rating,
+	}
+}
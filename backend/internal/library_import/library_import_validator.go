@@ -0,0 +1,47 @@
+package library_import
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type LibraryImportValidatorImpl struct{}
+
+func NewLibraryImportValidator() interfaces.LibraryImportValidator {
+	return &LibraryImportValidatorImpl{}
+}
+
+func (v *LibraryImportValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *LibraryImportValidatorImpl) ValidateJobID(jobID string) error {
+	if _, err := uuid.Parse(jobID); err != nil {
+		return fmt.Errorf("%w: invalid import job ID", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *LibraryImportValidatorImpl) ValidateSource(source string) error {
+	switch source {
+	case models.LibraryImportSourceCSV, models.LibraryImportSourceJSON,
+		models.LibraryImportSourcePlaynite, models.LibraryImportSourceGOG, models.LibraryImportSourceSteam,
+		models.LibraryImportSourceBarcode:
+		return nil
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, source)
+	}
+}
+
+func (v *LibraryImportValidatorImpl) ValidateRowID(rowID int64) error {
+	if rowID <= 0 {
+		return fmt.Errorf("%w: invalid import row ID", ErrValidationFailed)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package loans
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type LoansDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewLoansDbAdapter(appContext *appcontext.AppContext) (*LoansDbAdapter, error) {
+	appContext.Logger.Debug("Creating LoansDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &LoansDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetLoans retrieves the user's loans matching a status filter
+func (la *LoansDbAdapter) GetLoans(
+	ctx context.Context,
+	userID string,
+	status string,
+	now time.Time,
+) ([]models.GameLoanDB, error) {
+	var loans []models.GameLoanDB
+	if err := la.db.SelectContext(ctx, &loans, GetLoansQuery, userID, status, now); err != nil {
+		return nil, fmt.Errorf("error getting loans: %w", err)
+	}
+
+	return loans, nil
+}
+
+// POST
+// CreateLoan lends a physical copy. Returns:
+//   - ErrCopyNotFound if the copy isn't in the user's library
+//   - ErrValidationFailed if the copy is digital
+//   - ErrCopyOnLoan if the copy hasn't come back from its last loan
+func (la *LoansDbAdapter) CreateLoan(
+	ctx context.Context,
+	userID string,
+	loan models.LoanToSave,
+) (models.GameLoanDB, error) {
+	var created models.GameLoanDB
+	err := postgres.WithTransaction(ctx, la.db, la.logger, func(tx *sqlx.Tx) error {
+		var gameType string
+		if err := tx.GetContext(ctx, &gameType, GetLoanCopyTypeQuery, loan.UserGameID, userID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCopyNotFound
+			}
+			return fmt.Errorf("error checking game copy: %w", err)
+		}
+		if gameType != "physical" {
+			return fmt.Errorf("%w: only physical copies can be lent", ErrValidationFailed)
+		}
+
+		var onLoan bool
+		if err := tx.GetContext(ctx, &onLoan, CheckCopyOnLoanQuery, loan.UserGameID); err != nil {
+			return fmt.Errorf("error checking open loans: %w", err)
+		}
+		if onLoan {
+			return ErrCopyOnLoan
+		}
+
+		var loanID string
+		err := tx.GetContext(
+			ctx,
+			&loanID,
+			InsertLoanQuery,
+			userID,
+			loan.UserGameID,
+			loan.BorrowerName,
+			loan.BorrowerContact,
+			loan.LentAt,
+			loan.DueAt,
+			loan.RemindWhenOverdue,
+		)
+		if err != nil {
+			return fmt.Errorf("error creating loan: %w", err)
+		}
+
+		if err := tx.GetContext(ctx, &created, GetLoanQuery, userID, loanID); err != nil {
+			return fmt.Errorf("error reading created loan: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.GameLoanDB{}, err
+	}
+
+	return created, nil
+}
+
+// POST
+// ReturnLoan closes a loan, the copy is back in its sublocation. Returns:
+//   - ErrLoanNotFound if the loan isn't the user's
+//   - ErrLoanAlreadyReturned if it was closed before
+//   - ErrValidationFailed if returnedAt is before the copy was lent
+func (la *LoansDbAdapter) ReturnLoan(
+	ctx context.Context,
+	userID string,
+	loanID string,
+	returnedAt time.Time,
+) (models.GameLoanDB, error) {
+	var returned models.GameLoanDB
+	err := postgres.WithTransaction(ctx, la.db, la.logger, func(tx *sqlx.Tx) error {
+		var loan models.GameLoanDB
+		if err := tx.GetContext(ctx, &loan, GetLoanQuery, userID, loanID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrLoanNotFound
+			}
+			return fmt.Errorf("error getting loan: %w", err)
+		}
+		if loan.ReturnedAt.Valid {
+			return ErrLoanAlreadyReturned
+		}
+		if returnedAt.Before(loan.LentAt) {
+			return fmt.Errorf("%w: returnedAt cannot be before the copy was lent", ErrValidationFailed)
+		}
+
+		result, err := tx.ExecContext(ctx, ReturnLoanQuery, userID, loanID, returnedAt)
+		if err != nil {
+			return fmt.Errorf("error returning loan: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking returned loan: %w", err)
+		}
+		if rowsAffected == 0 {
+			// Returned by another request since it was read
+			return ErrLoanAlreadyReturned
+		}
+
+		if err := tx.GetContext(ctx, &returned, GetLoanQuery, userID, loanID); err != nil {
+			return fmt.Errorf("error reading returned loan: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.GameLoanDB{}, err
+	}
+
+	return returned, nil
+}
+
+// GET
+// GetDueLoanReminders retrieves overdue loans of every user that asked for a reminder and haven't had one
+func (la *LoansDbAdapter) GetDueLoanReminders(ctx context.Context, now time.Time) ([]models.LoanReminderDB, error) {
+	var reminders []models.LoanReminderDB
+	if err := la.db.SelectContext(ctx, &reminders, GetDueLoanRemindersQuery, now); err != nil {
+		return nil, fmt.Errorf("error getting due loan reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// PUT
+// MarkLoanReminderSent records the reminder so the loan isn't picked up again
+func (la *LoansDbAdapter) MarkLoanReminderSent(ctx context.Context, loanID string, sentAt time.Time) error {
+	if _, err := la.db.ExecContext(ctx, MarkLoanReminderSentQuery, loanID, sentAt); err != nil {
+		return fmt.Errorf("error marking loan reminder sent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package loans
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Lending physical copies and closing loans
+
+	Scenarios:
+	- CreateLoan returns ErrCopyOnLoan while the copy is lent out
+	- CreateLoan rejects digital copies
+	- ReturnLoan returns ErrLoanAlreadyReturned for closed loans
+*/
+
+func TestLoansDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	loanID := "9c1e4b7a-2f3d-4e5a-8b6c-7d8e9f0a1b2c"
+	lentAt := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	setupMockDB := func() (*LoansDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &LoansDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	loan := models.LoanToSave{
+		UserGameID:   7,
+		BorrowerName: "Sam",
+		LentAt:       lentAt,
+	}
+
+	/*
+		GIVEN a physical copy with an open loan
+		WHEN CreateLoan is called
+		THEN it should return ErrCopyOnLoan without inserting a loan
+	*/
+	t.Run("CreateLoan rejects copies on loan", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT game_type").
+			WithArgs(loan.UserGameID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"game_type"}).AddRow("physical"))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(loan.UserGameID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.CreateLoan(context.Background(), userID, loan)
+
+		// THEN
+		if !errors.Is(err, ErrCopyOnLoan) {
+			t.Errorf("Expected ErrCopyOnLoan, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a digital copy
+		WHEN CreateLoan is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("CreateLoan rejects digital copies", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT game_type").
+			WithArgs(loan.UserGameID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"game_type"}).AddRow("digital"))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.CreateLoan(context.Background(), userID, loan)
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a loan that has been returned
+		WHEN ReturnLoan is called
+		THEN it should return ErrLoanAlreadyReturned without updating it
+	*/
+	t.Run("ReturnLoan rejects returned loans", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("FROM game_loans gl").
+			WithArgs(userID, loanID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "user_game_id", "lent_at", "returned_at"}).
+				AddRow(loanID, userID, loan.UserGameID, lentAt, lentAt.AddDate(0, 0, 5)))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.ReturnLoan(context.Background(), userID, loanID, lentAt.AddDate(0, 0, 6))
+
+		// THEN
+		if !errors.Is(err, ErrLoanAlreadyReturned) {
+			t.Errorf("Expected ErrLoanAlreadyReturned, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
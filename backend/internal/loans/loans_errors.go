@@ -0,0 +1,32 @@
+package loans
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrLoanNotFound        = errors.New("loan not found")
+	ErrCopyNotFound        = errors.New("game copy not found in library")
+	ErrCopyOnLoan          = errors.New("game copy is already on loan")
+	ErrLoanAlreadyReturned = errors.New("loan has already been returned")
+	ErrValidationFailed    = errors.New("validation failed")
+	ErrDatabaseError       = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrLoanNotFound), errors.Is(err, ErrCopyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrCopyOnLoan), errors.Is(err, ErrLoanAlreadyReturned):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
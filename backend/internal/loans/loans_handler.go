@@ -0,0 +1,166 @@
+package loans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// RegisterLoanRoutes registers loan tracking under /library/loans
+func RegisterLoanRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	loansService services.LoansService,
+) {
+	r.Get("/", GetLoans(appCtx, loansService))
+	r.Get("/overdue", GetOverdueLoans(appCtx, loansService))
+	r.Post("/", CreateLoan(appCtx, loansService))
+	r.Post("/{loanID}/return", ReturnLoan(appCtx, loansService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the user from the request context, logging and responding when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, requestID string) (string, bool) {
+	userID := httputils.GetUserID(r)
+	if userID == "" {
+		appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+			"request_id": requestID,
+		})
+		handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+		return "", false
+	}
+	return userID, true
+}
+
+// helper fn to decode a JSON body into a request type, an empty body leaves the request untouched
+func decodeRequest(r *http.Request, request any) error {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: invalid request body", ErrValidationFailed)
+	}
+	return nil
+}
+
+// helper fn to write a successful response
+func respond(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, userID string, status int, data map[string]any) {
+	response := httputils.NewAPIResponse(r, userID, data)
+
+	httputils.RespondWithJSON(
+		httputils.NewResponseWriterAdapter(w),
+		appCtx.Logger,
+		status,
+		response,
+	)
+}
+
+// GetLoans handles GET requests for the user's loans, filtered by ?status=active|overdue|returned|all
+func GetLoans(appCtx *appcontext.AppContext, loansService services.LoansService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		loans, err := loansService.GetLoans(r.Context(), userID, r.URL.Query().Get("status"))
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"loans": loans})
+	}
+}
+
+// GetOverdueLoans handles GET requests for open loans past their due date
+func GetOverdueLoans(appCtx *appcontext.AppContext, loansService services.LoansService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		loans, err := loansService.GetOverdueLoans(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"loans": loans})
+	}
+}
+
+// CreateLoan handles POST requests to lend out a physical copy
+func CreateLoan(appCtx *appcontext.AppContext, loansService services.LoansService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.CreateLoanRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		loan, err := loansService.CreateLoan(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusCreated, map[string]any{"loan": loan})
+	}
+}
+
+// ReturnLoan handles POST requests that mark a loaned copy as returned
+func ReturnLoan(appCtx *appcontext.AppContext, loansService services.LoansService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		var request types.ReturnLoanRequest
+		if err := decodeRequest(r, &request); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		loan, err := loansService.ReturnLoan(r.Context(), userID, chi.URLParam(r, "loanID"), request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"loan": loan})
+	}
+}
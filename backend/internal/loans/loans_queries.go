@@ -0,0 +1,104 @@
+package loans
+
+// loanSelect reads a loan along with the copy, its platform and the sublocation it lives in.
+// Loans of trashed copies are hidden until the copy is restored.
+const loanSelect = `
+		SELECT
+			gl.id,
+			gl.user_id,
+			gl.user_game_id,
+			ug.game_id,
+			g.name as game_name,
+			p.name as platform_name,
+			sl.name as sublocation_name,
+			gl.borrower_name,
+			gl.borrower_contact,
+			gl.lent_at,
+			gl.due_at,
+			gl.returned_at,
+			gl.remind_when_overdue,
+			gl.reminder_sent_at,
+			gl.created_at,
+			gl.updated_at
+		FROM game_loans gl
+		JOIN user_games ug ON ug.id = gl.user_game_id AND ug.deleted_at IS NULL
+		JOIN games g ON g.id = ug.game_id
+		JOIN platforms p ON p.id = ug.platform_id
+		LEFT JOIN physical_game_locations pgl ON pgl.user_game_id = ug.id
+		LEFT JOIN sublocations sl ON sl.id = pgl.sublocation_id
+`
+
+const (
+	// $2 is one of models.LoanStatuses, $3 is the current time used to decide what is overdue.
+	// Open loans come first, the ones due soonest at the top.
+	GetLoansQuery = loanSelect + `
+		WHERE gl.user_id = $1
+		AND (
+			$2::text = 'all'
+			OR ($2::text = 'active' AND gl.returned_at IS NULL)
+			OR ($2::text = 'overdue' AND gl.returned_at IS NULL AND gl.due_at < $3)
+			OR ($2::text = 'returned' AND gl.returned_at IS NOT NULL)
+		)
+		ORDER BY gl.returned_at IS NOT NULL, gl.due_at ASC NULLS LAST, gl.lent_at DESC
+	`
+
+	GetLoanQuery = loanSelect + `
+		WHERE gl.user_id = $1 AND gl.id = $2
+	`
+
+	// Locks the copy so two loans for it can't be created at the same time
+	GetLoanCopyTypeQuery = `
+		SELECT game_type
+		FROM user_games
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	CheckCopyOnLoanQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM game_loans
+			WHERE user_game_id = $1 AND returned_at IS NULL
+		)
+	`
+
+	InsertLoanQuery = `
+		INSERT INTO game_loans (
+			user_id,
+			user_game_id,
+			borrower_name,
+			borrower_contact,
+			lent_at,
+			due_at,
+			remind_when_overdue
+		)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)
+		RETURNING id
+	`
+
+	ReturnLoanQuery = `
+		UPDATE game_loans
+		SET returned_at = $3, updated_at = NOW()
+		WHERE user_id = $1 AND id = $2 AND returned_at IS NULL
+	`
+
+	// -- REMINDERS --
+	GetDueLoanRemindersQuery = `
+		SELECT loans.*, u.email, u.first_name
+		FROM (` + loanSelect + `
+			WHERE gl.returned_at IS NULL
+			AND gl.remind_when_overdue
+			AND gl.reminder_sent_at IS NULL
+			AND gl.due_at < $1
+		) loans
+		JOIN users u ON u.id = loans.user_id
+		WHERE u.deleted_at IS NULL
+		ORDER BY loans.due_at
+		LIMIT 100
+	`
+
+	MarkLoanReminderSentQuery = `
+		UPDATE game_loans
+		SET reminder_sent_at = $2
+		WHERE id = $1
+	`
+)
@@ -0,0 +1,235 @@
+package loans
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// reminderInterval is how often overdue loans are checked for reminders
+const reminderInterval = time.Hour
+
+// loanEmailQueue is the part of email.EmailQueue used to send overdue reminders
+type loanEmailQueue interface {
+	EnqueueJob(ctx context.Context, jobType email.EmailJobType, userID, email string, data map[string]interface{}) error
+}
+
+type GameLoansService struct {
+	dbAdapter     interfaces.LoansDbAdapter
+	libraryCache  interfaces.LibraryCacheWrapper
+	emailQueue    loanEmailQueue
+	validator     interfaces.LoansValidator
+	logger        interfaces.Logger
+	now           func() time.Time
+	stopReminders context.CancelFunc
+}
+
+type LoansService interface {
+	GetLoans(ctx context.Context, userID string, status string) ([]types.LoanResponse, error)
+	GetOverdueLoans(ctx context.Context, userID string) ([]types.LoanResponse, error)
+	CreateLoan(ctx context.Context, userID string, request types.CreateLoanRequest) (types.LoanResponse, error)
+	ReturnLoan(ctx context.Context, userID string, loanID string, request types.ReturnLoanRequest) (types.LoanResponse, error)
+}
+
+// NewGameLoansService creates the loans service.
+// emailQueue may be nil when email isn't configured, loans then never send reminders.
+func NewGameLoansService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.LoansDbAdapter,
+	libraryCache interfaces.LibraryCacheWrapper,
+	emailQueue loanEmailQueue,
+) (*GameLoansService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if libraryCache == nil {
+		return nil, fmt.Errorf("libraryCache is required")
+	}
+
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		return nil, fmt.Errorf("creating sanitizer: %w", err)
+	}
+
+	validator, err := NewLoansValidator(sanitizer)
+	if err != nil {
+		return nil, fmt.Errorf("creating loans validator: %w", err)
+	}
+
+	return &GameLoansService{
+		dbAdapter:    dbAdapter,
+		libraryCache: libraryCache,
+		emailQueue:   emailQueue,
+		validator:    validator,
+		logger:       appContext.Logger,
+		now:          time.Now,
+	}, nil
+}
+
+// Start runs the overdue reminder job, nothing is started without an email queue
+func (ls *GameLoansService) Start(ctx context.Context) error {
+	if ls.emailQueue == nil {
+		ls.logger.Warn("Email is not configured, loan reminders are disabled", nil)
+		return nil
+	}
+
+	reminderCtx, cancel := context.WithCancel(context.Background())
+	ls.stopReminders = cancel
+	go worker.NewWorker(reminderInterval, ls.SendLoanReminders, nil, ls.logger).Start(reminderCtx)
+
+	return nil
+}
+
+// Stop stops the overdue reminder job
+func (ls *GameLoansService) Stop() error {
+	if ls.stopReminders != nil {
+		ls.stopReminders()
+	}
+	return nil
+}
+
+// GET
+// GetLoans lists the user's loans, status is one of models.LoanStatuses and defaults to open loans
+func (ls *GameLoansService) GetLoans(ctx context.Context, userID string, status string) ([]types.LoanResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+	status, err := ls.validator.ValidateStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	now := ls.now()
+	loans, err := ls.dbAdapter.GetLoans(ctx, userID, status, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformLoansToResponse(loans, now), nil
+}
+
+// GET
+// GetOverdueLoans lists open loans past their due date, the most overdue first
+func (ls *GameLoansService) GetOverdueLoans(ctx context.Context, userID string) ([]types.LoanResponse, error) {
+	return ls.GetLoans(ctx, userID, models.LoanStatusOverdue)
+}
+
+// POST
+// CreateLoan marks a physical copy as on loan, it stays in its sublocation
+func (ls *GameLoansService) CreateLoan(
+	ctx context.Context,
+	userID string,
+	request types.CreateLoanRequest,
+) (types.LoanResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	now := ls.now()
+	if err := ls.validator.ValidateCreateLoanRequest(request, now); err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	loan, err := ls.dbAdapter.CreateLoan(ctx, userID, TransformCreateLoanRequestToModel(request, now))
+	if err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	ls.invalidateLibrary(ctx, userID, loan.GameID)
+	return TransformLoanToResponse(loan, now), nil
+}
+
+// POST
+// ReturnLoan closes a loan, returnedAt defaults to now
+func (ls *GameLoansService) ReturnLoan(
+	ctx context.Context,
+	userID string,
+	loanID string,
+	request types.ReturnLoanRequest,
+) (types.LoanResponse, error) {
+	if err := ls.validator.ValidateUserID(userID); err != nil {
+		return types.LoanResponse{}, err
+	}
+	if err := ls.validator.ValidateLoanID(loanID); err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	now := ls.now()
+	if err := ls.validator.ValidateReturnLoanRequest(request, now); err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	returnedAt := now.UTC()
+	if request.ReturnedAt != nil {
+		returnedAt = time.Unix(*request.ReturnedAt, 0).UTC()
+	}
+
+	loan, err := ls.dbAdapter.ReturnLoan(ctx, userID, loanID, returnedAt)
+	if err != nil {
+		return types.LoanResponse{}, err
+	}
+
+	ls.invalidateLibrary(ctx, userID, loan.GameID)
+	return TransformLoanToResponse(loan, now), nil
+}
+
+// SendLoanReminders queues an email for each overdue loan that asked for one.
+// A loan is only marked as reminded once its email is queued, failures are picked up on the next run.
+func (ls *GameLoansService) SendLoanReminders(ctx context.Context) error {
+	if ls.emailQueue == nil {
+		return nil
+	}
+
+	now := ls.now()
+	reminders, err := ls.dbAdapter.GetDueLoanReminders(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		err := ls.emailQueue.EnqueueJob(ctx, email.EmailJobTypeLoanOverdue, reminder.UserID, reminder.Email, map[string]interface{}{
+			"userName":     reminder.FirstName,
+			"gameName":     reminder.GameName,
+			"borrowerName": reminder.BorrowerName,
+			"dueDate":      reminder.DueAt.Time,
+		})
+		if err != nil {
+			ls.logger.Error("Failed to queue loan reminder email", map[string]any{
+				"loanID": reminder.ID,
+				"error":  err,
+			})
+			continue
+		}
+
+		if err := ls.dbAdapter.MarkLoanReminderSent(ctx, reminder.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidateLibrary refreshes the library views that show whether a copy is on loan
+func (ls *GameLoansService) invalidateLibrary(ctx context.Context, userID string, gameID int64) {
+	if err := ls.libraryCache.InvalidateUserCache(ctx, userID); err != nil {
+		ls.logger.Error("Failed to invalidate library cache after loan change", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+	if err := ls.libraryCache.InvalidateGameCache(ctx, userID, gameID); err != nil {
+		ls.logger.Error("Failed to invalidate game cache after loan change", map[string]any{
+			"error":  err,
+			"userID": userID,
+			"gameID": gameID,
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package loans
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformCreateLoanRequestToModel trims a validated request, lentAt defaults to now
+func TransformCreateLoanRequestToModel(request types.CreateLoanRequest, now time.Time) models.LoanToSave {
+	loan := models.LoanToSave{
+		UserGameID:        request.UserGameID,
+		BorrowerName:      strings.TrimSpace(request.BorrowerName),
+		BorrowerContact:   strings.TrimSpace(request.BorrowerContact),
+		LentAt:            now.UTC(),
+		RemindWhenOverdue: request.RemindWhenOverdue,
+	}
+
+	if request.LentAt != nil {
+		loan.LentAt = time.Unix(*request.LentAt, 0).UTC()
+	}
+	if request.DueAt != nil {
+		dueAt := time.Unix(*request.DueAt, 0).UTC()
+		loan.DueAt = &dueAt
+	}
+
+	return loan
+}
+
+// TransformLoanToResponse converts a loan, now decides whether an open loan is overdue
+func TransformLoanToResponse(loan models.GameLoanDB, now time.Time) types.LoanResponse {
+	response := types.LoanResponse{
+		ID:                loan.ID,
+		UserGameID:        loan.UserGameID,
+		GameID:            loan.GameID,
+		GameName:          loan.GameName,
+		PlatformName:      loan.PlatformName,
+		SublocationName:   loan.SublocationName.String,
+		BorrowerName:      loan.BorrowerName,
+		BorrowerContact:   loan.BorrowerContact.String,
+		LentAt:            loan.LentAt.Unix(),
+		DueAt:             nullTimeToUnix(loan.DueAt),
+		ReturnedAt:        nullTimeToUnix(loan.ReturnedAt),
+		RemindWhenOverdue: loan.RemindWhenOverdue,
+		ReminderSentAt:    nullTimeToUnix(loan.ReminderSentAt),
+	}
+
+	if !loan.ReturnedAt.Valid && loan.DueAt.Valid && loan.DueAt.Time.Before(now) {
+		response.IsOverdue = true
+		response.DaysOverdue = daysOverdue(loan.DueAt.Time, now)
+	}
+
+	return response
+}
+
+func TransformLoansToResponse(loans []models.GameLoanDB, now time.Time) []types.LoanResponse {
+	response := make([]types.LoanResponse, 0, len(loans))
+	for _, loan := range loans {
+		response = append(response, TransformLoanToResponse(loan, now))
+	}
+	return response
+}
+
+// daysOverdue counts whole days past the due date, a loan due an hour ago is 0 days overdue
+func daysOverdue(dueAt time.Time, now time.Time) int {
+	return int(now.Sub(dueAt).Hours() / 24)
+}
+
+func nullTimeToUnix(value sql.NullTime) int64 {
+	if !value.Valid {
+		return 0
+	}
+	return value.Time.Unix()
+}
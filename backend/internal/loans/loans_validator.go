@@ -0,0 +1,122 @@
+package loans
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	MaxBorrowerNameLength    = 100
+	MaxBorrowerContactLength = 255
+)
+
+type LoansValidatorImpl struct {
+	sanitizer interfaces.Sanitizer
+}
+
+func NewLoansValidator(sanitizer interfaces.Sanitizer) (interfaces.LoansValidator, error) {
+	if sanitizer == nil {
+		return nil, fmt.Errorf("sanitizer cannot be nil")
+	}
+
+	return &LoansValidatorImpl{sanitizer: sanitizer}, nil
+}
+
+func (v *LoansValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *LoansValidatorImpl) ValidateLoanID(loanID string) error {
+	if _, err := uuid.Parse(loanID); err != nil {
+		return fmt.Errorf("%w: invalid loan ID '%s'", ErrValidationFailed, loanID)
+	}
+	return nil
+}
+
+// ValidateStatus normalizes the loan list filter, an empty status lists open loans
+func (v *LoansValidatorImpl) ValidateStatus(status string) (string, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		return models.LoanStatusActive, nil
+	}
+
+	for _, validStatus := range models.LoanStatuses {
+		if status == validStatus {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("%w: invalid status '%s', must be one of: %s", ErrValidationFailed, status, strings.Join(models.LoanStatuses, ", "))
+}
+
+func (v *LoansValidatorImpl) ValidateCreateLoanRequest(request types.CreateLoanRequest, now time.Time) error {
+	if request.UserGameID <= 0 {
+		return fmt.Errorf("%w: userGameId is required", ErrValidationFailed)
+	}
+
+	borrowerName := strings.TrimSpace(request.BorrowerName)
+	if borrowerName == "" {
+		return fmt.Errorf("%w: borrowerName is required", ErrValidationFailed)
+	}
+	if utf8.RuneCountInString(borrowerName) > MaxBorrowerNameLength {
+		return fmt.Errorf("%w: borrowerName must be %d characters or less", ErrValidationFailed, MaxBorrowerNameLength)
+	}
+	if err := v.checkPlainText("borrowerName", borrowerName); err != nil {
+		return err
+	}
+
+	borrowerContact := strings.TrimSpace(request.BorrowerContact)
+	if utf8.RuneCountInString(borrowerContact) > MaxBorrowerContactLength {
+		return fmt.Errorf("%w: borrowerContact must be %d characters or less", ErrValidationFailed, MaxBorrowerContactLength)
+	}
+	if err := v.checkPlainText("borrowerContact", borrowerContact); err != nil {
+		return err
+	}
+
+	lentAt := now
+	if request.LentAt != nil {
+		lentAt = time.Unix(*request.LentAt, 0)
+		if lentAt.After(now) {
+			return fmt.Errorf("%w: lentAt cannot be in the future", ErrValidationFailed)
+		}
+	}
+
+	if request.DueAt != nil && time.Unix(*request.DueAt, 0).Before(lentAt) {
+		return fmt.Errorf("%w: dueAt cannot be before lentAt", ErrValidationFailed)
+	}
+	if request.RemindWhenOverdue && request.DueAt == nil {
+		return fmt.Errorf("%w: a reminder needs a dueAt", ErrValidationFailed)
+	}
+
+	return nil
+}
+
+func (v *LoansValidatorImpl) ValidateReturnLoanRequest(request types.ReturnLoanRequest, now time.Time) error {
+	if request.ReturnedAt != nil && time.Unix(*request.ReturnedAt, 0).After(now) {
+		return fmt.Errorf("%w: returnedAt cannot be in the future", ErrValidationFailed)
+	}
+	return nil
+}
+
+// checkPlainText rejects input the sanitizer would change.
+// The escaped output isn't stored, so names like "O'Brien" keep their apostrophe.
+func (v *LoansValidatorImpl) checkPlainText(field string, value string) error {
+	sanitized, err := v.sanitizer.SanitizeString(value)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s content: %v", ErrValidationFailed, field, err)
+	}
+	if html.UnescapeString(sanitized) != value {
+		return fmt.Errorf("%w: %s must not contain HTML", ErrValidationFailed, field)
+	}
+	return nil
+}
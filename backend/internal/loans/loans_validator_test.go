@@ -0,0 +1,169 @@
+package loans
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Validating loan requests and the loan list filter
+
+	Scenarios:
+	- An empty status lists open loans, unknown statuses are rejected
+	- A loan needs a copy and a plain text borrower name
+	- Lend, due and return dates must be in order and not in the future
+	- A reminder needs a due date
+*/
+
+func TestLoansValidator(t *testing.T) {
+	sanitizer, err := security.NewSanitizer()
+	if err != nil {
+		t.Fatalf("Failed to create sanitizer: %v", err)
+	}
+	validator, err := NewLoansValidator(sanitizer)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	unix := func(t time.Time) *int64 {
+		value := t.Unix()
+		return &value
+	}
+
+	/*
+		GIVEN no status filter
+		WHEN ValidateStatus is called
+		THEN it should default to open loans
+	*/
+	t.Run("Empty status lists active loans", func(t *testing.T) {
+		// WHEN
+		status, err := validator.ValidateStatus("")
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if status != models.LoanStatusActive {
+			t.Errorf("Expected status '%s', got '%s'", models.LoanStatusActive, status)
+		}
+	})
+
+	/*
+		GIVEN a status that isn't one of models.LoanStatuses
+		WHEN ValidateStatus is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("Unknown status is rejected", func(t *testing.T) {
+		// WHEN
+		_, err := validator.ValidateStatus("lost")
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+
+	createRequests := []struct {
+		name      string
+		request   types.CreateLoanRequest
+		expectErr bool
+	}{
+		{
+			name: "Valid loan with reminder",
+			request: types.CreateLoanRequest{
+				UserGameID:        7,
+				BorrowerName:      "Sam O'Brien",
+				BorrowerContact:   "sam@example.com",
+				LentAt:            unix(now.AddDate(0, 0, -3)),
+				DueAt:             unix(now.AddDate(0, 0, 11)),
+				RemindWhenOverdue: true,
+			},
+		},
+		{
+			name:      "Missing copy",
+			request:   types.CreateLoanRequest{BorrowerName: "Sam"},
+			expectErr: true,
+		},
+		{
+			name:      "Missing borrower",
+			request:   types.CreateLoanRequest{UserGameID: 7, BorrowerName: "  "},
+			expectErr: true,
+		},
+		{
+			name:      "Overlong borrower",
+			request:   types.CreateLoanRequest{UserGameID: 7, BorrowerName: strings.Repeat("a", MaxBorrowerNameLength+1)},
+			expectErr: true,
+		},
+		{
+			name:      "HTML borrower",
+			request:   types.CreateLoanRequest{UserGameID: 7, BorrowerName: "<script>Sam</script>"},
+			expectErr: true,
+		},
+		{
+			name:      "Lent in the future",
+			request:   types.CreateLoanRequest{UserGameID: 7, BorrowerName: "Sam", LentAt: unix(now.Add(time.Hour))},
+			expectErr: true,
+		},
+		{
+			name: "Due before lent",
+			request: types.CreateLoanRequest{
+				UserGameID:   7,
+				BorrowerName: "Sam",
+				LentAt:       unix(now.AddDate(0, 0, -3)),
+				DueAt:        unix(now.AddDate(0, 0, -4)),
+			},
+			expectErr: true,
+		},
+		{
+			name:      "Reminder without due date",
+			request:   types.CreateLoanRequest{UserGameID: 7, BorrowerName: "Sam", RemindWhenOverdue: true},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range createRequests {
+		/*
+			GIVEN a create loan request
+			WHEN ValidateCreateLoanRequest is called
+			THEN it should only return ErrValidationFailed for invalid requests
+		*/
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			err := validator.ValidateCreateLoanRequest(testCase.request, now)
+
+			// THEN
+			if testCase.expectErr && !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Expected ErrValidationFailed, got %v", err)
+			}
+			if !testCase.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+
+	/*
+		GIVEN a return date in the future
+		WHEN ValidateReturnLoanRequest is called
+		THEN it should return ErrValidationFailed
+	*/
+	t.Run("Returned in the future", func(t *testing.T) {
+		// GIVEN
+		request := types.ReturnLoanRequest{ReturnedAt: unix(now.Add(time.Hour))}
+
+		// WHEN
+		err := validator.ValidateReturnLoanRequest(request, now)
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+}
@@ -137,7 +137,7 @@ const (
 	// ---------------- GAMES QUERIES ----------------
 	GetUserIDGameQuery = `
 		SELECT id FROM user_games
-		WHERE user_id = $1 AND game_id = $2
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NULL
 	`
 
 	GetAllGamesInDigitalLocationQuery = `
@@ -145,7 +145,7 @@ const (
 		FROM games g
 		JOIN user_games ug ON ug.game_id = g.id
 		JOIN digital_game_locations dgl ON dgl.user_game_id = ug.id
-		WHERE dgl.digital_location_id = $1 AND ug.user_id = $2
+		WHERE dgl.digital_location_id = $1 AND ug.user_id = $2 AND ug.deleted_at IS NULL
 	`
 
 	AddGameToDigitalLocationQuery = `
@@ -184,11 +184,11 @@ const (
             COALESCE(dls.billing_cycle, '') AS billing_cycle,
             COALESCE(dls.cost_per_cycle, 0) AS cost_per_cycle,
             dls.next_payment_date,
-            COUNT(dgl.id) AS stored_items
+            COUNT(ug.id) AS stored_items
         FROM digital_locations dl
         LEFT JOIN digital_location_subscriptions dls ON dl.id = dls.digital_location_id
         LEFT JOIN digital_game_locations dgl ON dl.id = dgl.digital_location_id
-        LEFT JOIN user_games ug ON dgl.user_game_id = ug.id AND ug.user_id = $1
+        LEFT JOIN user_games ug ON dgl.user_game_id = ug.id AND ug.user_id = $1 AND ug.deleted_at IS NULL
         WHERE dl.user_id = $1
         GROUP BY dl.id, dl.name, dl.is_subscription, dl.is_active, dl.url, dl.payment_method,
                  dl.created_at, dl.updated_at, dls.billing_cycle, dls.cost_per_cycle, dls.next_payment_date
@@ -207,12 +207,13 @@ const (
 							WHERE ug2.game_id = ug.game_id
 							AND ug2.platform_id = ug.platform_id
 							AND ug2.game_type = 'physical'
+							AND ug2.deleted_at IS NULL
 					) as has_physical_copy
 			FROM digital_game_locations dgl
 			JOIN user_games ug ON dgl.user_game_id = ug.id
 			JOIN games g ON ug.game_id = g.id
 			JOIN platforms p ON ug.platform_id = p.id
-			WHERE dgl.digital_location_id = $1 AND ug.user_id = $2
+			WHERE dgl.digital_location_id = $1 AND ug.user_id = $2 AND ug.deleted_at IS NULL
 			ORDER BY g.name
 	`
 )
\ No newline at end of file
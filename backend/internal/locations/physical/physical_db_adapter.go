@@ -49,7 +49,7 @@ const (
 	getSinglePhysicalLocationQuery = `
 		SELECT id, user_id, name, label, location_type, map_coordinates, bg_color, created_at, updated_at
 		FROM physical_locations
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
 
 	getSinglePhysicalLocationSublocationsQuery = `
@@ -79,20 +79,20 @@ const (
 						FROM games g
 						JOIN user_games ug ON ug.game_id = g.id
 						JOIN physical_game_locations pgl ON pgl.user_game_id = ug.id
-						WHERE pgl.sublocation_id = sl.id
+						WHERE pgl.sublocation_id = sl.id AND ug.deleted_at IS NULL
 					),
 					'[]'::json
 				)
 			)
 		)
 		FROM sublocations sl
-		WHERE sl.physical_location_id = $1
+		WHERE sl.physical_location_id = $1 AND sl.deleted_at IS NULL
 	`
 
 	getAllPhysicalLocationsQuery = `
 		SELECT id, user_id, name, label, location_type, map_coordinates, bg_color, created_at, updated_at
 		FROM physical_locations
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at
 	`
 
@@ -123,20 +123,20 @@ const (
 						FROM games g
 						JOIN user_games ug ON ug.game_id = g.id
 						JOIN physical_game_locations pgl ON pgl.user_game_id = ug.id
-						WHERE pgl.sublocation_id = sl.id
+						WHERE pgl.sublocation_id = sl.id AND ug.deleted_at IS NULL
 					),
 					'[]'::json
 				)
 			)
 		)
 		FROM sublocations sl
-		WHERE sl.physical_location_id = $1
+		WHERE sl.physical_location_id = $1 AND sl.deleted_at IS NULL
 	`
 
 	updatePhysicalLocationQuery = `
 		UPDATE physical_locations
 		SET name = $3, label = $4, location_type = $5, map_coordinates = $6, bg_color = $7, updated_at = NOW()
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 		RETURNING id, user_id, name, label, location_type, map_coordinates, bg_color, created_at, updated_at
 	`
 
@@ -151,7 +151,7 @@ const (
 				created_at,
 				updated_at
 		FROM physical_locations
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at
 	`
 
@@ -173,6 +173,8 @@ const (
         FROM sublocations sl
         JOIN physical_locations pl ON sl.physical_location_id = pl.id
         WHERE pl.user_id = $1
+        AND pl.deleted_at IS NULL
+        AND sl.deleted_at IS NULL
     )
     -- Step 2: For each sublocation, get its games
     SELECT
@@ -191,6 +193,7 @@ const (
                             WHERE ug2.game_id = ug.game_id
                             AND ug2.platform_id = ug.platform_id
                             AND ug2.game_type = 'digital'
+                            AND ug2.deleted_at IS NULL
                         )
                     )
                 )
@@ -199,6 +202,7 @@ const (
                 JOIN games g ON ug.game_id = g.id
                 JOIN platforms p ON ug.platform_id = p.id
                 WHERE pgl.sublocation_id = sd.sublocation_id
+                AND ug.deleted_at IS NULL
             ),
             '[]'::json
         ) as stored_games
@@ -206,16 +210,17 @@ const (
     ORDER BY sd.parent_location_name, sd.sublocation_name
 `
 
-	// Cascading delete queries
+	// Cascading delete queries, everything is moved to the trash with the same deleted_at
 	getSublocationsForPhysicalLocationsQuery = `
 		SELECT id FROM sublocations
-		WHERE physical_location_id = ANY($1)
+		WHERE physical_location_id = ANY($1) AND deleted_at IS NULL
 	`
 
 	getGamesInSublocationQuery = `
 		SELECT pgl.user_game_id
     FROM physical_game_locations pgl
-    WHERE pgl.sublocation_id = $1
+    JOIN user_games ug ON ug.id = pgl.user_game_id
+    WHERE pgl.sublocation_id = $1 AND ug.deleted_at IS NULL
 	`
 
 	checkGameExistsInOtherLocationsQuery = `
@@ -228,9 +233,22 @@ const (
 		)
 	`
 
-	deleteOrphanedGameQuery = `
-		DELETE FROM user_games
-    WHERE id = $1
+	trashOrphanedGameQuery = `
+		UPDATE user_games
+		SET deleted_at = NOW()
+		WHERE id = $1
+	`
+
+	trashSublocationsForPhysicalLocationsQuery = `
+		UPDATE sublocations
+		SET deleted_at = NOW()
+		WHERE physical_location_id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
+	`
+
+	trashPhysicalLocationsQuery = `
+		UPDATE physical_locations
+		SET deleted_at = NOW()
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
 	`
 )
 
@@ -570,7 +588,7 @@ func (pa *PhysicalDbAdapter) CreatePhysicalLocation(ctx context.Context, userID
 	var existingID string
 	err = tx.QueryRowContext(ctx, `
 		SELECT id FROM physical_locations
-		WHERE user_id = $1 AND LOWER(name) = LOWER($2)
+		WHERE user_id = $1 AND LOWER(name) = LOWER($2) AND deleted_at IS NULL
 	`, userID, location.Name).Scan(&existingID)
 
 	if err == nil {
@@ -705,7 +723,7 @@ func (pa *PhysicalDbAdapter) DeletePhysicalLocation(ctx context.Context, userID
 			var count int
 			err := tx.QueryRowxContext(ctx,
 				`SELECT COUNT(*) FROM physical_locations
-					WHERE id = ANY($1) AND user_id = $2`,
+					WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL`,
 					pq.Array(locationIDs),
 					userID,
 			).Scan(&count)
@@ -752,11 +770,11 @@ func (pa *PhysicalDbAdapter) DeletePhysicalLocation(ctx context.Context, userID
 						return fmt.Errorf("error checking other locations: %w", err)
 					}
 
-					// If game doesn't exist in other locations, delete it from user_games
+					// If game doesn't exist in other locations, move it to the trash with its location
 					if !gameExistsInOtherLocations {
 						_, err = tx.ExecContext(
 							ctx,
-							deleteOrphanedGameQuery,
+							trashOrphanedGameQuery,
 							userGameID,
 						)
 						if err != nil {
@@ -766,11 +784,20 @@ func (pa *PhysicalDbAdapter) DeletePhysicalLocation(ctx context.Context, userID
 				}
 			}
 
-			// 4. Delete the locations (sublocations will be deleted automatically via ON DELETE CASCADE)
+			// 4. Trash the sublocations, then the locations. Both keep NOW() so a restore can find them together
+			_, err = tx.ExecContext(
+				ctx,
+				trashSublocationsForPhysicalLocationsQuery,
+				pq.Array(locationIDs),
+				userID,
+			)
+			if err != nil {
+				return fmt.Errorf("error deleting sublocations: %w", err)
+			}
+
 			result, err := tx.ExecContext(
 				ctx,
-				`DELETE FROM physical_locations
-					WHERE id = ANY($1) AND user_id = $2`,
+				trashPhysicalLocationsQuery,
 				pq.Array(locationIDs),
 				userID,
 			)
@@ -627,7 +627,7 @@ func TestPhysicalDbAdapter(t *testing.T) {
 	/*
 		GIVEN a request to REMOVE a physical location
 		WHEN the location exists AND belongs to the user
-		THEN the adapter removes the location
+		THEN the adapter moves the location and its sublocations to the trash
 	*/
 	t.Run(`DeletePhysicalLocation - Successfully removes a location`, func(t *testing.T) {
 		// Setup
@@ -650,8 +650,11 @@ func TestPhysicalDbAdapter(t *testing.T) {
 		mock.ExpectQuery("SELECT id FROM sublocations").
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}))
-		// Delete location
-		mock.ExpectExec("DELETE FROM physical_locations").
+		// Trash sublocations, then the location
+		mock.ExpectExec("UPDATE sublocations").
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE physical_locations").
 			WithArgs(sqlmock.AnyArg(), userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 		// Expect commit
@@ -736,8 +739,11 @@ func TestPhysicalDbAdapter(t *testing.T) {
 		mock.ExpectQuery("SELECT id FROM sublocations").
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}))
-		// Delete the location and get an error
-		mock.ExpectExec("DELETE FROM physical_locations").
+		// Trash sublocations, then get an error trashing the location
+		mock.ExpectExec("UPDATE sublocations").
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE physical_locations").
 			WithArgs(sqlmock.AnyArg(), userID).
 			WillReturnError(dbError)
 		// Expect rollback
@@ -55,9 +55,10 @@ const (
 		WHERE pgl.id IS NULL AND dgl.id IS NULL
 	`
 
-	deleteOrphanedGameQuery = `
-		DELETE FROM user_games
-		WHERE id = ANY($1)
+	trashOrphanedGameQuery = `
+		UPDATE user_games
+		SET deleted_at = NOW()
+		WHERE id = $1
 	`
 )
 
@@ -88,7 +89,7 @@ func (sa *SublocationDbAdapter) GetSingleSublocation(ctx context.Context, userID
 	query := `
 		SELECT id, user_id, physical_location_id, name, location_type, stored_items, created_at, updated_at
 		FROM sublocations
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
 
 	var sublocation models.Sublocation
@@ -128,7 +129,7 @@ func (sa *SublocationDbAdapter) GetAllSublocations(ctx context.Context, userID s
 	query := `
 		SELECT id, user_id, physical_location_id, name, location_type, stored_items, created_at, updated_at
 		FROM sublocations
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY name
 	`
 
@@ -201,7 +202,7 @@ func (sa *SublocationDbAdapter) UpdateSublocation(ctx context.Context, userID st
 	query := `
 		UPDATE sublocations
 		SET name = $1, location_type = $2, stored_items = $3, updated_at = $4
-		WHERE id = $5 AND user_id = $6
+		WHERE id = $5 AND user_id = $6 AND deleted_at IS NULL
   `
 
 	now := time.Now()
@@ -316,7 +317,8 @@ func (sa *SublocationDbAdapter) DeleteSublocation(ctx context.Context, userID st
 					err := tx.SelectContext(ctx, &userGameIDs, `
 							SELECT pgl.user_game_id
 							FROM physical_game_locations pgl
-							WHERE pgl.sublocation_id = $1
+							JOIN user_games ug ON ug.id = pgl.user_game_id
+							WHERE pgl.sublocation_id = $1 AND ug.deleted_at IS NULL
 					`, sublocationID)
 					if err != nil {
 							return fmt.Errorf("error getting user games: %w", err)
@@ -335,7 +337,7 @@ func (sa *SublocationDbAdapter) DeleteSublocation(ctx context.Context, userID st
 								return fmt.Errorf("error checking other locations: %w", err)
 							}
 
-							// If game doesn't exist in other locations, delete it from user_games
+							// If game doesn't exist in other locations, move it to the trash with the sublocation
 							if !gameExistsInOtherLocations {
 								// Get game details before deletion for response
 								var gameDetails types.DeletedGameDetails
@@ -359,10 +361,10 @@ func (sa *SublocationDbAdapter) DeleteSublocation(ctx context.Context, userID st
 										return fmt.Errorf("error getting game details: %w", err)
 								}
 
-								// Delete the game
+								// Trash the game
 								_, err = tx.ExecContext(
 									ctx,
-									deleteOrphanedGameQuery,
+									trashOrphanedGameQuery,
 									userGameID,
 								)
 								if err != nil {
@@ -375,10 +377,11 @@ func (sa *SublocationDbAdapter) DeleteSublocation(ctx context.Context, userID st
 					}
 			}
 
-			// 2. Delete the sublocations
+			// 2. Trash the sublocations, games trashed above share their deleted_at
 			result, err := tx.ExecContext(ctx, `
-					DELETE FROM sublocations
-					WHERE id = ANY($1) AND user_id = $2
+					UPDATE sublocations
+					SET deleted_at = NOW()
+					WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
 			`, pq.Array(sublocationIDs), userID)
 			if err != nil {
 					return fmt.Errorf("error deleting sublocations: %w", err)
@@ -434,7 +437,7 @@ func (sa *SublocationDbAdapter) AddGameToSublocation(ctx context.Context, userID
 			}
 
 			// Verify sublocation belongs to user
-			checkSublocQuery := `SELECT id FROM sublocations WHERE id = $1 AND user_id = $2`
+			checkSublocQuery := `SELECT id FROM sublocations WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 			var sublocIDResult string
 			err = tx.QueryRowxContext(ctx, checkSublocQuery, sublocationID, userID).Scan(&sublocIDResult)
 			if err != nil {
@@ -467,7 +470,7 @@ func (sa *SublocationDbAdapter) RemoveGameFromSublocation(ctx context.Context, u
 
 	return postgres.WithTransaction(ctx, sa.db, sa.logger, func(tx *sqlx.Tx) error {
 		// 1. Verify game belongs to user
-		checkGameQuery := `SELECT id FROM user_games WHERE id = $1 AND user_id = $2`
+		checkGameQuery := `SELECT id FROM user_games WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 		var gameIDResult string
 		err := tx.QueryRowxContext(ctx, checkGameQuery, userGameID, userID).Scan(&gameIDResult)
 		if err != nil {
@@ -552,7 +555,7 @@ func (sa *SublocationDbAdapter) CheckGameOwnership(ctx context.Context, userID s
 	query := `
 		SELECT EXISTS (
 			SELECT 1 FROM user_games
-			WHERE id = $1 AND user_id = $2
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 		)
 	`
 
@@ -577,6 +580,7 @@ func (sa *SublocationDbAdapter) CheckDuplicateSublocation(ctx context.Context, u
 		SELECT COUNT(*)
 		FROM sublocations
 		WHERE user_id = $1 AND physical_location_id = $2 AND LOWER(name) = LOWER($3)
+		AND deleted_at IS NULL
 	`
 
 	var count int
@@ -609,7 +613,7 @@ func (sa *SublocationDbAdapter) MoveGameToSublocation(ctx context.Context, userI
 		}
 
 		// 2. Verify game ownership
-		checkGameQuery := `SELECT id FROM user_games WHERE id = $1 AND user_id = $2`
+		checkGameQuery := `SELECT id FROM user_games WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 		var gameIDResult string
 		err = tx.QueryRowxContext(ctx, checkGameQuery, userGameID, userID).Scan(&gameIDResult)
 		if err != nil {
@@ -620,7 +624,7 @@ func (sa *SublocationDbAdapter) MoveGameToSublocation(ctx context.Context, userI
 		}
 
 		// 3. Verify target sublocation ownership
-		checkSublocQuery := `SELECT id FROM sublocations WHERE id = $1 AND user_id = $2`
+		checkSublocQuery := `SELECT id FROM sublocations WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
 		var sublocIDResult string
 		err = tx.QueryRowxContext(ctx, checkSublocQuery, targetSublocationID, userID).Scan(&sublocIDResult)
 		if err != nil {
@@ -447,7 +447,7 @@ func TestSublocationDbAdapter(t *testing.T) {
 	/*
 		GIVEN a valid sublocation ID
 		WHEN DeleteSublocation is called
-		THEN it deletes the sublocation
+		THEN it moves the sublocation and its orphaned games to the trash
 	*/
 	t.Run("DeleteSublocation", func(t *testing.T) {
 		adapter, mock, err := setupMockDB()
@@ -464,7 +464,7 @@ func TestSublocationDbAdapter(t *testing.T) {
 
 		// Get user_game_ids in this sublocation
 		userGameRows := sqlmock.NewRows([]string{"user_game_id"}).AddRow(123)
-		mock.ExpectQuery("SELECT pgl.user_game_id FROM physical_game_locations pgl WHERE pgl.sublocation_id = \\$1").
+		mock.ExpectQuery("SELECT pgl.user_game_id FROM physical_game_locations pgl JOIN user_games ug ON ug.id = pgl.user_game_id WHERE pgl.sublocation_id = \\$1").
 			WithArgs(sublocationID).
 			WillReturnRows(userGameRows)
 
@@ -481,13 +481,13 @@ func TestSublocationDbAdapter(t *testing.T) {
 			WithArgs(123).
 			WillReturnRows(gameDetailsRows)
 
-		// Delete the orphaned game
-		mock.ExpectExec("DELETE FROM user_games WHERE id = ANY\\(\\$1\\)").
+		// Trash the orphaned game
+		mock.ExpectExec("UPDATE user_games SET deleted_at = NOW\\(\\) WHERE id = \\$1").
 			WithArgs(123).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		// Delete the sublocation
-		mock.ExpectExec("DELETE FROM sublocations WHERE id = ANY\\(\\$1\\) AND user_id = \\$2").
+		// Trash the sublocation
+		mock.ExpectExec("UPDATE sublocations SET deleted_at = NOW\\(\\) WHERE id = ANY\\(\\$1\\) AND user_id = \\$2").
 			WithArgs(pq.Array([]string{sublocationID}), userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -525,7 +525,7 @@ func TestSublocationDbAdapter(t *testing.T) {
 
 		// Get user_game_ids in this sublocation - return no rows
 		userGameRows := sqlmock.NewRows([]string{"user_game_id"})
-		mock.ExpectQuery("SELECT pgl.user_game_id FROM physical_game_locations pgl WHERE pgl.sublocation_id = \\$1").
+		mock.ExpectQuery("SELECT pgl.user_game_id FROM physical_game_locations pgl JOIN user_games ug ON ug.id = pgl.user_game_id WHERE pgl.sublocation_id = \\$1").
 			WithArgs(sublocationID).
 			WillReturnRows(userGameRows)
 
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Add-on types, the normalized text of the IGDB game types a game's add-ons can have
+const (
+	AddonTypeDLC        = "dlc"
+	AddonTypeExpansion  = "expansion"
+	AddonTypeStandalone = "standalone"
+	AddonTypeEpisode    = "episode"
+	AddonTypeSeason     = "season"
+	AddonTypePack       = "pack"
+	AddonTypeBundle     = "bundle"
+)
+
+// AddonTypes are the values accepted by game_addons.addon_type
+var AddonTypes = []string{
+	AddonTypeDLC,
+	AddonTypeExpansion,
+	AddonTypeStandalone,
+	AddonTypeEpisode,
+	AddonTypeSeason,
+	AddonTypePack,
+	AddonTypeBundle,
+}
+
+// GameAddon is a DLC or expansion of a game, as fetched from IGDB
+type GameAddon struct {
+	ID               int64  `db:"id"`
+	ParentGameID     int64  `db:"parent_game_id"`
+	Name             string `db:"name"`
+	AddonType        string `db:"addon_type"`
+	CoverURL         string `db:"cover_url"`
+	FirstReleaseDate int64  `db:"first_release_date"`
+}
+
+// GameAddonDB is a game_addons row along with whether the user owns it.
+// An add-on is owned when it was bought on its own or came with the edition of one of the user's copies.
+type GameAddonDB struct {
+	GameAddon
+	OwnedAddonID      sql.NullInt64  `db:"owned_addon_id"`
+	PlatformName      sql.NullString `db:"platform_name"`
+	PurchaseID        sql.NullInt64  `db:"purchase_id"`
+	AcquiredDate      sql.NullTime   `db:"acquired_date"`
+	IncludedInEdition sql.NullString `db:"included_in_edition"`
+}
+
+// Owned reports whether the user has the add-on one way or another
+func (a GameAddonDB) Owned() bool {
+	return a.OwnedAddonID.Valid || a.IncludedInEdition.Valid
+}
+
+// OwnedAddonToSave is a validated add-on ownership ready to be written
+type OwnedAddonToSave struct {
+	AddonID      int64
+	PlatformID   int64
+	PurchaseID   *int64
+	AcquiredDate *time.Time
+	Notes        string
+}
+
+// OwnedAddonDB is a user_game_addons row along with the add-on it is for
+type OwnedAddonDB struct {
+	ID           int64          `db:"id"`
+	AddonID      int64          `db:"addon_id"`
+	AddonName    string         `db:"addon_name"`
+	AddonType    string         `db:"addon_type"`
+	ParentGameID int64          `db:"parent_game_id"`
+	PlatformID   int64          `db:"platform_id"`
+	PlatformName string         `db:"platform_name"`
+	PurchaseID   sql.NullInt64  `db:"purchase_id"`
+	AcquiredDate sql.NullTime   `db:"acquired_date"`
+	Notes        sql.NullString `db:"notes"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+// EditionToSave is the edition of a copy with what came in the box, an empty edition clears it
+type EditionToSave struct {
+	UserGameID int64
+	Edition    string
+	Contents   []EditionContentToSave
+}
+
+// EditionContentToSave is one item of an edition, AddonID is set when the item is one of the game's add-ons
+type EditionContentToSave struct {
+	Name    string
+	AddonID *int64
+}
+
+// GameEditionDB is one of the user's copies of a game with its edition
+type GameEditionDB struct {
+	UserGameID   int64          `db:"user_game_id"`
+	PlatformName string         `db:"platform_name"`
+	GameType     string         `db:"game_type"`
+	Edition      sql.NullString `db:"edition"`
+	Contents     []EditionContentDB
+}
+
+// EditionContentDB is a user_game_edition_contents row
+type EditionContentDB struct {
+	UserGameID int64         `db:"user_game_id"`
+	Name       string        `db:"name"`
+	AddonID    sql.NullInt64 `db:"addon_id"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Barcode regions, the edition a box was printed for
+const (
+	BarcodeRegionNTSCU      = "ntsc-u"
+	BarcodeRegionNTSCJ      = "ntsc-j"
+	BarcodeRegionNTSCK      = "ntsc-k"
+	BarcodeRegionPAL        = "pal"
+	BarcodeRegionRegionFree = "region-free"
+	BarcodeRegionUnknown    = "unknown"
+)
+
+// BarcodeRegions are the values accepted for a mapping's region
+var BarcodeRegions = []string{
+	BarcodeRegionNTSCU,
+	BarcodeRegionNTSCJ,
+	BarcodeRegionNTSCK,
+	BarcodeRegionPAL,
+	BarcodeRegionRegionFree,
+	BarcodeRegionUnknown,
+}
+
+// BarcodeMappingDB is a barcodes row along with how many users confirmed it
+type BarcodeMappingDB struct {
+	ID              int64     `db:"id"`
+	Code            string    `db:"code"`
+	GameID          int64     `db:"game_id"`
+	GameName        string    `db:"game_name"`
+	PlatformID      int64     `db:"platform_id"`
+	PlatformName    string    `db:"platform_name"`
+	Region          string    `db:"region"`
+	Confirmations   int       `db:"confirmations"`
+	ConfirmedByUser bool      `db:"confirmed_by_user"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+// BarcodeMappingToSave is a validated mapping, its game and platform names come from IGDB
+type BarcodeMappingToSave struct {
+	Code         string
+	GameID       int64
+	GameName     string
+	PlatformID   int64
+	PlatformName string
+	Region       string
+}
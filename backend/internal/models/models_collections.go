@@ -0,0 +1,46 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// UserTagDB represents a user_tags row along with how many copies carry the tag
+type UserTagDB struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Name      string    `db:"name"`
+	GameCount int       `db:"game_count"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CollectionDB represents a collections row along with how many copies it holds
+type CollectionDB struct {
+	ID          string         `db:"id"`
+	UserID      string         `db:"user_id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	GameCount   int            `db:"game_count"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+// CollectionGameDB is a single copy in a collection
+type CollectionGameDB struct {
+	UserGameID   int64     `db:"user_game_id"`
+	GameID       int64     `db:"game_id"`
+	GameName     string    `db:"game_name"`
+	CoverURL     string    `db:"cover_url"`
+	PlatformID   int64     `db:"platform_id"`
+	PlatformName string    `db:"platform_name"`
+	GameType     string    `db:"game_type"`
+	AddedAt      time.Time `db:"added_at"`
+}
+
+// LibraryGameSelection picks copies in a user's library for bulk tagging and collections.
+// GameIDs select every copy of a game, UserGameIDs a single copy.
+type LibraryGameSelection struct {
+	UserGameIDs []int64
+	GameIDs     []int64
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Data export statuses
+const (
+	DataExportPending   = "pending"
+	DataExportRunning   = "running"
+	DataExportCompleted = "completed"
+	DataExportFailed    = "failed"
+	DataExportExpired   = "expired"
+)
+
+// DataExportDB represents a user_data_exports row
+type DataExportDB struct {
+	ID           string         `db:"id"`
+	UserID       string         `db:"user_id"`
+	Status       string         `db:"status"`
+	BlobKey      sql.NullString `db:"blob_key"`
+	SizeBytes    sql.NullInt64  `db:"size_bytes"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	CreatedAt    time.Time      `db:"created_at"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+	ExpiresAt    sql.NullTime   `db:"expires_at"`
+}
+
+// DataExportUserDB is the contact info needed to email the download link
+type DataExportUserDB struct {
+	Email     string `db:"email"`
+	FirstName string `db:"first_name"`
+}
+
+// DataExportTable is one dataset of an export, kept as plain columns and rows
+// so it can be written out as both JSON and CSV
+type DataExportTable struct {
+	Name    string
+	Columns []string
+	Rows    [][]any
+}
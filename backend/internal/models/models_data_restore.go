@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Data restore modes
+const (
+	// DataRestoreModeMerge keeps the user's current data and adds whatever the archive has that they don't
+	DataRestoreModeMerge = "merge"
+	// DataRestoreModeReplace deletes the user's locations, library and spend records before restoring
+	DataRestoreModeReplace = "replace"
+)
+
+// DataRestoreArchive is the part of an export archive a restore rebuilds.
+// Rows still carry the IDs they had when they were exported, the db adapter remaps them.
+type DataRestoreArchive struct {
+	FormatVersion     int
+	PhysicalLocations []RestorePhysicalLocation
+	Sublocations      []RestoreSublocation
+	DigitalLocations  []RestoreDigitalLocation
+	Subscriptions     []RestoreSubscription
+	Payments          []RestorePayment
+	OneTimePurchases  []RestoreOneTimePurchase
+	Games             []RestoreUserGame
+}
+
+// RestorePhysicalLocation is a row of json/physical_locations.json
+type RestorePhysicalLocation struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Label          *string `json:"label"`
+	LocationType   string  `json:"location_type"`
+	MapCoordinates *string `json:"map_coordinates"`
+	BgColor        string  `json:"bg_color"`
+}
+
+// RestoreSublocation is a row of json/sublocations.json
+type RestoreSublocation struct {
+	ID                 string  `json:"id"`
+	PhysicalLocationID *string `json:"physical_location_id"`
+	Name               string  `json:"name"`
+	LocationType       string  `json:"location_type"`
+}
+
+// RestoreDigitalLocation is a row of json/digital_locations.json
+type RestoreDigitalLocation struct {
+	ID             string      `json:"id"`
+	Name           string      `json:"name"`
+	IsSubscription bool        `json:"is_subscription"`
+	IsActive       bool        `json:"is_active"`
+	DiskSizeValue  json.Number `json:"disk_size_value"`
+	DiskSizeUnit   *string     `json:"disk_size_unit"`
+	URL            *string     `json:"url"`
+	PaymentMethod  *string     `json:"payment_method"`
+}
+
+// RestoreSubscription is a row of json/subscriptions.json
+type RestoreSubscription struct {
+	DigitalLocationID string      `json:"digital_location_id"`
+	BillingCycle      string      `json:"billing_cycle"`
+	CostPerCycle      json.Number `json:"cost_per_cycle"`
+	AnchorDate        time.Time   `json:"anchor_date"`
+	LastPaymentDate   *time.Time  `json:"last_payment_date"`
+	PaymentMethod     string      `json:"payment_method"`
+}
+
+// RestorePayment is a row of json/payments.json
+type RestorePayment struct {
+	DigitalLocationID string      `json:"digital_location_id"`
+	Amount            json.Number `json:"amount"`
+	PaymentDate       time.Time   `json:"payment_date"`
+	PaymentMethod     string      `json:"payment_method"`
+	TransactionID     *string     `json:"transaction_id"`
+}
+
+// RestoreOneTimePurchase is a row of json/one_time_purchases.json.
+// Spending categories are shared between users so they are matched by name, not ID.
+type RestoreOneTimePurchase struct {
+	Title                string      `json:"title"`
+	Amount               json.Number `json:"amount"`
+	PurchaseDate         time.Time   `json:"purchase_date"`
+	PaymentMethod        string      `json:"payment_method"`
+	SpendingCategoryName *string     `json:"spending_category_name"`
+	DigitalLocationID    *string     `json:"digital_location_id"`
+	IsDigital            bool        `json:"is_digital"`
+	IsWishlisted         bool        `json:"is_wishlisted"`
+}
+
+// RestoreUserGame is a row of json/games.json.
+// A copy stored in more than one place is exported once per location, so IDs can repeat.
+type RestoreUserGame struct {
+	ID                int64      `json:"id"`
+	GameID            int64      `json:"game_id"`
+	GameName          string     `json:"game_name"`
+	PlatformID        int64      `json:"platform_id"`
+	PlatformName      *string    `json:"platform_name"`
+	GameType          string     `json:"game_type"`
+	CopyNumber        int        `json:"copy_number"`
+	IsUniqueCopy      bool       `json:"is_unique_copy"`
+	Favorite          bool       `json:"favorite"`
+	Condition         *string    `json:"condition"`
+	HasOriginalCase   *bool      `json:"has_original_case"`
+	HasManual         *bool      `json:"has_manual"`
+	AcquiredDate      *time.Time `json:"acquired_date"`
+	PlayStatus        *string    `json:"play_status"`
+	StartedAt         *time.Time `json:"started_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	CompletionNote    *string    `json:"completion_note"`
+	PersonalRating    *int       `json:"personal_rating"`
+	Review            *string    `json:"review"`
+	PrivateNotes      *string    `json:"private_notes"`
+	CopyNotes         *string    `json:"copy_notes"`
+	SublocationID     *string    `json:"sublocation_id"`
+	DigitalLocationID *string    `json:"digital_location_id"`
+}
+
+// DataRestoreDatasetCount is how many rows of one dataset were created or matched to existing data
+type DataRestoreDatasetCount struct {
+	Name    string
+	Created int
+	Merged  int
+}
@@ -0,0 +1,49 @@
+package models
+
+import "database/sql"
+
+// StaleGameDB is a games row due for a metadata refresh
+type StaleGameDB struct {
+	ID               int64         `db:"id"`
+	Name             string        `db:"name"`
+	FirstReleaseDate sql.NullInt64 `db:"first_release_date"`
+}
+
+// GameMetadataTerm is a genre or theme as IGDB names it
+type GameMetadataTerm struct {
+	ID   int64
+	Name string
+}
+
+// NewGameMetadataTerms pairs IGDB IDs with their names, a term without both is skipped
+func NewGameMetadataTerms(ids []int64, names []string) []GameMetadataTerm {
+	terms := make([]GameMetadataTerm, 0, len(ids))
+	for i, id := range ids {
+		if i >= len(names) || id == 0 || names[i] == "" {
+			continue
+		}
+		terms = append(terms, GameMetadataTerm{ID: id, Name: names[i]})
+	}
+	return terms
+}
+
+// GameMetadataToSave is a game's metadata as IGDB currently has it
+type GameMetadataToSave struct {
+	ID               int64
+	Name             string
+	Summary          string
+	CoverURL         string
+	FirstReleaseDate int64
+	Rating           float64
+	Genres           []GameMetadataTerm
+	Themes           []GameMetadataTerm
+}
+
+// GameRemapResult counts the rows moved from a game IGDB merged into another one
+type GameRemapResult struct {
+	Copies        int64
+	WishlistItems int64
+	Barcodes      int64
+	Addons        int64
+	PriceHistory  int64
+}
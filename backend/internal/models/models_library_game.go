@@ -3,6 +3,8 @@ package models
 import (
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // This struct replaces LibraryGame
@@ -13,6 +15,8 @@ type GameToSave struct {
 	GameFirstReleaseDate  int64
 	GameType              GameToSaveIGDBType
 	GameThemeNames        []string
+	GameGenres            []GameMetadataTerm // saved to game_genres when the game is added
+	GameThemes            []GameMetadataTerm // saved to game_themes when the game is added
 	PlatformLocations     []GameToSaveLocation
 	GameRating            float64
 }
@@ -22,6 +26,17 @@ type GameToSaveLocation struct {
 	PlatformName string
 	Type         string  // "physical" or "digital"
 	Location     GameToSaveLocationDetails
+	CopyDetails  GameToSaveCopyDetails
+	PurchaseIDs  []string // one-time purchase IDs, nil leaves existing links alone
+}
+
+// GameToSaveCopyDetails describes a single physical copy, all fields are optional
+type GameToSaveCopyDetails struct {
+	Condition       string
+	HasOriginalCase *bool
+	HasManual       *bool
+	AcquiredDate    *time.Time
+	Notes           string
 }
 
 type GameToSaveIGDBType struct {
@@ -46,9 +61,11 @@ type BatchDeleteGameVersion struct {
 
 // LibraryLocationDB represents the database model for library BFF operations
 type GameLocationDatabaseEntry struct {
+	UserGameID             int64          `db:"user_game_id"`
 	GameID                 int64          `db:"game_id"`
 	PlatformID             int64          `db:"platform_id"`
 	PlatformName           string         `db:"platform_name"`
+	PlatformGroup          string         `db:"platform_group"`
 	Category               string         `db:"category"`
 	CreatedAt              time.Time      `db:"created_at"`
 	ParentLocationID       sql.NullString `db:"parent_location_id"`
@@ -58,6 +75,19 @@ type GameLocationDatabaseEntry struct {
 	SublocationID          sql.NullString `db:"sublocation_id"`
 	SublocationName        sql.NullString `db:"sublocation_name"`
 	SublocationType        sql.NullString `db:"sublocation_type"`
+	Condition              sql.NullString `db:"condition"`
+	HasOriginalCase        sql.NullBool   `db:"has_original_case"`
+	HasManual              sql.NullBool   `db:"has_manual"`
+	AcquiredDate           sql.NullTime   `db:"acquired_date"`
+	IsOnLoan               bool           `db:"is_on_loan"`
+	CopyNotes              sql.NullString `db:"copy_notes"`
+}
+
+// UserGameCostBasisDB is what a copy cost, purchases covering several copies are split evenly
+type UserGameCostBasisDB struct {
+	UserGameID  int64         `db:"user_game_id"`
+	CostBasis   float64       `db:"cost_basis"`
+	PurchaseIDs pq.Int64Array `db:"purchase_ids"`
 }
 
 // LibraryGameDB represents the database model for library games
@@ -85,12 +115,20 @@ type LibraryGameRefactoredDB struct {
 	GameTypeNormalizedText string   `db:"game_type_normalized_text"`
 	Favorite              bool      `db:"favorite"`
 	CreatedAt             time.Time `db:"created_at"`
+	PlayStatus            string    `db:"play_status"`
+	StartedAt             sql.NullTime `db:"started_at"`
+	FinishedAt            sql.NullTime `db:"finished_at"`
+	CompletionNote        sql.NullString `db:"completion_note"`
+	PersonalRating        sql.NullInt64  `db:"personal_rating"`
+	Review                sql.NullString `db:"review"`
+	PrivateNotes          sql.NullString `db:"private_notes"`
 }
 
 type PhysicalLocationDB struct {
 	GameID                 int64          `db:"game_id"`
 	PlatformID             int64          `db:"platform_id"`
 	PlatformName           string         `db:"platform_name"`
+	PlatformGroup          string         `db:"platform_group"`
 	Category               string         `db:"category"`
 	ParentLocationID       string         `db:"parent_location_id"`
 	ParentLocationName     string         `db:"parent_location_name"`
@@ -99,6 +137,12 @@ type PhysicalLocationDB struct {
 	SublocationID          string         `db:"sublocation_id"`
 	SublocationName        string         `db:"sublocation_name"`
 	SublocationType        string         `db:"sublocation_type"`
+	Condition              sql.NullString `db:"condition"`
+	HasOriginalCase        sql.NullBool   `db:"has_original_case"`
+	HasManual              sql.NullBool   `db:"has_manual"`
+	AcquiredDate           sql.NullTime   `db:"acquired_date"`
+	IsOnLoan               bool           `db:"is_on_loan"`
+	CopyNotes              sql.NullString `db:"copy_notes"`
 	CreatedAt              time.Time      `db:"created_at"`
 }
 
@@ -106,8 +150,82 @@ type DigitalLocationDB struct {
 	GameID               int64     `db:"game_id"`
 	PlatformID           int64     `db:"platform_id"`
 	PlatformName         string    `db:"platform_name"`
+	PlatformGroup        string    `db:"platform_group"`
 	Category             string    `db:"category"`
 	DigitalLocationID    string    `db:"digital_location_id"`
 	DigitalLocationName  string    `db:"digital_location_name"`
+	CopyNotes            sql.NullString `db:"copy_notes"`
 	CreatedAt            time.Time `db:"created_at"`
-}
\ No newline at end of file
+}
+// -- PAGINATED LIBRARY QUERY --
+// LibraryQuery is a validated page request against a user's library
+type LibraryQuery struct {
+	SortBy         string
+	SortDescending bool
+	Limit          int
+
+	// Keyset position of the last item on the previous page, nil for the first page
+	AfterSortValue *string
+	AfterGameID    *int64
+
+	// Optional filters, zero values mean no filtering
+	PlatformIDs []int64
+	GenreIDs    []int64
+	ThemeIDs    []int64
+	GameType    string
+	LocationID  string
+	Favorite    *bool
+	TagIDs      []string
+}
+
+// LibrarySearch is a validated library search
+type LibrarySearch struct {
+	Query string
+	Limit int
+
+	// Optional filters, zero values mean no filtering
+	PlatformIDs []int64
+	LocationID  string
+}
+
+// LibrarySearchResultDB is one ranked match of a library search.
+// Highlights wrap matched words in the markers passed to ts_headline.
+type LibrarySearchResultDB struct {
+	ID                    int64          `db:"id"`
+	Name                  string         `db:"name"`
+	CoverURL              string         `db:"cover_url"`
+	FirstReleaseDate      int64          `db:"first_release_date"`
+	Rating                float64        `db:"rating"`
+	Favorite              bool           `db:"favorite"`
+	PlayStatus            string         `db:"play_status"`
+	TotalPhysicalVersions int            `db:"total_physical_versions"`
+	TotalDigitalVersions  int            `db:"total_digital_versions"`
+	PlatformNames         pq.StringArray `db:"platform_names"`
+	NameHighlight         string         `db:"name_highlight"`
+	SummaryHighlight      string         `db:"summary_highlight"`
+	TextMatch             bool           `db:"text_match"`
+	Score                 float64        `db:"score"`
+}
+
+type LibraryGameListItemDB struct {
+	ID                    int64     `db:"id"`
+	Name                  string    `db:"name"`
+	CoverURL              string    `db:"cover_url"`
+	FirstReleaseDate      int64     `db:"first_release_date"`
+	Rating                float64   `db:"rating"`
+	Favorite              bool      `db:"favorite"`
+	PlayStatus            string    `db:"play_status"`
+	PersonalRating        sql.NullInt64 `db:"personal_rating"`
+	DateAdded             time.Time `db:"date_added"`
+	TotalPhysicalVersions int       `db:"total_physical_versions"`
+	TotalDigitalVersions  int       `db:"total_digital_versions"`
+	GenreNames            []string  `db:"-"` // Scanned manually
+	SortValue             string    `db:"sort_value"`
+}
+
+// LibraryGameTermCountDB is a genre or theme with how many of the user's games have it
+type LibraryGameTermCountDB struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	GameCount int    `db:"game_count"`
+}
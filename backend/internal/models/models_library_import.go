@@ -0,0 +1,119 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Library import job sources
+const (
+	LibraryImportSourceCSV  = "csv"
+	LibraryImportSourceJSON = "json"
+
+	// Exports from game launchers, entries that can't be matched go to the review queue
+	LibraryImportSourcePlaynite = "playnite"
+	LibraryImportSourceGOG      = "gog"
+	LibraryImportSourceSteam    = "steam"
+
+	// Games scanned in a barcode scan session, every row carries an IGDB ID
+	LibraryImportSourceBarcode = "barcode"
+)
+
+// IsLauncherImportSource reports whether a source is a launcher export rather than our own CSV/JSON format
+func IsLauncherImportSource(source string) bool {
+	switch source {
+	case LibraryImportSourcePlaynite, LibraryImportSourceGOG, LibraryImportSourceSteam:
+		return true
+	default:
+		return false
+	}
+}
+
+// Library import job statuses
+const (
+	LibraryImportJobPending   = "pending"
+	LibraryImportJobRunning   = "running"
+	LibraryImportJobCompleted = "completed"
+	LibraryImportJobFailed    = "failed"
+)
+
+// Library import row statuses. Dry runs stop at matched, real imports end at imported or failed.
+// Launcher imports send rows they can't match to needs_review, where the user resolves or dismisses them.
+const (
+	LibraryImportRowPending     = "pending"
+	LibraryImportRowMatched     = "matched"
+	LibraryImportRowImported    = "imported"
+	LibraryImportRowFailed      = "failed"
+	LibraryImportRowNeedsReview = "needs_review"
+	LibraryImportRowDismissed   = "dismissed"
+)
+
+// LibraryImportRowToSave is one parsed row of an import file, before it is matched against IGDB
+type LibraryImportRowToSave struct {
+	RowNumber int
+	Title     string
+	IGDBID    int64
+	Platform  string
+	GameType  string // "physical" or "digital"
+	Location  string // sublocation / digital location ID or name
+
+	// ExternalID is the launcher's own ID for the entry, such as a Steam app ID
+	ExternalID string
+}
+
+// LibraryImportJobDB represents a library_import_jobs row
+type LibraryImportJobDB struct {
+	ID            string         `db:"id"`
+	UserID        string         `db:"user_id"`
+	Source        string         `db:"source"`
+	DryRun        bool           `db:"dry_run"`
+	Status        string         `db:"status"`
+	TotalRows     int            `db:"total_rows"`
+	ProcessedRows int            `db:"processed_rows"`
+	SucceededRows int            `db:"succeeded_rows"`
+	FailedRows    int            `db:"failed_rows"`
+	ReviewRows    int            `db:"review_rows"`
+	ErrorMessage  sql.NullString `db:"error_message"`
+	CreatedAt     time.Time      `db:"created_at"`
+	StartedAt     sql.NullTime   `db:"started_at"`
+	CompletedAt   sql.NullTime   `db:"completed_at"`
+}
+
+// LibraryImportRowDB represents a library_import_rows row along with its match result
+type LibraryImportRowDB struct {
+	ID                  int64          `db:"id"`
+	JobID               string         `db:"job_id"`
+	RowNumber           int            `db:"row_number"`
+	Title               sql.NullString `db:"title"`
+	IGDBID              sql.NullInt64  `db:"igdb_id"`
+	Platform            sql.NullString `db:"platform"`
+	GameType            sql.NullString `db:"game_type"`
+	Location            sql.NullString `db:"location"`
+	ExternalID          sql.NullString `db:"external_id"`
+	Status              string         `db:"status"`
+	MatchedGameID       sql.NullInt64  `db:"matched_game_id"`
+	MatchedGameName     sql.NullString `db:"matched_game_name"`
+	MatchedPlatformID   sql.NullInt64  `db:"matched_platform_id"`
+	MatchedPlatformName sql.NullString `db:"matched_platform_name"`
+	MatchedLocationID   sql.NullString `db:"matched_location_id"`
+	Message             sql.NullString `db:"message"`
+}
+
+// LibraryImportRowResult is the outcome of processing a single import row.
+// For rows that need review the matched game is only a suggestion.
+type LibraryImportRowResult struct {
+	Status              string
+	MatchedGameID       int64
+	MatchedGameName     string
+	MatchedPlatformID   int64
+	MatchedPlatformName string
+	MatchedLocationID   string
+	Message             string
+}
+
+// LibraryImportLocationDB is a storage location an import row can be matched against
+type LibraryImportLocationDB struct {
+	ID       string `db:"id"`
+	Name     string `db:"name"`
+	GameType string `db:"game_type"` // "physical" for sublocations, "digital" for digital locations
+}
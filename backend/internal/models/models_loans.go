@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Loan list filters
+const (
+	LoanStatusActive   = "active"
+	LoanStatusOverdue  = "overdue"
+	LoanStatusReturned = "returned"
+	LoanStatusAll      = "all"
+)
+
+// LoanStatuses are the values accepted by the loan list's status filter
+var LoanStatuses = []string{LoanStatusActive, LoanStatusOverdue, LoanStatusReturned, LoanStatusAll}
+
+// GameLoanDB is a game_loans row along with the copy it is for
+type GameLoanDB struct {
+	ID                string         `db:"id"`
+	UserID            string         `db:"user_id"`
+	UserGameID        int64          `db:"user_game_id"`
+	GameID            int64          `db:"game_id"`
+	GameName          string         `db:"game_name"`
+	PlatformName      string         `db:"platform_name"`
+	SublocationName   sql.NullString `db:"sublocation_name"`
+	BorrowerName      string         `db:"borrower_name"`
+	BorrowerContact   sql.NullString `db:"borrower_contact"`
+	LentAt            time.Time      `db:"lent_at"`
+	DueAt             sql.NullTime   `db:"due_at"`
+	ReturnedAt        sql.NullTime   `db:"returned_at"`
+	RemindWhenOverdue bool           `db:"remind_when_overdue"`
+	ReminderSentAt    sql.NullTime   `db:"reminder_sent_at"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+// LoanToSave is a validated loan ready to be written
+type LoanToSave struct {
+	UserGameID        int64
+	BorrowerName      string
+	BorrowerContact   string
+	LentAt            time.Time
+	DueAt             *time.Time
+	RemindWhenOverdue bool
+}
+
+// LoanReminderDB is an overdue loan waiting for its reminder, with who to send it to
+type LoanReminderDB struct {
+	GameLoanDB
+	Email     string `db:"email"`
+	FirstName string `db:"first_name"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// Platform categories, the platforms table only allows these
+const (
+	PlatformCategoryConsole = "console"
+	PlatformCategoryPC      = "pc"
+	PlatformCategoryMobile  = "mobile"
+)
+
+// PlatformFamilyToSave is a family of platforms as IGDB has it, e.g. "PlayStation"
+type PlatformFamilyToSave struct {
+	ID   int64
+	Name string
+	Slug string
+}
+
+// PlatformToSave is a platform as IGDB has it, with the category and group we derive from it
+type PlatformToSave struct {
+	ID           int64
+	Name         string
+	Category     string
+	Model        string
+	Abbreviation string
+	Generation   int
+	FamilyID     int64 // 0 when the platform isn't part of a family
+	LogoURL      string
+	Group        string
+}
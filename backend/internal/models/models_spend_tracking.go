@@ -26,6 +26,9 @@ type SpendTrackingOneTimePurchaseDB struct {
 	MediaType         string     `db:"media_type"`
 	CreatedAt         time.Time  `db:"created_at"`
 	UpdatedAt         time.Time  `db:"updated_at"`
+	DeletedAt         *time.Time `db:"deleted_at"`
+	// UserGameIDs are the linked library copies, nil leaves existing links alone on update
+	UserGameIDs       []int64    `db:"-"`
 }
 
 // SpendTrackingLocationDB represents a digital location (subscription) in the database
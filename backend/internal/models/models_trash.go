@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Trash item types, also used in the restore route
+const (
+	TrashItemTypeGame             = "games"
+	TrashItemTypePhysicalLocation = "physical-locations"
+	TrashItemTypeSublocation      = "sublocations"
+	TrashItemTypePurchase         = "purchases"
+)
+
+// TrashItemTypes are the values accepted by the restore route's item type
+var TrashItemTypes = []string{
+	TrashItemTypeGame,
+	TrashItemTypePhysicalLocation,
+	TrashItemTypeSublocation,
+	TrashItemTypePurchase,
+}
+
+// TrashedGameDB is a library game with at least one copy in the trash
+type TrashedGameDB struct {
+	GameID        int64          `db:"game_id"`
+	GameName      string         `db:"game_name"`
+	CoverURL      string         `db:"cover_url"`
+	CopyCount     int            `db:"copy_count"`
+	PlatformNames pq.StringArray `db:"platform_names"`
+	DeletedAt     time.Time      `db:"deleted_at"`
+}
+
+// TrashedPhysicalLocationDB is a physical location in the trash
+type TrashedPhysicalLocationDB struct {
+	ID               string    `db:"id"`
+	Name             string    `db:"name"`
+	LocationType     string    `db:"location_type"`
+	SublocationCount int       `db:"sublocation_count"`
+	DeletedAt        time.Time `db:"deleted_at"`
+}
+
+// TrashedSublocationDB is a sublocation in the trash along with the location it belongs to
+type TrashedSublocationDB struct {
+	ID                   string    `db:"id"`
+	Name                 string    `db:"name"`
+	LocationType         string    `db:"location_type"`
+	PhysicalLocationID   string    `db:"physical_location_id"`
+	PhysicalLocationName string    `db:"physical_location_name"`
+	DeletedAt            time.Time `db:"deleted_at"`
+}
+
+// TrashedPurchaseDB is a one-time purchase in the trash
+type TrashedPurchaseDB struct {
+	ID           int       `db:"id"`
+	Title        string    `db:"title"`
+	Amount       float64   `db:"amount"`
+	PurchaseDate time.Time `db:"purchase_date"`
+	DeletedAt    time.Time `db:"deleted_at"`
+}
+
+// TrashDB is everything a user has in the trash
+type TrashDB struct {
+	Games             []TrashedGameDB
+	PhysicalLocations []TrashedPhysicalLocationDB
+	Sublocations      []TrashedSublocationDB
+	Purchases         []TrashedPurchaseDB
+}
+
+// TrashPurgeResult counts the rows removed for good by a purge
+type TrashPurgeResult struct {
+	Purchases         int64
+	Copies            int64
+	Sublocations      int64
+	PhysicalLocations int64
+}
+
+// TrashRestoreResult counts the rows brought back by a restore
+type TrashRestoreResult struct {
+	Copies            int64
+	Sublocations      int64
+	PhysicalLocations int64
+	Purchases         int64
+}
@@ -13,3 +13,67 @@ type UserGame struct {
 	// NOTE: Embedded game details (for queries that join tables)
 	Game    *Game     `json:"game,omitempty" db:"-"`
 }
+
+// Graded condition of a physical copy, ordered best to worst
+const (
+	GameConditionSealed = "sealed"
+	GameConditionMint   = "mint"
+	GameConditionGood   = "good"
+	GameConditionFair   = "fair"
+	GameConditionPoor   = "poor"
+	GameConditionLoose  = "loose"
+)
+
+// GameConditions lists every valid copy condition, ordered best to worst
+var GameConditions = []string{
+	GameConditionSealed,
+	GameConditionMint,
+	GameConditionGood,
+	GameConditionFair,
+	GameConditionPoor,
+	GameConditionLoose,
+}
+
+// Play status of a game, shared by every copy the user owns
+const (
+	PlayStatusBacklog        = "backlog"
+	PlayStatusPlaying        = "playing"
+	PlayStatusCompleted      = "completed"
+	PlayStatusHundredPercent = "hundred_percent"
+	PlayStatusDropped        = "dropped"
+	PlayStatusOnHold         = "on_hold"
+)
+
+// PlayStatuses lists every valid play status
+var PlayStatuses = []string{
+	PlayStatusBacklog,
+	PlayStatusPlaying,
+	PlayStatusCompleted,
+	PlayStatusHundredPercent,
+	PlayStatusDropped,
+	PlayStatusOnHold,
+}
+
+// GamePlayStatusToSave holds a play status update for every copy of a game
+type GamePlayStatusToSave struct {
+	GameID         int64
+	PlayStatus     string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	CompletionNote string
+}
+
+// Personal star rating bounds
+const (
+	MinPersonalRating = 1
+	MaxPersonalRating = 5
+)
+
+// GameReviewToSave holds the user's own rating, review and private notes for every copy of a game.
+// A nil rating or empty text clears the stored value.
+type GameReviewToSave struct {
+	GameID         int64
+	PersonalRating *int
+	Review         string
+	PrivateNotes   string
+}
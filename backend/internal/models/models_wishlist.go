@@ -0,0 +1,141 @@
+package models
+
+import "time"
+
+// WishlistItemToSave holds the data needed to create or update a wishlist entry
+type WishlistItemToSave struct {
+	GameID               int64
+	GameName             string
+	GameCoverURL         string
+	GameFirstReleaseDate int64
+	GameRating           float64
+	PlatformID           int64
+	ReleaseDate          *int64
+	IsOnSale             bool
+	CurrentPrice         *float64
+	SalePrice            *float64
+	TargetPrice          *float64
+	Priority             *int
+	MustHave             *bool
+}
+
+// WishlistItemDB represents a wishlist row joined with its game and platform
+type WishlistItemDB struct {
+	ID                   int64      `db:"id"`
+	GameID               int64      `db:"game_id"`
+	GameName             string     `db:"game_name"`
+	GameCoverURL         string     `db:"game_cover_url"`
+	GameFirstReleaseDate int64      `db:"game_first_release_date"`
+	PlatformID           int64      `db:"platform_id"`
+	PlatformName         string     `db:"platform_name"`
+	ReleaseDate          *int64     `db:"release_date"`
+	IsOnSale             bool       `db:"is_on_sale"`
+	CurrentPrice         *float64   `db:"current_price"`
+	SalePrice            *float64   `db:"sale_price"`
+	TargetPrice          *float64   `db:"target_price"`
+	Priority             int        `db:"priority"`
+	MustHave             bool       `db:"must_have"`
+	LastPriceCheck       *time.Time `db:"last_price_check"`
+	CreatedAt            time.Time  `db:"created_at"`
+	UpdatedAt            time.Time  `db:"updated_at"`
+}
+
+// PriceQuery is a wishlisted game on a platform that a price provider is asked about
+type PriceQuery struct {
+	GameID       int64  `db:"game_id" json:"game_id"`
+	GameName     string `db:"game_name" json:"game_name"`
+	PlatformID   int64  `db:"platform_id" json:"platform_id"`
+	PlatformName string `db:"platform_name" json:"platform_name"`
+}
+
+// GamePrice is a price reported by a price provider, SalePrice is nil when the game isn't discounted
+type GamePrice struct {
+	GameID       int64    `json:"game_id"`
+	PlatformID   int64    `json:"platform_id"`
+	RegularPrice float64  `json:"regular_price"`
+	SalePrice    *float64 `json:"sale_price,omitempty"`
+}
+
+// GamePriceHistoryDB represents a game_price_history row
+type GamePriceHistoryDB struct {
+	ID           int64     `db:"id"`
+	GameID       int64     `db:"game_id"`
+	PlatformID   int64     `db:"platform_id"`
+	Provider     string    `db:"provider"`
+	RegularPrice float64   `db:"regular_price"`
+	SalePrice    *float64  `db:"sale_price"`
+	CheckedAt    time.Time `db:"checked_at"`
+}
+
+// WishlistSaleAlertDB is a wishlist entry whose price reached its target, joined with who to tell
+type WishlistSaleAlertDB struct {
+	ID           int64   `db:"id"`
+	UserID       string  `db:"user_id"`
+	Email        string  `db:"email"`
+	FirstName    string  `db:"first_name"`
+	GameID       int64   `db:"game_id"`
+	GameName     string  `db:"game_name"`
+	PlatformName string  `db:"platform_name"`
+	TargetPrice  float64 `db:"target_price"`
+	Price        float64 `db:"price"`
+	RegularPrice float64 `db:"regular_price"`
+}
+
+// WishlistSettingsDB holds a user's wishlist preferences
+type WishlistSettingsDB struct {
+	ReleaseReminderDays int      `db:"release_reminder_days"`
+	MonthlyBudget       *float64 `db:"monthly_budget"`
+}
+
+// WishlistReleaseDB is a wishlisted game with a known release date.
+// ReleaseDate is the date the user set on the wishlist entry, or the game's first release date.
+type WishlistReleaseDB struct {
+	ID           int64  `db:"id"`
+	GameID       int64  `db:"game_id"`
+	GameName     string `db:"game_name"`
+	GameCoverURL string `db:"game_cover_url"`
+	PlatformID   int64  `db:"platform_id"`
+	PlatformName string `db:"platform_name"`
+	ReleaseDate  int64  `db:"release_date"`
+}
+
+// WishlistReleaseReminderDB is a release within a user's reminder window, joined with who to tell
+type WishlistReleaseReminderDB struct {
+	WishlistReleaseDB
+	UserID    string `db:"user_id"`
+	Email     string `db:"email"`
+	FirstName string `db:"first_name"`
+}
+
+// WishlistPurchaseToSave holds what's needed to move a wishlisted game into the library as a purchase.
+// Exactly one of SublocationID (physical copies) and DigitalLocationID (digital copies) is set.
+type WishlistPurchaseToSave struct {
+	GameID             int64
+	PlatformID         int64
+	SublocationID      string
+	DigitalLocationID  string
+	Title              string
+	Amount             float64
+	PaymentMethod      string
+	PurchaseDate       time.Time
+	SpendingCategoryID int
+	GameGenres         []GameMetadataTerm // saved to game_genres like a game added to the library
+	GameThemes         []GameMetadataTerm // saved to game_themes like a game added to the library
+}
+
+// GameType is the library game type of the copy the purchase adds
+func (p WishlistPurchaseToSave) GameType() string {
+	if p.DigitalLocationID != "" {
+		return "digital"
+	}
+	return "physical"
+}
+
+// WishlistPurchaseResult identifies the library copy and purchase created from a wishlist entry
+type WishlistPurchaseResult struct {
+	UserGameID   int64
+	PurchaseID   int
+	PlatformID   int64
+	Title        string
+	PurchaseDate time.Time
+}
@@ -0,0 +1,101 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type PlatformsDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewPlatformsDbAdapter(appContext *appcontext.AppContext) (*PlatformsDbAdapter, error) {
+	appContext.Logger.Debug("Creating PlatformsDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &PlatformsDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// PUT
+// SavePlatformTaxonomy upserts the platform families and platforms fetched from IGDB in one transaction
+func (pa *PlatformsDbAdapter) SavePlatformTaxonomy(
+	ctx context.Context,
+	families []models.PlatformFamilyToSave,
+	platforms []models.PlatformToSave,
+	syncedAt time.Time,
+) error {
+	return postgres.WithTransaction(ctx, pa.db, pa.logger, func(tx *sqlx.Tx) error {
+		if len(families) > 0 {
+			ids := make([]int64, len(families))
+			names := make([]string, len(families))
+			slugs := make([]string, len(families))
+			for i, family := range families {
+				ids[i] = family.ID
+				names[i] = family.Name
+				slugs[i] = family.Slug
+			}
+
+			if _, err := tx.ExecContext(ctx, UpsertPlatformFamiliesQuery, pq.Array(ids), pq.Array(names), pq.Array(slugs)); err != nil {
+				return fmt.Errorf("error saving platform families: %w", err)
+			}
+		}
+
+		if len(platforms) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(platforms))
+		names := make([]string, len(platforms))
+		categories := make([]string, len(platforms))
+		platformModels := make([]string, len(platforms))
+		abbreviations := make([]string, len(platforms))
+		generations := make([]int64, len(platforms))
+		familyIDs := make([]int64, len(platforms))
+		logoURLs := make([]string, len(platforms))
+		groups := make([]string, len(platforms))
+		for i, platform := range platforms {
+			ids[i] = platform.ID
+			names[i] = platform.Name
+			categories[i] = platform.Category
+			platformModels[i] = platform.Model
+			abbreviations[i] = platform.Abbreviation
+			generations[i] = int64(platform.Generation)
+			familyIDs[i] = platform.FamilyID
+			logoURLs[i] = platform.LogoURL
+			groups[i] = platform.Group
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			UpsertPlatformsQuery,
+			pq.Array(ids),
+			pq.Array(names),
+			pq.Array(categories),
+			pq.Array(platformModels),
+			pq.Array(abbreviations),
+			pq.Array(generations),
+			pq.Array(familyIDs),
+			pq.Array(logoURLs),
+			pq.Array(groups),
+			syncedAt,
+		); err != nil {
+			return fmt.Errorf("error saving platforms: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package platforms
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Saving the platform taxonomy synced from IGDB
+
+	Scenarios:
+	- SavePlatformTaxonomy upserts families before platforms in one transaction
+	- A failed platform upsert rolls the families back
+*/
+
+func TestPlatformsDbAdapter(t *testing.T) {
+	syncedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	families := []models.PlatformFamilyToSave{{ID: 1, Name: "PlayStation", Slug: "playstation"}}
+	platforms := []models.PlatformToSave{{
+		ID:       48,
+		Name:     "PlayStation 4",
+		Category: models.PlatformCategoryConsole,
+		Model:    "PS4",
+		FamilyID: 1,
+		Group:    "PlayStation",
+	}}
+
+	setupMockDB := func() (*PlatformsDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &PlatformsDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN a family and a platform in it
+		WHEN SavePlatformTaxonomy is called
+		THEN it should upsert the family, then the platform, and commit
+	*/
+	t.Run("SavePlatformTaxonomy upserts families then platforms", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO platform_families").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO platforms").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		err = adapter.SavePlatformTaxonomy(context.Background(), families, platforms, syncedAt)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN the platform upsert fails
+		WHEN SavePlatformTaxonomy is called
+		THEN it should roll back and return the error
+	*/
+	t.Run("SavePlatformTaxonomy rolls back on a failed platform upsert", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO platform_families").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO platforms").WillReturnError(context.DeadlineExceeded)
+		mock.ExpectRollback()
+
+		// WHEN
+		err = adapter.SavePlatformTaxonomy(context.Background(), families, platforms, syncedAt)
+
+		// THEN
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package platforms
+
+// Syncing upserts every IGDB platform family, then every platform.
+// Families go first so the platforms' platform_family_id always points at a row.
+const (
+	UpsertPlatformFamiliesQuery = `
+		INSERT INTO platform_families (id, name, slug)
+		SELECT * FROM UNNEST($1::BIGINT[], $2::TEXT[], $3::TEXT[])
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, slug = EXCLUDED.slug, updated_at = NOW()
+		WHERE (platform_families.name, platform_families.slug) IS DISTINCT FROM (EXCLUDED.name, EXCLUDED.slug)
+	`
+
+	// Platforms added from a game before the first sync get their placeholder category and model replaced here
+	UpsertPlatformsQuery = `
+		INSERT INTO platforms (
+			id, name, category, model, abbreviation, generation,
+			platform_family_id, logo_url, platform_group, synced_at
+		)
+		SELECT
+			id, name, category, model, NULLIF(abbreviation, ''), NULLIF(generation, 0),
+			NULLIF(platform_family_id, 0), NULLIF(logo_url, ''), platform_group, $10
+		FROM UNNEST(
+			$1::BIGINT[], $2::TEXT[], $3::TEXT[], $4::TEXT[], $5::TEXT[],
+			$6::INTEGER[], $7::BIGINT[], $8::TEXT[], $9::TEXT[]
+		) AS synced(
+			id, name, category, model, abbreviation, generation,
+			platform_family_id, logo_url, platform_group
+		)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			name = EXCLUDED.name,
+			category = EXCLUDED.category,
+			model = EXCLUDED.model,
+			abbreviation = EXCLUDED.abbreviation,
+			generation = EXCLUDED.generation,
+			platform_family_id = EXCLUDED.platform_family_id,
+			logo_url = EXCLUDED.logo_url,
+			platform_group = EXCLUDED.platform_group,
+			synced_at = EXCLUDED.synced_at,
+			updated_at = EXCLUDED.synced_at
+	`
+)
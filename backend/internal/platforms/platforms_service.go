@@ -0,0 +1,105 @@
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/search"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// syncInterval is how often the platform taxonomy is fetched from IGDB again, platforms rarely change
+const syncInterval = 24 * time.Hour
+
+// PlatformSyncService keeps the platforms table in line with IGDB's platforms and platform families
+type PlatformSyncService struct {
+	appContext  *appcontext.AppContext
+	dbAdapter   interfaces.PlatformsDbAdapter
+	igdbAdapter interfaces.IGDBPlatformAdapter
+	logger      interfaces.Logger
+	now         func() time.Time
+	stopSync    context.CancelFunc
+}
+
+func NewPlatformSyncService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.PlatformsDbAdapter,
+	igdbAdapter interfaces.IGDBPlatformAdapter,
+) (*PlatformSyncService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if igdbAdapter == nil {
+		return nil, fmt.Errorf("igdbAdapter is required")
+	}
+
+	return &PlatformSyncService{
+		appContext:  appContext,
+		dbAdapter:   dbAdapter,
+		igdbAdapter: igdbAdapter,
+		logger:      appContext.Logger,
+		now:         time.Now,
+	}, nil
+}
+
+// Start syncs the platforms straight away, then runs the sync job
+func (ps *PlatformSyncService) Start(ctx context.Context) error {
+	syncCtx, cancel := context.WithCancel(context.Background())
+	ps.stopSync = cancel
+
+	go func() {
+		if err := ps.SyncPlatforms(syncCtx); err != nil {
+			ps.logger.Error("Initial platform sync failed", map[string]any{"error": err})
+		}
+		worker.NewWorker(syncInterval, ps.SyncPlatforms, nil, ps.logger).Start(syncCtx)
+	}()
+
+	return nil
+}
+
+// Stop stops the sync job
+func (ps *PlatformSyncService) Stop() error {
+	if ps.stopSync != nil {
+		ps.stopSync()
+	}
+	return nil
+}
+
+// SyncPlatforms fetches every platform family and platform from IGDB and saves them
+func (ps *PlatformSyncService) SyncPlatforms(ctx context.Context) error {
+	var families []*types.IGDBPlatformFamilyResponse
+	err := search.WithTokenRefresh(ctx, ps.appContext, ps.igdbAdapter, func() error {
+		var err error
+		families, err = ps.igdbAdapter.GetPlatformFamilies(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching platform families from IGDB: %w", err)
+	}
+
+	var platforms []*types.IGDBPlatformResponse
+	err = search.WithTokenRefresh(ctx, ps.appContext, ps.igdbAdapter, func() error {
+		var err error
+		platforms, err = ps.igdbAdapter.GetPlatforms(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching platforms from IGDB: %w", err)
+	}
+
+	familiesToSave, platformsToSave := TransformPlatformTaxonomy(families, platforms)
+	if err := ps.dbAdapter.SavePlatformTaxonomy(ctx, familiesToSave, platformsToSave, ps.now()); err != nil {
+		return err
+	}
+
+	ps.logger.Info("Synced platforms from IGDB", map[string]any{
+		"families":  len(familiesToSave),
+		"platforms": len(platformsToSave),
+	})
+
+	return nil
+}
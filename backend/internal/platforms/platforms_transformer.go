@@ -0,0 +1,111 @@
+package platforms
+
+import (
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/igdb"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// mobilePlatformIDs are the IGDB operating systems that run on phones and tablets,
+// every other operating system is a computer one
+var mobilePlatformIDs = map[int64]bool{
+	34:  true, // Android
+	39:  true, // iOS
+	73:  true, // BlackBerry OS
+	74:  true, // Windows Phone
+	405: true, // Windows Mobile
+}
+
+// TransformPlatformTaxonomy takes the families and platforms we store from IGDB's responses.
+// A platform pointing at a family IGDB didn't return is saved without one.
+func TransformPlatformTaxonomy(
+	families []*types.IGDBPlatformFamilyResponse,
+	platforms []*types.IGDBPlatformResponse,
+) ([]models.PlatformFamilyToSave, []models.PlatformToSave) {
+	familiesToSave := make([]models.PlatformFamilyToSave, 0, len(families))
+	familyNames := make(map[int64]string, len(families))
+	for _, family := range families {
+		if family == nil || family.ID == 0 || strings.TrimSpace(family.Name) == "" {
+			continue
+		}
+		name := strings.TrimSpace(family.Name)
+		familyNames[family.ID] = name
+		familiesToSave = append(familiesToSave, models.PlatformFamilyToSave{
+			ID:   family.ID,
+			Name: name,
+			Slug: family.Slug,
+		})
+	}
+
+	platformsToSave := make([]models.PlatformToSave, 0, len(platforms))
+	for _, platform := range platforms {
+		if platform == nil || platform.ID == 0 || strings.TrimSpace(platform.Name) == "" {
+			continue
+		}
+
+		name := strings.TrimSpace(platform.Name)
+		familyID := platform.PlatformFamily
+		familyName, ok := familyNames[familyID]
+		if !ok {
+			familyID = 0
+		}
+
+		model := strings.TrimSpace(platform.Abbreviation)
+		if model == "" {
+			model = name
+		}
+
+		category := PlatformCategory(platform.ID, platform.PlatformType)
+		platformsToSave = append(platformsToSave, models.PlatformToSave{
+			ID:           platform.ID,
+			Name:         name,
+			Category:     category,
+			Model:        model,
+			Abbreviation: strings.TrimSpace(platform.Abbreviation),
+			Generation:   platform.Generation,
+			FamilyID:     familyID,
+			LogoURL:      platform.PlatformLogo.URL,
+			Group:        PlatformGroup(name, category, platform.PlatformType, familyName),
+		})
+	}
+
+	return familiesToSave, platformsToSave
+}
+
+// PlatformCategory maps an IGDB platform type onto the categories the platforms table allows
+func PlatformCategory(platformID int64, platformType int) string {
+	switch platformType {
+	case igdb.PlatformTypeComputer, igdb.PlatformTypePlatform:
+		return models.PlatformCategoryPC
+	case igdb.PlatformTypeOperatingSystem:
+		if mobilePlatformIDs[platformID] {
+			return models.PlatformCategoryMobile
+		}
+		return models.PlatformCategoryPC
+	default:
+		return models.PlatformCategoryConsole
+	}
+}
+
+// PlatformGroup is what a platform is grouped under, its family with handhelds kept apart
+// (e.g. "PlayStation", "Nintendo handhelds"). Platforms without a family fall back to their category,
+// consoles without one to their own name.
+func PlatformGroup(name string, category string, platformType int, familyName string) string {
+	if familyName != "" {
+		if platformType == igdb.PlatformTypePortableConsole {
+			return familyName + " handhelds"
+		}
+		return familyName
+	}
+
+	switch category {
+	case models.PlatformCategoryPC:
+		return "PC"
+	case models.PlatformCategoryMobile:
+		return "Mobile"
+	default:
+		return name
+	}
+}
@@ -0,0 +1,113 @@
+package platforms
+
+import (
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/igdb"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Turning IGDB platforms and platform families into the rows we store
+
+	Scenarios:
+	- Consoles are grouped by family, handhelds apart from their family's consoles
+	- Computers and mobile operating systems without a family are grouped as PC and Mobile
+	- A platform pointing at a family IGDB didn't return is saved without one
+	- The model falls back to the platform name when IGDB has no abbreviation
+*/
+
+func TestTransformPlatformTaxonomy(t *testing.T) {
+	families := []*types.IGDBPlatformFamilyResponse{
+		{ID: 1, Name: "PlayStation", Slug: "playstation"},
+		{ID: 5, Name: "Nintendo", Slug: "nintendo"},
+	}
+
+	/*
+		GIVEN a home console and a handheld from the same family
+		WHEN TransformPlatformTaxonomy is called
+		THEN the console is grouped under the family and the handheld under the family's handhelds
+	*/
+	t.Run("Groups consoles and handhelds by family", func(t *testing.T) {
+		// GIVEN
+		platforms := []*types.IGDBPlatformResponse{
+			{ID: 130, Name: "Nintendo Switch", Abbreviation: "Switch", Generation: 8, PlatformFamily: 5, PlatformType: igdb.PlatformTypeConsole},
+			{ID: 37, Name: "Nintendo 3DS", Abbreviation: "3DS", Generation: 8, PlatformFamily: 5, PlatformType: igdb.PlatformTypePortableConsole},
+		}
+
+		// WHEN
+		savedFamilies, saved := TransformPlatformTaxonomy(families, platforms)
+
+		// THEN
+		if len(savedFamilies) != 2 {
+			t.Fatalf("Expected 2 families, got %d", len(savedFamilies))
+		}
+		if saved[0].Group != "Nintendo" || saved[0].Category != models.PlatformCategoryConsole {
+			t.Errorf("Expected Switch to be a Nintendo console, got %q %q", saved[0].Group, saved[0].Category)
+		}
+		if saved[1].Group != "Nintendo handhelds" || saved[1].Category != models.PlatformCategoryConsole {
+			t.Errorf("Expected 3DS to be a Nintendo handheld console, got %q %q", saved[1].Group, saved[1].Category)
+		}
+		if saved[1].Model != "3DS" || saved[1].FamilyID != 5 || saved[1].Generation != 8 {
+			t.Errorf("Expected 3DS details to be kept, got %+v", saved[1])
+		}
+	})
+
+	/*
+		GIVEN a computer and mobile operating systems without a family
+		WHEN TransformPlatformTaxonomy is called
+		THEN they are categorised and grouped as PC and Mobile
+	*/
+	t.Run("Groups platforms without a family by category", func(t *testing.T) {
+		// GIVEN
+		platforms := []*types.IGDBPlatformResponse{
+			{ID: 6, Name: "PC (Microsoft Windows)", Abbreviation: "PC", PlatformType: igdb.PlatformTypeOperatingSystem},
+			{ID: 34, Name: "Android", PlatformType: igdb.PlatformTypeOperatingSystem},
+			{ID: 14, Name: "Mac", PlatformType: igdb.PlatformTypeComputer},
+		}
+
+		// WHEN
+		_, saved := TransformPlatformTaxonomy(families, platforms)
+
+		// THEN
+		expected := []struct {
+			category string
+			group    string
+		}{
+			{models.PlatformCategoryPC, "PC"},
+			{models.PlatformCategoryMobile, "Mobile"},
+			{models.PlatformCategoryPC, "PC"},
+		}
+		for i, want := range expected {
+			if saved[i].Category != want.category || saved[i].Group != want.group {
+				t.Errorf("Expected %s to be %q %q, got %q %q",
+					saved[i].Name, want.category, want.group, saved[i].Category, saved[i].Group)
+			}
+		}
+	})
+
+	/*
+		GIVEN a console pointing at a family IGDB didn't return and without an abbreviation
+		WHEN TransformPlatformTaxonomy is called
+		THEN it is saved without a family, grouped and modelled by its own name
+	*/
+	t.Run("Drops unknown families and falls back to the name", func(t *testing.T) {
+		// GIVEN
+		platforms := []*types.IGDBPlatformResponse{
+			{ID: 29, Name: "Sega Mega Drive/Genesis", PlatformFamily: 3, PlatformType: igdb.PlatformTypeConsole},
+		}
+
+		// WHEN
+		_, saved := TransformPlatformTaxonomy(families, platforms)
+
+		// THEN
+		if saved[0].FamilyID != 0 {
+			t.Errorf("Expected no family, got %d", saved[0].FamilyID)
+		}
+		if saved[0].Group != "Sega Mega Drive/Genesis" || saved[0].Model != "Sega Mega Drive/Genesis" {
+			t.Errorf("Expected the name as group and model, got %q %q", saved[0].Group, saved[0].Model)
+		}
+	})
+}
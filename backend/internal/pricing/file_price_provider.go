@@ -0,0 +1,159 @@
+package pricing
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// FilePriceProvider reads prices from a local JSON or CSV file, picked by the file's extension.
+// The file is read on every check so it can be edited while the server runs.
+//
+// JSON files hold a list of prices:
+//
+//	[{"game_id": 1942, "platform_id": 48, "regular_price": 59.99, "sale_price": 19.99}]
+//
+// CSV files have a header row with the same column names, an empty sale_price means no sale.
+// A platform_id of 0 prices the game on every platform.
+type FilePriceProvider struct {
+	path string
+}
+
+func NewFilePriceProvider(path string) (*FilePriceProvider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("price file path is required")
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".csv":
+	default:
+		return nil, fmt.Errorf("price file must be .json or .csv, got %s", path)
+	}
+
+	return &FilePriceProvider{path: path}, nil
+}
+
+func (fp *FilePriceProvider) Name() string {
+	return "file"
+}
+
+// GetPrices returns the file's prices for the queried games
+func (fp *FilePriceProvider) GetPrices(ctx context.Context, queries []models.PriceQuery) ([]models.GamePrice, error) {
+	file, err := os.Open(fp.path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening price file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []models.GamePrice
+	if strings.ToLower(filepath.Ext(fp.path)) == ".csv" {
+		entries, err = readPriceCSV(file)
+	} else {
+		err = json.NewDecoder(file).Decode(&entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading price file %s: %w", fp.path, err)
+	}
+
+	return matchPrices(queries, entries), nil
+}
+
+// matchPrices picks the entry for each query, an entry for the exact platform wins over a platform_id of 0
+func matchPrices(queries []models.PriceQuery, entries []models.GamePrice) []models.GamePrice {
+	type priceKey struct {
+		gameID     int64
+		platformID int64
+	}
+
+	byKey := make(map[priceKey]models.GamePrice, len(entries))
+	for _, entry := range entries {
+		byKey[priceKey{entry.GameID, entry.PlatformID}] = entry
+	}
+
+	prices := make([]models.GamePrice, 0, len(queries))
+	for _, query := range queries {
+		entry, ok := byKey[priceKey{query.GameID, query.PlatformID}]
+		if !ok {
+			entry, ok = byKey[priceKey{query.GameID, 0}]
+		}
+		if !ok {
+			continue
+		}
+
+		entry.PlatformID = query.PlatformID
+		prices = append(prices, entry)
+	}
+
+	return prices
+}
+
+func readPriceCSV(r io.Reader) ([]models.GamePrice, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"game_id", "regular_price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var prices []models.GamePrice
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var price models.GamePrice
+		if price.GameID, err = strconv.ParseInt(field(record, "game_id"), 10, 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid game_id: %w", line, err)
+		}
+		if value := field(record, "platform_id"); value != "" {
+			if price.PlatformID, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid platform_id: %w", line, err)
+			}
+		}
+		if price.RegularPrice, err = strconv.ParseFloat(field(record, "regular_price"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid regular_price: %w", line, err)
+		}
+		if value := field(record, "sale_price"); value != "" {
+			salePrice, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid sale_price: %w", line, err)
+			}
+			price.SalePrice = &salePrice
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
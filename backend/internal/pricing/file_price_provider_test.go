@@ -0,0 +1,147 @@
+package pricing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+/*
+	Behavior:
+	- Reading wishlist prices from a local JSON or CSV file
+
+	Scenarios:
+	- JSON prices are matched by game and platform
+	- CSV prices are matched, an empty sale_price means no sale
+	- A platform_id of 0 prices the game on every platform
+	- Games missing from the file are left out
+	- A malformed CSV row fails the check
+*/
+
+func writePriceFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write price file: %v", err)
+	}
+	return path
+}
+
+func TestFilePriceProvider(t *testing.T) {
+	queries := []models.PriceQuery{
+		{GameID: 1942, GameName: "The Witcher 3", PlatformID: 48},
+		{GameID: 1020, GameName: "Grand Theft Auto V", PlatformID: 6},
+		{GameID: 7346, GameName: "Breath of the Wild", PlatformID: 130},
+	}
+
+	/*
+		GIVEN a JSON price file with a sale on one game and a catch-all platform entry for another
+		WHEN GetPrices is called
+		THEN it should return both games and leave out the one that isn't in the file
+	*/
+	t.Run("Reads JSON prices", func(t *testing.T) {
+		// GIVEN
+		path := writePriceFile(t, "prices.json", `[
+			{"game_id": 1942, "platform_id": 48, "regular_price": 39.99, "sale_price": 9.99},
+			{"game_id": 1942, "platform_id": 6, "regular_price": 29.99},
+			{"game_id": 1020, "platform_id": 0, "regular_price": 19.99}
+		]`)
+		provider, err := NewFilePriceProvider(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// WHEN
+		prices, err := provider.GetPrices(context.Background(), queries)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(prices) != 2 {
+			t.Fatalf("expected 2 prices, got %d", len(prices))
+		}
+		if prices[0].GameID != 1942 || prices[0].RegularPrice != 39.99 || prices[0].SalePrice == nil || *prices[0].SalePrice != 9.99 {
+			t.Errorf("unexpected price for 1942: %+v", prices[0])
+		}
+		if prices[1].GameID != 1020 || prices[1].PlatformID != 6 || prices[1].SalePrice != nil {
+			t.Errorf("expected the catch-all price on platform 6, got %+v", prices[1])
+		}
+	})
+
+	/*
+		GIVEN a CSV price file with an empty sale_price
+		WHEN GetPrices is called
+		THEN it should return the regular price without a sale
+	*/
+	t.Run("Reads CSV prices", func(t *testing.T) {
+		// GIVEN
+		path := writePriceFile(t, "prices.csv", "game_id,platform_id,regular_price,sale_price\n"+
+			"1942,48,39.99,9.99\n"+
+			"7346,130,59.99,\n")
+		provider, err := NewFilePriceProvider(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// WHEN
+		prices, err := provider.GetPrices(context.Background(), queries)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(prices) != 2 {
+			t.Fatalf("expected 2 prices, got %d", len(prices))
+		}
+		if prices[0].SalePrice == nil || *prices[0].SalePrice != 9.99 {
+			t.Errorf("expected a sale price of 9.99, got %+v", prices[0])
+		}
+		if prices[1].GameID != 7346 || prices[1].RegularPrice != 59.99 || prices[1].SalePrice != nil {
+			t.Errorf("unexpected price for 7346: %+v", prices[1])
+		}
+	})
+
+	/*
+		GIVEN a CSV price file with a price that isn't a number
+		WHEN GetPrices is called
+		THEN it should return an error
+	*/
+	t.Run("Rejects malformed CSV rows", func(t *testing.T) {
+		// GIVEN
+		path := writePriceFile(t, "prices.csv", "game_id,platform_id,regular_price\n1942,48,cheap\n")
+		provider, err := NewFilePriceProvider(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// WHEN
+		_, err = provider.GetPrices(context.Background(), queries)
+
+		// THEN
+		if err == nil {
+			t.Error("expected an error for a malformed row")
+		}
+	})
+
+	/*
+		GIVEN a price file that isn't JSON or CSV
+		WHEN NewFilePriceProvider is called
+		THEN it should return an error
+	*/
+	t.Run("Rejects unsupported file types", func(t *testing.T) {
+		// GIVEN
+		path := "prices.xml"
+
+		// WHEN
+		_, err := NewFilePriceProvider(path)
+
+		// THEN
+		if err == nil {
+			t.Error("expected an error for an unsupported file type")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// httpPriceTimeout bounds a single request to the price API
+const httpPriceTimeout = 30 * time.Second
+
+// HTTPPriceProvider asks a price API for prices.
+// It POSTs {"items": [...]} to {baseURL}/prices and expects {"prices": [...]} back,
+// which makes it easy to point at a stub server in development.
+type HTTPPriceProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type httpPriceRequest struct {
+	Items []models.PriceQuery `json:"items"`
+}
+
+type httpPriceResponse struct {
+	Prices []models.GamePrice `json:"prices"`
+}
+
+func NewHTTPPriceProvider(baseURL string) (*HTTPPriceProvider, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("price API base URL is required")
+	}
+
+	return &HTTPPriceProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: httpPriceTimeout},
+	}, nil
+}
+
+func (hp *HTTPPriceProvider) Name() string {
+	return "http"
+}
+
+// GetPrices requests prices for the queried games in one call
+func (hp *HTTPPriceProvider) GetPrices(ctx context.Context, queries []models.PriceQuery) ([]models.GamePrice, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(httpPriceRequest{Items: queries})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding price request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hp.baseURL+"/prices", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating price request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := hp.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("price API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var response httpPriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error decoding price response: %w", err)
+	}
+
+	return response.Prices, nil
+}
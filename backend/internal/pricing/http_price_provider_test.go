@@ -0,0 +1,92 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+/*
+	Behavior:
+	- Requesting wishlist prices from a price API
+
+	Scenarios:
+	- The queried games are posted to /prices and the returned prices are decoded
+	- A non 200 response fails the check
+*/
+
+func TestHTTPPriceProvider(t *testing.T) {
+	queries := []models.PriceQuery{
+		{GameID: 1942, GameName: "The Witcher 3", PlatformID: 48, PlatformName: "PlayStation 4"},
+	}
+
+	/*
+		GIVEN a stub price API
+		WHEN GetPrices is called
+		THEN it should post the queries to /prices and return the prices it answers with
+	*/
+	t.Run("Posts queries and decodes prices", func(t *testing.T) {
+		// GIVEN
+		var received httpPriceRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/prices" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"prices": [{"game_id": 1942, "platform_id": 48, "regular_price": 39.99, "sale_price": 9.99}]}`))
+		}))
+		defer server.Close()
+
+		provider, err := NewHTTPPriceProvider(server.URL + "/")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// WHEN
+		prices, err := provider.GetPrices(context.Background(), queries)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(received.Items) != 1 || received.Items[0].GameName != "The Witcher 3" {
+			t.Errorf("expected the query to be posted, got %+v", received.Items)
+		}
+		if len(prices) != 1 || prices[0].SalePrice == nil || *prices[0].SalePrice != 9.99 {
+			t.Errorf("unexpected prices: %+v", prices)
+		}
+	})
+
+	/*
+		GIVEN a price API that answers with an error status
+		WHEN GetPrices is called
+		THEN it should return an error
+	*/
+	t.Run("Fails on error responses", func(t *testing.T) {
+		// GIVEN
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream unavailable", http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		provider, err := NewHTTPPriceProvider(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// WHEN
+		_, err = provider.GetPrices(context.Background(), queries)
+
+		// THEN
+		if err == nil {
+			t.Error("expected an error for a 502 response")
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package pricing
+
+import (
+	"fmt"
+
+	"github.com/lokeam/qko-beta/config"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+)
+
+// NewPriceProvider builds the provider named in the pricing config.
+// Returns nil when no provider is configured, wishlist price checks are turned off.
+func NewPriceProvider(cfg *config.PricingConfig) (interfaces.PriceProvider, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case config.PriceProviderFile:
+		return NewFilePriceProvider(cfg.FilePath)
+	case config.PriceProviderHTTP:
+		return NewHTTPPriceProvider(cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("unknown price provider %q", cfg.Provider)
+	}
+}
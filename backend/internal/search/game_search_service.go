@@ -13,6 +13,7 @@ import (
 	"github.com/lokeam/qko-beta/internal/models"
 	"github.com/lokeam/qko-beta/internal/search/searchdef"
 	security "github.com/lokeam/qko-beta/internal/shared/security/sanitizer"
+	validationErrors "github.com/lokeam/qko-beta/internal/shared/validation"
 	"github.com/lokeam/qko-beta/internal/types"
 )
 
@@ -93,8 +94,10 @@ func NewGameSearchService(appContext *appcontext.AppContext) (*GameSearchService
 func (s *GameSearchService) Search(ctx context.Context, req searchdef.SearchRequest) (*searchdef.SearchResult, error) {
 	// Add logging
 	s.logger.Debug("Making IGDB request", map[string]any{
-		"query": req.Query,
-		"limit": req.Limit,
+		"query":  req.Query,
+		"limit":  req.Limit,
+		"page":   req.Page,
+		"offset": req.Offset,
 	})
 
 	// 1. Sanitize the query.
@@ -105,30 +108,36 @@ func (s *GameSearchService) Search(ctx context.Context, req searchdef.SearchRequ
 	}
 	req.Query = sanitized
 
-	// 2. Validate the query.
-	if err := s.validator.ValidateQuery(searchdef.SearchQuery(req)); err != nil {
+	// 2. Build a SearchQuery from the request, a page becomes the offset it starts at.
+	// Note: SearchQuery must provide the ToCacheKey() method.
+	offset, err := searchOffset(req)
+	if err != nil {
 		s.logger.Error("Search validation failed", map[string]any{"error": err})
 		return nil, err
 	}
+	sq := searchdef.SearchQuery{Query: req.Query, Limit: req.Limit, Offset: offset}
 
-	// 3. Build a SearchQuery from the request.
-	// Note: SearchQuery must provide the ToCacheKey() method.
-	sq := searchdef.SearchQuery(req)
+	// 3. Validate the query.
+	if err := s.validator.ValidateQuery(sq); err != nil {
+		s.logger.Error("Search validation failed", map[string]any{"error": err})
+		return nil, err
+	}
 
-	// 3. Attempt to retrieve cached results.
+	// 4. Attempt to retrieve cached results.
 	cachedResult, err := s.cacheWrapper.GetCachedResults(ctx, sq)
 	if err == nil && cachedResult != nil {
 		s.logger.Debug("Cache hit", map[string]any{"query": req.Query})
 		return cachedResult, nil
 	}
 
-	s.logger.Debug("Cache miss; performing IGDB search", map[string]any{"query": req.Query, "limit": req.Limit})
+	s.logger.Debug("Cache miss; performing IGDB search", map[string]any{"query": req.Query, "limit": req.Limit, "offset": offset})
 
-	// 4. If cache miss, fetch data using adapter with retry for auth errors
+	// 5. If cache miss, fetch data using adapter with retry for auth errors
 	games, err := s.searchWithTokenRefresh(
 		ctx,
 		req.Query,
 		req.Limit,
+		offset,
 	)
 	if err != nil {
 		return nil, err
@@ -141,11 +150,13 @@ func (s *GameSearchService) Search(ctx context.Context, req searchdef.SearchRequ
 	}
 
 	result := &searchdef.SearchResult{Games: convertedGames}
+	result.WithPage(offset, req.Limit)
+	result.Meta.Query = req.Query
 	result.Meta.CacheHit = false
 	result.Meta.CacheTTL = s.config.Redis.RedisTTL
 	result.Meta.TimestampUTC = time.Now().UTC().Format(time.RFC3339)
 
-	// 5. Cache the fresh data.
+	// 6. Cache the fresh data.
 	if err := s.cacheWrapper.SetCachedResults(ctx, sq, result); err != nil {
 		s.logger.Error("Failed to cache fresh search results", map[string]any{"error": err})
 	}
@@ -153,6 +164,27 @@ func (s *GameSearchService) Search(ctx context.Context, req searchdef.SearchRequ
 	return result, nil
 }
 
+// searchOffset works out where the requested page starts, requests set either a page or an offset
+func searchOffset(req searchdef.SearchRequest) (int, error) {
+	if req.Page < 0 {
+		return 0, &validationErrors.ValidationError{
+			Field:   "page",
+			Message: "page must be a positive number",
+		}
+	}
+	if req.Page == 0 {
+		return req.Offset, nil
+	}
+	if req.Offset != 0 {
+		return 0, &validationErrors.ValidationError{
+			Field:   "page",
+			Message: "set either page or offset, not both",
+		}
+	}
+
+	return (req.Page - 1) * req.Limit, nil
+}
+
 // GetAllGameStorageLocations grabs all physical and digital locations for a user
 func (s *GameSearchService) GetAllGameStorageLocationsBFF(ctx context.Context, userID string) (types.AddGameFormStorageLocationsResponse, error) {
 	// No cache, no validation, no sanitization
@@ -191,46 +223,64 @@ func (s *GameSearchService) searchWithTokenRefresh(
 	ctx context.Context,
 	query string,
 	limit int,
+	offset int,
 ) ([]*models.Game, error) {
-	// Attempt to search IGDB
-	games, err := s.adapter.SearchGames(ctx, query, limit)
+	// Attempt to search IGDB, a 401 refreshes the token and retries once
+	var games []*models.Game
+	err := WithTokenRefresh(ctx, s.appContext, s.adapter, func() error {
+		var err error
+		games, err = s.adapter.SearchGames(ctx, query, limit, offset)
+
+		// Add logging
+		s.logger.Debug("IGDB SearchGames response", map[string]any{
+			"games": games,
+			"error": err,
+		})
+		return err
+	})
 
-	// Add logging
-	s.logger.Debug("IGDB SearchGames response", map[string]any{
-		"games": games,
+	return games, err
+}
+
+// WithTokenRefresh runs an IGDB call and, when IGDB turns it down as unauthorized,
+// fetches a fresh Twitch token, hands it to the adapter and retries the call once.
+// Every IGDB caller goes through it so an expired token never needs a restart.
+func WithTokenRefresh(
+	ctx context.Context,
+	appContext *appcontext.AppContext,
+	adapter interfaces.IGDBTokenUpdater,
+	call func() error,
+) error {
+	err := call()
+	if err == nil || !IAuthError(err) {
+		return err
+	}
+
+	appContext.Logger.Warn("Received authentication error from IGDB, attempting token refresh", map[string]any{
 		"error": err,
 	})
 
-	// If we get an error, check if it is related to auth (401)
-	if err != nil && IAuthError(err) {
-		s.logger.Warn("Received authentication error from IGDB, attempting token refresh", map[string]any{
+	// Attempt to refresh token
+	newToken, err := refreshToken(ctx, appContext)
+	if err != nil {
+		appContext.Logger.Error("Failed to refresh token", map[string]any{
 			"error": err,
 		})
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
 
-		// Attempt to refresh token
-		newToken, refreshErr := s.refreshToken(ctx)
-		if refreshErr != nil {
-			s.logger.Error("Failed to refresh token", map[string]any{
-				"error": refreshErr,
-			})
-			return nil, fmt.Errorf("failed to refresh token: %w", refreshErr)
-		}
-
-		// Update the token in the IGDB client
-		if err := s.adapter.UpdateToken(newToken); err != nil {
-			s.logger.Error("Failed to update token in IGDB client", map[string]any{
-				"error": err,
-			})
-			return nil, fmt.Errorf("failed to update token in IGDB client: %w", err)
-		}
-
-		s.logger.Info("Token refreshed successfully, retrying search request", nil)
-
-		// Retry the search with the new token
-		return s.adapter.SearchGames(ctx, query, limit)
+	// Update the token in the IGDB client
+	if err := adapter.UpdateToken(newToken); err != nil {
+		appContext.Logger.Error("Failed to update token in IGDB client", map[string]any{
+			"error": err,
+		})
+		return fmt.Errorf("failed to update token in IGDB client: %w", err)
 	}
 
-	return games, err
+	appContext.Logger.Info("Token refreshed successfully, retrying IGDB request", nil)
+
+	// Retry the call with the new token
+	return call()
 }
 
 // TODO: Move this to error handling package
@@ -246,17 +296,23 @@ func IAuthError(err error) bool {
 				 strings.Contains(strings.ToLower(err.Error()), "authentication")
 }
 
-// refreshToken refreshes the Twitch token and returns the new token
-func (s *GameSearchService) refreshToken(ctx context.Context) (string, error) {
-	token, err := s.appContext.TwitchTokenRetriever.GetToken(
+// IsRateLimitError reports whether IGDB turned a request down for going over its rate limit
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return strings.Contains(err.Error(), "status 429") ||
+		strings.Contains(strings.ToLower(err.Error()), "too many requests")
+}
+
+// refreshToken fetches a new Twitch token
+func refreshToken(ctx context.Context, appContext *appcontext.AppContext) (string, error) {
+	return appContext.TwitchTokenRetriever.GetToken(
 		ctx,
-		s.appContext.Config.IGDB.ClientID,
-		s.appContext.Config.IGDB.ClientSecret,
-		s.appContext.Config.IGDB.AuthURL,
-		s.logger,
+		appContext.Config.IGDB.ClientID,
+		appContext.Config.IGDB.ClientSecret,
+		appContext.Config.IGDB.AuthURL,
+		appContext.Logger,
 	)
-	if err != nil {
-		return "", fmt.Errorf("failed to refresh token: %w", err)
-	}
-	return token, nil
 }
\ No newline at end of file
@@ -35,6 +35,9 @@ import (
 		- Cache Miss:
 			* Search request passes both sanitization and validation BUT no cached result exists
 			  service then calls IGDB adapter to fetch data, convert results and cache data and return result
+		- 401 Authentication Error:
+			* IGDB adapter returns a 401, the service refreshes the token, hands it to the adapter
+			  and retries the search once
 		- Adapter Failure (Search Error):
 			* Search request passes both sanitization and validation AND cache exists
 			  IGDB adapter returns an error
@@ -43,6 +46,10 @@ import (
 			* Search request passes both sanitization and validation AND cache exists
 			  Caching wrapper returns an error (Redis failure)
 			  Game search service logs the error BUT still returns the result
+		- Paging:
+			* Search request for a page is sent to IGDB with the offset the page starts at,
+			  the result meta says which page it is and whether there are more
+			* Search request with both a page and an offset fails validation
 */
 
 /* ------ Helper to create a GameSearchService with Mocks ------ */
@@ -196,7 +203,7 @@ func TestGameSearchService(t *testing.T) {
 			}
 
 			testSearchService.adapter = &mocks.MockIGDBAdapter{
-				SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 					return fetchedGameResponse, nil
 				},
 			}
@@ -235,7 +242,7 @@ func TestGameSearchService(t *testing.T) {
 
         testSearchService := &GameSearchService{
             adapter: &mocks.MockIGDBAdapter{
-                SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+                SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
                     return nil, errors.New("401 Unauthorized: Invalid token")
                 },
                 UpdateTokenFunc: func(token string) error {
@@ -266,6 +273,54 @@ func TestGameSearchService(t *testing.T) {
     },
 	)
 
+	// --------- 401 Authentication Error Retried With A Fresh Token ---------
+	t.Run(
+		`Search service retries once with a refreshed token after a 401`,
+		func(t *testing.T) {
+			/*
+				GIVEN a valid search request with a cache miss
+				WHEN the IGDB adapter returns a 401 error the first time
+				THEN the service should hand the adapter a fresh token and return the retried results
+			*/
+			testLogger := testutils.NewTestLogger()
+			testSearchService := newMockGameSearchServiceWithDefaults(testLogger)
+			testSearchService.appContext = &appcontext.AppContext{
+				Config:               mocks.NewMockConfig(),
+				Logger:               testLogger,
+				TwitchTokenRetriever: &mocks.MockTwitchTokenRetriever{},
+			}
+
+			calls := 0
+			var updatedToken string
+			testSearchService.adapter = &mocks.MockIGDBAdapter{
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
+					calls++
+					if calls == 1 {
+						return nil, errors.New("401 Unauthorized: Invalid token")
+					}
+					return []*models.Game{{ID: 1, Name: "Dark Souls"}}, nil
+				},
+				UpdateTokenFunc: func(token string) error {
+					updatedToken = token
+					return nil
+				},
+			}
+
+			result, err := testSearchService.Search(ctx, searchdef.SearchRequest{
+				Query: "Dark Souls",
+			})
+			if err != nil {
+				t.Fatalf("expected the retried search to succeed, got: %v", err)
+			}
+			if calls != 2 || updatedToken != "mock-valid-access-token" {
+				t.Errorf("expected one retry with the refreshed token, got %d calls and token %q", calls, updatedToken)
+			}
+			if len(result.Games) != 1 || result.Games[0].ID != 1 {
+				t.Errorf("expected the retried results, got %+v", result.Games)
+			}
+		},
+	)
+
 	// --------- Adapter Failure (Search Error) ---------
 	t.Run(
 		`Search service fails on adapter error`,
@@ -279,7 +334,7 @@ func TestGameSearchService(t *testing.T) {
 			testSearchService := newMockGameSearchServiceWithDefaults(testLogger)
 
 			testSearchService.adapter = &mocks.MockIGDBAdapter{
-				SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 					return nil, errors.New("adapter failure")
 				},
 			}
@@ -313,7 +368,7 @@ func TestGameSearchService(t *testing.T) {
 			}
 
 			testSearchService.adapter = &mocks.MockIGDBAdapter{
-				SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 					return fetchedGameResponse, nil
 				},
 			}
@@ -336,6 +391,79 @@ func TestGameSearchService(t *testing.T) {
 			}
 		},
 	)
+
+	// --------- Paging ---------
+	t.Run(
+		`Search service pages through results with an offset`,
+		func(t *testing.T) {
+			/*
+				GIVEN a search request for the second page of two results
+				WHEN the Search() method is called and IGDB returns a full page
+				THEN the adapter should be asked for results from offset 2 and the meta should point to a next page
+			*/
+			testLogger := testutils.NewTestLogger()
+			testSearchService := newMockGameSearchServiceWithDefaults(testLogger)
+
+			var searchedOffset int
+			testSearchService.adapter = &mocks.MockIGDBAdapter{
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
+					searchedOffset = offset
+					return []*models.Game{{ID: 3, Name: "Dark Souls 3"}, {ID: 4, Name: "Dark Souls Remastered"}}, nil
+				},
+			}
+
+			searchServiceResult, err := testSearchService.Search(ctx, searchdef.SearchRequest{
+				Query: "Dark Souls",
+				Limit: 2,
+				Page:  2,
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if searchedOffset != 2 {
+				t.Errorf("expected IGDB to be searched from offset 2, got %d", searchedOffset)
+			}
+
+			meta := searchServiceResult.Meta
+			if meta.CurrentPage != 2 || !meta.HasPreviousPage || !meta.HasNextPage || meta.TotalPages != 3 || meta.Total != 4 {
+				t.Errorf("unexpected pagination meta: %+v", meta)
+			}
+		},
+	)
+
+	t.Run(
+		`Search service rejects a request with both a page and an offset`,
+		func(t *testing.T) {
+			/*
+				GIVEN a search request with both a page and an offset
+				WHEN the Search() method is called
+				THEN the service should return a validation error without searching IGDB
+			*/
+			testLogger := testutils.NewTestLogger()
+			testSearchService := newMockGameSearchServiceWithDefaults(testLogger)
+
+			searched := false
+			testSearchService.adapter = &mocks.MockIGDBAdapter{
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
+					searched = true
+					return nil, nil
+				},
+			}
+
+			_, err := testSearchService.Search(ctx, searchdef.SearchRequest{
+				Query:  "Dark Souls",
+				Limit:  2,
+				Page:   2,
+				Offset: 10,
+			})
+			if err == nil {
+				t.Errorf("expected a validation error, got nil")
+			}
+			if searched {
+				t.Errorf("expected IGDB not to be searched")
+			}
+		},
+	)
 }
 
 
@@ -97,16 +97,19 @@ func NewIGDBAdapter(appContext *appcontext.AppContext) (*IGDBAdapter, error) {
 //   3. Converts the IGDB response into our application's game model
 //   4. Handles any errors that occur during the process
 //
+// offset skips that many results, so callers can page past the first limit results.
+//
 // The query includes:
 //   - Basic game information (id, name, summary)
 //   - Release date and rating
 //   - Cover image URL
 //   - Platform, genre, and theme names
 //   - Game type information
-func (a *IGDBAdapter) SearchGames(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+func (a *IGDBAdapter) SearchGames(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 	a.logger.Info("IGDB Adapter - UPDATED SearchGames called", map[string]any{
-		"query": query,
-		"limit": limit,
+		"query":  query,
+		"limit":  limit,
+		"offset": offset,
 	})
 
 	// Create query builder with logger
@@ -114,7 +117,8 @@ func (a *IGDBAdapter) SearchGames(ctx context.Context, query string, limit int)
         Search(query).
         Fields(igdb.DefaultGameFields...).
         Where(igdb.GameTypeFilter).
-        Limit(limit)
+        Limit(limit).
+        Offset(offset)
 
 	// Execute the query
 	responses, err := a.client.ExecuteQuery(ctx, queryBuilder)
@@ -136,6 +140,77 @@ func (a *IGDBAdapter) SearchGames(ctx context.Context, query string, limit int)
 	return games, nil
 }
 
+// GetGamesByIDs looks up games by their IGDB IDs, at most igdb.MaxLimit at a time.
+// IDs that IGDB doesn't know about, or that fail the game type filter, are left out of the result.
+func (a *IGDBAdapter) GetGamesByIDs(ctx context.Context, ids []int64) ([]*models.Game, error) {
+	a.logger.Info("IGDB Adapter - GetGamesByIDs called", map[string]any{
+		"ids": ids,
+	})
+
+	if len(ids) == 0 {
+		return []*models.Game{}, nil
+	}
+	if len(ids) > igdb.MaxLimit {
+		return nil, igdb.NewInvalidLimitError(len(ids))
+	}
+
+	queryBuilder := igdb.NewIGDBQueryBuilder(a.logger).
+		IDs(ids...).
+		Fields(igdb.DefaultGameFields...).
+		Where(igdb.GameTypeFilter).
+		Limit(len(ids))
+
+	responses, err := a.client.ExecuteQuery(ctx, queryBuilder)
+	if err != nil {
+		a.logger.Error("Failed to execute IGDB query", map[string]any{
+			"error": err,
+			"ids":   ids,
+		})
+		return nil, err
+	}
+
+	return a.convertResponsesToGames(responses), nil
+}
+
+// GetPlatforms pages through every platform IGDB knows about
+func (a *IGDBAdapter) GetPlatforms(ctx context.Context) ([]*types.IGDBPlatformResponse, error) {
+	var platforms []*types.IGDBPlatformResponse
+	for offset := 0; ; offset += igdb.MaxPageSize {
+		page, err := a.client.GetPlatforms(ctx, offset)
+		if err != nil {
+			a.logger.Error("Failed to get platforms from IGDB", map[string]any{
+				"error":  err,
+				"offset": offset,
+			})
+			return nil, err
+		}
+
+		platforms = append(platforms, page...)
+		if len(page) < igdb.MaxPageSize {
+			return platforms, nil
+		}
+	}
+}
+
+// GetPlatformFamilies pages through every platform family IGDB knows about
+func (a *IGDBAdapter) GetPlatformFamilies(ctx context.Context) ([]*types.IGDBPlatformFamilyResponse, error) {
+	var families []*types.IGDBPlatformFamilyResponse
+	for offset := 0; ; offset += igdb.MaxPageSize {
+		page, err := a.client.GetPlatformFamilies(ctx, offset)
+		if err != nil {
+			a.logger.Error("Failed to get platform families from IGDB", map[string]any{
+				"error":  err,
+				"offset": offset,
+			})
+			return nil, err
+		}
+
+		families = append(families, page...)
+		if len(page) < igdb.MaxPageSize {
+			return families, nil
+		}
+	}
+}
 
 // UpdateToken updates the authentication token used by the IGDB client.
 // This is needed because IGDB tokens expire and need to be refreshed.
@@ -184,7 +259,9 @@ func (a *IGDBAdapter) convertResponsesToGames(responses []*types.IGDBResponse) [
 
 			// Pre-allocate slices with known capacity
 			platformNames := make([]string, len(resp.Platforms))
+			genreIDs := make([]int64, len(resp.Genres))
 			genreNames := make([]string, len(resp.Genres))
+			themeIDs := make([]int64, len(resp.Themes))
 			themeNames := make([]string, len(resp.Themes))
 
 			platforms := make([]models.PlatformInfo, len(resp.Platforms))
@@ -199,11 +276,13 @@ func (a *IGDBAdapter) convertResponsesToGames(responses []*types.IGDBResponse) [
 
 			// Convert genres using index-based loop
 			for j := 0; j < len(resp.Genres); j++ {
+					genreIDs[j] = resp.Genres[j].ID
 					genreNames[j] = resp.Genres[j].Name
 			}
 
 			// Convert themes using index-based loop
 			for j := 0; j < len(resp.Themes); j++ {
+					themeIDs[j] = resp.Themes[j].ID
 					themeNames[j] = resp.Themes[j].Name
 			}
 
@@ -218,6 +297,8 @@ func (a *IGDBAdapter) convertResponsesToGames(responses []*types.IGDBResponse) [
 					GameTypeResponse: gameTypeforResponse,
 					Platforms:        platforms,
 					PlatformNames:    platformNames,
+					Genres:           genreIDs,
+					Themes:           themeIDs,
 					GenreNames:       genreNames,
 					ThemeNames:       themeNames,
 			}
@@ -238,3 +319,71 @@ func getGameType(id int64) types.GameType {
 	}
 	return types.GameType{} // Return zero value if not found
 }
+
+// GetGameAddons looks up a game's DLCs, expansions and standalone expansions.
+// The add-on type comes from each add-on's game type through getGameType, add-ons without one
+// fall back to the relation they were listed under. A game IGDB doesn't know about has no add-ons.
+func (a *IGDBAdapter) GetGameAddons(ctx context.Context, gameID int64) ([]*models.GameAddon, error) {
+	response, err := a.client.GetGameAddons(ctx, gameID)
+	if err != nil {
+		a.logger.Error("Failed to get game addons from IGDB", map[string]any{
+			"error":  err,
+			"gameID": gameID,
+		})
+		return nil, err
+	}
+	if response == nil {
+		return []*models.GameAddon{}, nil
+	}
+
+	return convertAddonResponses(response), nil
+}
+
+// Helper fn - convertAddonResponses flattens a game's add-on relations, an add-on listed twice is kept once
+func convertAddonResponses(response *types.IGDBGameAddonsResponse) []*models.GameAddon {
+	relations := []struct {
+		addons       []types.IGDBAddonResponse
+		fallbackType string
+	}{
+		{response.DLCs, models.AddonTypeDLC},
+		{response.Expansions, models.AddonTypeExpansion},
+		{response.StandaloneExpansions, models.AddonTypeStandalone},
+	}
+
+	addons := make([]*models.GameAddon, 0, len(response.DLCs)+len(response.Expansions)+len(response.StandaloneExpansions))
+	seen := make(map[int64]bool, cap(addons))
+	for _, relation := range relations {
+		for _, resp := range relation.addons {
+			if resp.ID == 0 || resp.Name == "" || seen[resp.ID] {
+				continue
+			}
+			seen[resp.ID] = true
+
+			addonType := relation.fallbackType
+			if gameType := getGameType(resp.GameType.ID).NormalizedText; isAddonType(gameType) {
+				addonType = gameType
+			}
+
+			addons = append(addons, &models.GameAddon{
+				ID:               resp.ID,
+				ParentGameID:     response.ID,
+				Name:             resp.Name,
+				AddonType:        addonType,
+				CoverURL:         resp.Cover.URL,
+				FirstReleaseDate: resp.FirstReleaseDate,
+			})
+		}
+	}
+
+	return addons
+}
+
+// Helper fn - isAddonType reports whether a normalized game type is one an add-on can have
+func isAddonType(normalizedText string) bool {
+	for _, addonType := range models.AddonTypes {
+		if normalizedText == addonType {
+			return true
+		}
+	}
+	return false
+}
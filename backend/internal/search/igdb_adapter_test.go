@@ -12,6 +12,7 @@ import (
 	"github.com/lokeam/qko-beta/internal/models"
 	"github.com/lokeam/qko-beta/internal/testutils"
 	"github.com/lokeam/qko-beta/internal/testutils/mocks"
+	"github.com/lokeam/qko-beta/internal/types"
 )
 
 /*
@@ -43,12 +44,12 @@ func TestIGDBAdapter(t *testing.T) {
 				THEN SearchGames() returns and error indicating the IGDB API returned a non 200 status code
 			*/
 			mockAdapter := &mocks.MockIGDBAdapter{
-        SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+        SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
             return nil, fmt.Errorf("HTTP error: non-200 status code")
         },
     	}
 
-			_, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 1)
+			_, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 1, 0)
 			if testErr == nil {
 					t.Fatal("expected error for non 200 response but got nil")
 			}
@@ -91,7 +92,7 @@ func TestIGDBAdapter(t *testing.T) {
 		testIGDBAdapter.client.SetHTTPClient(mockHTTPClient)
 
 		// Perform the search
-		_, searchErr := testIGDBAdapter.SearchGames(context.Background(), "DarkSouls", 1)
+		_, searchErr := testIGDBAdapter.SearchGames(context.Background(), "DarkSouls", 1, 0)
 
 		// Assert the error
 		if searchErr == nil {
@@ -105,12 +106,12 @@ func TestIGDBAdapter(t *testing.T) {
 
 	t.Run(`SearchGames() returns an irregular JSON response`, func(t *testing.T) {
     mockAdapter := &mocks.MockIGDBAdapter{
-				SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 						return nil, fmt.Errorf("failed to decode JSON response")
 				},
 		}
 
-		_, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 1)
+		_, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 1, 0)
 		if testErr == nil {
 				t.Fatal("expected JSON decoding error but got nil")
 		}
@@ -129,14 +130,14 @@ func TestIGDBAdapter(t *testing.T) {
 				(thus indicating that the circuit breaker is still open)
 			*/
 			mockAdapter := &mocks.MockIGDBAdapter{
-        SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+        SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
             return nil, errors.New("circuit breaker error")
         },
 			}
 
 			var finalErr error
 			for i := 0; i < 5; i++ {
-					_, finalErr = mockAdapter.SearchGames(context.Background(), "Dark Souls", 1)
+					_, finalErr = mockAdapter.SearchGames(context.Background(), "Dark Souls", 1, 0)
 			}
 
 			if finalErr == nil {
@@ -155,7 +156,7 @@ func TestIGDBAdapter(t *testing.T) {
 				THEN SearchGames() should return an error related to the context being cancelled/expired
 			*/
 				mockAdapter := &mocks.MockIGDBAdapter{
-					SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+					SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 							return nil, context.Canceled
 					},
 			}
@@ -163,7 +164,7 @@ func TestIGDBAdapter(t *testing.T) {
 			testContext, cancel := context.WithCancel(context.Background())
 			cancel()
 
-			_, testErr := mockAdapter.SearchGames(testContext, "Dark Souls", 1)
+			_, testErr := mockAdapter.SearchGames(testContext, "Dark Souls", 1, 0)
 			if testErr == nil {
 					t.Fatalf("expected an error due to context cancellation but instead got: %v", testErr)
 			}
@@ -186,12 +187,12 @@ func TestIGDBAdapter(t *testing.T) {
 			}
 
 			mockAdapter := &mocks.MockIGDBAdapter{
-					SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+					SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 							return expectedGames, nil
 					},
 			}
 
-			actualGames, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 3)
+			actualGames, testErr := mockAdapter.SearchGames(context.Background(), "Dark Souls", 3, 0)
 			if testErr != nil {
 					t.Fatalf("expected no error on successful search, but got: %v", testErr)
 			}
@@ -200,3 +201,53 @@ func TestIGDBAdapter(t *testing.T) {
 			}
 	})
 }
+
+func TestConvertAddonResponses(t *testing.T) {
+	/*
+		GIVEN a game with DLCs, expansions and standalone expansions
+		AND an add-on listed under two relations
+		WHEN convertAddonResponses() is called
+		THEN every add-on is returned once, linked to the game
+		AND its type comes from its game type, or from its relation when the game type isn't an add-on type
+	*/
+	response := &types.IGDBGameAddonsResponse{
+		ID: 1942,
+		DLCs: []types.IGDBAddonResponse{
+			{ID: 10, Name: "Hearts of Stone", GameType: types.IGDBResponseGameType{ID: 2, Type: "Expansion"}},
+			{ID: 11, Name: "Witcher Contracts", Cover: types.IGDBResponseGameCover{URL: "//images.igdb.com/11.jpg"}},
+			{ID: 12, Name: "Season Pass", GameType: types.IGDBResponseGameType{ID: 7, Type: "Season"}},
+		},
+		Expansions: []types.IGDBAddonResponse{
+			{ID: 10, Name: "Hearts of Stone", GameType: types.IGDBResponseGameType{ID: 2, Type: "Expansion"}},
+			{ID: 13, Name: "Blood and Wine"},
+		},
+		StandaloneExpansions: []types.IGDBAddonResponse{
+			{ID: 14, Name: "Thronebreaker", GameType: types.IGDBResponseGameType{ID: 4, Type: "Standalone Expansion"}},
+			{ID: 15, Name: ""},
+		},
+	}
+
+	addons := convertAddonResponses(response)
+
+	expected := map[int64]string{
+		10: models.AddonTypeExpansion,
+		11: models.AddonTypeDLC,
+		12: models.AddonTypeSeason,
+		13: models.AddonTypeExpansion,
+		14: models.AddonTypeStandalone,
+	}
+	if len(addons) != len(expected) {
+		t.Fatalf("expected %d addons, got %d", len(expected), len(addons))
+	}
+	for _, addon := range addons {
+		if addon.ParentGameID != 1942 {
+			t.Errorf("expected addon %d to belong to game 1942, got %d", addon.ID, addon.ParentGameID)
+		}
+		if addon.AddonType != expected[addon.ID] {
+			t.Errorf("expected addon %d to be a %s, got %s", addon.ID, expected[addon.ID], addon.AddonType)
+		}
+	}
+	if addons[1].CoverURL != "//images.igdb.com/11.jpg" {
+		t.Errorf("expected cover URL to be kept, got %q", addons[1].CoverURL)
+	}
+}
@@ -28,8 +28,8 @@ const (
 					sl.name as sublocation_name,
 					sl.location_type as sublocation_type
 			FROM physical_locations pl
-			LEFT JOIN sublocations sl ON sl.physical_location_id = pl.id
-			WHERE pl.user_id = $1
+			LEFT JOIN sublocations sl ON sl.physical_location_id = pl.id AND sl.deleted_at IS NULL
+			WHERE pl.user_id = $1 AND pl.deleted_at IS NULL
 			ORDER BY pl.name, sl.name
 	`
 
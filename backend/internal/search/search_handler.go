@@ -24,8 +24,10 @@ type SearchHandler struct {
 }
 
 type SearchRequestBody struct {
-	Query string `json:"query"`
-	Limit int    `json:"limit,omitempty"`
+	Query  string `json:"query"`
+	Limit  int    `json:"limit,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	Offset int    `json:"offset,omitempty"`
 }
 
 func NewSearchHandler(
@@ -124,14 +126,30 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Optional page or offset, from the body or the query string like limit
+	page := body.Page
+	if pageStr := r.URL.Query().Get("page"); page == 0 && pageStr != "" {
+		if parsed, err := strconv.Atoi(pageStr); err == nil {
+			page = parsed
+		}
+	}
+	offset := body.Offset
+	if offsetStr := r.URL.Query().Get("offset"); offset == 0 && offsetStr != "" {
+		if parsed, err := strconv.Atoi(offsetStr); err == nil {
+			offset = parsed
+		}
+	}
+
 	h.appContext.Logger.Info("Handling search", map[string]any{
 		"query":      query,
 		"limit":      limit,
+		"page":       page,
+		"offset":     offset,
 		"request_id": requestID,
 	})
 
 	// 8. Build the search request.
-	req := searchdef.SearchRequest{Query: query, Limit: limit}
+	req := searchdef.SearchRequest{Query: query, Limit: limit, Page: page, Offset: offset}
 	var result *searchdef.SearchResult
 	result, err = h.searchService.Search(r.Context(), req)
 	if err != nil {
@@ -143,6 +161,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	response := searchdef.SearchResponse{
 		Games: result.Games,
 		Total: len(result.Games),
+		Meta:  result.Meta,
 	}
 
 	// 10. Check if the current search response contains items in a user's library or wishlist
@@ -235,7 +254,7 @@ func (h *SearchHandler) handleError(w http.ResponseWriter, requestID string, err
 }
 
 // Helper function to check if a game is in a list
-func containsGame(games []models.GameToSave, gameID int64) bool {
+func containsGame(games []models.WishlistItemDB, gameID int64) bool {
 	for _, game := range games {
 		if game.GameID == gameID {
 			return true
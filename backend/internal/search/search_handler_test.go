@@ -80,6 +80,34 @@ func (mls *mockLibraryService) UpdateLibraryGame(ctx context.Context, userID str
 	return nil
 }
 
+func (mls *mockLibraryService) UpdateLibraryGamePlayStatus(ctx context.Context, userID string, status models.GamePlayStatusToSave) (types.LibraryGamePlayStatusResponse, error) {
+	return types.LibraryGamePlayStatusResponse{}, nil
+}
+
+func (mls *mockLibraryService) QueryLibraryGames(ctx context.Context, userID string, request types.LibraryQueryRequest) (types.LibraryQueryResponse, error) {
+	return types.LibraryQueryResponse{}, nil
+}
+
+func (mls *mockLibraryService) SearchLibraryGames(ctx context.Context, userID string, request types.LibrarySearchRequest) (types.LibrarySearchResponse, error) {
+	return types.LibrarySearchResponse{}, nil
+}
+
+func (mls *mockLibraryService) GetLibraryGenres(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (mls *mockLibraryService) GetLibraryThemes(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (mls *mockLibraryService) UpdateLibraryGameReview(ctx context.Context, userID string, review models.GameReviewToSave) (types.LibraryGameReviewResponse, error) {
+	return types.LibraryGameReviewResponse{}, nil
+}
+
+func (mls *mockLibraryService) SetLibraryGameFavorite(ctx context.Context, userID string, gameID int64, favorite bool) (types.LibraryGameFavoriteResponse, error) {
+	return types.LibraryGameFavoriteResponse{}, nil
+}
+
 func (mls *mockLibraryService) DeleteLibraryGame(ctx context.Context, userID string, gameID int64) error {
 	return nil
 }
@@ -96,20 +124,68 @@ func (mls *mockLibraryService) DeleteGameVersions(ctx context.Context, userID st
 	return types.BatchDeleteLibraryGameResponse{}, nil
 }
 
-func (mls *mockLibraryService) GetLibraryRefactoredBFFResponse(ctx context.Context, userID string) (types.LibraryBFFRefactoredResponse, error) {
+func (mls *mockLibraryService) GetLibraryRefactoredBFFResponse(ctx context.Context, userID string, filters types.LibraryBFFFilters) (types.LibraryBFFRefactoredResponse, error) {
 	return types.LibraryBFFRefactoredResponse{}, nil
 }
 
 // mockWishlistService implements the WishlistService interface for testing
 type mockWishlistService struct {
-	wishlistItems []models.GameToSave
+	wishlistItems []models.WishlistItemDB
 	err           error
 }
 
-func (mws *mockWishlistService) GetWishlistItems(ctx context.Context, userID string) ([]models.GameToSave, error) {
+func (mws *mockWishlistService) GetWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, error) {
 	return mws.wishlistItems, mws.err
 }
 
+func (mws *mockWishlistService) GetWishlistBFFResponse(ctx context.Context, userID string) (types.WishlistBFFResponse, error) {
+	return types.WishlistBFFResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) GetSingleWishlistItem(ctx context.Context, userID string, gameID int64) (types.WishlistItemBFFResponse, error) {
+	return types.WishlistItemBFFResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) IsGameInWishlist(ctx context.Context, userID string, gameID int64) (bool, error) {
+	return false, mws.err
+}
+
+func (mws *mockWishlistService) CreateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error) {
+	return types.WishlistItemBFFResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) UpdateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error) {
+	return types.WishlistItemBFFResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) DeleteWishlistItem(ctx context.Context, userID string, gameID int64) error {
+	return mws.err
+}
+
+func (mws *mockWishlistService) GetPriceHistory(ctx context.Context, userID string, gameID int64) (types.WishlistPriceHistoryResponse, error) {
+	return types.WishlistPriceHistoryResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) GetUpcomingReleases(ctx context.Context, userID string, days int) (types.WishlistUpcomingResponse, error) {
+	return types.WishlistUpcomingResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) GetWishlistSettings(ctx context.Context, userID string) (types.WishlistSettingsResponse, error) {
+	return types.WishlistSettingsResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) UpdateWishlistSettings(ctx context.Context, userID string, request types.UpdateWishlistSettingsRequest) (types.WishlistSettingsResponse, error) {
+	return types.WishlistSettingsResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) GetBudgetSuggestions(ctx context.Context, userID string) (types.WishlistBudgetResponse, error) {
+	return types.WishlistBudgetResponse{}, mws.err
+}
+
+func (mws *mockWishlistService) PurchaseWishlistItem(ctx context.Context, userID string, purchase models.WishlistPurchaseToSave) (types.WishlistPurchaseResponse, error) {
+	return types.WishlistPurchaseResponse{}, mws.err
+}
+
 // Helper function to create a SearchResult
 func mockSearchResultWithGames(games []models.Game) *searchdef.SearchResult {
 	return &searchdef.SearchResult{
@@ -199,7 +275,7 @@ func TestSearchHandler(t *testing.T) {
 		mockLibraryService.err = nil
 
 		// Setup mock wishlist service
-		mockWishlistService.wishlistItems = []models.GameToSave{{GameID: 1}}
+		mockWishlistService.wishlistItems = []models.WishlistItemDB{{GameID: 1}}
 		mockWishlistService.err = nil
 
 		req, recorder := createRequestWithUserID(http.MethodPost, "/search", `{"query": "dark souls"}`)
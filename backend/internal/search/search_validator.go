@@ -15,7 +15,7 @@ const (
 	MaxQueryLength = 100
 	MinResultLimit = 1
 	MaxResultLimit = 50
-	MaxResultOffset = 500
+	MaxResultOffset = searchdef.MaxOffset
 )
 
 // SearchValidator struct
@@ -47,7 +47,7 @@ func (v *SearchValidator) ValidateQuery(query searchdef.SearchQuery) error {
 	}
 
 	// Result limit validation
-	if err := v.validateResultLimit(query.Limit, 0); err != nil {
+	if err := v.validateResultLimit(query.Limit, query.Offset); err != nil {
 		searchQueryViolations = append(searchQueryViolations, err.Error())
 	}
 
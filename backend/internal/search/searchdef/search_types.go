@@ -10,24 +10,28 @@ import (
 const (
 	DefaultPageSize = 30
 	MaxPageSize     = 50
+	MaxOffset       = 500
 )
 
 // PROCESSED DATA FOR FRONTEND
+// Page is 1-based, a request sets either Page or Offset
 type SearchRequest struct {
-	Query string `json:"query"`
-	Limit int    `json:"limit,omitempty"`
+	Query  string `json:"query"`
+	Limit  int    `json:"limit,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	Offset int    `json:"offset,omitempty"`
 }
 
 // SearchQuery represents the search parameters. This type should include any fields
 // needed to generate a unique cache key.
-// For this example, we assume a simple query string.
 type SearchQuery struct {
-	Query string
-	Limit int
+	Query  string
+	Limit  int
+	Offset int
 }
 
 func (sq SearchQuery) ToCacheKey() string {
-	return fmt.Sprintf("search:%s:%d", sq.Query, sq.Limit)
+	return fmt.Sprintf("search:%s:%d:%d", sq.Query, sq.Limit, sq.Offset)
 }
 
 // Game represents a title from IGDB
@@ -43,6 +47,7 @@ func (sq SearchQuery) ToCacheKey() string {
 type SearchResponse struct {
 	Games []models.Game `json:"games"`
 	Total int    `json:"total"`
+	Meta  SearchMeta    `json:"meta"`
 }
 
 // Search Meta contains info about the search request
@@ -124,3 +129,24 @@ func (r *SearchResult) calculatePagination(currentPage int, limit int) {
 	r.Meta.HasPreviousPage = currentPage > 1
 	r.Meta.HasNextPage = currentPage < r.Meta.TotalPages
 }
+
+// WithPage fills in the pagination of a page of results starting at offset.
+// IGDB doesn't say how many results a search has, so a full page is taken to mean there may be another one,
+// as long as it starts within MaxOffset. Total counts the results up to and including this page.
+func (r *SearchResult) WithPage(offset int, limit int) *SearchResult {
+	if limit < 1 {
+		return r
+	}
+
+	r.Meta.ResultsPerPage = limit
+	r.Meta.CurrentPage = offset/limit + 1
+	r.Meta.Total = offset + len(r.Games)
+	r.Meta.HasPreviousPage = offset > 0
+	r.Meta.HasNextPage = len(r.Games) == limit && offset+limit <= MaxOffset
+
+	r.Meta.TotalPages = r.Meta.CurrentPage
+	if r.Meta.HasNextPage {
+		r.Meta.TotalPages++
+	}
+	return r
+}
@@ -61,7 +61,11 @@ type SublocationService interface {
 // LibraryService defines operations for managing the game library
 type LibraryService interface {
 	GetSingleLibraryGame(ctx context.Context, userID string, gameID int64) (types.LibraryGameItemBFFResponseFINAL, error)
-	GetLibraryRefactoredBFFResponse(ctx context.Context, userID string) (types.LibraryBFFRefactoredResponse, error)
+	GetLibraryRefactoredBFFResponse(ctx context.Context, userID string, filters types.LibraryBFFFilters) (types.LibraryBFFRefactoredResponse, error)
+	QueryLibraryGames(ctx context.Context, userID string, request types.LibraryQueryRequest) (types.LibraryQueryResponse, error)
+	SearchLibraryGames(ctx context.Context, userID string, request types.LibrarySearchRequest) (types.LibrarySearchResponse, error)
+	GetLibraryGenres(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error)
+	GetLibraryThemes(ctx context.Context, userID string) ([]types.LibraryGameTermResponse, error)
 
 	// MARKED FOR DELETION - LEGACY RESPONSE
 	GetAllLibraryItemsBFF(ctx context.Context, userID string) (types.LibraryBFFResponseFINAL, error)
@@ -69,15 +73,97 @@ type LibraryService interface {
 
 	CreateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
 	UpdateLibraryGame(ctx context.Context, userID string, game models.GameToSave) error
+	UpdateLibraryGamePlayStatus(ctx context.Context, userID string, status models.GamePlayStatusToSave) (types.LibraryGamePlayStatusResponse, error)
+	UpdateLibraryGameReview(ctx context.Context, userID string, review models.GameReviewToSave) (types.LibraryGameReviewResponse, error)
+	SetLibraryGameFavorite(ctx context.Context, userID string, gameID int64, favorite bool) (types.LibraryGameFavoriteResponse, error)
 	DeleteLibraryGame(ctx context.Context, userID string, gameID int64) error
 	DeleteGameVersions(ctx context.Context, userID string, gameID int64, request types.BatchDeleteLibraryGameRequest) (types.BatchDeleteLibraryGameResponse, error)
 	InvalidateUserCache(ctx context.Context, userID string) error
 	IsGameInLibraryBFF(ctx context.Context, userID string, gameID int64) (bool, error)
 }
 
+// LibraryImportService defines operations for bulk importing games into the library
+type LibraryImportService interface {
+	CreateImportJob(ctx context.Context, userID string, source string, dryRun bool, rows []models.LibraryImportRowToSave) (types.LibraryImportJobResponse, error)
+	GetImportJob(ctx context.Context, userID string, jobID string) (types.LibraryImportJobResponse, error)
+	GetReviewQueue(ctx context.Context, userID string) ([]types.LibraryImportRowResponse, error)
+	ResolveReviewRow(ctx context.Context, userID string, rowID int64, request types.ResolveLibraryImportRowRequest) (types.LibraryImportRowResponse, error)
+	DismissReviewRow(ctx context.Context, userID string, rowID int64) error
+}
+
+// CollectionsService defines operations for user owned tags and collections of library games
+type CollectionsService interface {
+	GetTags(ctx context.Context, userID string) ([]types.TagResponse, error)
+	CreateTag(ctx context.Context, userID string, request types.TagRequest) (types.TagResponse, error)
+	UpdateTag(ctx context.Context, userID string, tagID string, request types.TagRequest) (types.TagResponse, error)
+	DeleteTag(ctx context.Context, userID string, tagID string) error
+	BulkTagGames(ctx context.Context, userID string, request types.BulkTagRequest) (types.LibraryGameSelectionResponse, error)
+	BulkUntagGames(ctx context.Context, userID string, request types.BulkTagRequest) (types.LibraryGameSelectionResponse, error)
+
+	GetCollections(ctx context.Context, userID string) ([]types.CollectionResponse, error)
+	GetCollection(ctx context.Context, userID string, collectionID string) (types.CollectionResponse, error)
+	CreateCollection(ctx context.Context, userID string, request types.CollectionRequest) (types.CollectionResponse, error)
+	UpdateCollection(ctx context.Context, userID string, collectionID string, request types.CollectionRequest) (types.CollectionResponse, error)
+	DeleteCollection(ctx context.Context, userID string, collectionID string) error
+	AddCollectionGames(ctx context.Context, userID string, collectionID string, request types.LibraryGameSelectionRequest) (types.LibraryGameSelectionResponse, error)
+	RemoveCollectionGames(ctx context.Context, userID string, collectionID string, request types.LibraryGameSelectionRequest) (types.LibraryGameSelectionResponse, error)
+}
+
+// LoansService defines operations for lending physical copies to friends
+type LoansService interface {
+	GetLoans(ctx context.Context, userID string, status string) ([]types.LoanResponse, error)
+	GetOverdueLoans(ctx context.Context, userID string) ([]types.LoanResponse, error)
+	CreateLoan(ctx context.Context, userID string, request types.CreateLoanRequest) (types.LoanResponse, error)
+	ReturnLoan(ctx context.Context, userID string, loanID string, request types.ReturnLoanRequest) (types.LoanResponse, error)
+}
+
+// BarcodesService defines operations for looking up scanned barcodes and adding scanned games
+type BarcodesService interface {
+	LookupBarcode(ctx context.Context, userID string, code string) (types.BarcodeLookupResponse, error)
+	SaveBarcodeMapping(ctx context.Context, userID string, request types.BarcodeMappingRequest) (types.BarcodeCandidateResponse, error)
+	CreateScanSession(ctx context.Context, userID string, request types.BarcodeScanSessionRequest) (types.BarcodeScanSessionResponse, error)
+}
+
+// AddonsService defines operations for tracking DLC, expansions and editions of library games
+type AddonsService interface {
+	GetGameAddons(ctx context.Context, userID string, gameID int64) (types.GameAddonsResponse, error)
+	CreateOwnedAddon(ctx context.Context, userID string, request types.CreateOwnedAddonRequest) (types.OwnedAddonResponse, error)
+	DeleteOwnedAddon(ctx context.Context, userID string, ownedAddonID int64) error
+	UpdateEdition(ctx context.Context, userID string, userGameID int64, request types.UpdateEditionRequest) (types.GameEditionResponse, error)
+}
+
+// TrashService defines operations for listing and restoring deleted items
+type TrashService interface {
+	GetTrash(ctx context.Context, userID string) (types.TrashResponse, error)
+	RestoreItem(ctx context.Context, userID string, itemType string, itemID string) (types.TrashRestoreResponse, error)
+}
+
+// DataExportService defines operations for exporting all of a user's data
+type DataExportService interface {
+	RequestDataExport(ctx context.Context, userID string) (types.DataExportResponse, error)
+	GetDataExport(ctx context.Context, userID string, exportID string) (types.DataExportResponse, error)
+}
+
+// DataRestoreService defines operations for rebuilding a user's data from an export archive
+type DataRestoreService interface {
+	RestoreUserData(ctx context.Context, userID string, mode string, archive []byte) (types.DataRestoreResponse, error)
+}
+
 // WishlistService defines operations for managing the wishlist
 type WishlistService interface {
-	GetWishlistItems(ctx context.Context, userID string) ([]models.GameToSave, error)
+	GetWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, error)
+	GetWishlistBFFResponse(ctx context.Context, userID string) (types.WishlistBFFResponse, error)
+	GetSingleWishlistItem(ctx context.Context, userID string, gameID int64) (types.WishlistItemBFFResponse, error)
+	IsGameInWishlist(ctx context.Context, userID string, gameID int64) (bool, error)
+	CreateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	UpdateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	DeleteWishlistItem(ctx context.Context, userID string, gameID int64) error
+	GetPriceHistory(ctx context.Context, userID string, gameID int64) (types.WishlistPriceHistoryResponse, error)
+	GetUpcomingReleases(ctx context.Context, userID string, days int) (types.WishlistUpcomingResponse, error)
+	GetBudgetSuggestions(ctx context.Context, userID string) (types.WishlistBudgetResponse, error)
+	GetWishlistSettings(ctx context.Context, userID string) (types.WishlistSettingsResponse, error)
+	UpdateWishlistSettings(ctx context.Context, userID string, request types.UpdateWishlistSettingsRequest) (types.WishlistSettingsResponse, error)
+	PurchaseWishlistItem(ctx context.Context, userID string, purchase models.WishlistPurchaseToSave) (types.WishlistPurchaseResponse, error)
 }
 
 // SearchService defines operations for searching
@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lokeam/qko-beta/internal/interfaces"
+)
+
+// jobQueueBuffer is how many jobs can wait for a free worker
+const jobQueueBuffer = 100
+
+// ErrJobQueueFull is returned when a job is enqueued while every buffered slot is taken
+var ErrJobQueueFull = errors.New("job queue is full")
+
+// QueuedJob is a job a JobQueue runs, LogFields identify it in the queue's logs
+type QueuedJob interface {
+	LogFields() map[string]any
+}
+
+// JobQueue runs jobs on a fixed number of background workers.
+// Stopping it cancels the context in-flight jobs run with, jobs still waiting are dropped.
+type JobQueue[T QueuedJob] struct {
+	name    string
+	process func(ctx context.Context, job T) error
+	logger  interfaces.Logger
+	jobs    chan T
+	workers int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.RWMutex
+	running bool
+}
+
+// NewJobQueue creates a queue that hands each job to process, name is used in its logs and errors
+func NewJobQueue[T QueuedJob](
+	name string,
+	workers int,
+	process func(ctx context.Context, job T) error,
+	logger interfaces.Logger,
+) *JobQueue[T] {
+	if workers <= 0 {
+		workers = 1 // Default number of workers
+	}
+
+	return &JobQueue[T]{
+		name:    name,
+		process: process,
+		logger:  logger,
+		workers: workers,
+	}
+}
+
+// Start starts the queue's workers
+func (q *JobQueue[T]) Start() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return fmt.Errorf("%s queue is already running", q.name)
+	}
+
+	q.running = true
+	q.jobs = make(chan T, jobQueueBuffer)
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	q.logger.Info("Starting job queue", map[string]any{
+		"queue":   q.name,
+		"workers": q.workers,
+	})
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.worker(i)
+	}
+
+	return nil
+}
+
+// Stop cancels in-flight jobs and waits for the workers to exit
+func (q *JobQueue[T]) Stop() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.running {
+		return fmt.Errorf("%s queue is not running", q.name)
+	}
+
+	q.logger.Info("Stopping job queue", map[string]any{"queue": q.name})
+	q.running = false
+	q.cancel()
+	close(q.jobs)
+
+	q.wg.Wait()
+
+	return nil
+}
+
+// EnqueueJob adds a job to the queue, returns ErrJobQueueFull when there is no room for it
+func (q *JobQueue[T]) EnqueueJob(job T) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if !q.running {
+		return fmt.Errorf("%s queue is not running", q.name)
+	}
+
+	select {
+	case q.jobs <- job:
+		q.logger.Info("Job enqueued", q.logFields(job, nil))
+		return nil
+	default:
+		return ErrJobQueueFull
+	}
+}
+
+// IsRunning returns true if the queue is running
+func (q *JobQueue[T]) IsRunning() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.running
+}
+
+// worker runs jobs until the queue is stopped
+func (q *JobQueue[T]) worker(id int) {
+	defer q.wg.Done()
+
+	for job := range q.jobs {
+		if q.ctx.Err() != nil {
+			continue
+		}
+
+		if err := q.process(q.ctx, job); err != nil {
+			q.logger.Error("Failed to process queued job", q.logFields(job, map[string]any{
+				"workerID": id,
+				"error":    err.Error(),
+			}))
+		}
+	}
+
+	q.logger.Info("Job queue worker stopping", map[string]any{
+		"queue":    q.name,
+		"workerID": id,
+	})
+}
+
+// logFields merges the job's fields with the queue name and any extra fields
+func (q *JobQueue[T]) logFields(job T, extra map[string]any) map[string]any {
+	fields := map[string]any{"queue": q.name}
+	for key, value := range job.LogFields() {
+		fields[key] = value
+	}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	return fields
+}
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Behaviors:
+		- JobQueue hands every enqueued job to its processor on a background worker
+		- JobQueue turns jobs away when it isn't running or its buffer is full
+		- JobQueue cancels in-flight jobs when it stops
+
+	Scenarios:
+		- Jobs enqueued on a running queue are all processed
+		- Enqueueing before Start returns an error
+		- Enqueueing past the buffer returns ErrJobQueueFull
+		- Stop cancels the context a running job was handed
+*/
+
+type testQueuedJob struct {
+	ID int
+}
+
+func (job testQueuedJob) LogFields() map[string]any {
+	return map[string]any{"id": job.ID}
+}
+
+func TestJobQueue(t *testing.T) {
+	/*
+		GIVEN a running queue with two workers
+		WHEN three jobs are enqueued
+		THEN every job is processed
+	*/
+	t.Run("Processes every enqueued job", func(t *testing.T) {
+		// GIVEN
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		processed := make(map[int]bool)
+		queue := NewJobQueue("test", 2, func(ctx context.Context, job testQueuedJob) error {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			processed[job.ID] = true
+			return nil
+		}, testutils.NewTestLogger())
+		assert.NoError(t, queue.Start())
+
+		// WHEN
+		wg.Add(3)
+		for id := 1; id <= 3; id++ {
+			assert.NoError(t, queue.EnqueueJob(testQueuedJob{ID: id}))
+		}
+		wg.Wait()
+
+		// THEN
+		assert.NoError(t, queue.Stop())
+		assert.Equal(t, map[int]bool{1: true, 2: true, 3: true}, processed)
+		assert.False(t, queue.IsRunning())
+	})
+
+	/*
+		GIVEN a queue that hasn't been started
+		WHEN a job is enqueued
+		THEN it is turned away
+	*/
+	t.Run("Enqueueing before Start fails", func(t *testing.T) {
+		// GIVEN
+		queue := NewJobQueue("test", 1, func(ctx context.Context, job testQueuedJob) error {
+			return nil
+		}, testutils.NewTestLogger())
+
+		// WHEN
+		err := queue.EnqueueJob(testQueuedJob{ID: 1})
+
+		// THEN
+		assert.EqualError(t, err, "test queue is not running")
+	})
+
+	/*
+		GIVEN a running queue whose only worker is busy and whose buffer is full
+		WHEN another job is enqueued
+		THEN ErrJobQueueFull is returned, and stopping the queue cancels the busy job
+	*/
+	t.Run("Full queue turns jobs away and Stop cancels running jobs", func(t *testing.T) {
+		// GIVEN
+		started := make(chan struct{})
+		cancelled := make(chan struct{})
+		queue := NewJobQueue("test", 1, func(ctx context.Context, job testQueuedJob) error {
+			if job.ID == 0 {
+				close(started)
+				<-ctx.Done()
+				close(cancelled)
+			}
+			return ctx.Err()
+		}, testutils.NewTestLogger())
+		assert.NoError(t, queue.Start())
+
+		assert.NoError(t, queue.EnqueueJob(testQueuedJob{ID: 0}))
+		<-started
+		for id := 1; id <= jobQueueBuffer; id++ {
+			assert.NoError(t, queue.EnqueueJob(testQueuedJob{ID: id}))
+		}
+
+		// WHEN
+		err := queue.EnqueueJob(testQueuedJob{ID: jobQueueBuffer + 1})
+
+		// THEN
+		assert.ErrorIs(t, err, ErrJobQueueFull)
+		assert.NoError(t, queue.Stop())
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("expected Stop to cancel the running job")
+		}
+	})
+}
@@ -93,6 +93,7 @@ func (stc *SpendTrackingCalculator) calculateCurrentYearOneTimeCosts(
 		FROM one_time_purchases otp
 		LEFT JOIN spending_categories sc ON otp.spending_category_id = sc.id
 		WHERE otp.user_id = $1
+		AND otp.deleted_at IS NULL
 		AND EXTRACT(YEAR FROM purchase_date) = $2
 		ORDER BY purchase_date DESC`,
 		userID,
@@ -431,6 +431,10 @@ func (sta *SpendTrackingDbAdapter) GetSingleSpendTrackingItem(
 		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to get one-time purchase: %w", err)
 	}
 
+	if err := sta.db.SelectContext(ctx, &purchase.UserGameIDs, GetOneTimePurchaseGameIDsQuery, purchase.ID); err != nil {
+		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to get linked library games: %w", err)
+	}
+
 	sta.logger.Debug("GetSingleSpendTrackingItem success", map[string]any{
 		"purchase": purchase,
 	})
@@ -479,6 +483,13 @@ func (sta *SpendTrackingDbAdapter) CreateOneTimePurchase(
 		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to create one-time purchase: %w", err)
 	}
 
+	// Link the purchase to the library copies it paid for
+	if err := sta.replacePurchaseGames(ctx, tx, userID, newPurchase.ID, request.UserGameIDs); err != nil {
+		tx.Rollback()
+		return models.SpendTrackingOneTimePurchaseDB{}, err
+	}
+	newPurchase.UserGameIDs = request.UserGameIDs
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to commit transaction: %w", err)
@@ -530,6 +541,14 @@ func (sta *SpendTrackingDbAdapter) UpdateOneTimePurchase(
 		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to update one-time purchase: %w", err)
 	}
 
+	// A nil list keeps the current links
+	if request.UserGameIDs != nil {
+		if err := sta.replacePurchaseGames(ctx, tx, userID, updatedPurchase.ID, request.UserGameIDs); err != nil {
+			tx.Rollback()
+			return models.SpendTrackingOneTimePurchaseDB{}, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return models.SpendTrackingOneTimePurchaseDB{}, fmt.Errorf("failed to commit transaction: %w", err)
@@ -542,6 +561,65 @@ func (sta *SpendTrackingDbAdapter) UpdateOneTimePurchase(
 	return updatedPurchase, nil
 }
 
+// replacePurchaseGames swaps the library copies linked to a purchase.
+// Returns ErrValidationFailed if any copy isn't in the user's library.
+func (sta *SpendTrackingDbAdapter) replacePurchaseGames(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	userID string,
+	purchaseID int,
+	userGameIDs []int64,
+) error {
+	if _, err := tx.ExecContext(ctx, DeleteOneTimePurchaseGamesQuery, purchaseID); err != nil {
+		return fmt.Errorf("failed to clear linked library games: %w", err)
+	}
+	if len(userGameIDs) == 0 {
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx, LinkOneTimePurchaseGamesQuery, purchaseID, userID, pq.Array(userGameIDs))
+	if err != nil {
+		return fmt.Errorf("failed to link library games: %w", err)
+	}
+
+	linked, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check linked library games: %w", err)
+	}
+	if linked != int64(len(userGameIDs)) {
+		return fmt.Errorf("%w: one or more library games not found", ErrValidationFailed)
+	}
+
+	return nil
+}
+
+// GetLinkedGameIDs returns the games whose library copies the purchases are linked to
+func (sta *SpendTrackingDbAdapter) GetLinkedGameIDs(
+	ctx context.Context,
+	userID string,
+	itemIDs []string,
+) ([]int64, error) {
+	// Convert itemIDs from ["one-15"] to [15] before passing to SQL
+	purchaseIDs := make([]int, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		if !strings.HasPrefix(itemID, "one-") {
+			return nil, fmt.Errorf("invalid item ID format: must start with 'one-'")
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(itemID, "one-"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid item ID format: %w", err)
+		}
+		purchaseIDs = append(purchaseIDs, id)
+	}
+
+	gameIDs := []int64{}
+	if err := sta.db.SelectContext(ctx, &gameIDs, GetOneTimePurchaseLinkedGameIDsQuery, userID, pq.Array(purchaseIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get linked library games: %w", err)
+	}
+
+	return gameIDs, nil
+}
+
 func (sta *SpendTrackingDbAdapter) DeleteSpendTrackingItems(
 	ctx context.Context,
 	userID string,
@@ -597,10 +675,10 @@ func (sta *SpendTrackingDbAdapter) DeleteSpendTrackingItems(
 		return 0, fmt.Errorf("one or more one-time purchases not found or do not belong to user")
 	}
 
-	// Delete all items in one go
+	// Move all items to the trash in one go
 	result, err := tx.ExecContext(
 		ctx,
-		DeleteOneTimePurchasesQuery,
+		TrashOneTimePurchasesQuery,
 		pq.Array(numericIDs),  // ← Use numericIDs instead of itemIDs
 		userID,
 	)
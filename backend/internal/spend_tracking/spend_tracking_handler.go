@@ -115,7 +115,7 @@ func (h *SpendTrackingHandler) CreateOneTimePurchase(w http.ResponseWriter, r *h
 	// Call service method
 	createdOneTimePurchase, err := h.spendTrackingService.CreateOneTimePurchase(r.Context(), userID, spendTrackingRequest)
 	if err != nil {
-		h.handleError(w, requestID, err, http.StatusInternalServerError)
+		h.handleError(w, requestID, err, GetStatusCodeForError(err))
 		return
 	}
 
@@ -178,11 +178,7 @@ func (h *SpendTrackingHandler) UpdateOneTimePurchase(w http.ResponseWriter, r *h
 			"requestID": requestID,
 			"error":     err,
 		})
-		statusCode := http.StatusInternalServerError
-		if errors.Is(err, ErrSpendTrackingItemNotFound) {
-			statusCode = http.StatusNotFound
-		}
-		h.handleError(w, requestID, err, statusCode)
+		h.handleError(w, requestID, err, GetStatusCodeForError(err))
 		return
 	}
 
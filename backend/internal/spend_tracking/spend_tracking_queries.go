@@ -30,6 +30,7 @@ const (
     FROM one_time_purchases otp
     LEFT JOIN spending_categories sc ON otp.spending_category_id = sc.id
     WHERE otp.user_id = $1
+    AND otp.deleted_at IS NULL
     AND EXTRACT(YEAR FROM purchase_date) = EXTRACT(YEAR FROM $2::timestamp)
     AND EXTRACT(MONTH FROM purchase_date) = EXTRACT(MONTH FROM $2::timestamp)
     ORDER BY purchase_date DESC
@@ -95,7 +96,7 @@ const (
     SET title = $1, amount = $2, purchase_date = $3, payment_method = $4,
 			spending_category_id = $5, digital_location_id = $6, is_digital = $7,
 			is_wishlisted = $8, updated_at = NOW()
-    WHERE id = $9 AND user_id = $10
+    WHERE id = $9 AND user_id = $10 AND deleted_at IS NULL
     RETURNING id, user_id, title, amount, purchase_date, payment_method,
 			spending_category_id, digital_location_id, is_digital, is_wishlisted,
 			created_at, updated_at
@@ -106,17 +107,55 @@ const (
 			spending_category_id, digital_location_id, is_digital, is_wishlisted,
 			created_at, updated_at
 		FROM one_time_purchases
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`
 
 	CheckIfAllOneTimePurchasesExistForUserQuery = `
 		SELECT COUNT(*)
 		FROM one_time_purchases
-		WHERE id = ANY($1) AND user_id = $2
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
 	`
 
-	DeleteOneTimePurchasesQuery = `
-		DELETE FROM one_time_purchases
-		WHERE id = ANY($1) AND user_id = $2
+	// Trashed purchases keep their library links so a restore brings them back
+	TrashOneTimePurchasesQuery = `
+		UPDATE one_time_purchases
+		SET deleted_at = NOW()
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NULL
 	`
-)
\ No newline at end of file
+
+	GetOneTimePurchaseGameIDsQuery = `
+		SELECT otpg.user_game_id
+		FROM one_time_purchase_games otpg
+		JOIN user_games ug ON ug.id = otpg.user_game_id
+		WHERE otpg.purchase_id = $1 AND ug.deleted_at IS NULL
+		ORDER BY otpg.user_game_id
+	`
+
+	// Links to trashed copies are kept so restoring the copy restores the link
+	DeleteOneTimePurchaseGamesQuery = `
+		DELETE FROM one_time_purchase_games otpg
+		USING user_games ug
+		WHERE otpg.purchase_id = $1
+		AND ug.id = otpg.user_game_id
+		AND ug.deleted_at IS NULL
+	`
+
+	// Copies that aren't the user's are skipped, the caller compares the row count
+	LinkOneTimePurchaseGamesQuery = `
+		INSERT INTO one_time_purchase_games (purchase_id, user_game_id)
+		SELECT $1, ug.id
+		FROM user_games ug
+		WHERE ug.user_id = $2 AND ug.id = ANY($3::int[]) AND ug.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`
+
+	// Games whose copies the purchases paid for, the library shows each purchase's cost on the game
+	GetOneTimePurchaseLinkedGameIDsQuery = `
+		SELECT DISTINCT ug.game_id
+		FROM one_time_purchase_games otpg
+		JOIN one_time_purchases otp ON otp.id = otpg.purchase_id
+		JOIN user_games ug ON ug.id = otpg.user_game_id
+		WHERE otp.user_id = $1 AND otpg.purchase_id = ANY($2::int[])
+		ORDER BY ug.game_id
+	`
+)
@@ -16,6 +16,7 @@ type SpendTrackingService struct {
     dbAdapter               interfaces.SpendTrackingDbAdapter
     cacheWrapper            interfaces.SpendTrackingCacheWrapper
     dashboardCacheWrapper   interfaces.DashboardCacheWrapper
+    libraryCacheWrapper     interfaces.LibraryCacheWrapper
     validator               interfaces.SpendTrackingValidator
     logger                  interfaces.Logger
 }
@@ -25,6 +26,7 @@ func NewSpendTrackingService(
     dbAdapter interfaces.SpendTrackingDbAdapter,
     cacheWrapper interfaces.SpendTrackingCacheWrapper,
     dashboardCacheWrapper interfaces.DashboardCacheWrapper,
+    libraryCacheWrapper interfaces.LibraryCacheWrapper,
 ) (*SpendTrackingService, error) {
     if dbAdapter == nil {
         return nil, fmt.Errorf("dbAdapter is required")
@@ -35,11 +37,15 @@ func NewSpendTrackingService(
     if dashboardCacheWrapper == nil {
         return nil, fmt.Errorf("dashboardCacheWrapper is required")
     }
+    if libraryCacheWrapper == nil {
+        return nil, fmt.Errorf("libraryCacheWrapper is required")
+    }
 
     return &SpendTrackingService{
         dbAdapter:    dbAdapter,
         cacheWrapper: cacheWrapper,
         dashboardCacheWrapper: dashboardCacheWrapper,
+        libraryCacheWrapper: libraryCacheWrapper,
         validator:    NewSpendTrackingValidator(),
         logger:       appContext.Logger,
     }, nil
@@ -146,6 +152,9 @@ func (sts *SpendTrackingService) CreateOneTimePurchase(
         // DB update successful, continue despite error
     }
 
+    // Invalidate library cache, linked games show what the purchase cost
+    sts.invalidateLibraryGames(ctx, userID, sts.linkedGameIDs(ctx, userID, []string{fmt.Sprintf("one-%d", createdPurchase.ID)}))
+
     sts.logger.Debug("CreateOneTimePurchase success", map[string]any{
         "oneTimePurchase": createdPurchase,
     })
@@ -196,6 +205,9 @@ func (sts *SpendTrackingService) UpdateOneTimePurchase(
 		return fmt.Errorf("failed to get existing one-time purchase: %w", err)
 	}
 
+	// Games linked before the update lose the purchase if the links change
+	previousGameIDs := sts.linkedGameIDs(ctx, userID, []string{request.ID})
+
 	// Transform request to database model
 	oneTimePurchase, err := TransformUpdateRequestToModel(request, purchaseID, userID)
 	if err != nil {
@@ -236,6 +248,10 @@ func (sts *SpendTrackingService) UpdateOneTimePurchase(
 		// DB update successful, continue despite error
 	}
 
+	// Invalidate library cache for the games linked before and after the update
+	currentGameIDs := sts.linkedGameIDs(ctx, userID, []string{request.ID})
+	sts.invalidateLibraryGames(ctx, userID, append(previousGameIDs, currentGameIDs...))
+
 	sts.logger.Debug("UpdateOneTimePurchase success", map[string]any{
 		"oneTimePurchase": oneTimePurchase,
 	})
@@ -303,6 +319,9 @@ func (sts *SpendTrackingService) DeleteSpendTrackingItems(
         }, nil
     }
 
+    // Trashed purchases no longer count towards their games' cost
+    linkedGameIDs := sts.linkedGameIDs(ctx, userID, validatedIDs)
+
     // Remove items from database using validated IDs
     deletedCount, err := sts.dbAdapter.DeleteSpendTrackingItems(ctx, userID, validatedIDs)
     if err != nil {
@@ -338,6 +357,9 @@ func (sts *SpendTrackingService) DeleteSpendTrackingItems(
         // Continue despite cache error since DB operation was successful
     }
 
+    // Invalidate library cache for the games the purchases were linked to
+    sts.invalidateLibraryGames(ctx, userID, linkedGameIDs)
+
     sts.logger.Debug("DeleteSpendTrackingItems completed successfully", map[string]any{
         "userID":        userID,
         "itemIDs":       validatedIDs,
@@ -349,4 +371,45 @@ func (sts *SpendTrackingService) DeleteSpendTrackingItems(
         DeletedCount: int(deletedCount),
         DeletedItems: []types.DeletedSpendTrackingItemDetails{},
     }, nil
-}
\ No newline at end of file
+}
+
+// linkedGameIDs looks up the games the purchases are linked to.
+// A failed lookup is logged, the library list is still invalidated without the games.
+func (sts *SpendTrackingService) linkedGameIDs(ctx context.Context, userID string, itemIDs []string) []int64 {
+	gameIDs, err := sts.dbAdapter.GetLinkedGameIDs(ctx, userID, itemIDs)
+	if err != nil {
+		sts.logger.Error("Failed to get library games linked to purchases", map[string]any{
+			"error":   err,
+			"userID":  userID,
+			"itemIDs": itemIDs,
+		})
+		return nil
+	}
+	return gameIDs
+}
+
+// invalidateLibraryGames refreshes the user's library and the details of every linked game
+func (sts *SpendTrackingService) invalidateLibraryGames(ctx context.Context, userID string, gameIDs []int64) {
+	if err := sts.libraryCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		sts.logger.Error("Failed to invalidate library cache after purchase change", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+
+	invalidated := make(map[int64]bool, len(gameIDs))
+	for _, gameID := range gameIDs {
+		if invalidated[gameID] {
+			continue
+		}
+		invalidated[gameID] = true
+
+		if err := sts.libraryCacheWrapper.InvalidateGameCache(ctx, userID, gameID); err != nil {
+			sts.logger.Error("Failed to invalidate library game cache after purchase change", map[string]any{
+				"error":  err,
+				"userID": userID,
+				"gameID": gameID,
+			})
+		}
+	}
+}
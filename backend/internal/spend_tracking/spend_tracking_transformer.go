@@ -66,6 +66,7 @@ func TransformCreateRequestToModel(
 		MediaType:         mediaType,
 		CreatedAt:         now,
 		UpdatedAt:         now,
+		UserGameIDs:       uniqueUserGameIDs(request.UserGameIDs),
 	}, nil
 }
 
@@ -106,7 +107,25 @@ func TransformUpdateRequestToModel(
 		IsWishlisted:      isWishlisted,
 		CreatedAt:         now, // Keep original creation time
 		UpdatedAt:         now,
+		UserGameIDs:       uniqueUserGameIDs(request.UserGameIDs),
 	}
 
 	return spendTrackingModel, nil
-}
\ No newline at end of file
+}
+
+// uniqueUserGameIDs drops repeated copies, nil stays nil so updates can tell "unchanged" from "cleared"
+func uniqueUserGameIDs(userGameIDs []int64) []int64 {
+	if userGameIDs == nil {
+		return nil
+	}
+
+	seen := make(map[int64]bool, len(userGameIDs))
+	unique := make([]int64, 0, len(userGameIDs))
+	for _, id := range userGameIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -48,6 +48,11 @@ func (v *SpendTrackingValidatorImpl) ValidateOneTimePurchase(request types.Spend
 	if request.SpendingCategoryID <= 0 {
 		return &ValidationError{Field: "spending_category_id", Message: "spending_category_id is required"}
 	}
+	for _, userGameID := range request.UserGameIDs {
+		if userGameID <= 0 {
+			return &ValidationError{Field: "user_game_ids", Message: "user_game_ids must be positive integers"}
+		}
+	}
 	return nil
 }
 
@@ -35,7 +35,7 @@ func DefaultValidator() *MockValidator {
 // DefaultIGDBAdapter returns a MockIGDBAdapter with default (happy path) behavior.
 func DefaultIGDBAdapter() *MockIGDBAdapter {
 		return &MockIGDBAdapter{
-				SearchGamesFunc: func(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+				SearchGamesFunc: func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 						// Default: return an empty slice (or a minimal dummy value).
 						return []*models.Game{}, nil
 				},
@@ -55,6 +55,61 @@ func (m *MockLibraryService) UpdateLibraryGame(
 	return nil
 }
 
+func (m *MockLibraryService) UpdateLibraryGamePlayStatus(
+	ctx context.Context,
+	userID string,
+	status models.GamePlayStatusToSave,
+) (types.LibraryGamePlayStatusResponse, error) {
+	return types.LibraryGamePlayStatusResponse{}, nil
+}
+
+func (m *MockLibraryService) QueryLibraryGames(
+	ctx context.Context,
+	userID string,
+	request types.LibraryQueryRequest,
+) (types.LibraryQueryResponse, error) {
+	return types.LibraryQueryResponse{}, nil
+}
+
+func (m *MockLibraryService) SearchLibraryGames(
+	ctx context.Context,
+	userID string,
+	request types.LibrarySearchRequest,
+) (types.LibrarySearchResponse, error) {
+	return types.LibrarySearchResponse{}, nil
+}
+
+func (m *MockLibraryService) GetLibraryGenres(
+	ctx context.Context,
+	userID string,
+) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (m *MockLibraryService) GetLibraryThemes(
+	ctx context.Context,
+	userID string,
+) ([]types.LibraryGameTermResponse, error) {
+	return []types.LibraryGameTermResponse{}, nil
+}
+
+func (m *MockLibraryService) UpdateLibraryGameReview(
+	ctx context.Context,
+	userID string,
+	review models.GameReviewToSave,
+) (types.LibraryGameReviewResponse, error) {
+	return types.LibraryGameReviewResponse{}, nil
+}
+
+func (m *MockLibraryService) SetLibraryGameFavorite(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+	favorite bool,
+) (types.LibraryGameFavoriteResponse, error) {
+	return types.LibraryGameFavoriteResponse{}, nil
+}
+
 // LEGACY BFF RESPONSE - MARKED FOR DELETION
 func (m *MockLibraryService) GetAllLibraryItemsBFF(
 	ctx context.Context,
@@ -67,8 +122,9 @@ func (m *MockLibraryService) GetAllLibraryItemsBFF(
 func (m *MockLibraryService) GetLibraryRefactoredBFFResponse(
 	ctx context.Context,
 	userID string,
+	filters types.LibraryBFFFilters,
 ) (types.LibraryBFFRefactoredResponse, error) {
-	args := m.Called(ctx, userID)
+	args := m.Called(ctx, userID, filters)
 	return args.Get(0).(types.LibraryBFFRefactoredResponse), args.Error(1)
 }
 
@@ -4,19 +4,161 @@ import (
 	"context"
 
 	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
 )
 
 // MockWishlistService implements services.WishlistService
 type MockWishlistService struct {
-	GetWishlistItemsFunc func(ctx context.Context, userID string) ([]models.GameToSave, error)
+	GetWishlistItemsFunc       func(ctx context.Context, userID string) ([]models.WishlistItemDB, error)
+	GetWishlistBFFResponseFunc func(ctx context.Context, userID string) (types.WishlistBFFResponse, error)
+	GetSingleWishlistItemFunc  func(ctx context.Context, userID string, gameID int64) (types.WishlistItemBFFResponse, error)
+	IsGameInWishlistFunc       func(ctx context.Context, userID string, gameID int64) (bool, error)
+	CreateWishlistItemFunc     func(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	UpdateWishlistItemFunc     func(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	DeleteWishlistItemFunc     func(ctx context.Context, userID string, gameID int64) error
+	GetPriceHistoryFunc        func(ctx context.Context, userID string, gameID int64) (types.WishlistPriceHistoryResponse, error)
+	GetUpcomingReleasesFunc    func(ctx context.Context, userID string, days int) (types.WishlistUpcomingResponse, error)
+	GetBudgetSuggestionsFunc   func(ctx context.Context, userID string) (types.WishlistBudgetResponse, error)
+	GetWishlistSettingsFunc    func(ctx context.Context, userID string) (types.WishlistSettingsResponse, error)
+	UpdateWishlistSettingsFunc func(ctx context.Context, userID string, request types.UpdateWishlistSettingsRequest) (types.WishlistSettingsResponse, error)
+	PurchaseWishlistItemFunc   func(ctx context.Context, userID string, purchase models.WishlistPurchaseToSave) (types.WishlistPurchaseResponse, error)
 }
 
 func (m *MockWishlistService) GetWishlistItems(
 	ctx context.Context,
 	userID string,
-) ([]models.GameToSave, error) {
+) ([]models.WishlistItemDB, error) {
 	if m.GetWishlistItemsFunc != nil {
 		return m.GetWishlistItemsFunc(ctx, userID)
 	}
-	return []models.GameToSave{}, nil
-}
\ No newline at end of file
+	return []models.WishlistItemDB{}, nil
+}
+
+func (m *MockWishlistService) GetWishlistBFFResponse(
+	ctx context.Context,
+	userID string,
+) (types.WishlistBFFResponse, error) {
+	if m.GetWishlistBFFResponseFunc != nil {
+		return m.GetWishlistBFFResponseFunc(ctx, userID)
+	}
+	return types.WishlistBFFResponse{}, nil
+}
+
+func (m *MockWishlistService) GetSingleWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (types.WishlistItemBFFResponse, error) {
+	if m.GetSingleWishlistItemFunc != nil {
+		return m.GetSingleWishlistItemFunc(ctx, userID, gameID)
+	}
+	return types.WishlistItemBFFResponse{}, nil
+}
+
+func (m *MockWishlistService) IsGameInWishlist(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (bool, error) {
+	if m.IsGameInWishlistFunc != nil {
+		return m.IsGameInWishlistFunc(ctx, userID, gameID)
+	}
+	return false, nil
+}
+
+func (m *MockWishlistService) CreateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) (types.WishlistItemBFFResponse, error) {
+	if m.CreateWishlistItemFunc != nil {
+		return m.CreateWishlistItemFunc(ctx, userID, item)
+	}
+	return types.WishlistItemBFFResponse{}, nil
+}
+
+func (m *MockWishlistService) UpdateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) (types.WishlistItemBFFResponse, error) {
+	if m.UpdateWishlistItemFunc != nil {
+		return m.UpdateWishlistItemFunc(ctx, userID, item)
+	}
+	return types.WishlistItemBFFResponse{}, nil
+}
+
+func (m *MockWishlistService) DeleteWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) error {
+	if m.DeleteWishlistItemFunc != nil {
+		return m.DeleteWishlistItemFunc(ctx, userID, gameID)
+	}
+	return nil
+}
+
+func (m *MockWishlistService) GetPriceHistory(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (types.WishlistPriceHistoryResponse, error) {
+	if m.GetPriceHistoryFunc != nil {
+		return m.GetPriceHistoryFunc(ctx, userID, gameID)
+	}
+	return types.WishlistPriceHistoryResponse{}, nil
+}
+
+func (m *MockWishlistService) GetUpcomingReleases(
+	ctx context.Context,
+	userID string,
+	days int,
+) (types.WishlistUpcomingResponse, error) {
+	if m.GetUpcomingReleasesFunc != nil {
+		return m.GetUpcomingReleasesFunc(ctx, userID, days)
+	}
+	return types.WishlistUpcomingResponse{}, nil
+}
+
+func (m *MockWishlistService) GetWishlistSettings(
+	ctx context.Context,
+	userID string,
+) (types.WishlistSettingsResponse, error) {
+	if m.GetWishlistSettingsFunc != nil {
+		return m.GetWishlistSettingsFunc(ctx, userID)
+	}
+	return types.WishlistSettingsResponse{}, nil
+}
+
+func (m *MockWishlistService) UpdateWishlistSettings(
+	ctx context.Context,
+	userID string,
+	request types.UpdateWishlistSettingsRequest,
+) (types.WishlistSettingsResponse, error) {
+	if m.UpdateWishlistSettingsFunc != nil {
+		return m.UpdateWishlistSettingsFunc(ctx, userID, request)
+	}
+	return types.WishlistSettingsResponse{}, nil
+}
+
+func (m *MockWishlistService) PurchaseWishlistItem(
+	ctx context.Context,
+	userID string,
+	purchase models.WishlistPurchaseToSave,
+) (types.WishlistPurchaseResponse, error) {
+	if m.PurchaseWishlistItemFunc != nil {
+		return m.PurchaseWishlistItemFunc(ctx, userID, purchase)
+	}
+	return types.WishlistPurchaseResponse{}, nil
+}
+
+func (m *MockWishlistService) GetBudgetSuggestions(
+	ctx context.Context,
+	userID string,
+) (types.WishlistBudgetResponse, error) {
+	if m.GetBudgetSuggestionsFunc != nil {
+		return m.GetBudgetSuggestionsFunc(ctx, userID)
+	}
+	return types.WishlistBudgetResponse{}, nil
+}
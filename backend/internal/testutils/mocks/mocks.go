@@ -36,17 +36,25 @@ func (mv *MockValidator) ValidateQuery(query searchdef.SearchQuery) error {
 
 // FakeIGDBAdapter implements interfaces.IGDBAdapter.
 type MockIGDBAdapter struct {
-	SearchGamesFunc func(ctx context.Context, query string, limit int) ([]*models.Game, error)
-	UpdateTokenFunc func(token string) error
+	SearchGamesFunc   func(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error)
+	GetGamesByIDsFunc func(ctx context.Context, ids []int64) ([]*models.Game, error)
+	UpdateTokenFunc   func(token string) error
 }
 
-func (mv *MockIGDBAdapter) SearchGames(ctx context.Context, query string, limit int) ([]*models.Game, error) {
+func (mv *MockIGDBAdapter) SearchGames(ctx context.Context, query string, limit int, offset int) ([]*models.Game, error) {
 	if mv.SearchGamesFunc != nil {
-		return mv.SearchGamesFunc(ctx, query, limit)
+		return mv.SearchGamesFunc(ctx, query, limit, offset)
 	}
 	return nil, errors.New("SearchGamesFunc not defined")
 }
 
+func (mv *MockIGDBAdapter) GetGamesByIDs(ctx context.Context, ids []int64) ([]*models.Game, error) {
+	if mv.GetGamesByIDsFunc != nil {
+		return mv.GetGamesByIDsFunc(ctx, ids)
+	}
+	return nil, errors.New("GetGamesByIDsFunc not defined")
+}
+
 func (mv *MockIGDBAdapter) UpdateToken(token string) error {
 	if mv.UpdateTokenFunc != nil {
 		return mv.UpdateTokenFunc(token)
@@ -0,0 +1,301 @@
+package trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+// uniqueViolation is the Postgres error code raised by the partial unique indexes on active rows
+const uniqueViolation = "23505"
+
+type TrashDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewTrashDbAdapter(appContext *appcontext.AppContext) (*TrashDbAdapter, error) {
+	appContext.Logger.Debug("Creating TrashDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &TrashDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetTrash retrieves everything the user has in the trash
+func (ta *TrashDbAdapter) GetTrash(ctx context.Context, userID string) (models.TrashDB, error) {
+	var trash models.TrashDB
+
+	if err := ta.db.SelectContext(ctx, &trash.Games, GetTrashedGamesQuery, userID); err != nil {
+		return models.TrashDB{}, fmt.Errorf("error getting trashed games: %w", err)
+	}
+	if err := ta.db.SelectContext(ctx, &trash.PhysicalLocations, GetTrashedPhysicalLocationsQuery, userID); err != nil {
+		return models.TrashDB{}, fmt.Errorf("error getting trashed physical locations: %w", err)
+	}
+	if err := ta.db.SelectContext(ctx, &trash.Sublocations, GetTrashedSublocationsQuery, userID); err != nil {
+		return models.TrashDB{}, fmt.Errorf("error getting trashed sublocations: %w", err)
+	}
+	if err := ta.db.SelectContext(ctx, &trash.Purchases, GetTrashedPurchasesQuery, userID); err != nil {
+		return models.TrashDB{}, fmt.Errorf("error getting trashed purchases: %w", err)
+	}
+
+	return trash, nil
+}
+
+// POST
+// RestoreGame brings back every trashed copy of a game, along with any sublocation and location holding them. Returns:
+//   - ErrTrashItemNotFound if none of the game's copies are in the trash
+//   - ErrRestoreConflict if the game or a location was added again since
+func (ta *TrashDbAdapter) RestoreGame(ctx context.Context, userID string, gameID int64) (models.TrashRestoreResult, error) {
+	var result models.TrashRestoreResult
+	err := postgres.WithTransaction(ctx, ta.db, ta.logger, func(tx *sqlx.Tx) error {
+		var copyIDs []int64
+		if err := tx.SelectContext(ctx, &copyIDs, GetTrashedGameCopyIDsQuery, userID, gameID); err != nil {
+			return fmt.Errorf("error getting trashed copies: %w", err)
+		}
+		if len(copyIDs) == 0 {
+			return ErrTrashItemNotFound
+		}
+
+		var sublocationIDs []string
+		if err := tx.SelectContext(ctx, &sublocationIDs, GetTrashedSublocationIDsForCopiesQuery, pq.Array(copyIDs)); err != nil {
+			return fmt.Errorf("error getting trashed sublocations of copies: %w", err)
+		}
+
+		var err error
+		result, err = ta.restore(ctx, tx, userID, sublocationIDs, copyIDs)
+		return err
+	})
+	if err != nil {
+		return models.TrashRestoreResult{}, err
+	}
+
+	return result, nil
+}
+
+// POST
+// RestorePhysicalLocation brings back a location with the sublocations and copies trashed along with it. Returns:
+//   - ErrTrashItemNotFound if the location isn't in the user's trash
+//   - ErrRestoreConflict if an active location or copy is in the way
+func (ta *TrashDbAdapter) RestorePhysicalLocation(ctx context.Context, userID string, locationID string) (models.TrashRestoreResult, error) {
+	var result models.TrashRestoreResult
+	err := postgres.WithTransaction(ctx, ta.db, ta.logger, func(tx *sqlx.Tx) error {
+		var deletedAt time.Time
+		if err := tx.GetContext(ctx, &deletedAt, GetTrashedPhysicalLocationQuery, locationID, userID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTrashItemNotFound
+			}
+			return fmt.Errorf("error getting trashed physical location: %w", err)
+		}
+
+		var sublocationIDs []string
+		if err := tx.SelectContext(ctx, &sublocationIDs, GetSublocationIDsTrashedWithLocationQuery, locationID, deletedAt); err != nil {
+			return fmt.Errorf("error getting sublocations trashed with location: %w", err)
+		}
+
+		copyIDs, err := ta.getCopyIDsTrashedWithSublocations(ctx, tx, sublocationIDs, deletedAt)
+		if err != nil {
+			return err
+		}
+
+		result, err = ta.restoreWithLocations(ctx, tx, userID, []string{locationID}, sublocationIDs, copyIDs)
+		return err
+	})
+	if err != nil {
+		return models.TrashRestoreResult{}, err
+	}
+
+	return result, nil
+}
+
+// POST
+// RestoreSublocation brings back a sublocation with the copies trashed along with it, and its location if that is trashed too. Returns:
+//   - ErrTrashItemNotFound if the sublocation isn't in the user's trash
+//   - ErrRestoreConflict if an active sublocation, location or copy is in the way
+func (ta *TrashDbAdapter) RestoreSublocation(ctx context.Context, userID string, sublocationID string) (models.TrashRestoreResult, error) {
+	var result models.TrashRestoreResult
+	err := postgres.WithTransaction(ctx, ta.db, ta.logger, func(tx *sqlx.Tx) error {
+		var deletedAt time.Time
+		if err := tx.GetContext(ctx, &deletedAt, GetTrashedSublocationQuery, sublocationID, userID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrTrashItemNotFound
+			}
+			return fmt.Errorf("error getting trashed sublocation: %w", err)
+		}
+
+		sublocationIDs := []string{sublocationID}
+		copyIDs, err := ta.getCopyIDsTrashedWithSublocations(ctx, tx, sublocationIDs, deletedAt)
+		if err != nil {
+			return err
+		}
+
+		result, err = ta.restore(ctx, tx, userID, sublocationIDs, copyIDs)
+		return err
+	})
+	if err != nil {
+		return models.TrashRestoreResult{}, err
+	}
+
+	return result, nil
+}
+
+// POST
+// RestorePurchase brings back a one-time purchase, its links to copies were kept while it was in the trash. Returns:
+//   - ErrTrashItemNotFound if the purchase isn't in the user's trash
+func (ta *TrashDbAdapter) RestorePurchase(ctx context.Context, userID string, purchaseID int) (models.TrashRestoreResult, error) {
+	result, err := ta.db.ExecContext(ctx, RestorePurchaseQuery, purchaseID, userID)
+	if err != nil {
+		return models.TrashRestoreResult{}, fmt.Errorf("error restoring purchase: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.TrashRestoreResult{}, fmt.Errorf("error checking restored purchase: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.TrashRestoreResult{}, ErrTrashItemNotFound
+	}
+
+	return models.TrashRestoreResult{Purchases: rowsAffected}, nil
+}
+
+// DELETE
+// PurgeTrash removes rows of every user that were trashed before the cutoff, they can't be restored afterwards
+func (ta *TrashDbAdapter) PurgeTrash(ctx context.Context, cutoff time.Time) (models.TrashPurgeResult, error) {
+	var result models.TrashPurgeResult
+	err := postgres.WithTransaction(ctx, ta.db, ta.logger, func(tx *sqlx.Tx) error {
+		purges := []struct {
+			name  string
+			query string
+			count *int64
+		}{
+			{"purchases", PurgeTrashedPurchasesQuery, &result.Purchases},
+			{"copies", PurgeTrashedUserGamesQuery, &result.Copies},
+			{"sublocations", PurgeTrashedSublocationsQuery, &result.Sublocations},
+			{"physical locations", PurgeTrashedPhysicalLocationsQuery, &result.PhysicalLocations},
+		}
+
+		for _, purge := range purges {
+			deleted, err := tx.ExecContext(ctx, purge.query, cutoff)
+			if err != nil {
+				return fmt.Errorf("error purging trashed %s: %w", purge.name, err)
+			}
+			if *purge.count, err = deleted.RowsAffected(); err != nil {
+				return fmt.Errorf("error counting purged %s: %w", purge.name, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.TrashPurgeResult{}, err
+	}
+
+	return result, nil
+}
+
+// getCopyIDsTrashedWithSublocations finds the copies that went to the trash with the given sublocations
+func (ta *TrashDbAdapter) getCopyIDsTrashedWithSublocations(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	sublocationIDs []string,
+	deletedAt time.Time,
+) ([]int64, error) {
+	var copyIDs []int64
+	if len(sublocationIDs) == 0 {
+		return copyIDs, nil
+	}
+
+	if err := tx.SelectContext(ctx, &copyIDs, GetCopyIDsTrashedWithSublocationsQuery, pq.Array(sublocationIDs), deletedAt); err != nil {
+		return nil, fmt.Errorf("error getting copies trashed with sublocations: %w", err)
+	}
+	return copyIDs, nil
+}
+
+// restore brings back sublocations and copies, along with any trashed location the sublocations belong to
+func (ta *TrashDbAdapter) restore(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	userID string,
+	sublocationIDs []string,
+	copyIDs []int64,
+) (models.TrashRestoreResult, error) {
+	var locationIDs []string
+	if len(sublocationIDs) > 0 {
+		if err := tx.SelectContext(ctx, &locationIDs, GetTrashedLocationIDsForSublocationsQuery, pq.Array(sublocationIDs)); err != nil {
+			return models.TrashRestoreResult{}, fmt.Errorf("error getting trashed locations of sublocations: %w", err)
+		}
+	}
+
+	return ta.restoreWithLocations(ctx, tx, userID, locationIDs, sublocationIDs, copyIDs)
+}
+
+// restoreWithLocations clears deleted_at on locations, then sublocations, then copies,
+// so a restored copy never lands in a trashed sublocation
+func (ta *TrashDbAdapter) restoreWithLocations(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	userID string,
+	locationIDs []string,
+	sublocationIDs []string,
+	copyIDs []int64,
+) (models.TrashRestoreResult, error) {
+	var result models.TrashRestoreResult
+
+	if len(locationIDs) > 0 {
+		var nameTaken bool
+		if err := tx.GetContext(ctx, &nameTaken, CheckRestoredLocationNameTakenQuery, pq.Array(locationIDs)); err != nil {
+			return models.TrashRestoreResult{}, fmt.Errorf("error checking restored location names: %w", err)
+		}
+		if nameTaken {
+			return models.TrashRestoreResult{}, fmt.Errorf("%w: a physical location with the same name", ErrRestoreConflict)
+		}
+	}
+
+	restores := []struct {
+		name  string
+		query string
+		ids   any
+		count int
+		total *int64
+	}{
+		{"physical locations", RestorePhysicalLocationsQuery, pq.Array(locationIDs), len(locationIDs), &result.PhysicalLocations},
+		{"sublocations", RestoreSublocationsQuery, pq.Array(sublocationIDs), len(sublocationIDs), &result.Sublocations},
+		{"copies", RestoreUserGamesQuery, pq.Array(copyIDs), len(copyIDs), &result.Copies},
+	}
+
+	for _, restore := range restores {
+		if restore.count == 0 {
+			continue
+		}
+
+		restored, err := tx.ExecContext(ctx, restore.query, restore.ids, userID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				return models.TrashRestoreResult{}, fmt.Errorf("%w: %s already added again", ErrRestoreConflict, restore.name)
+			}
+			return models.TrashRestoreResult{}, fmt.Errorf("error restoring %s: %w", restore.name, err)
+		}
+		if *restore.total, err = restored.RowsAffected(); err != nil {
+			return models.TrashRestoreResult{}, fmt.Errorf("error counting restored %s: %w", restore.name, err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,195 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Restoring trashed items and purging them after the retention period
+
+	Scenarios:
+	- RestoreGame returns ErrTrashItemNotFound when none of the game's copies are trashed
+	- RestorePhysicalLocation brings back the sublocations and copies trashed with it
+	- RestorePhysicalLocation returns ErrRestoreConflict when the name was reused
+	- PurgeTrash deletes purchases and copies before the locations holding them
+*/
+
+func TestTrashDbAdapter(t *testing.T) {
+	userID := "test-user-id"
+	locationID := "9c1e4b7a-2f3d-4e5a-8b6c-7d8e9f0a1b2c"
+	sublocationID := "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+	deletedAt := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	setupMockDB := func() (*TrashDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &TrashDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN a game with no copies in the trash
+		WHEN RestoreGame is called
+		THEN it should return ErrTrashItemNotFound without restoring anything
+	*/
+	t.Run("RestoreGame returns not found for games outside the trash", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM user_games").
+			WithArgs(userID, int64(42)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.RestoreGame(context.Background(), userID, 42)
+
+		// THEN
+		if !errors.Is(err, ErrTrashItemNotFound) {
+			t.Errorf("Expected ErrTrashItemNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a trashed location with a sublocation and a copy that went to the trash with it
+		WHEN RestorePhysicalLocation is called
+		THEN it should restore the location, then the sublocation, then the copy
+	*/
+	t.Run("RestorePhysicalLocation restores what was trashed with it", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT deleted_at FROM physical_locations").
+			WithArgs(locationID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"deleted_at"}).AddRow(deletedAt))
+		mock.ExpectQuery("SELECT id FROM sublocations").
+			WithArgs(locationID, deletedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(sublocationID))
+		mock.ExpectQuery("SELECT DISTINCT ug.id FROM user_games ug").
+			WithArgs(sqlmock.AnyArg(), deletedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec("UPDATE physical_locations SET deleted_at = NULL").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE sublocations SET deleted_at = NULL").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE user_games SET deleted_at = NULL").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		result, err := adapter.RestorePhysicalLocation(context.Background(), userID, locationID)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.PhysicalLocations != 1 || result.Sublocations != 1 || result.Copies != 1 {
+			t.Errorf("Expected one location, sublocation and copy restored, got %+v", result)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a trashed location whose name is used by an active location
+		WHEN RestorePhysicalLocation is called
+		THEN it should return ErrRestoreConflict without restoring anything
+	*/
+	t.Run("RestorePhysicalLocation rejects a reused name", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT deleted_at FROM physical_locations").
+			WithArgs(locationID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"deleted_at"}).AddRow(deletedAt))
+		mock.ExpectQuery("SELECT id FROM sublocations").
+			WithArgs(locationID, deletedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.RestorePhysicalLocation(context.Background(), userID, locationID)
+
+		// THEN
+		if !errors.Is(err, ErrRestoreConflict) {
+			t.Errorf("Expected ErrRestoreConflict, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN rows that have been in the trash past the cutoff
+		WHEN PurgeTrash is called
+		THEN it should delete purchases, copies, sublocations and locations in that order
+	*/
+	t.Run("PurgeTrash deletes children before their locations", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		cutoff := deletedAt.Add(24 * time.Hour)
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM one_time_purchases").
+			WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec("DELETE FROM user_games").
+			WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("DELETE FROM sublocations").
+			WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM physical_locations").
+			WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// WHEN
+		result, err := adapter.PurgeTrash(context.Background(), cutoff)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Purchases != 2 || result.Copies != 3 || result.Sublocations != 1 || result.PhysicalLocations != 1 {
+			t.Errorf("Unexpected purge counts: %+v", result)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unmet expectations: %v", err)
+		}
+	})
+}
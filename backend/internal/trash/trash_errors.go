@@ -0,0 +1,30 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrTrashItemNotFound = errors.New("item not found in trash")
+	ErrRestoreConflict   = errors.New("restore conflicts with an active item")
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrDatabaseError     = errors.New("database error")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrTrashItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrRestoreConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
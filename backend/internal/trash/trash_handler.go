@@ -0,0 +1,107 @@
+package trash
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+)
+
+// RegisterTrashRoutes registers the trash listing and restores under /trash
+func RegisterTrashRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	trashService services.TrashService,
+) {
+	r.Get("/", GetTrash(appCtx, trashService))
+	r.Post("/{itemType}/{itemID}/restore", RestoreTrashItem(appCtx, trashService))
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the user from the request context, logging and responding when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, requestID string) (string, bool) {
+	userID := httputils.GetUserID(r)
+	if userID == "" {
+		appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+			"request_id": requestID,
+		})
+		handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+		return "", false
+	}
+	return userID, true
+}
+
+// helper fn to write a successful response
+func respond(w http.ResponseWriter, r *http.Request, appCtx *appcontext.AppContext, userID string, status int, data map[string]any) {
+	response := httputils.NewAPIResponse(r, userID, data)
+
+	httputils.RespondWithJSON(
+		httputils.NewResponseWriterAdapter(w),
+		appCtx.Logger,
+		status,
+		response,
+	)
+}
+
+// GetTrash handles GET requests for everything in the user's trash
+func GetTrash(appCtx *appcontext.AppContext, trashService services.TrashService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		trash, err := trashService.GetTrash(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"trash": trash})
+	}
+}
+
+// RestoreTrashItem handles POST requests that take a game, location, sublocation or purchase out of the trash
+func RestoreTrashItem(appCtx *appcontext.AppContext, trashService services.TrashService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+		userID, ok := requireUserID(w, r, appCtx, requestID)
+		if !ok {
+			return
+		}
+
+		restored, err := trashService.RestoreItem(
+			r.Context(),
+			userID,
+			chi.URLParam(r, "itemType"),
+			chi.URLParam(r, "itemID"),
+		)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		respond(w, r, appCtx, userID, http.StatusOK, map[string]any{"restored": restored})
+	}
+}
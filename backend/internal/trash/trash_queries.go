@@ -0,0 +1,173 @@
+package trash
+
+// Trash listing, every query takes the user ID as $1.
+// A game is listed once however many of its copies are in the trash, deleted_at is when its oldest copy went in.
+// Sublocations trashed along with their physical location are listed under the location.
+const (
+	GetTrashedGamesQuery = `
+		SELECT
+			ug.game_id,
+			g.name AS game_name,
+			COALESCE(g.cover_url, '') AS cover_url,
+			COUNT(*) AS copy_count,
+			ARRAY_AGG(DISTINCT p.name) AS platform_names,
+			MIN(ug.deleted_at) AS deleted_at
+		FROM user_games ug
+		JOIN games g ON g.id = ug.game_id
+		JOIN platforms p ON p.id = ug.platform_id
+		WHERE ug.user_id = $1 AND ug.deleted_at IS NOT NULL
+		GROUP BY ug.game_id, g.name, g.cover_url
+		ORDER BY MIN(ug.deleted_at) DESC
+	`
+
+	GetTrashedPhysicalLocationsQuery = `
+		SELECT
+			pl.id,
+			pl.name,
+			pl.location_type,
+			pl.deleted_at,
+			(
+				SELECT COUNT(*)
+				FROM sublocations sl
+				WHERE sl.physical_location_id = pl.id AND sl.deleted_at = pl.deleted_at
+			) AS sublocation_count
+		FROM physical_locations pl
+		WHERE pl.user_id = $1 AND pl.deleted_at IS NOT NULL
+		ORDER BY pl.deleted_at DESC
+	`
+
+	GetTrashedSublocationsQuery = `
+		SELECT
+			sl.id,
+			sl.name,
+			sl.location_type,
+			sl.physical_location_id,
+			pl.name AS physical_location_name,
+			sl.deleted_at
+		FROM sublocations sl
+		JOIN physical_locations pl ON pl.id = sl.physical_location_id
+		WHERE sl.user_id = $1
+		AND sl.deleted_at IS NOT NULL
+		AND pl.deleted_at IS DISTINCT FROM sl.deleted_at
+		ORDER BY sl.deleted_at DESC
+	`
+
+	GetTrashedPurchasesQuery = `
+		SELECT id, title, amount, purchase_date, deleted_at
+		FROM one_time_purchases
+		WHERE user_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+)
+
+// Restoring an item finds everything that has to come back with it before clearing deleted_at.
+// A copy can't be active in a trashed sublocation, so restoring a copy also restores its sublocation and location.
+const (
+	GetTrashedGameCopyIDsQuery = `
+		SELECT id
+		FROM user_games
+		WHERE user_id = $1 AND game_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	GetTrashedPhysicalLocationQuery = `
+		SELECT deleted_at
+		FROM physical_locations
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	GetTrashedSublocationQuery = `
+		SELECT deleted_at
+		FROM sublocations
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	// Sublocations that went to the trash along with a location, $2 is the location's deleted_at
+	GetSublocationIDsTrashedWithLocationQuery = `
+		SELECT id
+		FROM sublocations
+		WHERE physical_location_id = $1 AND deleted_at = $2
+	`
+
+	// Copies that went to the trash along with their sublocations, $2 is the sublocations' deleted_at
+	GetCopyIDsTrashedWithSublocationsQuery = `
+		SELECT DISTINCT ug.id
+		FROM user_games ug
+		JOIN physical_game_locations pgl ON pgl.user_game_id = ug.id
+		WHERE pgl.sublocation_id = ANY($1) AND ug.deleted_at = $2
+	`
+
+	GetTrashedSublocationIDsForCopiesQuery = `
+		SELECT DISTINCT sl.id
+		FROM sublocations sl
+		JOIN physical_game_locations pgl ON pgl.sublocation_id = sl.id
+		WHERE pgl.user_game_id = ANY($1) AND sl.deleted_at IS NOT NULL
+	`
+
+	GetTrashedLocationIDsForSublocationsQuery = `
+		SELECT DISTINCT pl.id
+		FROM physical_locations pl
+		JOIN sublocations sl ON sl.physical_location_id = pl.id
+		WHERE sl.id = ANY($1) AND pl.deleted_at IS NOT NULL
+	`
+
+	// Physical location names aren't a database constraint, creating one checks for an active location with the same name
+	CheckRestoredLocationNameTakenQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM physical_locations trashed
+			JOIN physical_locations active
+				ON active.user_id = trashed.user_id
+				AND LOWER(active.name) = LOWER(trashed.name)
+				AND active.deleted_at IS NULL
+			WHERE trashed.id = ANY($1)
+		)
+	`
+
+	RestorePhysicalLocationsQuery = `
+		UPDATE physical_locations
+		SET deleted_at = NULL
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	RestoreSublocationsQuery = `
+		UPDATE sublocations
+		SET deleted_at = NULL
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	RestoreUserGamesQuery = `
+		UPDATE user_games
+		SET deleted_at = NULL
+		WHERE id = ANY($1) AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	RestorePurchaseQuery = `
+		UPDATE one_time_purchases
+		SET deleted_at = NULL
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+	`
+)
+
+// Purging removes rows trashed before $1 for every user.
+// Purchases and copies go first, their links cascade, then sublocations and the locations that held them.
+const (
+	PurgeTrashedPurchasesQuery = `
+		DELETE FROM one_time_purchases
+		WHERE deleted_at < $1
+	`
+
+	PurgeTrashedUserGamesQuery = `
+		DELETE FROM user_games
+		WHERE deleted_at < $1
+	`
+
+	PurgeTrashedSublocationsQuery = `
+		DELETE FROM sublocations
+		WHERE deleted_at < $1
+	`
+
+	PurgeTrashedPhysicalLocationsQuery = `
+		DELETE FROM physical_locations
+		WHERE deleted_at < $1
+	`
+)
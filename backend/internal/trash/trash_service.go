@@ -0,0 +1,201 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// purgeInterval is how often items past the retention period are removed
+const purgeInterval = time.Hour
+
+// defaultRetentionDays is used when no retention period is configured
+const defaultRetentionDays = 30
+
+// userCacheInvalidator is the part of each domain's cache adapter a restore needs
+type userCacheInvalidator interface {
+	InvalidateUserCache(ctx context.Context, userID string) error
+}
+
+type GameTrashService struct {
+	dbAdapter        interfaces.TrashDbAdapter
+	analyticsService analytics.Service
+	userCaches       []userCacheInvalidator
+	validator        interfaces.TrashValidator
+	logger           interfaces.Logger
+	retention        time.Duration
+	now              func() time.Time
+	stopPurge        context.CancelFunc
+}
+
+type TrashService interface {
+	GetTrash(ctx context.Context, userID string) (types.TrashResponse, error)
+	RestoreItem(ctx context.Context, userID string, itemType string, itemID string) (types.TrashRestoreResponse, error)
+}
+
+// NewGameTrashService creates the trash service.
+// Items are purged retentionDays after they were deleted, userCaches are cleared whenever something is restored.
+func NewGameTrashService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.TrashDbAdapter,
+	analyticsService analytics.Service,
+	retentionDays int,
+	userCaches ...userCacheInvalidator,
+) (*GameTrashService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if analyticsService == nil {
+		return nil, fmt.Errorf("analyticsService is required")
+	}
+	if retentionDays <= 0 {
+		retentionDays = defaultRetentionDays
+	}
+
+	return &GameTrashService{
+		dbAdapter:        dbAdapter,
+		analyticsService: analyticsService,
+		userCaches:       userCaches,
+		validator:        NewTrashValidator(),
+		logger:           appContext.Logger,
+		retention:        time.Duration(retentionDays) * 24 * time.Hour,
+		now:              time.Now,
+	}, nil
+}
+
+// Start runs the purge job
+func (ts *GameTrashService) Start(ctx context.Context) error {
+	purgeCtx, cancel := context.WithCancel(context.Background())
+	ts.stopPurge = cancel
+	go worker.NewWorker(purgeInterval, ts.PurgeExpiredTrash, nil, ts.logger).Start(purgeCtx)
+
+	return nil
+}
+
+// Stop stops the purge job
+func (ts *GameTrashService) Stop() error {
+	if ts.stopPurge != nil {
+		ts.stopPurge()
+	}
+	return nil
+}
+
+// GET
+// GetTrash lists everything the user deleted that can still be restored
+func (ts *GameTrashService) GetTrash(ctx context.Context, userID string) (types.TrashResponse, error) {
+	if err := ts.validator.ValidateUserID(userID); err != nil {
+		return types.TrashResponse{}, err
+	}
+
+	trash, err := ts.dbAdapter.GetTrash(ctx, userID)
+	if err != nil {
+		return types.TrashResponse{}, err
+	}
+
+	return TransformTrashToResponse(trash, ts.retention), nil
+}
+
+// POST
+// RestoreItem takes an item out of the trash, itemType is one of models.TrashItemTypes
+func (ts *GameTrashService) RestoreItem(
+	ctx context.Context,
+	userID string,
+	itemType string,
+	itemID string,
+) (types.TrashRestoreResponse, error) {
+	if err := ts.validator.ValidateUserID(userID); err != nil {
+		return types.TrashRestoreResponse{}, err
+	}
+	if err := ts.validator.ValidateItemType(itemType); err != nil {
+		return types.TrashRestoreResponse{}, err
+	}
+
+	var result models.TrashRestoreResult
+	var err error
+	switch itemType {
+	case models.TrashItemTypeGame:
+		var gameID int64
+		if gameID, err = ts.validator.ValidateGameID(itemID); err != nil {
+			return types.TrashRestoreResponse{}, err
+		}
+		result, err = ts.dbAdapter.RestoreGame(ctx, userID, gameID)
+
+	case models.TrashItemTypePhysicalLocation:
+		if err = ts.validator.ValidateLocationID(itemID); err != nil {
+			return types.TrashRestoreResponse{}, err
+		}
+		result, err = ts.dbAdapter.RestorePhysicalLocation(ctx, userID, itemID)
+
+	case models.TrashItemTypeSublocation:
+		if err = ts.validator.ValidateLocationID(itemID); err != nil {
+			return types.TrashRestoreResponse{}, err
+		}
+		result, err = ts.dbAdapter.RestoreSublocation(ctx, userID, itemID)
+
+	case models.TrashItemTypePurchase:
+		var purchaseID int
+		if purchaseID, err = ts.validator.ValidatePurchaseID(itemID); err != nil {
+			return types.TrashRestoreResponse{}, err
+		}
+		result, err = ts.dbAdapter.RestorePurchase(ctx, userID, purchaseID)
+	}
+	if err != nil {
+		return types.TrashRestoreResponse{}, err
+	}
+
+	ts.invalidateCaches(ctx, userID)
+	return TransformRestoreResultToResponse(itemType, itemID, result), nil
+}
+
+// PurgeExpiredTrash removes items that have been in the trash for longer than the retention period.
+// Purged rows were already hidden, so no cache needs clearing.
+func (ts *GameTrashService) PurgeExpiredTrash(ctx context.Context) error {
+	cutoff := ts.now().Add(-ts.retention)
+	result, err := ts.dbAdapter.PurgeTrash(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	if result != (models.TrashPurgeResult{}) {
+		ts.logger.Info("Purged expired trash", map[string]any{
+			"cutoff":            cutoff,
+			"purchases":         result.Purchases,
+			"copies":            result.Copies,
+			"sublocations":      result.Sublocations,
+			"physicalLocations": result.PhysicalLocations,
+		})
+	}
+
+	return nil
+}
+
+// invalidateCaches clears everything a restored item can show up in
+func (ts *GameTrashService) invalidateCaches(ctx context.Context, userID string) {
+	for _, cache := range ts.userCaches {
+		if err := cache.InvalidateUserCache(ctx, userID); err != nil {
+			ts.logger.Error("Failed to invalidate cache after trash restore", map[string]any{
+				"error":  err,
+				"userID": userID,
+			})
+		}
+	}
+
+	if err := ts.analyticsService.InvalidateDomains(ctx, userID, []string{
+		analytics.DomainGeneral,
+		analytics.DomainFinancial,
+		analytics.DomainStorage,
+		analytics.DomainInventory,
+	}); err != nil {
+		ts.logger.Warn("Failed to invalidate analytics cache after trash restore", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+}
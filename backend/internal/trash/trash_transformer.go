@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformTrashToResponse converts the trash listing, retention decides when each item is purged
+func TransformTrashToResponse(trash models.TrashDB, retention time.Duration) types.TrashResponse {
+	response := types.TrashResponse{
+		Games:             make([]types.TrashedGameResponse, 0, len(trash.Games)),
+		PhysicalLocations: make([]types.TrashedPhysicalLocationResponse, 0, len(trash.PhysicalLocations)),
+		Sublocations:      make([]types.TrashedSublocationResponse, 0, len(trash.Sublocations)),
+		Purchases:         make([]types.TrashedPurchaseResponse, 0, len(trash.Purchases)),
+		RetentionDays:     int(retention / (24 * time.Hour)),
+	}
+
+	for _, game := range trash.Games {
+		platformNames := []string(game.PlatformNames)
+		if platformNames == nil {
+			platformNames = []string{}
+		}
+
+		response.Games = append(response.Games, types.TrashedGameResponse{
+			GameID:        game.GameID,
+			GameName:      game.GameName,
+			CoverURL:      game.CoverURL,
+			CopyCount:     game.CopyCount,
+			PlatformNames: platformNames,
+			DeletedAt:     game.DeletedAt.Unix(),
+			PurgeAt:       game.DeletedAt.Add(retention).Unix(),
+		})
+	}
+
+	for _, location := range trash.PhysicalLocations {
+		response.PhysicalLocations = append(response.PhysicalLocations, types.TrashedPhysicalLocationResponse{
+			ID:               location.ID,
+			Name:             location.Name,
+			LocationType:     location.LocationType,
+			SublocationCount: location.SublocationCount,
+			DeletedAt:        location.DeletedAt.Unix(),
+			PurgeAt:          location.DeletedAt.Add(retention).Unix(),
+		})
+	}
+
+	for _, sublocation := range trash.Sublocations {
+		response.Sublocations = append(response.Sublocations, types.TrashedSublocationResponse{
+			ID:                   sublocation.ID,
+			Name:                 sublocation.Name,
+			LocationType:         sublocation.LocationType,
+			PhysicalLocationID:   sublocation.PhysicalLocationID,
+			PhysicalLocationName: sublocation.PhysicalLocationName,
+			DeletedAt:            sublocation.DeletedAt.Unix(),
+			PurgeAt:              sublocation.DeletedAt.Add(retention).Unix(),
+		})
+	}
+
+	for _, purchase := range trash.Purchases {
+		response.Purchases = append(response.Purchases, types.TrashedPurchaseResponse{
+			ID:           fmt.Sprintf("%s%d", purchaseIDPrefix, purchase.ID),
+			Title:        purchase.Title,
+			Amount:       purchase.Amount,
+			PurchaseDate: purchase.PurchaseDate.Unix(),
+			DeletedAt:    purchase.DeletedAt.Unix(),
+			PurgeAt:      purchase.DeletedAt.Add(retention).Unix(),
+		})
+	}
+
+	return response
+}
+
+func TransformRestoreResultToResponse(itemType string, itemID string, result models.TrashRestoreResult) types.TrashRestoreResponse {
+	return types.TrashRestoreResponse{
+		ItemType:                  itemType,
+		ItemID:                    itemID,
+		RestoredCopies:            result.Copies,
+		RestoredSublocations:      result.Sublocations,
+		RestoredPhysicalLocations: result.PhysicalLocations,
+		RestoredPurchases:         result.Purchases,
+	}
+}
@@ -0,0 +1,60 @@
+package trash
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// purchaseIDPrefix marks one-time purchase IDs, the same format spend tracking hands out
+const purchaseIDPrefix = "one-"
+
+type TrashValidatorImpl struct{}
+
+func NewTrashValidator() interfaces.TrashValidator {
+	return &TrashValidatorImpl{}
+}
+
+func (v *TrashValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *TrashValidatorImpl) ValidateItemType(itemType string) error {
+	for _, validType := range models.TrashItemTypes {
+		if itemType == validType {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: invalid item type '%s', must be one of: %s", ErrValidationFailed, itemType, strings.Join(models.TrashItemTypes, ", "))
+}
+
+func (v *TrashValidatorImpl) ValidateGameID(itemID string) (int64, error) {
+	gameID, err := strconv.ParseInt(itemID, 10, 64)
+	if err != nil || gameID <= 0 {
+		return 0, fmt.Errorf("%w: invalid game ID '%s'", ErrValidationFailed, itemID)
+	}
+	return gameID, nil
+}
+
+func (v *TrashValidatorImpl) ValidateLocationID(itemID string) error {
+	if _, err := uuid.Parse(itemID); err != nil {
+		return fmt.Errorf("%w: invalid location ID '%s'", ErrValidationFailed, itemID)
+	}
+	return nil
+}
+
+// ValidatePurchaseID accepts "one-16" as well as a bare "16"
+func (v *TrashValidatorImpl) ValidatePurchaseID(itemID string) (int, error) {
+	purchaseID, err := strconv.Atoi(strings.TrimPrefix(itemID, purchaseIDPrefix))
+	if err != nil || purchaseID <= 0 {
+		return 0, fmt.Errorf("%w: invalid purchase ID '%s'", ErrValidationFailed, itemID)
+	}
+	return purchaseID, nil
+}
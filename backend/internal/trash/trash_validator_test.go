@@ -0,0 +1,83 @@
+package trash
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+/*
+	Behavior:
+	- Validating the item type and ID of a restore
+
+	Scenarios:
+	- Only games, physical-locations, sublocations and purchases can be restored
+	- Purchase IDs accept the spend tracking "one-<id>" format
+	- Malformed IDs are rejected
+*/
+
+func TestTrashValidator(t *testing.T) {
+	validator := NewTrashValidator()
+
+	/*
+		GIVEN each supported item type and one that isn't
+		WHEN ValidateItemType is called
+		THEN only the supported types should pass
+	*/
+	t.Run("Item types", func(t *testing.T) {
+		for _, itemType := range models.TrashItemTypes {
+			if err := validator.ValidateItemType(itemType); err != nil {
+				t.Errorf("Expected '%s' to be valid, got %v", itemType, err)
+			}
+		}
+
+		// WHEN
+		err := validator.ValidateItemType("wishlist")
+
+		// THEN
+		if !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed, got %v", err)
+		}
+	})
+
+	/*
+		GIVEN a purchase ID with and without the "one-" prefix
+		WHEN ValidatePurchaseID is called
+		THEN both should resolve to the numeric ID
+	*/
+	t.Run("Purchase IDs accept the spend tracking format", func(t *testing.T) {
+		for _, itemID := range []string{"one-16", "16"} {
+			// WHEN
+			purchaseID, err := validator.ValidatePurchaseID(itemID)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("Expected no error for '%s', got %v", itemID, err)
+			}
+			if purchaseID != 16 {
+				t.Errorf("Expected purchase ID 16 for '%s', got %d", itemID, purchaseID)
+			}
+		}
+	})
+
+	/*
+		GIVEN malformed IDs for each item type
+		WHEN they are validated
+		THEN each should return ErrValidationFailed
+	*/
+	t.Run("Malformed IDs are rejected", func(t *testing.T) {
+		if _, err := validator.ValidateGameID("abc"); !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed for game ID, got %v", err)
+		}
+		if _, err := validator.ValidateGameID("0"); !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed for zero game ID, got %v", err)
+		}
+		if err := validator.ValidateLocationID("not-a-uuid"); !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed for location ID, got %v", err)
+		}
+		if _, err := validator.ValidatePurchaseID("sub-16"); !errors.Is(err, ErrValidationFailed) {
+			t.Errorf("Expected ErrValidationFailed for purchase ID, got %v", err)
+		}
+	})
+}
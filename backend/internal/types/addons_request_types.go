@@ -0,0 +1,19 @@
+package types
+
+type CreateOwnedAddonRequest struct {
+	AddonID      int64  `json:"addonId"`
+	PlatformID   int64  `json:"platformId"`
+	PurchaseID   string `json:"purchaseId,omitempty"`   // One-time purchase ID ("one-123"), filed under DLC spending
+	AcquiredDate *int64 `json:"acquiredDate,omitempty"` // Unix timestamp
+	Notes        string `json:"notes,omitempty"`
+}
+
+type UpdateEditionRequest struct {
+	Edition  string                  `json:"edition"` // Empty clears the edition and its contents
+	Contents []EditionContentRequest `json:"contents"`
+}
+
+type EditionContentRequest struct {
+	Name    string `json:"name"`
+	AddonID *int64 `json:"addonId,omitempty"` // Set when the item is one of the game's add-ons
+}
@@ -0,0 +1,53 @@
+package types
+
+// GameAddonsResponse is a library game's add-ons split by whether the user has them, along with the editions of their copies
+type GameAddonsResponse struct {
+	GameID   int64                 `json:"gameId"`
+	Owned    []GameAddonResponse   `json:"owned"`
+	Missing  []GameAddonResponse   `json:"missing"`
+	Editions []GameEditionResponse `json:"editions"`
+}
+
+type GameAddonResponse struct {
+	ID               int64                   `json:"id"`
+	Name             string                  `json:"name"`
+	AddonType        GameTypeResponse        `json:"addonType"`
+	CoverURL         string                  `json:"coverUrl,omitempty"`
+	FirstReleaseDate int64                   `json:"firstReleaseDate,omitempty"`
+	Ownership        *AddonOwnershipResponse `json:"ownership,omitempty"`
+}
+
+// AddonOwnershipResponse says how the user got an add-on, ID is only set when it was bought on its own
+type AddonOwnershipResponse struct {
+	ID                int64  `json:"id,omitempty"`
+	PlatformName      string `json:"platformName,omitempty"`
+	PurchaseID        string `json:"purchaseId,omitempty"`
+	AcquiredDate      int64  `json:"acquiredDate,omitempty"`
+	IncludedInEdition string `json:"includedInEdition,omitempty"`
+}
+
+type OwnedAddonResponse struct {
+	ID           int64            `json:"id"`
+	AddonID      int64            `json:"addonId"`
+	AddonName    string           `json:"addonName"`
+	AddonType    GameTypeResponse `json:"addonType"`
+	GameID       int64            `json:"gameId"`
+	PlatformID   int64            `json:"platformId"`
+	PlatformName string           `json:"platformName"`
+	PurchaseID   string           `json:"purchaseId,omitempty"`
+	AcquiredDate int64            `json:"acquiredDate,omitempty"`
+	Notes        string           `json:"notes,omitempty"`
+}
+
+type GameEditionResponse struct {
+	UserGameID   int64                    `json:"userGameId"`
+	PlatformName string                   `json:"platformName"`
+	GameType     string                   `json:"gameType"`
+	Edition      string                   `json:"edition,omitempty"`
+	Contents     []EditionContentResponse `json:"contents"`
+}
+
+type EditionContentResponse struct {
+	Name    string `json:"name"`
+	AddonID int64  `json:"addonId,omitempty"`
+}
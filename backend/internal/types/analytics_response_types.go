@@ -10,6 +10,9 @@ type AnalyticsGeneralStatsResponse struct {
 	MonthlySubscriptionCost float64 `json:"monthly_subscription_cost" db:"monthly_subscription_cost"`
 	TotalDigitalLocations   int     `json:"total_digital_locations" db:"total_digital_locations"`
 	TotalPhysicalLocations  int     `json:"total_physical_locations" db:"total_physical_locations"`
+	BacklogGames            int     `json:"backlog_games" db:"backlog_games"`
+	CompletedGames          int     `json:"completed_games" db:"completed_games"`
+	CompletionRate          float64 `json:"completion_rate" db:"completion_rate"` // percentage of owned games completed
 }
 
 // FinancialStats contains detailed financial information
@@ -0,0 +1,18 @@
+package types
+
+// BarcodeMappingRequest teaches or confirms which game a barcode belongs to.
+// gameName is what the game is looked up by on IGDB, the stored names come from IGDB.
+type BarcodeMappingRequest struct {
+	Code       string `json:"code"`
+	GameID     int64  `json:"gameId"`
+	GameName   string `json:"gameName"`
+	PlatformID int64  `json:"platformId"`
+	Region     string `json:"region,omitempty"` // defaults to unknown
+}
+
+// BarcodeScanSessionRequest adds every scanned code as a physical copy in one sublocation
+type BarcodeScanSessionRequest struct {
+	SublocationID string   `json:"sublocationId"`
+	Codes         []string `json:"codes"`
+	DryRun        bool     `json:"dryRun"`
+}
@@ -0,0 +1,48 @@
+package types
+
+type BarcodeLookupResponse struct {
+	Code       string                     `json:"code"`
+	Candidates []BarcodeCandidateResponse `json:"candidates"`
+}
+
+// BarcodeCandidateResponse is one game a barcode maps to, the best confirmed first.
+// Game is the IGDB search result, it is left out when search no longer finds the game.
+type BarcodeCandidateResponse struct {
+	ID              int64                `json:"id"`
+	GameID          int64                `json:"gameId"`
+	GameName        string               `json:"gameName"`
+	PlatformID      int64                `json:"platformId"`
+	PlatformName    string               `json:"platformName"`
+	Region          string               `json:"region"`
+	Confirmations   int                  `json:"confirmations"`
+	ConfirmedByUser bool                 `json:"confirmedByUser"`
+	Game            *BarcodeGameResponse `json:"game,omitempty"`
+}
+
+type BarcodeGameResponse struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	CoverURL         string  `json:"coverUrl"`
+	FirstReleaseDate int64   `json:"firstReleaseDate,omitempty"`
+	Rating           float64 `json:"rating,omitempty"`
+	IsInLibrary      bool    `json:"isInLibrary"`
+}
+
+type BarcodeScanSessionResponse struct {
+	Results []BarcodeScanResultResponse `json:"results"`
+
+	// Import is the library import adding the recognized codes, nil when none were recognized
+	Import *LibraryImportJobResponse `json:"import,omitempty"`
+}
+
+// BarcodeScanResultResponse reports what happened to one scanned code.
+// RowNumber points at the code's row in the import report.
+type BarcodeScanResultResponse struct {
+	Code         string `json:"code"`
+	Status       string `json:"status"`
+	RowNumber    int    `json:"rowNumber,omitempty"`
+	GameID       int64  `json:"gameId,omitempty"`
+	GameName     string `json:"gameName,omitempty"`
+	PlatformName string `json:"platformName,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
@@ -0,0 +1,25 @@
+package types
+
+// TagRequest is the body of POST and PUT /library/tags
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// CollectionRequest is the body of POST and PUT /library/collections
+type CollectionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// LibraryGameSelectionRequest picks the copies a bulk operation applies to.
+// gameIds selects every copy of a game, userGameIds a single copy.
+type LibraryGameSelectionRequest struct {
+	UserGameIDs []int64 `json:"userGameIds"`
+	GameIDs     []int64 `json:"gameIds"`
+}
+
+// BulkTagRequest is the body of POST /library/tags/bulk-tag and /library/tags/bulk-untag
+type BulkTagRequest struct {
+	TagIDs []string `json:"tagIds"`
+	LibraryGameSelectionRequest
+}
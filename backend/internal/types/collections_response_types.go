@@ -0,0 +1,35 @@
+package types
+
+type TagResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	GameCount int    `json:"gameCount"`
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+}
+
+type CollectionResponse struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	GameCount   int                      `json:"gameCount"`
+	CreatedAt   int64                    `json:"createdAt"`
+	UpdatedAt   int64                    `json:"updatedAt"`
+	Games       []CollectionGameResponse `json:"games,omitempty"`
+}
+
+type CollectionGameResponse struct {
+	UserGameID   int64  `json:"userGameId"`
+	GameID       int64  `json:"gameId"`
+	GameName     string `json:"gameName"`
+	CoverURL     string `json:"coverUrl"`
+	PlatformID   int64  `json:"platformId"`
+	PlatformName string `json:"platformName"`
+	GameType     string `json:"gameType"`
+	AddedAt      int64  `json:"addedAt"`
+}
+
+// LibraryGameSelectionResponse reports how many tag or collection links a bulk request added or removed
+type LibraryGameSelectionResponse struct {
+	Updated int64 `json:"updated"`
+}
@@ -0,0 +1,13 @@
+package types
+
+// DataExportResponse is the status resource for a personal data export
+type DataExportResponse struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	SizeBytes    int64  `json:"sizeBytes,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	DownloadURL  string `json:"downloadUrl,omitempty"`
+	CreatedAt    int64  `json:"createdAt"`
+	CompletedAt  int64  `json:"completedAt,omitempty"`
+	ExpiresAt    int64  `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,16 @@
+package types
+
+// DataRestoreResponse summarizes what a restore wrote
+type DataRestoreResponse struct {
+	Mode          string                       `json:"mode"`
+	FormatVersion int                          `json:"formatVersion"`
+	Datasets      []DataRestoreDatasetResponse `json:"datasets"`
+}
+
+// DataRestoreDatasetResponse counts the rows of one dataset that were created,
+// and the rows that matched data the user already had and were left as is
+type DataRestoreDatasetResponse struct {
+	Name    string `json:"name"`
+	Created int    `json:"created"`
+	Merged  int    `json:"merged"`
+}
@@ -45,3 +45,44 @@ type IGDBResponseGameType struct {
 }
 
 //
+
+// IGDBPlatformResponse represents a platform from the IGDB platforms endpoint
+type IGDBPlatformResponse struct {
+	ID             int64                    `json:"id"`
+	Name           string                   `json:"name"`
+	Abbreviation   string                   `json:"abbreviation"`
+	Generation     int                      `json:"generation"`
+	PlatformFamily int64                    `json:"platform_family"`
+	PlatformLogo   IGDBResponsePlatformLogo `json:"platform_logo"`
+	PlatformType   int                      `json:"platform_type"`
+}
+
+// IGDBResponsePlatformLogo represents a platform's logo image
+type IGDBResponsePlatformLogo struct {
+	ID  int64  `json:"id"`
+	URL string `json:"url"`
+}
+
+// IGDBPlatformFamilyResponse represents a family of platforms (e.g. "PlayStation", "Nintendo")
+type IGDBPlatformFamilyResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// IGDBGameAddonsResponse represents a game's add-on relations from the IGDB games endpoint
+type IGDBGameAddonsResponse struct {
+	ID                   int64               `json:"id"`
+	DLCs                 []IGDBAddonResponse `json:"dlcs"`
+	Expansions           []IGDBAddonResponse `json:"expansions"`
+	StandaloneExpansions []IGDBAddonResponse `json:"standalone_expansions"`
+}
+
+// IGDBAddonResponse represents a DLC or expansion of a game
+type IGDBAddonResponse struct {
+	ID               int64                 `json:"id"`
+	Name             string                `json:"name"`
+	Cover            IGDBResponseGameCover `json:"cover"`
+	FirstReleaseDate int64                 `json:"first_release_date"`
+	GameType         IGDBResponseGameType  `json:"game_type"`
+}
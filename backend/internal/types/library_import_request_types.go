@@ -0,0 +1,24 @@
+package types
+
+// LibraryImportRowRequest is a single game in a JSON library import.
+// Either title or igdb_id is required, location takes a sublocation / digital location ID or name.
+type LibraryImportRowRequest struct {
+	Title    string `json:"title"`
+	IGDBID   int64  `json:"igdb_id"`
+	Platform string `json:"platform"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+}
+
+// LibraryImportRequest is the JSON body accepted by POST /library/imports
+type LibraryImportRequest struct {
+	Rows []LibraryImportRowRequest `json:"rows"`
+}
+
+// ResolveLibraryImportRowRequest is the body of POST /library/imports/review/{rowID}/resolve.
+// Empty fields fall back to the row's suggested game and its original platform and location.
+type ResolveLibraryImportRowRequest struct {
+	IGDBID   int64  `json:"igdb_id"`
+	Platform string `json:"platform"`
+	Location string `json:"location"`
+}
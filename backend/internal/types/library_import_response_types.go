@@ -0,0 +1,39 @@
+package types
+
+// LibraryImportRowResponse reports what happened to a single import row
+type LibraryImportRowResponse struct {
+	ID                  int64  `json:"id"`
+	JobID               string `json:"jobId,omitempty"`
+	RowNumber           int    `json:"rowNumber"`
+	Title               string `json:"title,omitempty"`
+	IGDBID              int64  `json:"igdbId,omitempty"`
+	Platform            string `json:"platform,omitempty"`
+	Type                string `json:"type,omitempty"`
+	Location            string `json:"location,omitempty"`
+	ExternalID          string `json:"externalId,omitempty"`
+	Status              string `json:"status"`
+	MatchedGameID       int64  `json:"matchedGameId,omitempty"`
+	MatchedGameName     string `json:"matchedGameName,omitempty"`
+	MatchedPlatformID   int64  `json:"matchedPlatformId,omitempty"`
+	MatchedPlatformName string `json:"matchedPlatformName,omitempty"`
+	MatchedLocationID   string `json:"matchedLocationId,omitempty"`
+	Message             string `json:"message,omitempty"`
+}
+
+// LibraryImportJobResponse is the status resource for a library import
+type LibraryImportJobResponse struct {
+	ID            string                     `json:"id"`
+	Source        string                     `json:"source"`
+	DryRun        bool                       `json:"dryRun"`
+	Status        string                     `json:"status"`
+	TotalRows     int                        `json:"totalRows"`
+	ProcessedRows int                        `json:"processedRows"`
+	SucceededRows int                        `json:"succeededRows"`
+	FailedRows    int                        `json:"failedRows"`
+	ReviewRows    int                        `json:"reviewRows"`
+	ErrorMessage  string                     `json:"errorMessage,omitempty"`
+	CreatedAt     int64                      `json:"createdAt"`
+	StartedAt     int64                      `json:"startedAt,omitempty"`
+	CompletedAt   int64                      `json:"completedAt,omitempty"`
+	Rows          []LibraryImportRowResponse `json:"rows,omitempty"`
+}
@@ -7,6 +7,8 @@ type CreateLibraryGameRequest struct {
 	GameFirstReleaseDate         int64                           `json:"game_first_release_date"`
 	GameType                     LibraryRequestGameType          `json:"game_type"`
 	GameThemeNames               []string                        `json:"game_theme_names"`
+	GameGenres                   []LibraryRequestGameTerm        `json:"game_genres,omitempty"`
+	GameThemes                   []LibraryRequestGameTerm        `json:"game_themes,omitempty"`
 	GameRating                   float64                         `json:"game_rating"`
 	GamesByPlatformAndLocation   []LibraryRequestGameLocation    `json:"games_by_platform_and_location"`
 }
@@ -15,6 +17,22 @@ type UpdateLibraryGameRequest struct {
 	GamesByPlatformAndLocation    []LibraryRequestGameLocation    `json:"games_by_platform_and_location"`
 }
 
+// UpdateLibraryGamePlayStatusRequest sets the play status shared by every copy of a game
+type UpdateLibraryGamePlayStatusRequest struct {
+	PlayStatus     string `json:"play_status"`
+	StartedAt      *int64 `json:"started_at,omitempty"`  // Unix timestamp
+	FinishedAt     *int64 `json:"finished_at,omitempty"` // Unix timestamp
+	CompletionNote string `json:"completion_note,omitempty"`
+}
+
+// UpdateLibraryGameReviewRequest sets the user's own rating, review and private notes for a game.
+// Every field is replaced, so omitting one clears it.
+type UpdateLibraryGameReviewRequest struct {
+	PersonalRating *int   `json:"personal_rating,omitempty"` // 1 to 5 stars
+	Review         string `json:"review,omitempty"`
+	PrivateNotes   string `json:"private_notes,omitempty"`
+}
+
 // BatchDeleteLibraryGameRequest represents a request to delete specific platform versions of a game
 type BatchDeleteLibraryGameRequest struct {
 	GameID    int64                           `json:"game_id"`
@@ -29,19 +47,64 @@ type BatchDeleteLibraryGameVersion struct {
 	PlatformID  int64  `json:"platform_id"`  // platform ID
 }
 
+// LibraryRequestGameTerm is a genre or theme of the game being added, as IGDB returned it in search
+type LibraryRequestGameTerm struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
 type LibraryRequestGameType struct {
 	DisplayText     string `json:"display_text"`
 	NormalizedText  string `json:"normalized_text"`
 }
 
 type LibraryRequestGameLocation struct {
-	PlatformID   int64        `json:"platform_id"`
-	PlatformName string       `json:"platform_name"`
-	Type         string       `json:"type"`
-	Location     GameLocation `json:"location"`
+	PlatformID      int64        `json:"platform_id"`
+	PlatformName    string       `json:"platform_name"`
+	Type            string       `json:"type"`
+	Location        GameLocation `json:"location"`
+	Condition       string       `json:"condition,omitempty"`
+	HasOriginalCase *bool        `json:"has_original_case,omitempty"`
+	HasManual       *bool        `json:"has_manual,omitempty"`
+	AcquiredDate    *int64       `json:"acquired_date,omitempty"` // Unix timestamp
+	Notes           string       `json:"notes,omitempty"`         // Private notes about this copy
+	// PurchaseIDs are one-time purchases ("one-16") that paid for this copy, only read on update.
+	// Omitting the field keeps the current links and an empty list clears them.
+	PurchaseIDs     []string     `json:"purchase_ids,omitempty"`
 }
 
 type GameLocation struct {
 	SublocationID     string  `json:"sublocation_id,omitempty"`
 	DigitalLocationID string  `json:"digital_location_id,omitempty"`
-}
\ No newline at end of file
+}
+// LibraryBFFFilters narrows the library BFF response, zero values mean no filtering
+type LibraryBFFFilters struct {
+	Conditions      []string
+	HasOriginalCase *bool
+	HasManual       *bool
+	PlayStatuses    []string
+	Favorite        *bool
+}
+
+// LibraryQueryRequest is the raw query string of GET /library, validated by the service
+type LibraryQueryRequest struct {
+	Cursor      string
+	Limit       int
+	SortBy      string
+	SortOrder   string
+	PlatformIDs []int64
+	GenreIDs    []int64
+	ThemeIDs    []int64
+	GameType    string
+	LocationID  string
+	Favorite    *bool
+	TagIDs      []string
+}
+
+// LibrarySearchRequest is the raw query string of GET /library/search, validated by the service
+type LibrarySearchRequest struct {
+	Query       string
+	Limit       int
+	PlatformIDs []int64
+	LocationID  string
+}
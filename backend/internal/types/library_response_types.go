@@ -84,6 +84,7 @@ type LibraryGamesByPlatformAndLocationItemFINAL struct {
 	ID                 int64     `json:"id"`
 	PlatformID         int64     `json:"platform_id"`
 	PlatformName       string    `json:"platform_name"`
+	PlatformGroup      string    `json:"platform_group"`
 	IsPC               bool      `json:"is_pc"`
 	IsMobile           bool      `json:"is_mobile"`
 	DateAdded          int64     `json:"date_added"`
@@ -94,6 +95,16 @@ type LibraryGamesByPlatformAndLocationItemFINAL struct {
 	SublocationID      string    `json:"sublocation_id"`
 	SublocationName    string    `json:"sublocation_name"`
 	SublocationType    string    `json:"sublocation_type"`
+	Condition          string    `json:"condition,omitempty"`
+	HasOriginalCase    *bool     `json:"has_original_case,omitempty"`
+	HasManual          *bool     `json:"has_manual,omitempty"`
+	AcquiredDate       int64     `json:"acquired_date,omitempty"`
+	UserGameID         int64     `json:"user_game_id"`
+	CostBasis          *float64  `json:"cost_basis,omitempty"`
+	PurchaseIDs        []string  `json:"purchase_ids,omitempty"`
+	// IsOnLoan is set while the copy is lent out, it stays in its sublocation
+	IsOnLoan           bool      `json:"is_on_loan"`
+	CopyNotes          string    `json:"copy_notes,omitempty"`
 }
 
 // LibraryGameItemBFFResponseFINAL represents a game item in the BFF response
@@ -105,6 +116,8 @@ type LibraryGameItemBFFResponseFINAL struct {
 	GameTypeNormalizedText string                                  `json:"game_type_normalized_text"`
 	IsFavorite            bool                                     `json:"is_favorite"`
 	GamesByPlatformAndLocation []LibraryGamesByPlatformAndLocationItemFINAL `json:"games_by_platform_and_location"`
+	// TotalCostBasis is only set on single game responses, when a purchase is linked to a copy
+	TotalCostBasis        *float64                                 `json:"total_cost_basis,omitempty"`
 }
 
 // LibraryBFFResponseFINAL represents the final BFF response structure
@@ -136,8 +149,15 @@ type GameLocationDBResponse struct {
 
 // -- REFACTORED LIBRARY RESPONSE TYPES, TO LEGACY TYPES ABOVE WHEN COMPLETE --
 type PlatformVersionResponse struct {
-		PlatformName string `json:"platformName"`
-    PlatformId   int64  `json:"platformId"`
+		PlatformName    string `json:"platformName"`
+    PlatformId      int64  `json:"platformId"`
+    PlatformGroup   string `json:"platformGroup"`
+    Condition       string `json:"condition,omitempty"`
+    HasOriginalCase *bool  `json:"hasOriginalCase,omitempty"`
+    HasManual       *bool  `json:"hasManual,omitempty"`
+    AcquiredDate    int64  `json:"acquiredDate,omitempty"`
+    IsOnLoan        bool   `json:"isOnLoan,omitempty"`
+    CopyNotes       string `json:"copyNotes,omitempty"`
 }
 
 type LibraryBFFSinglePhysicalLocationResponse struct {
@@ -166,13 +186,94 @@ type SingleLibraryGameBFFResponse struct {
 	GenreNames            []string                                        `json:"genreNames"`
 	GameType              GameTypeResponse                                `json:"gameType"`
 	Favorite              bool                                            `json:"favorite"`
+	PlayStatus            string                                          `json:"playStatus"`
+	StartedAt             int64                                           `json:"startedAt,omitempty"`
+	FinishedAt            int64                                           `json:"finishedAt,omitempty"`
+	CompletionNote        string                                          `json:"completionNote,omitempty"`
+	PersonalRating        *int                                            `json:"personalRating,omitempty"`
+	Review                string                                          `json:"review,omitempty"`
+	PrivateNotes          string                                          `json:"privateNotes,omitempty"`
 	TotalPhysicalVersions int                                             `json:"totalPhysicalVersions"`
 	TotalDigitalVersions  int                                             `json:"totalDigitalVersions"`
 	PhysicalLocations     []LibraryBFFSinglePhysicalLocationResponse      `json:"physicalLocations"`
 	DigitalLocations      []LibraryBFFSingleDigitalLocationResponse       `json:"digitalLocations"`
 }
 
+type LibraryGamePlayStatusResponse struct {
+	GameID         int64  `json:"gameId"`
+	PlayStatus     string `json:"playStatus"`
+	StartedAt      int64  `json:"startedAt,omitempty"`
+	FinishedAt     int64  `json:"finishedAt,omitempty"`
+	CompletionNote string `json:"completionNote,omitempty"`
+}
+
+type LibraryGameReviewResponse struct {
+	GameID         int64  `json:"gameId"`
+	PersonalRating *int   `json:"personalRating,omitempty"`
+	Review         string `json:"review,omitempty"`
+	PrivateNotes   string `json:"privateNotes,omitempty"`
+}
+
+type LibraryGameFavoriteResponse struct {
+	GameID   int64 `json:"gameId"`
+	Favorite bool  `json:"favorite"`
+}
+
 type LibraryBFFRefactoredResponse struct {
 	LibraryItems  []SingleLibraryGameBFFResponse `json:"libraryItems"`
   RecentlyAdded []SingleLibraryGameBFFResponse `json:"recentlyAdded"`
-}
\ No newline at end of file
+}
+// -- PAGINATED LIBRARY QUERY RESPONSE --
+type LibraryGameListItemResponse struct {
+	ID                    int64    `json:"id"`
+	Name                  string   `json:"name"`
+	CoverURL              string   `json:"coverUrl"`
+	FirstReleaseDate      int64    `json:"firstReleaseDate"`
+	Rating                float64  `json:"rating"`
+	GenreNames            []string `json:"genreNames"`
+	Favorite              bool     `json:"favorite"`
+	PlayStatus            string   `json:"playStatus"`
+	PersonalRating        *int     `json:"personalRating,omitempty"`
+	DateAdded             int64    `json:"dateAdded"`
+	TotalPhysicalVersions int      `json:"totalPhysicalVersions"`
+	TotalDigitalVersions  int      `json:"totalDigitalVersions"`
+}
+
+type LibraryQueryResponse struct {
+	Items      []LibraryGameListItemResponse `json:"items"`
+	NextCursor string                        `json:"nextCursor,omitempty"`
+	HasMore    bool                          `json:"hasMore"`
+	Limit      int                           `json:"limit"`
+}
+
+// -- LIBRARY SEARCH RESPONSE --
+// Highlights are HTML escaped with matched words wrapped in <mark> tags
+type LibrarySearchResultResponse struct {
+	ID                    int64    `json:"id"`
+	Name                  string   `json:"name"`
+	CoverURL              string   `json:"coverUrl"`
+	FirstReleaseDate      int64    `json:"firstReleaseDate"`
+	Rating                float64  `json:"rating"`
+	Favorite              bool     `json:"favorite"`
+	PlayStatus            string   `json:"playStatus"`
+	PlatformNames         []string `json:"platformNames"`
+	TotalPhysicalVersions int      `json:"totalPhysicalVersions"`
+	TotalDigitalVersions  int      `json:"totalDigitalVersions"`
+	NameHighlight         string   `json:"nameHighlight"`
+	SummaryHighlight      string   `json:"summaryHighlight,omitempty"`
+	MatchType             string   `json:"matchType"`
+	Score                 float64  `json:"score"`
+}
+
+type LibrarySearchResponse struct {
+	Query string                        `json:"query"`
+	Items []LibrarySearchResultResponse `json:"items"`
+	Limit int                           `json:"limit"`
+}
+
+// LibraryGameTermResponse is a genre or theme in the user's library, its ID works as a genre_id or theme_id filter
+type LibraryGameTermResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	GameCount int    `json:"game_count"`
+}
@@ -0,0 +1,14 @@
+package types
+
+type CreateLoanRequest struct {
+	UserGameID        int64  `json:"userGameId"`
+	BorrowerName      string `json:"borrowerName"`
+	BorrowerContact   string `json:"borrowerContact,omitempty"`
+	LentAt            *int64 `json:"lentAt,omitempty"` // Unix timestamp, defaults to now
+	DueAt             *int64 `json:"dueAt,omitempty"`  // Unix timestamp
+	RemindWhenOverdue bool   `json:"remindWhenOverdue"`
+}
+
+type ReturnLoanRequest struct {
+	ReturnedAt *int64 `json:"returnedAt,omitempty"` // Unix timestamp, defaults to now
+}
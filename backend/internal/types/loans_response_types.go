@@ -0,0 +1,19 @@
+package types
+
+type LoanResponse struct {
+	ID                string `json:"id"`
+	UserGameID        int64  `json:"userGameId"`
+	GameID            int64  `json:"gameId"`
+	GameName          string `json:"gameName"`
+	PlatformName      string `json:"platformName"`
+	SublocationName   string `json:"sublocationName,omitempty"`
+	BorrowerName      string `json:"borrowerName"`
+	BorrowerContact   string `json:"borrowerContact,omitempty"`
+	LentAt            int64  `json:"lentAt"`
+	DueAt             int64  `json:"dueAt,omitempty"`
+	ReturnedAt        int64  `json:"returnedAt,omitempty"`
+	IsOverdue         bool   `json:"isOverdue"`
+	DaysOverdue       int    `json:"daysOverdue,omitempty"`
+	RemindWhenOverdue bool   `json:"remindWhenOverdue"`
+	ReminderSentAt    int64  `json:"reminderSentAt,omitempty"`
+}
@@ -10,4 +10,7 @@ type SpendTrackingRequest struct {
 	DigitalLocationID     *string        `json:"digital_location_id,omitempty"`
 	IsWishlisted          *bool          `json:"is_wishlisted,omitempty"`
 	IsDigital             *bool          `json:"is_digital,omitempty"`
-}
\ No newline at end of file
+	// UserGameIDs are the library copies the purchase paid for.
+	// On update, omitting the field keeps the current links and an empty list clears them.
+	UserGameIDs           []int64        `json:"user_game_ids,omitempty"`
+}
@@ -0,0 +1,60 @@
+package types
+
+// TrashResponse lists everything in the user's trash.
+// Each item is purged for good at its purgeAt, retentionDays after it was deleted.
+type TrashResponse struct {
+	Games             []TrashedGameResponse             `json:"games"`
+	PhysicalLocations []TrashedPhysicalLocationResponse `json:"physicalLocations"`
+	Sublocations      []TrashedSublocationResponse      `json:"sublocations"`
+	Purchases         []TrashedPurchaseResponse         `json:"purchases"`
+	RetentionDays     int                               `json:"retentionDays"`
+}
+
+type TrashedGameResponse struct {
+	GameID        int64    `json:"gameId"`
+	GameName      string   `json:"gameName"`
+	CoverURL      string   `json:"coverUrl,omitempty"`
+	CopyCount     int      `json:"copyCount"`
+	PlatformNames []string `json:"platformNames"`
+	DeletedAt     int64    `json:"deletedAt"`
+	PurgeAt       int64    `json:"purgeAt"`
+}
+
+type TrashedPhysicalLocationResponse struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	LocationType     string `json:"locationType"`
+	SublocationCount int    `json:"sublocationCount"`
+	DeletedAt        int64  `json:"deletedAt"`
+	PurgeAt          int64  `json:"purgeAt"`
+}
+
+type TrashedSublocationResponse struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	LocationType         string `json:"locationType"`
+	PhysicalLocationID   string `json:"physicalLocationId"`
+	PhysicalLocationName string `json:"physicalLocationName"`
+	DeletedAt            int64  `json:"deletedAt"`
+	PurgeAt              int64  `json:"purgeAt"`
+}
+
+// TrashedPurchaseResponse uses the same "one-<id>" IDs as spend tracking
+type TrashedPurchaseResponse struct {
+	ID           string  `json:"id"`
+	Title        string  `json:"title"`
+	Amount       float64 `json:"amount"`
+	PurchaseDate int64   `json:"purchaseDate"`
+	DeletedAt    int64   `json:"deletedAt"`
+	PurgeAt      int64   `json:"purgeAt"`
+}
+
+// TrashRestoreResponse counts what came back with a restored item
+type TrashRestoreResponse struct {
+	ItemType                  string `json:"itemType"`
+	ItemID                    string `json:"itemId"`
+	RestoredCopies            int64  `json:"restoredCopies"`
+	RestoredSublocations      int64  `json:"restoredSublocations"`
+	RestoredPhysicalLocations int64  `json:"restoredPhysicalLocations"`
+	RestoredPurchases         int64  `json:"restoredPurchases"`
+}
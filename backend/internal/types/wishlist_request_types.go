@@ -0,0 +1,49 @@
+package types
+
+// CreateWishlistItemRequest is the request body for adding a game to the wishlist
+type CreateWishlistItemRequest struct {
+	GameID               int64    `json:"game_id"`
+	GameName             string   `json:"game_name"`
+	GameCoverURL         string   `json:"game_cover_url"`
+	GameFirstReleaseDate int64    `json:"game_first_release_date"`
+	GameRating           float64  `json:"game_rating"`
+	PlatformID           int64    `json:"platform_id"`
+	ReleaseDate          *int64   `json:"release_date,omitempty"`
+	IsOnSale             bool     `json:"is_on_sale"`
+	CurrentPrice         *float64 `json:"current_price,omitempty"`
+	SalePrice            *float64 `json:"sale_price,omitempty"`
+	TargetPrice          *float64 `json:"target_price,omitempty"`
+	Priority             *int     `json:"priority,omitempty"`
+	MustHave             *bool    `json:"must_have,omitempty"`
+}
+
+// UpdateWishlistItemRequest is the request body for updating an existing wishlist entry.
+// Priority and MustHave keep their value when left out, prices are only set by the price checks.
+type UpdateWishlistItemRequest struct {
+	PlatformID  int64    `json:"platform_id"`
+	ReleaseDate *int64   `json:"release_date,omitempty"`
+	TargetPrice *float64 `json:"target_price,omitempty"`
+	Priority    *int     `json:"priority,omitempty"`
+	MustHave    *bool    `json:"must_have,omitempty"`
+}
+
+// UpdateWishlistSettingsRequest is the request body for changing wishlist settings, fields left out keep their value
+type UpdateWishlistSettingsRequest struct {
+	ReleaseReminderDays *int     `json:"release_reminder_days,omitempty"`
+	MonthlyBudget       *float64 `json:"monthly_budget,omitempty"`
+}
+
+// PurchaseWishlistItemRequest is the request body for marking a wishlisted game as purchased.
+// Set sublocation_id for a physical copy or digital_location_id for a digital one.
+type PurchaseWishlistItemRequest struct {
+	PlatformID         int64                    `json:"platform_id,omitempty"`
+	SublocationID      string                   `json:"sublocation_id,omitempty"`
+	DigitalLocationID  string                   `json:"digital_location_id,omitempty"`
+	Title              string                   `json:"title,omitempty"`
+	Amount             float64                  `json:"amount"`
+	PaymentMethod      string                   `json:"payment_method"`
+	PurchaseDate       *int64                   `json:"purchase_date,omitempty"`
+	SpendingCategoryID int                      `json:"spending_category_id,omitempty"`
+	GameGenres         []LibraryRequestGameTerm `json:"game_genres,omitempty"`
+	GameThemes         []LibraryRequestGameTerm `json:"game_themes,omitempty"`
+}
@@ -0,0 +1,118 @@
+package types
+
+// WishlistItemBFFResponse represents a single wishlist entry in the BFF response
+type WishlistItemBFFResponse struct {
+	ID               int64    `json:"id"`
+	GameID           int64    `json:"gameId"`
+	Name             string   `json:"name"`
+	CoverURL         string   `json:"coverUrl"`
+	FirstReleaseDate int64    `json:"firstReleaseDate"`
+	PlatformID       int64    `json:"platformId"`
+	PlatformName     string   `json:"platformName"`
+	ReleaseDate      *int64   `json:"releaseDate,omitempty"`
+	IsOnSale         bool     `json:"isOnSale"`
+	CurrentPrice     *float64 `json:"currentPrice,omitempty"`
+	SalePrice        *float64 `json:"salePrice,omitempty"`
+	TargetPrice      *float64 `json:"targetPrice,omitempty"`
+	Priority         int      `json:"priority"`
+	MustHave         bool     `json:"mustHave"`
+	LastPriceCheck   int64    `json:"lastPriceCheck,omitempty"`
+	CreatedAt        int64    `json:"createdAt"`
+	UpdatedAt        int64    `json:"updatedAt"`
+}
+
+// WishlistBFFResponse is the top-level wishlist response type
+type WishlistBFFResponse struct {
+	WishlistItems []WishlistItemBFFResponse `json:"wishlistItems"`
+	TotalItems    int                       `json:"totalItems"`
+	ItemsOnSale   int                       `json:"itemsOnSale"`
+}
+
+// WishlistPricePointResponse is one price check for a wishlisted game
+type WishlistPricePointResponse struct {
+	Provider     string   `json:"provider"`
+	RegularPrice float64  `json:"regularPrice"`
+	SalePrice    *float64 `json:"salePrice,omitempty"`
+	CheckedAt    int64    `json:"checkedAt"`
+}
+
+// WishlistPriceHistoryResponse lists the prices found for a wishlisted game, newest first
+type WishlistPriceHistoryResponse struct {
+	GameID      int64                        `json:"gameId"`
+	PlatformID  int64                        `json:"platformId"`
+	TargetPrice *float64                     `json:"targetPrice,omitempty"`
+	Prices      []WishlistPricePointResponse `json:"prices"`
+}
+
+// WishlistSettingsResponse holds the user's wishlist preferences
+type WishlistSettingsResponse struct {
+	ReleaseReminderDays int      `json:"releaseReminderDays"`
+	MonthlyBudget       *float64 `json:"monthlyBudget,omitempty"`
+}
+
+// WishlistUpcomingItemResponse is a wishlisted game on the release calendar
+type WishlistUpcomingItemResponse struct {
+	ID               int64  `json:"id"`
+	GameID           int64  `json:"gameId"`
+	Name             string `json:"name"`
+	CoverURL         string `json:"coverUrl"`
+	PlatformID       int64  `json:"platformId"`
+	PlatformName     string `json:"platformName"`
+	ReleaseDate      int64  `json:"releaseDate"`
+	DaysUntilRelease int    `json:"daysUntilRelease"`
+}
+
+// WishlistReleaseMonthResponse groups the release calendar by month, formatted as 2006-01
+type WishlistReleaseMonthResponse struct {
+	Month string                         `json:"month"`
+	Items []WishlistUpcomingItemResponse `json:"items"`
+}
+
+// WishlistUpcomingResponse is the release calendar for the next Days days
+type WishlistUpcomingResponse struct {
+	Days                int                            `json:"days"`
+	ReleaseReminderDays int                            `json:"releaseReminderDays"`
+	TotalItems          int                            `json:"totalItems"`
+	Months              []WishlistReleaseMonthResponse `json:"months"`
+}
+
+// WishlistPurchaseResponse describes the library copy and purchase a wishlist entry became
+type WishlistPurchaseResponse struct {
+	GameID       int64   `json:"gameId"`
+	UserGameID   int64   `json:"userGameId"`
+	PlatformID   int64   `json:"platformId"`
+	GameType     string  `json:"gameType"`
+	PurchaseID   string  `json:"purchaseId"`
+	Title        string  `json:"title"`
+	Amount       float64 `json:"amount"`
+	PurchaseDate int64   `json:"purchaseDate"`
+}
+
+// WishlistBudgetItemResponse is a wishlisted game suggested for this month, Price is what it costs today
+type WishlistBudgetItemResponse struct {
+	ID           int64   `json:"id"`
+	GameID       int64   `json:"gameId"`
+	Name         string  `json:"name"`
+	CoverURL     string  `json:"coverUrl"`
+	PlatformID   int64   `json:"platformId"`
+	PlatformName string  `json:"platformName"`
+	Priority     int     `json:"priority"`
+	MustHave     bool    `json:"mustHave"`
+	IsOnSale     bool    `json:"isOnSale"`
+	Price        float64 `json:"price"`
+}
+
+// WishlistBudgetResponse suggests what to buy from the wishlist with what's left of this month's budget.
+// AffordableNow lists every game that fits the remaining budget on its own,
+// ProjectedMonth the games that fit together, picked in priority order.
+type WishlistBudgetResponse struct {
+	Month              string                       `json:"month"`
+	MonthlyBudget      *float64                     `json:"monthlyBudget,omitempty"`
+	SpentThisMonth     float64                      `json:"spentThisMonth"`
+	RemainingBudget    float64                      `json:"remainingBudget"`
+	AffordableNow      []WishlistBudgetItemResponse `json:"affordableNow"`
+	ProjectedMonth     []WishlistBudgetItemResponse `json:"projectedMonth"`
+	ProjectedTotal     float64                      `json:"projectedTotal"`
+	ProjectedRemaining float64                      `json:"projectedRemaining"`
+	UnpricedItems      int                          `json:"unpricedItems"`
+}
@@ -2,27 +2,475 @@ package wishlist
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/lokeam/qko-beta/internal/appcontext"
 	"github.com/lokeam/qko-beta/internal/interfaces"
 	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
 )
 
 type GameWishlistService struct {
-	logger interfaces.Logger
+	dbAdapter                 interfaces.WishlistDbAdapter
+	cacheWrapper              interfaces.WishlistCacheWrapper
+	dashboardCacheWrapper     interfaces.DashboardCacheWrapper
+	libraryCacheWrapper       interfaces.LibraryCacheWrapper
+	spendTrackingCacheWrapper interfaces.SpendTrackingCacheWrapper
+	spendingCalculator        wishlistSpendingCalculator
+	validator                 interfaces.WishlistValidator
+	logger                    interfaces.Logger
+	now                       func() time.Time
 }
 
 type WishlistService interface {
-	GetWishlistItems(ctx context.Context, userID string) ([]models.GameToSave, error)
+	GetWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, error)
+	GetWishlistBFFResponse(ctx context.Context, userID string) (types.WishlistBFFResponse, error)
+	GetSingleWishlistItem(ctx context.Context, userID string, gameID int64) (types.WishlistItemBFFResponse, error)
+	IsGameInWishlist(ctx context.Context, userID string, gameID int64) (bool, error)
+
+	CreateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	UpdateWishlistItem(ctx context.Context, userID string, item models.WishlistItemToSave) (types.WishlistItemBFFResponse, error)
+	DeleteWishlistItem(ctx context.Context, userID string, gameID int64) error
+
+	GetPriceHistory(ctx context.Context, userID string, gameID int64) (types.WishlistPriceHistoryResponse, error)
+	GetUpcomingReleases(ctx context.Context, userID string, days int) (types.WishlistUpcomingResponse, error)
+	GetBudgetSuggestions(ctx context.Context, userID string) (types.WishlistBudgetResponse, error)
+
+	GetWishlistSettings(ctx context.Context, userID string) (types.WishlistSettingsResponse, error)
+	UpdateWishlistSettings(ctx context.Context, userID string, request types.UpdateWishlistSettingsRequest) (types.WishlistSettingsResponse, error)
+
+	PurchaseWishlistItem(ctx context.Context, userID string, purchase models.WishlistPurchaseToSave) (types.WishlistPurchaseResponse, error)
 }
 
-func NewGameWishlistService(appContext *appcontext.AppContext) (*GameWishlistService, error) {
+func NewGameWishlistService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.WishlistDbAdapter,
+	cacheWrapper interfaces.WishlistCacheWrapper,
+	dashboardCacheWrapper interfaces.DashboardCacheWrapper,
+	libraryCacheWrapper interfaces.LibraryCacheWrapper,
+	spendTrackingCacheWrapper interfaces.SpendTrackingCacheWrapper,
+	spendingCalculator wishlistSpendingCalculator,
+) (*GameWishlistService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if cacheWrapper == nil {
+		return nil, fmt.Errorf("cacheWrapper is required")
+	}
+	if dashboardCacheWrapper == nil {
+		return nil, fmt.Errorf("dashboardCacheWrapper is required")
+	}
+	if libraryCacheWrapper == nil {
+		return nil, fmt.Errorf("libraryCacheWrapper is required")
+	}
+	if spendTrackingCacheWrapper == nil {
+		return nil, fmt.Errorf("spendTrackingCacheWrapper is required")
+	}
+	if spendingCalculator == nil {
+		return nil, fmt.Errorf("spendingCalculator is required")
+	}
+
 	return &GameWishlistService{
-		logger: appContext.Logger,
+		dbAdapter:                 dbAdapter,
+		cacheWrapper:              cacheWrapper,
+		dashboardCacheWrapper:     dashboardCacheWrapper,
+		libraryCacheWrapper:       libraryCacheWrapper,
+		spendTrackingCacheWrapper: spendTrackingCacheWrapper,
+		spendingCalculator:        spendingCalculator,
+		validator:                 NewWishlistValidator(),
+		logger:                    appContext.Logger,
+		now:                       time.Now,
 	}, nil
 }
 
-func (w *GameWishlistService) GetWishlistItems(ctx context.Context, userID string) ([]models.GameToSave, error) {
-	// TODO: Implement, return an empty list for testing
-	return []models.GameToSave{}, nil
+// GET
+// GetWishlistItems returns every wishlist entry for a user, first checks cache then db as fallback
+func (ws *GameWishlistService) GetWishlistItems(
+	ctx context.Context,
+	userID string,
+) ([]models.WishlistItemDB, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	// Try to get from cache first
+	cachedItems, found, err := ws.cacheWrapper.GetCachedWishlistItems(ctx, userID)
+	if err == nil && found {
+		ws.logger.Debug("Cache hit for user wishlist", map[string]any{
+			"userID": userID,
+		})
+		return cachedItems, nil
+	}
+
+	// Cache miss, get from db
+	items, err := ws.dbAdapter.GetWishlistItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []models.WishlistItemDB{}
+	}
+
+	// Cache the results
+	if err := ws.cacheWrapper.SetCachedWishlistItems(ctx, userID, items); err != nil {
+		ws.logger.Error("Failed to cache user wishlist", map[string]any{"error": err})
+	}
+
+	return items, nil
+}
+
+// GetWishlistBFFResponse returns the wishlist page payload for a user
+func (ws *GameWishlistService) GetWishlistBFFResponse(
+	ctx context.Context,
+	userID string,
+) (types.WishlistBFFResponse, error) {
+	items, err := ws.GetWishlistItems(ctx, userID)
+	if err != nil {
+		return types.WishlistBFFResponse{}, err
+	}
+
+	return TransformWishlistItemsToBFFResponse(items), nil
+}
+
+func (ws *GameWishlistService) GetSingleWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (types.WishlistItemBFFResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+	if err := ws.validator.ValidateGameID(gameID); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+
+	item, err := ws.dbAdapter.GetSingleWishlistItem(ctx, userID, gameID)
+	if err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+
+	return TransformWishlistItemDBToResponse(item), nil
+}
+
+func (ws *GameWishlistService) IsGameInWishlist(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (bool, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return false, err
+	}
+	if err := ws.validator.ValidateGameID(gameID); err != nil {
+		return false, err
+	}
+
+	return ws.dbAdapter.IsGameInWishlist(ctx, userID, gameID)
+}
+
+// GetPriceHistory returns the prices found for a wishlisted game on the platform it's wishlisted for
+func (ws *GameWishlistService) GetPriceHistory(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (types.WishlistPriceHistoryResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistPriceHistoryResponse{}, err
+	}
+	if err := ws.validator.ValidateGameID(gameID); err != nil {
+		return types.WishlistPriceHistoryResponse{}, err
+	}
+
+	item, err := ws.dbAdapter.GetSingleWishlistItem(ctx, userID, gameID)
+	if err != nil {
+		return types.WishlistPriceHistoryResponse{}, err
+	}
+
+	history, err := ws.dbAdapter.GetPriceHistory(ctx, userID, gameID)
+	if err != nil {
+		return types.WishlistPriceHistoryResponse{}, err
+	}
+
+	return TransformPriceHistoryToResponse(item, history), nil
+}
+
+// GetUpcomingReleases returns the release calendar of wishlisted games coming out in the next days days.
+// A days of 0 uses DefaultUpcomingDays.
+func (ws *GameWishlistService) GetUpcomingReleases(
+	ctx context.Context,
+	userID string,
+	days int,
+) (types.WishlistUpcomingResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistUpcomingResponse{}, err
+	}
+	if days == 0 {
+		days = DefaultUpcomingDays
+	}
+	if err := ws.validator.ValidateUpcomingDays(days); err != nil {
+		return types.WishlistUpcomingResponse{}, err
+	}
+
+	settings, err := ws.dbAdapter.GetWishlistSettings(ctx, userID)
+	if err != nil {
+		return types.WishlistUpcomingResponse{}, err
+	}
+
+	// Games releasing earlier today are still upcoming
+	today := ws.now().UTC().Truncate(24 * time.Hour)
+	releases, err := ws.dbAdapter.GetUpcomingReleases(
+		ctx,
+		userID,
+		today.Unix(),
+		today.AddDate(0, 0, days).Unix()-1,
+	)
+	if err != nil {
+		return types.WishlistUpcomingResponse{}, err
+	}
+
+	return TransformUpcomingReleasesToResponse(releases, today, days, settings), nil
+}
+
+// GetBudgetSuggestions suggests wishlisted games that fit what's left of this month's budget
+// after the spending spend tracking already has for the month.
+func (ws *GameWishlistService) GetBudgetSuggestions(
+	ctx context.Context,
+	userID string,
+) (types.WishlistBudgetResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistBudgetResponse{}, err
+	}
+
+	settings, err := ws.dbAdapter.GetWishlistSettings(ctx, userID)
+	if err != nil {
+		return types.WishlistBudgetResponse{}, err
+	}
+
+	items, err := ws.GetWishlistItems(ctx, userID)
+	if err != nil {
+		return types.WishlistBudgetResponse{}, err
+	}
+
+	now := ws.now().UTC()
+	spending, err := ws.spendingCalculator.CalculateCurrentMonthAggregation(userID, now)
+	if err != nil {
+		return types.WishlistBudgetResponse{}, fmt.Errorf("error calculating this month's spending: %w", err)
+	}
+
+	return PlanWishlistBudget(items, settings.MonthlyBudget, spending.TotalMonthlySpending, now), nil
+}
+
+// SETTINGS
+func (ws *GameWishlistService) GetWishlistSettings(
+	ctx context.Context,
+	userID string,
+) (types.WishlistSettingsResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistSettingsResponse{}, err
+	}
+
+	settings, err := ws.dbAdapter.GetWishlistSettings(ctx, userID)
+	if err != nil {
+		return types.WishlistSettingsResponse{}, err
+	}
+
+	return TransformWishlistSettingsToResponse(settings), nil
+}
+
+// UpdateWishlistSettings changes the settings included in the request and keeps the rest
+func (ws *GameWishlistService) UpdateWishlistSettings(
+	ctx context.Context,
+	userID string,
+	request types.UpdateWishlistSettingsRequest,
+) (types.WishlistSettingsResponse, error) {
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistSettingsResponse{}, err
+	}
+
+	settings, err := ws.dbAdapter.GetWishlistSettings(ctx, userID)
+	if err != nil {
+		return types.WishlistSettingsResponse{}, err
+	}
+
+	if request.ReleaseReminderDays != nil {
+		if err := ws.validator.ValidateReleaseReminderDays(*request.ReleaseReminderDays); err != nil {
+			return types.WishlistSettingsResponse{}, err
+		}
+		settings.ReleaseReminderDays = *request.ReleaseReminderDays
+	}
+
+	if request.MonthlyBudget != nil {
+		if err := ws.validator.ValidateMonthlyBudget(*request.MonthlyBudget); err != nil {
+			return types.WishlistSettingsResponse{}, err
+		}
+		settings.MonthlyBudget = request.MonthlyBudget
+	}
+
+	if err := ws.dbAdapter.UpdateWishlistSettings(ctx, userID, settings); err != nil {
+		return types.WishlistSettingsResponse{}, err
+	}
+
+	return TransformWishlistSettingsToResponse(settings), nil
+}
+
+// POST
+func (ws *GameWishlistService) CreateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) (types.WishlistItemBFFResponse, error) {
+	ws.logger.Info("GameWishlistService - CreateWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": item.GameID,
+	})
+
+	// Validate inputs
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+	if err := ws.validator.ValidateWishlistItem(item); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+	if item.GameName == "" {
+		return types.WishlistItemBFFResponse{}, fmt.Errorf("%w: game name is required", ErrValidationFailed)
+	}
+
+	// Add to db
+	if err := ws.dbAdapter.CreateWishlistItem(ctx, userID, item); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+
+	ws.invalidateCaches(ctx, userID)
+
+	return ws.GetSingleWishlistItem(ctx, userID, item.GameID)
+}
+
+// PUT
+func (ws *GameWishlistService) UpdateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) (types.WishlistItemBFFResponse, error) {
+	ws.logger.Info("GameWishlistService - UpdateWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": item.GameID,
+	})
+
+	// Validate inputs
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+	if err := ws.validator.ValidateWishlistItem(item); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+
+	// Update in db
+	if err := ws.dbAdapter.UpdateWishlistItem(ctx, userID, item); err != nil {
+		return types.WishlistItemBFFResponse{}, err
+	}
+
+	ws.invalidateCaches(ctx, userID)
+
+	return ws.GetSingleWishlistItem(ctx, userID, item.GameID)
+}
+
+// DELETE
+func (ws *GameWishlistService) DeleteWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) error {
+	// Validate inputs
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+	if err := ws.validator.ValidateGameID(gameID); err != nil {
+		return err
+	}
+
+	// Remove from db
+	if err := ws.dbAdapter.DeleteWishlistItem(ctx, userID, gameID); err != nil {
+		return err
+	}
+
+	ws.invalidateCaches(ctx, userID)
+
+	return nil
+}
+
+// PurchaseWishlistItem adds a wishlisted game to the library, logs what it cost and removes it from the wishlist.
+// Without a purchase date the purchase is dated now.
+func (ws *GameWishlistService) PurchaseWishlistItem(
+	ctx context.Context,
+	userID string,
+	purchase models.WishlistPurchaseToSave,
+) (types.WishlistPurchaseResponse, error) {
+	ws.logger.Info("GameWishlistService - PurchaseWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": purchase.GameID,
+	})
+
+	if purchase.PurchaseDate.IsZero() {
+		purchase.PurchaseDate = ws.now().UTC()
+	}
+
+	// Validate inputs
+	if err := ws.validator.ValidateUserID(userID); err != nil {
+		return types.WishlistPurchaseResponse{}, err
+	}
+	if err := ws.validator.ValidatePurchase(purchase); err != nil {
+		return types.WishlistPurchaseResponse{}, err
+	}
+
+	result, err := ws.dbAdapter.PurchaseWishlistItem(ctx, userID, purchase)
+	if err != nil {
+		return types.WishlistPurchaseResponse{}, err
+	}
+
+	ws.invalidateCaches(ctx, userID)
+	ws.invalidatePurchaseCaches(ctx, userID, purchase.GameID)
+
+	return TransformWishlistPurchaseToResponse(purchase, result), nil
+}
+
+// invalidateCaches clears the wishlist and dashboard caches after a write
+func (ws *GameWishlistService) invalidateCaches(ctx context.Context, userID string) {
+	// Invalidate wishlist cache
+	if err := ws.cacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ws.logger.Error("Failed to invalidate wishlist cache", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+
+	// Invalidate dashboard cache to refresh statistics
+	if err := ws.dashboardCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ws.logger.Error("Failed to invalidate dashboard cache after wishlist change", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+}
+
+// invalidatePurchaseCaches clears the library and spend tracking caches after a wishlist entry is purchased
+func (ws *GameWishlistService) invalidatePurchaseCaches(ctx context.Context, userID string, gameID int64) {
+	if err := ws.libraryCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ws.logger.Error("Failed to invalidate library cache after wishlist purchase", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+	if err := ws.libraryCacheWrapper.InvalidateGameCache(ctx, userID, gameID); err != nil {
+		ws.logger.Error("Failed to invalidate library game cache after wishlist purchase", map[string]any{
+			"error":  err,
+			"userID": userID,
+			"gameID": gameID,
+		})
+	}
+	if err := ws.spendTrackingCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ws.logger.Error("Failed to invalidate spend tracking cache after wishlist purchase", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
 }
@@ -0,0 +1,134 @@
+package wishlist
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+const (
+	// MinWishlistPriority is the most wanted priority
+	MinWishlistPriority = 1
+
+	// MaxWishlistPriority is the least wanted priority
+	MaxWishlistPriority = 5
+
+	// DefaultWishlistPriority is given to entries added without a priority
+	DefaultWishlistPriority = 3
+
+	// MaxMonthlyBudget is the largest budget wishlist_settings.monthly_budget holds
+	MaxMonthlyBudget = 99999999.99
+)
+
+// wishlistSpendingCalculator is the part of the spend tracking calculator the budget planner reads this month's spending from
+type wishlistSpendingCalculator interface {
+	CalculateCurrentMonthAggregation(userID string, targetMonth time.Time) (types.SpendTrackingCalculatorCurrentMonthData, error)
+}
+
+// PlanWishlistBudget suggests wishlist games for what's left of the month's budget.
+// Games without a price or releasing after this month are left out, games are picked
+// must-haves first, then by priority, then cheapest first.
+func PlanWishlistBudget(
+	items []models.WishlistItemDB,
+	monthlyBudget *float64,
+	spentThisMonth float64,
+	now time.Time,
+) types.WishlistBudgetResponse {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0).Unix()
+
+	response := types.WishlistBudgetResponse{
+		Month:          monthStart.Format("2006-01"),
+		MonthlyBudget:  monthlyBudget,
+		SpentThisMonth: roundToCents(spentThisMonth),
+		AffordableNow:  make([]types.WishlistBudgetItemResponse, 0),
+		ProjectedMonth: make([]types.WishlistBudgetItemResponse, 0),
+	}
+
+	candidates := make([]types.WishlistBudgetItemResponse, 0, len(items))
+	for _, item := range items {
+		price, ok := wishlistItemPrice(item)
+		if !ok {
+			response.UnpricedItems++
+			continue
+		}
+		if releaseDate := wishlistItemReleaseDate(item); releaseDate >= monthEnd {
+			continue
+		}
+
+		candidates = append(candidates, types.WishlistBudgetItemResponse{
+			ID:           item.ID,
+			GameID:       item.GameID,
+			Name:         item.GameName,
+			CoverURL:     item.GameCoverURL,
+			PlatformID:   item.PlatformID,
+			PlatformName: item.PlatformName,
+			Priority:     item.Priority,
+			MustHave:     item.MustHave,
+			IsOnSale:     item.IsOnSale,
+			Price:        price,
+		})
+	}
+
+	// Without a budget there is nothing to plan against
+	if monthlyBudget == nil {
+		return response
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].MustHave != candidates[j].MustHave {
+			return candidates[i].MustHave
+		}
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority < candidates[j].Priority
+		}
+		return candidates[i].Price < candidates[j].Price
+	})
+
+	remaining := roundToCents(math.Max(*monthlyBudget-spentThisMonth, 0))
+	response.RemainingBudget = remaining
+
+	projected := 0.0
+	for _, candidate := range candidates {
+		if candidate.Price > remaining {
+			continue
+		}
+		response.AffordableNow = append(response.AffordableNow, candidate)
+
+		if roundToCents(projected+candidate.Price) <= remaining {
+			projected = roundToCents(projected + candidate.Price)
+			response.ProjectedMonth = append(response.ProjectedMonth, candidate)
+		}
+	}
+
+	response.ProjectedTotal = projected
+	response.ProjectedRemaining = roundToCents(remaining - projected)
+
+	return response
+}
+
+// wishlistItemPrice is what the game costs today, the sale price while it's on sale
+func wishlistItemPrice(item models.WishlistItemDB) (float64, bool) {
+	if item.IsOnSale && item.SalePrice != nil {
+		return *item.SalePrice, true
+	}
+	if item.CurrentPrice != nil {
+		return *item.CurrentPrice, true
+	}
+	return 0, false
+}
+
+// wishlistItemReleaseDate prefers the release date set on the entry over the game's first release date
+func wishlistItemReleaseDate(item models.WishlistItemDB) int64 {
+	if item.ReleaseDate != nil {
+		return *item.ReleaseDate
+	}
+	return item.GameFirstReleaseDate
+}
+
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
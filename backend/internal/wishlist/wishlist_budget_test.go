@@ -0,0 +1,118 @@
+package wishlist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Suggesting wishlist games that fit what's left of the month's budget
+
+	Scenarios:
+	- Games are ranked must-have first, then by priority, then by price
+	- Affordable games fit the remaining budget on their own, projected games fit together
+	- Unpriced games are counted, games releasing after this month are left out
+	- Spending over budget leaves nothing affordable
+	- Without a budget nothing is suggested
+*/
+
+func TestPlanWishlistBudget(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	price := func(amount float64) *float64 { return &amount }
+	nextMonth := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	items := []models.WishlistItemDB{
+		{ID: 1, GameID: 101, GameName: "Low priority", Priority: 5, CurrentPrice: price(10)},
+		{ID: 2, GameID: 102, GameName: "On sale", Priority: 2, IsOnSale: true, CurrentPrice: price(60), SalePrice: price(30)},
+		{ID: 3, GameID: 103, GameName: "Must have", Priority: 4, MustHave: true, CurrentPrice: price(40)},
+		{ID: 4, GameID: 104, GameName: "Too expensive", Priority: 1, CurrentPrice: price(80)},
+		{ID: 5, GameID: 105, GameName: "No price", Priority: 1},
+		{ID: 6, GameID: 106, GameName: "Not out yet", Priority: 1, CurrentPrice: price(5), ReleaseDate: &nextMonth},
+	}
+
+	/*
+		GIVEN a budget of 100 with 25 already spent this month
+		WHEN PlanWishlistBudget is called
+		THEN it should suggest the priced games already out, in rank order, within the remaining 75
+	*/
+	t.Run("Suggests games within the remaining budget", func(t *testing.T) {
+		// GIVEN
+		budget := 100.0
+
+		// WHEN
+		response := PlanWishlistBudget(items, &budget, 25, now)
+
+		// THEN
+		if response.Month != "2024-06" || response.RemainingBudget != 75 {
+			t.Errorf("Expected 75 left for 2024-06, got %.2f for %s", response.RemainingBudget, response.Month)
+		}
+		if response.UnpricedItems != 1 {
+			t.Errorf("Expected 1 unpriced item, got %d", response.UnpricedItems)
+		}
+
+		affordable := budgetGameIDs(response.AffordableNow)
+		if !reflect.DeepEqual(affordable, []int64{103, 102, 101}) {
+			t.Errorf("Expected affordable games [103 102 101], got %v", affordable)
+		}
+
+		projected := budgetGameIDs(response.ProjectedMonth)
+		if !reflect.DeepEqual(projected, []int64{103, 102}) {
+			t.Errorf("Expected projected games [103 102], got %v", projected)
+		}
+		if response.ProjectedTotal != 70 || response.ProjectedRemaining != 5 {
+			t.Errorf("Expected 70 projected with 5 left, got %.2f with %.2f left", response.ProjectedTotal, response.ProjectedRemaining)
+		}
+	})
+
+	/*
+		GIVEN spending this month already over the budget
+		WHEN PlanWishlistBudget is called
+		THEN nothing should be affordable
+	*/
+	t.Run("Over budget suggests nothing", func(t *testing.T) {
+		// GIVEN
+		budget := 50.0
+
+		// WHEN
+		response := PlanWishlistBudget(items, &budget, 80, now)
+
+		// THEN
+		if response.RemainingBudget != 0 {
+			t.Errorf("Expected no budget left, got %.2f", response.RemainingBudget)
+		}
+		if len(response.AffordableNow) != 0 || len(response.ProjectedMonth) != 0 {
+			t.Errorf("Expected no suggestions, got %+v and %+v", response.AffordableNow, response.ProjectedMonth)
+		}
+	})
+
+	/*
+		GIVEN a user who hasn't set a budget
+		WHEN PlanWishlistBudget is called
+		THEN it should return the month's spending without suggestions
+	*/
+	t.Run("No budget suggests nothing", func(t *testing.T) {
+		// WHEN
+		response := PlanWishlistBudget(items, nil, 25, now)
+
+		// THEN
+		if response.MonthlyBudget != nil || response.SpentThisMonth != 25 {
+			t.Errorf("Expected no budget and 25 spent, got %v and %.2f", response.MonthlyBudget, response.SpentThisMonth)
+		}
+		if len(response.AffordableNow) != 0 || len(response.ProjectedMonth) != 0 {
+			t.Errorf("Expected no suggestions, got %+v and %+v", response.AffordableNow, response.ProjectedMonth)
+		}
+	})
+}
+
+func budgetGameIDs(items []types.WishlistBudgetItemResponse) []int64 {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.GameID
+	}
+	return ids
+}
@@ -0,0 +1,63 @@
+package wishlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+type WishlistCacheAdapter struct {
+	cacheWrapper interfaces.CacheWrapper
+}
+
+// Constants for cache keys
+const (
+	wishlistCacheKey = "wishlist:%s"
+)
+
+func NewWishlistCacheAdapter(
+	cacheWrapper interfaces.CacheWrapper,
+) (interfaces.WishlistCacheWrapper, error) {
+	return &WishlistCacheAdapter{
+		cacheWrapper: cacheWrapper,
+	}, nil
+}
+
+func (wca *WishlistCacheAdapter) GetCachedWishlistItems(
+	ctx context.Context,
+	userID string,
+) ([]models.WishlistItemDB, bool, error) {
+	cacheKey := fmt.Sprintf(wishlistCacheKey, userID)
+
+	var items []models.WishlistItemDB
+	cacheHit, err := wca.cacheWrapper.GetCachedResults(ctx, cacheKey, &items)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if cacheHit {
+		return items, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (wca *WishlistCacheAdapter) SetCachedWishlistItems(
+	ctx context.Context,
+	userID string,
+	items []models.WishlistItemDB,
+) error {
+	cacheKey := fmt.Sprintf(wishlistCacheKey, userID)
+	return wca.cacheWrapper.SetCachedResults(ctx, cacheKey, items)
+}
+
+// Invalidates all wishlist cache entries for a specific user
+func (wca *WishlistCacheAdapter) InvalidateUserCache(
+	ctx context.Context,
+	userID string,
+) error {
+	cacheKey := fmt.Sprintf(wishlistCacheKey, userID)
+	return wca.cacheWrapper.DeleteCacheKey(ctx, cacheKey)
+}
@@ -0,0 +1,202 @@
+package wishlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+/*
+	Behavior:
+	- Getting cached wishlist items for a user
+	- Setting cached wishlist items for a user
+	- Invalidating cache for a user
+
+	Scenarios:
+	- GetCachedWishlistItems with cache hit
+	- GetCachedWishlistItems with cache miss
+	- GetCachedWishlistItems with cache error
+	- SetCachedWishlistItems success
+	- InvalidateUserCache success
+*/
+
+type MockCacheWrapper struct {
+	mock.Mock
+}
+
+// Mock implementations of CacheWrapper methods
+func (m *MockCacheWrapper) GetCachedResults(ctx context.Context, key string, result any) (bool, error) {
+	args := m.Called(ctx, key, result)
+	return args.Get(0).(bool), args.Error(1)
+}
+
+func (m *MockCacheWrapper) SetCachedResults(ctx context.Context, key string, result any) error {
+	args := m.Called(ctx, key, result)
+	return args.Error(0)
+}
+
+func (m *MockCacheWrapper) DeleteCacheKey(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockCacheWrapper) InvalidateCache(ctx context.Context, cacheKey string) error {
+	args := m.Called(ctx, cacheKey)
+	return args.Error(0)
+}
+
+func TestWishlistCacheAdapter(t *testing.T) {
+	// Setup test data
+	testUserID := "test-user-id"
+	testError := errors.New("cache error")
+
+	testItems := []models.WishlistItemDB{
+		{
+			ID:           1,
+			GameID:       123,
+			GameName:     "Test Game",
+			PlatformID:   48,
+			PlatformName: "PlayStation 4",
+		},
+	}
+
+	// Helper func to create a new adapter with a mock cache wrapper
+	createAdapter := func(mockCache *MockCacheWrapper) *WishlistCacheAdapter {
+		adapter, _ := NewWishlistCacheAdapter(mockCache)
+		return adapter.(*WishlistCacheAdapter)
+	}
+
+	// ------ GetCachedWishlistItems() ------
+	/*
+		GIVEN a cache wrapper that returns cached wishlist items
+		WHEN GetCachedWishlistItems is called
+		THEN it should return the cached items and report a hit
+	*/
+	t.Run("GetCachedWishlistItems with cache hit", func(t *testing.T) {
+		// GIVEN
+		mockCache := new(MockCacheWrapper)
+		mockCache.On("GetCachedResults", mock.Anything, "wishlist:test-user-id", mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+			result := args.Get(2).(*[]models.WishlistItemDB)
+			*result = testItems
+		})
+
+		adapter := createAdapter(mockCache)
+
+		// WHEN
+		items, found, err := adapter.GetCachedWishlistItems(context.Background(), testUserID)
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if !found {
+			t.Error("Expected cache hit")
+		}
+		if len(items) != 1 || items[0].GameID != 123 {
+			t.Errorf("Expected cached item with game ID 123, got %v", items)
+		}
+		mockCache.AssertExpectations(t)
+	})
+
+	/*
+		GIVEN a cache wrapper with no entry for the user
+		WHEN GetCachedWishlistItems is called
+		THEN it should report a miss without error
+	*/
+	t.Run("GetCachedWishlistItems with cache miss", func(t *testing.T) {
+		// GIVEN
+		mockCache := new(MockCacheWrapper)
+		mockCache.On("GetCachedResults", mock.Anything, "wishlist:test-user-id", mock.Anything).Return(false, nil)
+
+		adapter := createAdapter(mockCache)
+
+		// WHEN
+		items, found, err := adapter.GetCachedWishlistItems(context.Background(), testUserID)
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if found {
+			t.Error("Expected cache miss")
+		}
+		if items != nil {
+			t.Errorf("Expected nil items, got %v", items)
+		}
+		mockCache.AssertExpectations(t)
+	})
+
+	/*
+		GIVEN a cache wrapper that returns an error
+		WHEN GetCachedWishlistItems is called
+		THEN it should return the error
+	*/
+	t.Run("GetCachedWishlistItems with cache error", func(t *testing.T) {
+		// GIVEN
+		mockCache := new(MockCacheWrapper)
+		mockCache.On("GetCachedResults", mock.Anything, "wishlist:test-user-id", mock.Anything).Return(false, testError)
+
+		adapter := createAdapter(mockCache)
+
+		// WHEN
+		_, found, err := adapter.GetCachedWishlistItems(context.Background(), testUserID)
+
+		// THEN
+		if err != testError {
+			t.Errorf("Expected error %v, got %v", testError, err)
+		}
+		if found {
+			t.Error("Expected cache miss on error")
+		}
+		mockCache.AssertExpectations(t)
+	})
+
+	// ------ SetCachedWishlistItems() ------
+	/*
+		GIVEN a working cache wrapper
+		WHEN SetCachedWishlistItems is called
+		THEN it should store the items under the user's wishlist key
+	*/
+	t.Run("SetCachedWishlistItems success", func(t *testing.T) {
+		// GIVEN
+		mockCache := new(MockCacheWrapper)
+		mockCache.On("SetCachedResults", mock.Anything, "wishlist:test-user-id", testItems).Return(nil)
+
+		adapter := createAdapter(mockCache)
+
+		// WHEN
+		err := adapter.SetCachedWishlistItems(context.Background(), testUserID, testItems)
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		mockCache.AssertExpectations(t)
+	})
+
+	// ------ InvalidateUserCache() ------
+	/*
+		GIVEN a working cache wrapper
+		WHEN InvalidateUserCache is called
+		THEN it should delete the user's wishlist key
+	*/
+	t.Run("InvalidateUserCache success", func(t *testing.T) {
+		// GIVEN
+		mockCache := new(MockCacheWrapper)
+		mockCache.On("DeleteCacheKey", mock.Anything, "wishlist:test-user-id").Return(nil)
+
+		adapter := createAdapter(mockCache)
+
+		// WHEN
+		err := adapter.InvalidateUserCache(context.Background(), testUserID)
+
+		// THEN
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		mockCache.AssertExpectations(t)
+	})
+}
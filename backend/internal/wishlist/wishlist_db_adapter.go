@@ -0,0 +1,558 @@
+package wishlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/library"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/postgres"
+)
+
+type WishlistDbAdapter struct {
+	db     *sqlx.DB
+	logger interfaces.Logger
+}
+
+func NewWishlistDbAdapter(appContext *appcontext.AppContext) (*WishlistDbAdapter, error) {
+	appContext.Logger.Debug("Creating WishlistDbAdapter", map[string]any{"appContext": appContext})
+
+	// Use shared DB pool
+	db := appContext.DB
+
+	return &WishlistDbAdapter{
+		db:     db,
+		logger: appContext.Logger,
+	}, nil
+}
+
+// GET
+// GetWishlistItems retrieves every wishlist entry for a user, newest first
+func (wa *WishlistDbAdapter) GetWishlistItems(
+	ctx context.Context,
+	userID string,
+) ([]models.WishlistItemDB, error) {
+	wa.logger.Debug("WishlistDbAdapter - GetWishlistItems called", map[string]any{
+		"userID": userID,
+	})
+
+	var items []models.WishlistItemDB
+	if err := wa.db.SelectContext(ctx, &items, GetWishlistItemsQuery, userID); err != nil {
+		return nil, fmt.Errorf("error getting wishlist items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetSingleWishlistItem retrieves a single wishlist entry.
+// Returns ErrWishlistItemNotFound if the game isn't in the user's wishlist.
+func (wa *WishlistDbAdapter) GetSingleWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (models.WishlistItemDB, error) {
+	wa.logger.Debug("WishlistDbAdapter - GetSingleWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": gameID,
+	})
+
+	var item models.WishlistItemDB
+	err := wa.db.GetContext(ctx, &item, GetSingleWishlistItemQuery, userID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WishlistItemDB{}, ErrWishlistItemNotFound
+		}
+		return models.WishlistItemDB{}, fmt.Errorf("error getting wishlist item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (wa *WishlistDbAdapter) IsGameInWishlist(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) (bool, error) {
+	var exists bool
+	err := wa.db.QueryRowContext(ctx, CheckIfGameIsInWishlistQuery, userID, gameID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("error checking if game exists in wishlist: %w", err)
+	}
+
+	return exists, nil
+}
+
+// POST
+// CreateWishlistItem adds a game to the user's wishlist.
+// The game row is created first if this is the first time we've seen it.
+// Returns ErrDuplicateWishlistItem if the game is already wishlisted.
+func (wa *WishlistDbAdapter) CreateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) error {
+	wa.logger.Info("WishlistDbAdapter - CreateWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": item.GameID,
+	})
+
+	priority := DefaultWishlistPriority
+	if item.Priority != nil {
+		priority = *item.Priority
+	}
+
+	return postgres.WithTransaction(ctx, wa.db, wa.logger, func(tx *sqlx.Tx) error {
+		// STEP 1: Ensure game exists
+		_, err := tx.ExecContext(
+			ctx,
+			EnsureWishlistGameExistsQuery,
+			item.GameID,
+			item.GameName,
+			item.GameCoverURL,
+			item.GameFirstReleaseDate,
+			item.GameRating,
+		)
+		if err != nil {
+			return fmt.Errorf("error ensuring game exists: %w", err)
+		}
+
+		// STEP 2: Add wishlist entry
+		result, err := tx.ExecContext(
+			ctx,
+			CreateWishlistItemQuery,
+			userID,
+			item.GameID,
+			item.PlatformID,
+			item.ReleaseDate,
+			item.IsOnSale,
+			item.CurrentPrice,
+			item.SalePrice,
+			item.TargetPrice,
+			priority,
+			item.MustHave != nil && *item.MustHave,
+		)
+		if err != nil {
+			return fmt.Errorf("error creating wishlist item: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrDuplicateWishlistItem
+		}
+
+		return nil
+	})
+}
+
+// PUT
+// UpdateWishlistItem updates platform, release date, target price, priority and must-have for a wishlist entry.
+// Returns ErrWishlistItemNotFound if the game isn't in the user's wishlist.
+func (wa *WishlistDbAdapter) UpdateWishlistItem(
+	ctx context.Context,
+	userID string,
+	item models.WishlistItemToSave,
+) error {
+	wa.logger.Info("WishlistDbAdapter - UpdateWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": item.GameID,
+	})
+
+	result, err := wa.db.ExecContext(
+		ctx,
+		UpdateWishlistItemQuery,
+		userID,
+		item.GameID,
+		item.PlatformID,
+		item.ReleaseDate,
+		item.TargetPrice,
+		item.Priority,
+		item.MustHave,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating wishlist item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+// DELETE
+// DeleteWishlistItem removes a game from the user's wishlist.
+// Returns ErrWishlistItemNotFound if the game isn't in the user's wishlist.
+func (wa *WishlistDbAdapter) DeleteWishlistItem(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) error {
+	wa.logger.Info("WishlistDbAdapter - DeleteWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": gameID,
+	})
+
+	result, err := wa.db.ExecContext(ctx, DeleteWishlistItemQuery, userID, gameID)
+	if err != nil {
+		return fmt.Errorf("error deleting wishlist item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+// PRICE CHECKS
+// GetPriceQueries returns the game and platform pairs on anyone's wishlist that weren't checked since checkedBefore
+func (wa *WishlistDbAdapter) GetPriceQueries(
+	ctx context.Context,
+	checkedBefore time.Time,
+	limit int,
+) ([]models.PriceQuery, error) {
+	var queries []models.PriceQuery
+	if err := wa.db.SelectContext(ctx, &queries, GetPriceQueriesQuery, checkedBefore, limit); err != nil {
+		return nil, fmt.Errorf("error getting wishlist games to price: %w", err)
+	}
+
+	return queries, nil
+}
+
+// SaveGamePrices records the provider's prices in the price history and on every matching wishlist entry.
+// Checked games without a price only have their check time updated.
+// Returns the users whose wishlist changed.
+func (wa *WishlistDbAdapter) SaveGamePrices(
+	ctx context.Context,
+	provider string,
+	checked []models.PriceQuery,
+	prices []models.GamePrice,
+	checkedAt time.Time,
+) ([]string, error) {
+	wa.logger.Debug("WishlistDbAdapter - SaveGamePrices called", map[string]any{
+		"provider": provider,
+		"checked":  len(checked),
+		"prices":   len(prices),
+	})
+
+	gameIDs := make([]int64, len(prices))
+	platformIDs := make([]int64, len(prices))
+	regularPrices := make([]float64, len(prices))
+	salePrices := make([]sql.NullFloat64, len(prices))
+	for i, price := range prices {
+		gameIDs[i] = price.GameID
+		platformIDs[i] = price.PlatformID
+		regularPrices[i] = price.RegularPrice
+		if price.SalePrice != nil {
+			salePrices[i] = sql.NullFloat64{Float64: *price.SalePrice, Valid: true}
+		}
+	}
+
+	checkedGameIDs := make([]int64, len(checked))
+	checkedPlatformIDs := make([]int64, len(checked))
+	for i, query := range checked {
+		checkedGameIDs[i] = query.GameID
+		checkedPlatformIDs[i] = query.PlatformID
+	}
+
+	var userIDs []string
+	err := postgres.WithTransaction(ctx, wa.db, wa.logger, func(tx *sqlx.Tx) error {
+		// STEP 1: Record the check for everything asked about
+		_, err := tx.ExecContext(
+			ctx,
+			MarkPricesCheckedQuery,
+			pq.Array(checkedGameIDs),
+			pq.Array(checkedPlatformIDs),
+			checkedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error marking prices checked: %w", err)
+		}
+
+		if len(prices) == 0 {
+			return nil
+		}
+
+		// STEP 2: Add to the price history
+		_, err = tx.ExecContext(
+			ctx,
+			InsertGamePriceHistoryQuery,
+			provider,
+			pq.Array(gameIDs),
+			pq.Array(platformIDs),
+			pq.Array(regularPrices),
+			pq.Array(salePrices),
+			checkedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error saving price history: %w", err)
+		}
+
+		// STEP 3: Update wishlist entries
+		err = tx.SelectContext(
+			ctx,
+			&userIDs,
+			UpdateWishlistPricesQuery,
+			pq.Array(gameIDs),
+			pq.Array(platformIDs),
+			pq.Array(regularPrices),
+			pq.Array(salePrices),
+			checkedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error updating wishlist prices: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// GetDueSaleAlerts returns wishlist entries priced at or below their target that haven't been alerted yet
+func (wa *WishlistDbAdapter) GetDueSaleAlerts(ctx context.Context) ([]models.WishlistSaleAlertDB, error) {
+	var alerts []models.WishlistSaleAlertDB
+	if err := wa.db.SelectContext(ctx, &alerts, GetDueSaleAlertsQuery); err != nil {
+		return nil, fmt.Errorf("error getting due sale alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (wa *WishlistDbAdapter) MarkSaleAlertSent(ctx context.Context, wishlistID int64, sentAt time.Time) error {
+	if _, err := wa.db.ExecContext(ctx, MarkSaleAlertSentQuery, wishlistID, sentAt); err != nil {
+		return fmt.Errorf("error marking sale alert sent: %w", err)
+	}
+
+	return nil
+}
+
+// GetPriceHistory returns the most recent prices for a wishlisted game on the platform it's wishlisted for
+func (wa *WishlistDbAdapter) GetPriceHistory(
+	ctx context.Context,
+	userID string,
+	gameID int64,
+) ([]models.GamePriceHistoryDB, error) {
+	var history []models.GamePriceHistoryDB
+	if err := wa.db.SelectContext(ctx, &history, GetPriceHistoryQuery, userID, gameID); err != nil {
+		return nil, fmt.Errorf("error getting price history: %w", err)
+	}
+
+	return history, nil
+}
+
+// RELEASES
+// GetUpcomingReleases returns the user's wishlisted games releasing between from and to, as unix seconds
+func (wa *WishlistDbAdapter) GetUpcomingReleases(
+	ctx context.Context,
+	userID string,
+	from int64,
+	to int64,
+) ([]models.WishlistReleaseDB, error) {
+	var releases []models.WishlistReleaseDB
+	if err := wa.db.SelectContext(ctx, &releases, GetUpcomingReleasesQuery, userID, from, to); err != nil {
+		return nil, fmt.Errorf("error getting upcoming releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// GetDueReleaseReminders returns releases inside each user's reminder window that haven't been reminded for their current date
+func (wa *WishlistDbAdapter) GetDueReleaseReminders(
+	ctx context.Context,
+	now time.Time,
+	defaultDays int,
+) ([]models.WishlistReleaseReminderDB, error) {
+	var reminders []models.WishlistReleaseReminderDB
+	if err := wa.db.SelectContext(ctx, &reminders, GetDueReleaseRemindersQuery, now.Unix(), defaultDays); err != nil {
+		return nil, fmt.Errorf("error getting due release reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// MarkReleaseRemindersSent records the release date each wishlist entry was reminded for
+func (wa *WishlistDbAdapter) MarkReleaseRemindersSent(ctx context.Context, wishlistIDs []int64) error {
+	if _, err := wa.db.ExecContext(ctx, MarkReleaseRemindersSentQuery, pq.Array(wishlistIDs)); err != nil {
+		return fmt.Errorf("error marking release reminders sent: %w", err)
+	}
+
+	return nil
+}
+
+// SETTINGS
+// GetWishlistSettings returns the user's wishlist settings, or the defaults if they never saved any
+func (wa *WishlistDbAdapter) GetWishlistSettings(ctx context.Context, userID string) (models.WishlistSettingsDB, error) {
+	settings := models.WishlistSettingsDB{ReleaseReminderDays: DefaultReleaseReminderDays}
+	err := wa.db.GetContext(ctx, &settings, GetWishlistSettingsQuery, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.WishlistSettingsDB{}, fmt.Errorf("error getting wishlist settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func (wa *WishlistDbAdapter) UpdateWishlistSettings(
+	ctx context.Context,
+	userID string,
+	settings models.WishlistSettingsDB,
+) error {
+	wa.logger.Info("WishlistDbAdapter - UpdateWishlistSettings called", map[string]any{
+		"userID": userID,
+	})
+
+	if _, err := wa.db.ExecContext(ctx, UpsertWishlistSettingsQuery, userID, settings.ReleaseReminderDays, settings.MonthlyBudget); err != nil {
+		return fmt.Errorf("error updating wishlist settings: %w", err)
+	}
+
+	return nil
+}
+
+// PURCHASES
+// PurchaseWishlistItem moves a wishlisted game into the library in one transaction:
+// it adds a copy at the chosen location, logs the purchase against it and removes the wishlist entry.
+// Returns ErrWishlistItemNotFound if the game isn't in the user's wishlist,
+// ErrLocationNotFound if the location isn't one of the user's.
+func (wa *WishlistDbAdapter) PurchaseWishlistItem(
+	ctx context.Context,
+	userID string,
+	purchase models.WishlistPurchaseToSave,
+) (models.WishlistPurchaseResult, error) {
+	wa.logger.Info("WishlistDbAdapter - PurchaseWishlistItem called", map[string]any{
+		"userID": userID,
+		"gameID": purchase.GameID,
+	})
+
+	var result models.WishlistPurchaseResult
+	err := postgres.WithTransaction(ctx, wa.db, wa.logger, func(tx *sqlx.Tx) error {
+		// STEP 1: Lock the wishlist entry
+		var wishlistPlatformID int64
+		var gameName string
+		err := tx.QueryRowContext(ctx, LockWishlistItemForPurchaseQuery, userID, purchase.GameID).
+			Scan(&wishlistPlatformID, &gameName)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrWishlistItemNotFound
+			}
+			return fmt.Errorf("error getting wishlist item: %w", err)
+		}
+
+		result.PlatformID = purchase.PlatformID
+		if result.PlatformID == 0 {
+			result.PlatformID = wishlistPlatformID
+		}
+		result.Title = purchase.Title
+		if result.Title == "" {
+			result.Title = gameName
+		}
+		result.PurchaseDate = purchase.PurchaseDate
+
+		var platformExists bool
+		if err := tx.QueryRowContext(ctx, CheckPlatformExistsQuery, result.PlatformID).Scan(&platformExists); err != nil {
+			return fmt.Errorf("error checking platform: %w", err)
+		}
+		if !platformExists {
+			return fmt.Errorf("%w: platform %d not found", ErrValidationFailed, result.PlatformID)
+		}
+
+		// STEP 2: Add the copy the way the library does, after any copies the user already has on the platform
+		if err := library.SaveGameTerms(ctx, tx, purchase.GameID, purchase.GameGenres, purchase.GameThemes); err != nil {
+			return err
+		}
+		var copyNumber int
+		err = tx.QueryRowContext(ctx, GetNextCopyNumberQuery, userID, purchase.GameID, result.PlatformID, purchase.GameType()).
+			Scan(&copyNumber)
+		if err != nil {
+			return fmt.Errorf("error numbering library copy: %w", err)
+		}
+		err = tx.QueryRowContext(
+			ctx,
+			library.InsertUserGameCopyQuery,
+			userID,
+			purchase.GameID,
+			result.PlatformID,
+			purchase.GameType(),
+			"",
+			nil,
+			nil,
+			purchase.PurchaseDate,
+			"",
+			copyNumber,
+		).Scan(&result.UserGameID)
+		if err != nil {
+			return fmt.Errorf("error adding game to library: %w", err)
+		}
+
+		// STEP 3: Put it at its location
+		var location sql.Result
+		if purchase.GameType() == "digital" {
+			location, err = tx.ExecContext(ctx, InsertPurchasedDigitalLocationQuery, result.UserGameID, purchase.DigitalLocationID, userID)
+		} else {
+			location, err = tx.ExecContext(ctx, InsertPurchasedPhysicalLocationQuery, result.UserGameID, purchase.SublocationID, userID)
+		}
+		if err != nil {
+			return fmt.Errorf("error adding game location: %w", err)
+		}
+		rowsAffected, err := location.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrLocationNotFound
+		}
+
+		// STEP 4: Log the purchase and link it to the copy
+		err = tx.QueryRowContext(
+			ctx,
+			InsertWishlistPurchaseQuery,
+			userID,
+			result.Title,
+			purchase.Amount,
+			purchase.PurchaseDate,
+			purchase.PaymentMethod,
+			purchase.SpendingCategoryID,
+			purchase.GameType()+"_game",
+			purchase.DigitalLocationID,
+			purchase.GameType() == "digital",
+		).Scan(&result.PurchaseID)
+		if err != nil {
+			return fmt.Errorf("error creating purchase: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, LinkWishlistPurchaseGameQuery, result.PurchaseID, result.UserGameID); err != nil {
+			return fmt.Errorf("error linking purchase to library game: %w", err)
+		}
+
+		// STEP 5: Remove the wishlist entry
+		if _, err := tx.ExecContext(ctx, DeleteWishlistItemQuery, userID, purchase.GameID); err != nil {
+			return fmt.Errorf("error deleting wishlist item: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.WishlistPurchaseResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,375 @@
+package wishlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Saving checked prices onto the price history and wishlist entries
+	- Updating a wishlist entry without touching the prices the price checks own
+	- Turning a wishlist entry into a library copy and a purchase in one transaction
+
+	Scenarios:
+	- SaveGamePrices marks every checked game, records the history and returns the users it updated
+	- SaveGamePrices with no prices only marks the games as checked
+	- UpdateWishlistItem only sends the fields users own, even when prices are set on the item
+	- PurchaseWishlistItem adds the copy, logs the purchase and removes the wishlist entry
+	- PurchaseWishlistItem saves the game's genres and themes before adding the copy
+	- PurchaseWishlistItem rolls back when the location isn't the user's
+	- PurchaseWishlistItem fails for games that aren't wishlisted
+*/
+
+func TestWishlistDbAdapterPrices(t *testing.T) {
+	checkedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	checked := []models.PriceQuery{
+		{GameID: 1942, PlatformID: 48},
+		{GameID: 1020, PlatformID: 6},
+	}
+
+	setupMockDB := func() (*WishlistDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &WishlistDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	/*
+		GIVEN two checked games and a price for one of them
+		WHEN SaveGamePrices is called
+		THEN it should mark both checked, add the price to the history and update the wishlist in one transaction
+	*/
+	t.Run("SaveGamePrices records history and updates the wishlist", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		salePrice := 9.99
+		prices := []models.GamePrice{{GameID: 1942, PlatformID: 48, RegularPrice: 39.99, SalePrice: &salePrice}}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE wishlist w SET last_price_check").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), checkedAt).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("INSERT INTO game_price_history").
+			WithArgs("fake", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), checkedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("UPDATE wishlist w SET current_price").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), checkedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("user-1").AddRow("user-2"))
+		mock.ExpectCommit()
+
+		// WHEN
+		userIDs, err := adapter.SaveGamePrices(context.Background(), "fake", checked, prices, checkedAt)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(userIDs) != 2 {
+			t.Errorf("Expected 2 updated users, got %v", userIDs)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN checked games the provider had no price for
+		WHEN SaveGamePrices is called
+		THEN it should only mark them as checked
+	*/
+	t.Run("SaveGamePrices without prices only marks games checked", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE wishlist w SET last_price_check").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), checkedAt).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		// WHEN
+		userIDs, err := adapter.SaveGamePrices(context.Background(), "fake", checked, nil, checkedAt)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(userIDs) != 0 {
+			t.Errorf("Expected no updated users, got %v", userIDs)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestWishlistDbAdapterPurchase(t *testing.T) {
+	purchaseDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	sublocationID := "5f0c2a4e-8d3b-4c1a-9e7f-2b6d8a1c3e90"
+	purchase := models.WishlistPurchaseToSave{
+		GameID:        1942,
+		SublocationID: sublocationID,
+		Amount:        39.99,
+		PaymentMethod: "visa",
+		PurchaseDate:  purchaseDate,
+	}
+
+	setupMockDB := func() (*WishlistDbAdapter, sqlmock.Sqlmock, error) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adapter := &WishlistDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		return adapter, mock, nil
+	}
+
+	expectLockedItem := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT w.platform_id, g.name").
+			WithArgs("user-1", int64(1942)).
+			WillReturnRows(sqlmock.NewRows([]string{"platform_id", "name"}).AddRow(48, "The Witcher 3"))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(int64(48)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(copy_number\\), 0\\) \\+ 1").
+			WithArgs("user-1", int64(1942), int64(48), "physical").
+			WillReturnRows(sqlmock.NewRows([]string{"copy_number"}).AddRow(2))
+		mock.ExpectQuery("WITH shared AS (.+) INSERT INTO user_games").
+			WithArgs("user-1", int64(1942), int64(48), "physical", "", nil, nil, purchaseDate, "", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(77))
+	}
+
+	expectPurchaseLogged := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec("INSERT INTO physical_game_locations").
+			WithArgs(int64(77), sublocationID, "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("INSERT INTO one_time_purchases").
+			WithArgs("user-1", "The Witcher 3", 39.99, purchaseDate, "visa", 0, "physical_game", "", false).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(16))
+		mock.ExpectExec("INSERT INTO one_time_purchase_games").
+			WithArgs(16, int64(77)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM wishlist").
+			WithArgs("user-1", int64(1942)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	/*
+		GIVEN a wishlisted game and one of the user's sublocations
+		WHEN PurchaseWishlistItem is called without a platform or title
+		THEN it should use the wishlist platform and game name, add the next copy sharing the game's play status,
+		     review and favorite, log the purchase and remove the entry
+	*/
+	t.Run("PurchaseWishlistItem adds the copy and purchase", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		expectLockedItem(mock)
+		expectPurchaseLogged(mock)
+
+		// WHEN
+		result, err := adapter.PurchaseWishlistItem(context.Background(), "user-1", purchase)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.UserGameID != 77 || result.PurchaseID != 16 || result.PlatformID != 48 || result.Title != "The Witcher 3" {
+			t.Errorf("Unexpected result %+v", result)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a purchase sent with the game's genres and themes
+		WHEN PurchaseWishlistItem is called
+		THEN it should save and link them before adding the copy, like adding the game to the library does
+	*/
+	t.Run("PurchaseWishlistItem saves the game's genres and themes", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		withTerms := purchase
+		withTerms.GameGenres = []models.GameMetadataTerm{{ID: 12, Name: "Role-playing (RPG)"}}
+		withTerms.GameThemes = []models.GameMetadataTerm{{ID: 17, Name: "Fantasy"}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT w.platform_id, g.name").
+			WithArgs("user-1", int64(1942)).
+			WillReturnRows(sqlmock.NewRows([]string{"platform_id", "name"}).AddRow(48, "The Witcher 3"))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(int64(48)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec("INSERT INTO genres").
+			WithArgs(pq.Array([]int64{12}), pq.Array([]string{"Role-playing (RPG)"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO game_genres").
+			WithArgs(int64(1942), pq.Array([]int64{12})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO themes").
+			WithArgs(pq.Array([]int64{17}), pq.Array([]string{"Fantasy"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO game_themes").
+			WithArgs(int64(1942), pq.Array([]int64{17})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT COALESCE\\(MAX\\(copy_number\\), 0\\) \\+ 1").
+			WithArgs("user-1", int64(1942), int64(48), "physical").
+			WillReturnRows(sqlmock.NewRows([]string{"copy_number"}).AddRow(2))
+		mock.ExpectQuery("WITH shared AS (.+) INSERT INTO user_games").
+			WithArgs("user-1", int64(1942), int64(48), "physical", "", nil, nil, purchaseDate, "", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(77))
+		expectPurchaseLogged(mock)
+
+		// WHEN
+		_, err = adapter.PurchaseWishlistItem(context.Background(), "user-1", withTerms)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a sublocation that isn't the user's
+		WHEN PurchaseWishlistItem is called
+		THEN it should return ErrLocationNotFound and roll back
+	*/
+	t.Run("PurchaseWishlistItem rolls back for an unknown location", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		expectLockedItem(mock)
+		mock.ExpectExec("INSERT INTO physical_game_locations").
+			WithArgs(int64(77), sublocationID, "user-1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.PurchaseWishlistItem(context.Background(), "user-1", purchase)
+
+		// THEN
+		if !errors.Is(err, ErrLocationNotFound) {
+			t.Errorf("Expected ErrLocationNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+
+	/*
+		GIVEN a game that isn't in the user's wishlist
+		WHEN PurchaseWishlistItem is called
+		THEN it should return ErrWishlistItemNotFound
+	*/
+	t.Run("PurchaseWishlistItem fails for games not in the wishlist", func(t *testing.T) {
+		// GIVEN
+		adapter, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT w.platform_id, g.name").
+			WithArgs("user-1", int64(1942)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		// WHEN
+		_, err = adapter.PurchaseWishlistItem(context.Background(), "user-1", purchase)
+
+		// THEN
+		if !errors.Is(err, ErrWishlistItemNotFound) {
+			t.Errorf("Expected ErrWishlistItemNotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestWishlistDbAdapterUpdate(t *testing.T) {
+	/*
+		GIVEN an update that also carries prices
+		WHEN UpdateWishlistItem is called
+		THEN it should only write platform, release date, target price, priority and must-have
+	*/
+	t.Run("UpdateWishlistItem leaves prices to the price checks", func(t *testing.T) {
+		// GIVEN
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("Failed to setup mock DB: %v", err)
+		}
+		adapter := &WishlistDbAdapter{
+			db:     sqlx.NewDb(db, "postgres"),
+			logger: testutils.NewTestLogger(),
+		}
+
+		currentPrice, targetPrice := 1.0, 20.0
+		item := models.WishlistItemToSave{
+			GameID:       1942,
+			PlatformID:   48,
+			IsOnSale:     true,
+			CurrentPrice: &currentPrice,
+			SalePrice:    &currentPrice,
+			TargetPrice:  &targetPrice,
+		}
+
+		mock.ExpectExec("UPDATE wishlist SET platform_id = \\$3").
+			WithArgs("user-1", int64(1942), int64(48), nil, &targetPrice, nil, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// WHEN
+		err = adapter.UpdateWishlistItem(context.Background(), "user-1", item)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package wishlist
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Package errors with errors.Is
+var (
+	ErrWishlistItemNotFound  = errors.New("game not found in wishlist")
+	ErrDuplicateWishlistItem = errors.New("game already exists in wishlist")
+	ErrValidationFailed      = errors.New("validation failed")
+	ErrDatabaseError         = errors.New("database error")
+	ErrLocationNotFound      = errors.New("location not found")
+)
+
+// GetStatusCodeForError returns the appropriate HTTP status code for a given error
+func GetStatusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ErrWishlistItemNotFound), errors.Is(err, ErrLocationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrValidationFailed):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDuplicateWishlistItem):
+		return http.StatusConflict
+	case errors.Is(err, ErrDatabaseError):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
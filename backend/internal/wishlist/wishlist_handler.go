@@ -0,0 +1,628 @@
+package wishlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/services"
+	"github.com/lokeam/qko-beta/internal/shared/httputils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+func RegisterWishlistRoutes(
+	r chi.Router,
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+	analyticsService analytics.Service,
+) {
+	// Base routes
+	r.Get("/", GetWishlistBFF(appCtx, wishlistService))
+	r.Post("/", CreateWishlistItem(appCtx, wishlistService, analyticsService))
+	r.Get("/upcoming", GetUpcomingReleases(appCtx, wishlistService))
+	r.Get("/budget", GetWishlistBudget(appCtx, wishlistService))
+	r.Get("/settings", GetWishlistSettings(appCtx, wishlistService))
+	r.Put("/settings", UpdateWishlistSettings(appCtx, wishlistService))
+
+	// Nested routes with ID
+	r.Route("/games/{gameID}", func(r chi.Router) {
+		r.Get("/", GetSingleWishlistItem(appCtx, wishlistService))
+		r.Put("/", UpdateWishlistItem(appCtx, wishlistService, analyticsService))
+		r.Delete("/", DeleteWishlistItem(appCtx, wishlistService, analyticsService))
+		r.Get("/prices", GetWishlistPriceHistory(appCtx, wishlistService))
+		r.Post("/purchase", PurchaseWishlistItem(appCtx, wishlistService, analyticsService))
+	})
+}
+
+// helper fn to standardize error handling
+func handleError(
+	w http.ResponseWriter,
+	logger interfaces.Logger,
+	requestID string,
+	err error,
+) {
+	statusCode := GetStatusCodeForError(err)
+	httputils.RespondWithError(
+		httputils.NewResponseWriterAdapter(w),
+		logger,
+		requestID,
+		err,
+		statusCode,
+	)
+}
+
+// helper fn to read the gameID url param
+func parseGameID(r *http.Request) (int64, error) {
+	gameID, err := strconv.ParseInt(chi.URLParam(r, "gameID"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid ID: must be a number", ErrValidationFailed)
+	}
+	return gameID, nil
+}
+
+// helper fn to invalidate analytics after a wishlist write
+func invalidateWishlistAnalytics(
+	r *http.Request,
+	appCtx *appcontext.AppContext,
+	analyticsService analytics.Service,
+	requestID string,
+	userID string,
+) {
+	if err := analyticsService.InvalidateDomain(r.Context(), userID, analytics.DomainWishlist); err != nil {
+		appCtx.Logger.Warn("Failed to invalidate analytics cache", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"error":     err,
+		})
+	}
+}
+
+// purchaseAnalyticsDomains are the analytics a wishlist purchase changes: the wishlist, spending and the library
+var purchaseAnalyticsDomains = []string{
+	analytics.DomainWishlist,
+	analytics.DomainFinancial,
+	analytics.DomainInventory,
+	analytics.DomainStorage,
+	analytics.DomainGeneral,
+}
+
+// GetWishlistBFF handles GET requests for the /wishlist page
+func GetWishlistBFF(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		wishlistPayload, err := wishlistService.GetWishlistBFFResponse(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": wishlistPayload,
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetSingleWishlistItem handles GET requests for a single wishlist entry
+func GetSingleWishlistItem(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := parseGameID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		item, err := wishlistService.GetSingleWishlistItem(r.Context(), userID, gameID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"item": item,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetWishlistPriceHistory handles GET requests for the prices found for a wishlisted game
+func GetWishlistPriceHistory(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := parseGameID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		history, err := wishlistService.GetPriceHistory(r.Context(), userID, gameID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"priceHistory": history,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetUpcomingReleases handles GET requests for the wishlist release calendar.
+// The optional days query param sets how far ahead to look.
+func GetUpcomingReleases(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		var days int
+		if rawDays := r.URL.Query().Get("days"); rawDays != "" {
+			parsedDays, err := strconv.Atoi(rawDays)
+			if err != nil {
+				handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: days must be a number", ErrValidationFailed))
+				return
+			}
+			days = parsedDays
+		}
+
+		upcoming, err := wishlistService.GetUpcomingReleases(r.Context(), userID, days)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"upcoming": upcoming,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetWishlistSettings handles GET requests for the user's wishlist settings
+func GetWishlistSettings(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		settings, err := wishlistService.GetWishlistSettings(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"settings": settings,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// GetWishlistBudget handles GET requests for the wishlist games that fit this month's budget
+func GetWishlistBudget(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		budget, err := wishlistService.GetBudgetSuggestions(r.Context(), userID)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"budget": budget,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// UpdateWishlistSettings handles PUT requests for changing the user's wishlist settings
+func UpdateWishlistSettings(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		var request types.UpdateWishlistSettingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		settings, err := wishlistService.UpdateWishlistSettings(r.Context(), userID, request)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"settings": settings,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// CreateWishlistItem handles POST requests for adding a game to the wishlist
+func CreateWishlistItem(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+	analyticsService analytics.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		appCtx.Logger.Info("Adding game to wishlist", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+		})
+
+		var request types.CreateWishlistItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		requestAdapter := NewWishlistRequestAdapter()
+		wishlistItem := requestAdapter.AdaptCreateRequestToWishlistItemModel(request)
+
+		item, err := wishlistService.CreateWishlistItem(r.Context(), userID, wishlistItem)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		invalidateWishlistAnalytics(r, appCtx, analyticsService, requestID, userID)
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"item":    item,
+				"message": "Game added to wishlist successfully",
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusCreated,
+			response,
+		)
+	}
+}
+
+// UpdateWishlistItem handles PUT requests for updating a wishlist entry
+func UpdateWishlistItem(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+	analyticsService analytics.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := parseGameID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		appCtx.Logger.Info("Updating wishlist item", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+		})
+
+		var request types.UpdateWishlistItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		requestAdapter := NewWishlistRequestAdapter()
+		wishlistItem := requestAdapter.AdaptUpdateRequestToWishlistItemModel(request)
+		wishlistItem.GameID = gameID
+
+		item, err := wishlistService.UpdateWishlistItem(r.Context(), userID, wishlistItem)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		invalidateWishlistAnalytics(r, appCtx, analyticsService, requestID, userID)
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"item": item,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// DeleteWishlistItem handles DELETE requests for removing a game from the wishlist
+func DeleteWishlistItem(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+	analyticsService analytics.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := parseGameID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		appCtx.Logger.Info("Removing game from wishlist", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+		})
+
+		if err := wishlistService.DeleteWishlistItem(r.Context(), userID, gameID); err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		invalidateWishlistAnalytics(r, appCtx, analyticsService, requestID, userID)
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"id":      gameID,
+				"message": "Game removed from wishlist successfully",
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusOK,
+			response,
+		)
+	}
+}
+
+// PurchaseWishlistItem handles POST requests for marking a wishlisted game as purchased.
+// The game is added to the library and its purchase logged, then it leaves the wishlist.
+func PurchaseWishlistItem(
+	appCtx *appcontext.AppContext,
+	wishlistService services.WishlistService,
+	analyticsService analytics.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestID := httputils.GetRequestID(r)
+
+		userID := httputils.GetUserID(r)
+		if userID == "" {
+			appCtx.Logger.Error("userID NOT FOUND in request context", map[string]any{
+				"request_id": requestID,
+			})
+			handleError(w, appCtx.Logger, requestID, errors.New("userID not found in request context"))
+			return
+		}
+
+		gameID, err := parseGameID(r)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		appCtx.Logger.Info("Purchasing wishlist item", map[string]any{
+			"requestID": requestID,
+			"userID":    userID,
+			"gameID":    gameID,
+		})
+
+		var request types.PurchaseWishlistItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			handleError(w, appCtx.Logger, requestID, fmt.Errorf("%w: invalid request body", ErrValidationFailed))
+			return
+		}
+
+		requestAdapter := NewWishlistRequestAdapter()
+		purchase := requestAdapter.AdaptPurchaseRequestToPurchaseModel(request)
+		purchase.GameID = gameID
+
+		result, err := wishlistService.PurchaseWishlistItem(r.Context(), userID, purchase)
+		if err != nil {
+			handleError(w, appCtx.Logger, requestID, err)
+			return
+		}
+
+		if err := analyticsService.InvalidateDomains(r.Context(), userID, purchaseAnalyticsDomains); err != nil {
+			appCtx.Logger.Warn("Failed to invalidate analytics cache", map[string]any{
+				"requestID": requestID,
+				"userID":    userID,
+				"error":     err,
+			})
+		}
+
+		response := httputils.NewAPIResponse(r, userID, map[string]any{
+			"wishlist": map[string]any{
+				"purchase": result,
+			},
+		})
+
+		httputils.RespondWithJSON(
+			httputils.NewResponseWriterAdapter(w),
+			appCtx.Logger,
+			http.StatusCreated,
+			response,
+		)
+	}
+}
@@ -0,0 +1,230 @@
+package wishlist
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/config"
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+)
+
+const (
+	// pricesPerRun caps how many game and platform pairs one run prices, the rest wait for the next run
+	pricesPerRun = 500
+
+	// priceBatchSize is how many games are sent to the price provider at once
+	priceBatchSize = 50
+)
+
+// wishlistEmailQueue is the part of email.EmailQueue used to send sale alerts
+type wishlistEmailQueue interface {
+	EnqueueJob(ctx context.Context, jobType email.EmailJobType, userID, email string, data map[string]interface{}) error
+}
+
+// WishlistPriceCheckService keeps wishlist prices up to date from a price provider.
+// Every run records the prices it finds and emails users whose wishlisted game dropped to their target price.
+type WishlistPriceCheckService struct {
+	dbAdapter             interfaces.WishlistPriceDbAdapter
+	cacheWrapper          interfaces.WishlistCacheWrapper
+	dashboardCacheWrapper interfaces.DashboardCacheWrapper
+	provider              interfaces.PriceProvider
+	emailQueue            wishlistEmailQueue
+	logger                interfaces.Logger
+	interval              time.Duration
+	now                   func() time.Time
+	stopChecks            context.CancelFunc
+}
+
+// NewWishlistPriceCheckService creates the price check service.
+// emailQueue may be nil when email isn't configured, prices are then tracked without sending alerts.
+func NewWishlistPriceCheckService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.WishlistPriceDbAdapter,
+	cacheWrapper interfaces.WishlistCacheWrapper,
+	dashboardCacheWrapper interfaces.DashboardCacheWrapper,
+	provider interfaces.PriceProvider,
+	emailQueue wishlistEmailQueue,
+) (*WishlistPriceCheckService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+	if cacheWrapper == nil {
+		return nil, fmt.Errorf("cacheWrapper is required")
+	}
+	if dashboardCacheWrapper == nil {
+		return nil, fmt.Errorf("dashboardCacheWrapper is required")
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("provider is required")
+	}
+
+	interval := time.Duration(config.DefaultPriceCheckIntervalHours) * time.Hour
+	if appContext.Config != nil && appContext.Config.Pricing != nil && appContext.Config.Pricing.CheckInterval > 0 {
+		interval = appContext.Config.Pricing.CheckInterval
+	}
+
+	return &WishlistPriceCheckService{
+		dbAdapter:             dbAdapter,
+		cacheWrapper:          cacheWrapper,
+		dashboardCacheWrapper: dashboardCacheWrapper,
+		provider:              provider,
+		emailQueue:            emailQueue,
+		logger:                appContext.Logger,
+		interval:              interval,
+		now:                   time.Now,
+	}, nil
+}
+
+// Start runs the price check job
+func (ps *WishlistPriceCheckService) Start(ctx context.Context) error {
+	if ps.emailQueue == nil {
+		ps.logger.Warn("Email is not configured, wishlist sale alerts are disabled", nil)
+	}
+
+	checkCtx, cancel := context.WithCancel(context.Background())
+	ps.stopChecks = cancel
+	go worker.NewWorker(ps.interval, ps.CheckPrices, nil, ps.logger).Start(checkCtx)
+
+	return nil
+}
+
+// Stop stops the price check job
+func (ps *WishlistPriceCheckService) Stop() error {
+	if ps.stopChecks != nil {
+		ps.stopChecks()
+	}
+	return nil
+}
+
+// CheckPrices prices the wishlisted games not checked within the interval, then sends any sale alerts that are due.
+// A batch the provider fails on is logged and left for the next run.
+func (ps *WishlistPriceCheckService) CheckPrices(ctx context.Context) error {
+	queries, err := ps.dbAdapter.GetPriceQueries(ctx, ps.now().Add(-ps.interval), pricesPerRun)
+	if err != nil {
+		return err
+	}
+
+	changedUsers := make(map[string]bool)
+	var priced int
+	for start := 0; start < len(queries); start += priceBatchSize {
+		batch := queries[start:min(start+priceBatchSize, len(queries))]
+
+		prices, err := ps.provider.GetPrices(ctx, batch)
+		if err != nil {
+			ps.logger.Error("Failed to get wishlist prices", map[string]any{
+				"provider": ps.provider.Name(),
+				"games":    len(batch),
+				"error":    err,
+			})
+			continue
+		}
+
+		prices = validPrices(batch, prices)
+		userIDs, err := ps.dbAdapter.SaveGamePrices(ctx, ps.provider.Name(), batch, prices, ps.now())
+		if err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			changedUsers[userID] = true
+		}
+		priced += len(prices)
+	}
+
+	for userID := range changedUsers {
+		ps.invalidateCaches(ctx, userID)
+	}
+
+	if len(queries) > 0 {
+		ps.logger.Info("Checked wishlist prices", map[string]any{
+			"provider": ps.provider.Name(),
+			"checked":  len(queries),
+			"priced":   priced,
+			"users":    len(changedUsers),
+		})
+	}
+
+	return ps.sendSaleAlerts(ctx)
+}
+
+// validPrices drops prices for games that weren't asked about and prices that can't be right
+func validPrices(batch []models.PriceQuery, prices []models.GamePrice) []models.GamePrice {
+	type priceKey struct {
+		gameID     int64
+		platformID int64
+	}
+
+	asked := make(map[priceKey]bool, len(batch))
+	for _, query := range batch {
+		asked[priceKey{query.GameID, query.PlatformID}] = true
+	}
+
+	valid := make([]models.GamePrice, 0, len(prices))
+	for _, price := range prices {
+		key := priceKey{price.GameID, price.PlatformID}
+		if !asked[key] || price.RegularPrice < 0 || (price.SalePrice != nil && *price.SalePrice < 0) {
+			continue
+		}
+		asked[key] = false
+		valid = append(valid, price)
+	}
+
+	return valid
+}
+
+// sendSaleAlerts queues an email for each wishlist entry at or below its target price.
+// An entry is only marked as alerted once its email is queued, failures are picked up on the next run.
+func (ps *WishlistPriceCheckService) sendSaleAlerts(ctx context.Context) error {
+	if ps.emailQueue == nil {
+		return nil
+	}
+
+	alerts, err := ps.dbAdapter.GetDueSaleAlerts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		err := ps.emailQueue.EnqueueJob(ctx, email.EmailJobTypeWishlistSale, alert.UserID, alert.Email, map[string]interface{}{
+			"userName":     alert.FirstName,
+			"gameName":     alert.GameName,
+			"platformName": alert.PlatformName,
+			"price":        alert.Price,
+			"regularPrice": alert.RegularPrice,
+			"targetPrice":  alert.TargetPrice,
+		})
+		if err != nil {
+			ps.logger.Error("Failed to queue wishlist sale alert", map[string]any{
+				"wishlistID": alert.ID,
+				"error":      err,
+			})
+			continue
+		}
+
+		if err := ps.dbAdapter.MarkSaleAlertSent(ctx, alert.ID, ps.now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidateCaches clears the wishlist and dashboard caches of a user whose prices changed
+func (ps *WishlistPriceCheckService) invalidateCaches(ctx context.Context, userID string) {
+	if err := ps.cacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ps.logger.Error("Failed to invalidate wishlist cache after price check", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+	if err := ps.dashboardCacheWrapper.InvalidateUserCache(ctx, userID); err != nil {
+		ps.logger.Error("Failed to invalidate dashboard cache after price check", map[string]any{
+			"error":  err,
+			"userID": userID,
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package wishlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+/*
+	Behavior:
+	- Checking wishlist prices on a schedule and alerting users when a game hits their target price
+
+	Scenarios:
+	- Prices from the provider are saved, unknown games and negative prices are dropped
+	- Users whose wishlist changed get their caches cleared
+	- Due sale alerts are queued and marked as sent
+	- A provider failure skips the batch without failing the run
+	- Without an email queue prices are still saved and no alerts are sent
+*/
+
+type fakeWishlistPriceDbAdapter struct {
+	queries    []models.PriceQuery
+	alerts     []models.WishlistSaleAlertDB
+	checked    []models.PriceQuery
+	saved      []models.GamePrice
+	userIDs    []string
+	alertsSent []int64
+}
+
+func (f *fakeWishlistPriceDbAdapter) GetPriceQueries(ctx context.Context, checkedBefore time.Time, limit int) ([]models.PriceQuery, error) {
+	return f.queries, nil
+}
+
+func (f *fakeWishlistPriceDbAdapter) SaveGamePrices(
+	ctx context.Context,
+	provider string,
+	checked []models.PriceQuery,
+	prices []models.GamePrice,
+	checkedAt time.Time,
+) ([]string, error) {
+	f.checked = append(f.checked, checked...)
+	f.saved = append(f.saved, prices...)
+	return f.userIDs, nil
+}
+
+func (f *fakeWishlistPriceDbAdapter) GetDueSaleAlerts(ctx context.Context) ([]models.WishlistSaleAlertDB, error) {
+	return f.alerts, nil
+}
+
+func (f *fakeWishlistPriceDbAdapter) MarkSaleAlertSent(ctx context.Context, wishlistID int64, sentAt time.Time) error {
+	f.alertsSent = append(f.alertsSent, wishlistID)
+	return nil
+}
+
+type fakePriceProvider struct {
+	prices []models.GamePrice
+	err    error
+}
+
+func (f *fakePriceProvider) Name() string {
+	return "fake"
+}
+
+func (f *fakePriceProvider) GetPrices(ctx context.Context, queries []models.PriceQuery) ([]models.GamePrice, error) {
+	return f.prices, f.err
+}
+
+type fakeWishlistEmailQueue struct {
+	jobs []map[string]interface{}
+}
+
+func (f *fakeWishlistEmailQueue) EnqueueJob(
+	ctx context.Context,
+	jobType email.EmailJobType,
+	userID, emailAddress string,
+	data map[string]interface{},
+) error {
+	f.jobs = append(f.jobs, data)
+	return nil
+}
+
+type fakeWishlistCaches struct {
+	invalidated []string
+}
+
+func (f *fakeWishlistCaches) GetCachedWishlistItems(ctx context.Context, userID string) ([]models.WishlistItemDB, bool, error) {
+	return nil, false, nil
+}
+
+func (f *fakeWishlistCaches) SetCachedWishlistItems(ctx context.Context, userID string, items []models.WishlistItemDB) error {
+	return nil
+}
+
+func (f *fakeWishlistCaches) InvalidateUserCache(ctx context.Context, userID string) error {
+	f.invalidated = append(f.invalidated, userID)
+	return nil
+}
+
+func (f *fakeWishlistCaches) GetCachedDashboardBFF(ctx context.Context, userID string) (types.DashboardBFFResponse, error) {
+	return types.DashboardBFFResponse{}, nil
+}
+
+func (f *fakeWishlistCaches) SetCachedDashboardBFF(ctx context.Context, userID string, response types.DashboardBFFResponse) error {
+	return nil
+}
+
+func TestWishlistPriceCheckService(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	salePrice := 9.99
+	negativePrice := -1.0
+
+	newService := func(
+		dbAdapter *fakeWishlistPriceDbAdapter,
+		provider *fakePriceProvider,
+		caches *fakeWishlistCaches,
+		emailQueue wishlistEmailQueue,
+	) *WishlistPriceCheckService {
+		return &WishlistPriceCheckService{
+			dbAdapter:             dbAdapter,
+			cacheWrapper:          caches,
+			dashboardCacheWrapper: caches,
+			provider:              provider,
+			emailQueue:            emailQueue,
+			logger:                testutils.NewTestLogger(),
+			interval:              6 * time.Hour,
+			now:                   func() time.Time { return now },
+		}
+	}
+
+	/*
+		GIVEN two wishlisted games, the provider prices one, a game nobody asked about and a negative sale
+		WHEN CheckPrices is called
+		THEN it should save only the valid price, clear the caches and queue the due sale alert
+	*/
+	t.Run("Saves prices and queues sale alerts", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeWishlistPriceDbAdapter{
+			queries: []models.PriceQuery{
+				{GameID: 1942, GameName: "The Witcher 3", PlatformID: 48},
+				{GameID: 1020, GameName: "Grand Theft Auto V", PlatformID: 6},
+			},
+			userIDs: []string{"user-1"},
+			alerts: []models.WishlistSaleAlertDB{{
+				ID:           7,
+				UserID:       "user-1",
+				Email:        "user@example.com",
+				GameName:     "The Witcher 3",
+				TargetPrice:  10,
+				Price:        9.99,
+				RegularPrice: 39.99,
+			}},
+		}
+		provider := &fakePriceProvider{prices: []models.GamePrice{
+			{GameID: 1942, PlatformID: 48, RegularPrice: 39.99, SalePrice: &salePrice},
+			{GameID: 1020, PlatformID: 6, RegularPrice: 29.99, SalePrice: &negativePrice},
+			{GameID: 9999, PlatformID: 48, RegularPrice: 5},
+		}}
+		caches := &fakeWishlistCaches{}
+		emailQueue := &fakeWishlistEmailQueue{}
+
+		// WHEN
+		err := newService(dbAdapter, provider, caches, emailQueue).CheckPrices(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.checked) != 2 {
+			t.Errorf("Expected both games to be marked checked, got %+v", dbAdapter.checked)
+		}
+		if len(dbAdapter.saved) != 1 || dbAdapter.saved[0].GameID != 1942 {
+			t.Errorf("Expected only game 1942 to be saved, got %+v", dbAdapter.saved)
+		}
+		if len(caches.invalidated) != 2 || caches.invalidated[0] != "user-1" {
+			t.Errorf("Expected the wishlist and dashboard caches of user-1 to be cleared, got %v", caches.invalidated)
+		}
+		if len(emailQueue.jobs) != 1 || emailQueue.jobs[0]["price"] != 9.99 {
+			t.Errorf("Expected one sale alert at 9.99, got %+v", emailQueue.jobs)
+		}
+		if len(dbAdapter.alertsSent) != 1 || dbAdapter.alertsSent[0] != 7 {
+			t.Errorf("Expected alert 7 to be marked sent, got %v", dbAdapter.alertsSent)
+		}
+	})
+
+	/*
+		GIVEN a provider that fails
+		WHEN CheckPrices is called
+		THEN it should save nothing and still finish without an error
+	*/
+	t.Run("Provider failure skips the batch", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeWishlistPriceDbAdapter{
+			queries: []models.PriceQuery{{GameID: 1942, GameName: "The Witcher 3", PlatformID: 48}},
+		}
+		provider := &fakePriceProvider{err: errors.New("price API returned status 502")}
+
+		// WHEN
+		err := newService(dbAdapter, provider, &fakeWishlistCaches{}, &fakeWishlistEmailQueue{}).CheckPrices(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.checked) != 0 || len(dbAdapter.saved) != 0 {
+			t.Errorf("Expected nothing to be saved, got checked %+v and saved %+v", dbAdapter.checked, dbAdapter.saved)
+		}
+	})
+
+	/*
+		GIVEN no email queue and a due sale alert
+		WHEN CheckPrices is called
+		THEN it should save prices without marking the alert as sent
+	*/
+	t.Run("No email queue skips alerts", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeWishlistPriceDbAdapter{
+			queries: []models.PriceQuery{{GameID: 1942, GameName: "The Witcher 3", PlatformID: 48}},
+			alerts:  []models.WishlistSaleAlertDB{{ID: 7, UserID: "user-1"}},
+		}
+		provider := &fakePriceProvider{prices: []models.GamePrice{{GameID: 1942, PlatformID: 48, RegularPrice: 39.99}}}
+
+		// WHEN
+		err := newService(dbAdapter, provider, &fakeWishlistCaches{}, nil).CheckPrices(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.saved) != 1 {
+			t.Errorf("Expected the price to be saved, got %+v", dbAdapter.saved)
+		}
+		if len(dbAdapter.alertsSent) != 0 {
+			t.Errorf("Expected no alerts to be marked sent, got %v", dbAdapter.alertsSent)
+		}
+	})
+}
@@ -0,0 +1,336 @@
+package wishlist
+
+const (
+	CheckIfGameIsInWishlistQuery = `
+		SELECT EXISTS(
+			SELECT 1 FROM wishlist
+			WHERE user_id = $1 AND game_id = $2
+		)
+	`
+
+	GetWishlistItemsQuery = `
+		SELECT
+			w.id,
+			w.game_id,
+			g.name as game_name,
+			COALESCE(g.cover_url, '') as game_cover_url,
+			COALESCE(g.first_release_date, 0) as game_first_release_date,
+			w.platform_id,
+			COALESCE(p.name, '') as platform_name,
+			w.release_date,
+			COALESCE(w.is_on_sale, false) as is_on_sale,
+			w.current_price,
+			w.sale_price,
+			w.target_price,
+			w.priority,
+			w.must_have,
+			w.last_price_check,
+			w.created_at,
+			w.updated_at
+		FROM wishlist w
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		WHERE w.user_id = $1
+		ORDER BY w.created_at DESC, w.id DESC
+	`
+
+	GetSingleWishlistItemQuery = `
+		SELECT
+			w.id,
+			w.game_id,
+			g.name as game_name,
+			COALESCE(g.cover_url, '') as game_cover_url,
+			COALESCE(g.first_release_date, 0) as game_first_release_date,
+			w.platform_id,
+			COALESCE(p.name, '') as platform_name,
+			w.release_date,
+			COALESCE(w.is_on_sale, false) as is_on_sale,
+			w.current_price,
+			w.sale_price,
+			w.target_price,
+			w.priority,
+			w.must_have,
+			w.last_price_check,
+			w.created_at,
+			w.updated_at
+		FROM wishlist w
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		WHERE w.user_id = $1 AND w.game_id = $2
+	`
+
+	EnsureWishlistGameExistsQuery = `
+		INSERT INTO games (id, name, cover_url, first_release_date, rating)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	CreateWishlistItemQuery = `
+		INSERT INTO wishlist (
+			user_id,
+			game_id,
+			platform_id,
+			release_date,
+			is_on_sale,
+			current_price,
+			sale_price,
+			target_price,
+			priority,
+			must_have,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		ON CONFLICT (user_id, game_id) DO NOTHING
+	`
+
+	// Priority and must-have keep their value when not given. Prices belong to the price check worker,
+	// a new platform drops the old platform's prices so the worker prices it on its next run.
+	UpdateWishlistItemQuery = `
+		UPDATE wishlist
+		SET platform_id = $3,
+			release_date = $4,
+			target_price = $5,
+			sale_alert_sent_at = CASE
+				WHEN target_price IS DISTINCT FROM $5 OR platform_id IS DISTINCT FROM $3 THEN NULL
+				ELSE sale_alert_sent_at
+			END,
+			is_on_sale = CASE WHEN platform_id IS DISTINCT FROM $3 THEN false ELSE is_on_sale END,
+			current_price = CASE WHEN platform_id IS DISTINCT FROM $3 THEN NULL ELSE current_price END,
+			sale_price = CASE WHEN platform_id IS DISTINCT FROM $3 THEN NULL ELSE sale_price END,
+			last_price_check = CASE WHEN platform_id IS DISTINCT FROM $3 THEN NULL ELSE last_price_check END,
+			priority = COALESCE($6, priority),
+			must_have = COALESCE($7, must_have),
+			updated_at = NOW()
+		WHERE user_id = $1 AND game_id = $2
+	`
+
+	DeleteWishlistItemQuery = `
+		DELETE FROM wishlist
+		WHERE user_id = $1 AND game_id = $2
+	`
+
+	// GetPriceQueriesQuery picks the game and platform pairs checked longest ago, never checked first.
+	// Wishlists of deleted accounts aren't priced.
+	GetPriceQueriesQuery = `
+		SELECT
+			w.game_id,
+			g.name as game_name,
+			w.platform_id,
+			COALESCE(p.name, '') as platform_name
+		FROM wishlist w
+		JOIN users u ON u.id = w.user_id
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		WHERE u.deleted_at IS NULL
+		AND (w.last_price_check IS NULL OR w.last_price_check < $1)
+		GROUP BY w.game_id, g.name, w.platform_id, p.name
+		ORDER BY MIN(w.last_price_check) NULLS FIRST, w.game_id
+		LIMIT $2
+	`
+
+	InsertGamePriceHistoryQuery = `
+		INSERT INTO game_price_history (game_id, platform_id, provider, regular_price, sale_price, checked_at)
+		SELECT price.game_id, price.platform_id, $1, price.regular_price, price.sale_price, $6
+		FROM UNNEST($2::bigint[], $3::bigint[], $4::numeric[], $5::numeric[])
+			AS price(game_id, platform_id, regular_price, sale_price)
+	`
+
+	// UpdateWishlistPricesQuery copies the new price to every wishlist entry for the game and platform.
+	// The sale alert is reset once the price goes back above the target so the next drop alerts again.
+	UpdateWishlistPricesQuery = `
+		UPDATE wishlist w
+		SET current_price = price.regular_price,
+			sale_price = price.sale_price,
+			is_on_sale = price.sale_price IS NOT NULL AND price.sale_price < price.regular_price,
+			sale_alert_sent_at = CASE
+				WHEN w.target_price IS NOT NULL AND COALESCE(price.sale_price, price.regular_price) <= w.target_price
+				THEN w.sale_alert_sent_at
+			END,
+			last_price_check = $5
+		FROM UNNEST($1::bigint[], $2::bigint[], $3::numeric[], $4::numeric[])
+			AS price(game_id, platform_id, regular_price, sale_price)
+		WHERE w.game_id = price.game_id AND w.platform_id = price.platform_id
+		RETURNING w.user_id
+	`
+
+	// MarkPricesCheckedQuery records a check for games the provider had no price for,
+	// so they wait for the next interval instead of being asked about on every run
+	MarkPricesCheckedQuery = `
+		UPDATE wishlist w
+		SET last_price_check = $3
+		FROM UNNEST($1::bigint[], $2::bigint[]) AS checked(game_id, platform_id)
+		WHERE w.game_id = checked.game_id AND w.platform_id = checked.platform_id
+	`
+
+	GetDueSaleAlertsQuery = `
+		SELECT
+			w.id,
+			w.user_id,
+			u.email,
+			COALESCE(u.first_name, '') as first_name,
+			w.game_id,
+			g.name as game_name,
+			COALESCE(p.name, '') as platform_name,
+			w.target_price,
+			COALESCE(w.sale_price, w.current_price) as price,
+			w.current_price as regular_price
+		FROM wishlist w
+		JOIN users u ON u.id = w.user_id
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		WHERE u.deleted_at IS NULL
+		AND w.target_price IS NOT NULL
+		AND w.sale_alert_sent_at IS NULL
+		AND w.current_price IS NOT NULL
+		AND COALESCE(w.sale_price, w.current_price) <= w.target_price
+		ORDER BY w.id
+		LIMIT 100
+	`
+
+	MarkSaleAlertSentQuery = `
+		UPDATE wishlist
+		SET sale_alert_sent_at = $2
+		WHERE id = $1
+	`
+
+	GetPriceHistoryQuery = `
+		SELECT
+			h.id,
+			h.game_id,
+			h.platform_id,
+			h.provider,
+			h.regular_price,
+			h.sale_price,
+			h.checked_at
+		FROM game_price_history h
+		JOIN wishlist w ON w.game_id = h.game_id AND w.platform_id = h.platform_id
+		WHERE w.user_id = $1 AND w.game_id = $2
+		ORDER BY h.checked_at DESC, h.id DESC
+		LIMIT 100
+	`
+
+	// wishlistReleaseDate is the release date the user set, falling back to the game's first release date
+	wishlistReleaseDate = `COALESCE(NULLIF(w.release_date, 0), NULLIF(g.first_release_date, 0))`
+
+	// GetUpcomingReleasesQuery lists a user's wishlisted games releasing between $2 and $3, soonest first
+	GetUpcomingReleasesQuery = `
+		SELECT
+			w.id,
+			w.game_id,
+			g.name as game_name,
+			COALESCE(g.cover_url, '') as game_cover_url,
+			w.platform_id,
+			COALESCE(p.name, '') as platform_name,
+			` + wishlistReleaseDate + ` as release_date
+		FROM wishlist w
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		WHERE w.user_id = $1
+		AND ` + wishlistReleaseDate + ` BETWEEN $2 AND $3
+		ORDER BY release_date, g.name
+	`
+
+	// GetDueReleaseRemindersQuery finds releases inside each user's reminder window that weren't reminded
+	// for that date yet, users without settings get the default window of $2 days
+	GetDueReleaseRemindersQuery = `
+		SELECT
+			w.id,
+			w.game_id,
+			g.name as game_name,
+			COALESCE(g.cover_url, '') as game_cover_url,
+			w.platform_id,
+			COALESCE(p.name, '') as platform_name,
+			` + wishlistReleaseDate + ` as release_date,
+			w.user_id,
+			u.email,
+			COALESCE(u.first_name, '') as first_name
+		FROM wishlist w
+		JOIN users u ON u.id = w.user_id
+		JOIN games g ON w.game_id = g.id
+		LEFT JOIN platforms p ON w.platform_id = p.id
+		LEFT JOIN wishlist_settings s ON s.user_id = w.user_id
+		WHERE u.deleted_at IS NULL
+		AND COALESCE(s.release_reminder_days, $2) > 0
+		AND ` + wishlistReleaseDate + ` BETWEEN $1 AND $1 + COALESCE(s.release_reminder_days, $2) * 86400
+		AND w.release_reminder_for IS DISTINCT FROM ` + wishlistReleaseDate + `
+		ORDER BY w.user_id, release_date
+		LIMIT 500
+	`
+
+	MarkReleaseRemindersSentQuery = `
+		UPDATE wishlist w
+		SET release_reminder_for = ` + wishlistReleaseDate + `
+		FROM games g
+		WHERE g.id = w.game_id AND w.id = ANY($1)
+	`
+
+	GetWishlistSettingsQuery = `
+		SELECT release_reminder_days, monthly_budget
+		FROM wishlist_settings
+		WHERE user_id = $1
+	`
+
+	UpsertWishlistSettingsQuery = `
+		INSERT INTO wishlist_settings (user_id, release_reminder_days, monthly_budget, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET release_reminder_days = EXCLUDED.release_reminder_days,
+			monthly_budget = EXCLUDED.monthly_budget,
+			updated_at = NOW()
+	`
+
+	// LockWishlistItemForPurchaseQuery keeps a second purchase of the same entry waiting until the first one is done
+	LockWishlistItemForPurchaseQuery = `
+		SELECT w.platform_id, g.name
+		FROM wishlist w
+		JOIN games g ON w.game_id = g.id
+		WHERE w.user_id = $1 AND w.game_id = $2
+		FOR UPDATE OF w
+	`
+
+	CheckPlatformExistsQuery = `
+		SELECT EXISTS(SELECT 1 FROM platforms WHERE id = $1)
+	`
+
+	// GetNextCopyNumberQuery numbers a purchased copy after any the user already has of the game on that platform
+	GetNextCopyNumberQuery = `
+		SELECT COALESCE(MAX(copy_number), 0) + 1
+		FROM user_games
+		WHERE user_id = $1 AND game_id = $2 AND platform_id = $3 AND game_type = $4 AND deleted_at IS NULL
+	`
+
+	// Locations that aren't the user's insert nothing, the caller checks the row count
+	InsertPurchasedPhysicalLocationQuery = `
+		INSERT INTO physical_game_locations (user_game_id, sublocation_id)
+		SELECT $1, s.id
+		FROM sublocations s
+		WHERE s.id = $2 AND s.user_id = $3 AND s.deleted_at IS NULL
+	`
+
+	InsertPurchasedDigitalLocationQuery = `
+		INSERT INTO digital_game_locations (user_game_id, digital_location_id)
+		SELECT $1, dl.id
+		FROM digital_locations dl
+		WHERE dl.id = $2 AND dl.user_id = $3
+	`
+
+	// InsertWishlistPurchaseQuery logs the purchase, without a category it goes under physical or digital games
+	InsertWishlistPurchaseQuery = `
+		INSERT INTO one_time_purchases (
+			user_id, title, amount, purchase_date, payment_method,
+			spending_category_id, digital_location_id, is_digital, is_wishlisted
+		)
+		VALUES (
+			$1, $2, $3, $4, $5,
+			COALESCE(NULLIF($6, 0), (SELECT id FROM spending_categories WHERE media_type = $7 ORDER BY id LIMIT 1)),
+			NULLIF($8, '')::uuid, $9, true
+		)
+		RETURNING id
+	`
+
+	LinkWishlistPurchaseGameQuery = `
+		INSERT INTO one_time_purchase_games (purchase_id, user_game_id)
+		VALUES ($1, $2)
+	`
+)
@@ -0,0 +1,139 @@
+package wishlist
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/shared/worker"
+)
+
+const (
+	// DefaultReleaseReminderDays is how far ahead releases are reminded for users who never changed their settings
+	DefaultReleaseReminderDays = 7
+
+	// MaxReleaseReminderDays is the longest reminder window a user can pick
+	MaxReleaseReminderDays = 90
+
+	// DefaultUpcomingDays is how far ahead the release calendar looks unless asked otherwise
+	DefaultUpcomingDays = 90
+
+	// MaxUpcomingDays is the furthest ahead the release calendar looks
+	MaxUpcomingDays = 365
+
+	// releaseReminderInterval is how often upcoming releases are checked for reminders
+	releaseReminderInterval = 24 * time.Hour
+)
+
+// WishlistReleaseReminderService emails users about wishlisted games releasing within their reminder window.
+// Each user gets one email listing every release that came into their window since the last run.
+type WishlistReleaseReminderService struct {
+	dbAdapter     interfaces.WishlistReleaseDbAdapter
+	emailQueue    wishlistEmailQueue
+	logger        interfaces.Logger
+	now           func() time.Time
+	stopReminders context.CancelFunc
+}
+
+// NewWishlistReleaseReminderService creates the release reminder service.
+// emailQueue may be nil when email isn't configured, no reminders are sent then.
+func NewWishlistReleaseReminderService(
+	appContext *appcontext.AppContext,
+	dbAdapter interfaces.WishlistReleaseDbAdapter,
+	emailQueue wishlistEmailQueue,
+) (*WishlistReleaseReminderService, error) {
+	if dbAdapter == nil {
+		return nil, fmt.Errorf("dbAdapter is required")
+	}
+
+	return &WishlistReleaseReminderService{
+		dbAdapter:  dbAdapter,
+		emailQueue: emailQueue,
+		logger:     appContext.Logger,
+		now:        time.Now,
+	}, nil
+}
+
+// Start runs the daily release reminder job
+func (rs *WishlistReleaseReminderService) Start(ctx context.Context) error {
+	if rs.emailQueue == nil {
+		rs.logger.Warn("Email is not configured, wishlist release reminders are disabled", nil)
+		return nil
+	}
+
+	reminderCtx, cancel := context.WithCancel(context.Background())
+	rs.stopReminders = cancel
+	go worker.NewWorker(releaseReminderInterval, rs.SendReleaseReminders, nil, rs.logger).Start(reminderCtx)
+
+	return nil
+}
+
+// Stop stops the release reminder job
+func (rs *WishlistReleaseReminderService) Stop() error {
+	if rs.stopReminders != nil {
+		rs.stopReminders()
+	}
+	return nil
+}
+
+// SendReleaseReminders queues one email per user for the releases due a reminder.
+// Releases are only marked as reminded once their email is queued, failures are picked up on the next run.
+func (rs *WishlistReleaseReminderService) SendReleaseReminders(ctx context.Context) error {
+	if rs.emailQueue == nil {
+		return nil
+	}
+
+	reminders, err := rs.dbAdapter.GetDueReleaseReminders(ctx, rs.now(), DefaultReleaseReminderDays)
+	if err != nil {
+		return err
+	}
+
+	// Reminders come back ordered by user
+	for start := 0; start < len(reminders); {
+		end := start
+		for end < len(reminders) && reminders[end].UserID == reminders[start].UserID {
+			end++
+		}
+		userReminders := reminders[start:end]
+		start = end
+
+		if err := rs.queueReminder(ctx, userReminders); err != nil {
+			rs.logger.Error("Failed to queue release reminder email", map[string]any{
+				"userID": userReminders[0].UserID,
+				"error":  err,
+			})
+			continue
+		}
+
+		wishlistIDs := make([]int64, len(userReminders))
+		for i, reminder := range userReminders {
+			wishlistIDs[i] = reminder.ID
+		}
+		if err := rs.dbAdapter.MarkReleaseRemindersSent(ctx, wishlistIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queueReminder queues the email for a single user's releases
+func (rs *WishlistReleaseReminderService) queueReminder(ctx context.Context, reminders []models.WishlistReleaseReminderDB) error {
+	games := make([]email.ReleaseReminderGame, len(reminders))
+	for i, reminder := range reminders {
+		games[i] = email.ReleaseReminderGame{
+			GameName:     reminder.GameName,
+			PlatformName: reminder.PlatformName,
+			ReleaseDate:  time.Unix(reminder.ReleaseDate, 0).UTC(),
+		}
+	}
+
+	return rs.emailQueue.EnqueueJob(ctx, email.EmailJobTypeReleaseReminder, reminders[0].UserID, reminders[0].Email, map[string]interface{}{
+		"userName": reminders[0].FirstName,
+		"games":    games,
+	})
+}
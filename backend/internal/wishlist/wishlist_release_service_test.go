@@ -0,0 +1,163 @@
+package wishlist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/email"
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/testutils"
+)
+
+/*
+	Behavior:
+	- Emailing users about wishlisted games releasing inside their reminder window
+
+	Scenarios:
+	- Each user gets one email listing all of their due releases, which are then marked as reminded
+	- Without an email queue nothing is sent or marked
+*/
+
+type fakeWishlistReleaseDbAdapter struct {
+	reminders   []models.WishlistReleaseReminderDB
+	defaultDays int
+	marked      [][]int64
+}
+
+func (f *fakeWishlistReleaseDbAdapter) GetDueReleaseReminders(
+	ctx context.Context,
+	now time.Time,
+	defaultDays int,
+) ([]models.WishlistReleaseReminderDB, error) {
+	f.defaultDays = defaultDays
+	return f.reminders, nil
+}
+
+func (f *fakeWishlistReleaseDbAdapter) MarkReleaseRemindersSent(ctx context.Context, wishlistIDs []int64) error {
+	f.marked = append(f.marked, wishlistIDs)
+	return nil
+}
+
+func TestWishlistReleaseReminderService(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	newReminder := func(id int64, userID, gameName string, releaseDate time.Time) models.WishlistReleaseReminderDB {
+		return models.WishlistReleaseReminderDB{
+			WishlistReleaseDB: models.WishlistReleaseDB{
+				ID:          id,
+				GameName:    gameName,
+				ReleaseDate: releaseDate.Unix(),
+			},
+			UserID: userID,
+			Email:  userID + "@example.com",
+		}
+	}
+
+	/*
+		GIVEN two releases due for one user and one for another
+		WHEN SendReleaseReminders is called
+		THEN it should queue one email per user and mark each user's releases as reminded
+	*/
+	t.Run("Queues one email per user", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeWishlistReleaseDbAdapter{
+			reminders: []models.WishlistReleaseReminderDB{
+				newReminder(1, "user-1", "Hollow Knight: Silksong", now.AddDate(0, 0, 2)),
+				newReminder(2, "user-1", "Metroid Prime 4", now.AddDate(0, 0, 5)),
+				newReminder(3, "user-2", "Hades II", now.AddDate(0, 0, 1)),
+			},
+		}
+		emailQueue := &fakeWishlistEmailQueue{}
+		service := &WishlistReleaseReminderService{
+			dbAdapter:  dbAdapter,
+			emailQueue: emailQueue,
+			logger:     testutils.NewTestLogger(),
+			now:        func() time.Time { return now },
+		}
+
+		// WHEN
+		err := service.SendReleaseReminders(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dbAdapter.defaultDays != DefaultReleaseReminderDays {
+			t.Errorf("Expected the default window of %d days, got %d", DefaultReleaseReminderDays, dbAdapter.defaultDays)
+		}
+		if len(emailQueue.jobs) != 2 {
+			t.Fatalf("Expected 2 emails, got %d", len(emailQueue.jobs))
+		}
+		games, ok := emailQueue.jobs[0]["games"].([]email.ReleaseReminderGame)
+		if !ok || len(games) != 2 || games[1].GameName != "Metroid Prime 4" {
+			t.Errorf("Expected both of user-1's releases in one email, got %+v", emailQueue.jobs[0]["games"])
+		}
+		if len(dbAdapter.marked) != 2 || len(dbAdapter.marked[0]) != 2 || dbAdapter.marked[1][0] != 3 {
+			t.Errorf("Expected releases [1 2] and [3] to be marked, got %v", dbAdapter.marked)
+		}
+	})
+
+	/*
+		GIVEN no email queue
+		WHEN SendReleaseReminders is called
+		THEN it should not look up or mark any reminders
+	*/
+	t.Run("No email queue sends nothing", func(t *testing.T) {
+		// GIVEN
+		dbAdapter := &fakeWishlistReleaseDbAdapter{
+			reminders: []models.WishlistReleaseReminderDB{newReminder(1, "user-1", "Hades II", now.AddDate(0, 0, 1))},
+		}
+		service := &WishlistReleaseReminderService{
+			dbAdapter: dbAdapter,
+			logger:    testutils.NewTestLogger(),
+			now:       func() time.Time { return now },
+		}
+
+		// WHEN
+		err := service.SendReleaseReminders(context.Background())
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(dbAdapter.marked) != 0 {
+			t.Errorf("Expected nothing to be marked, got %v", dbAdapter.marked)
+		}
+	})
+}
+
+/*
+GIVEN releases across two months
+WHEN TransformUpcomingReleasesToResponse is called
+THEN it should group them by month and count the days until each release
+*/
+func TestTransformUpcomingReleasesToResponse(t *testing.T) {
+	// GIVEN
+	today := time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)
+	releases := []models.WishlistReleaseDB{
+		{ID: 1, GameName: "Hades II", ReleaseDate: time.Date(2024, 6, 28, 15, 0, 0, 0, time.UTC).Unix()},
+		{ID: 2, GameName: "Hollow Knight: Silksong", ReleaseDate: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC).Unix()},
+		{ID: 3, GameName: "Metroid Prime 4", ReleaseDate: time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC).Unix()},
+	}
+
+	// WHEN
+	response := TransformUpcomingReleasesToResponse(releases, today, 30, models.WishlistSettingsDB{ReleaseReminderDays: 7})
+
+	// THEN
+	if response.TotalItems != 3 || response.ReleaseReminderDays != 7 {
+		t.Errorf("Unexpected totals: %+v", response)
+	}
+	if len(response.Months) != 2 || response.Months[0].Month != "2024-06" || response.Months[1].Month != "2024-07" {
+		t.Fatalf("Expected June and July, got %+v", response.Months)
+	}
+	if len(response.Months[0].Items) != 2 {
+		t.Errorf("Expected 2 releases in June, got %d", len(response.Months[0].Items))
+	}
+	if days := response.Months[0].Items[0].DaysUntilRelease; days != 0 {
+		t.Errorf("Expected a release later today to be 0 days away, got %d", days)
+	}
+	if days := response.Months[1].Items[0].DaysUntilRelease; days != 5 {
+		t.Errorf("Expected the July release to be 5 days away, got %d", days)
+	}
+}
@@ -0,0 +1,84 @@
+package wishlist
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+type WishlistRequestAdapter struct{}
+
+func NewWishlistRequestAdapter() *WishlistRequestAdapter {
+	return &WishlistRequestAdapter{}
+}
+
+func (a *WishlistRequestAdapter) AdaptCreateRequestToWishlistItemModel(
+	request types.CreateWishlistItemRequest,
+) models.WishlistItemToSave {
+	return models.WishlistItemToSave{
+		GameID:               request.GameID,
+		GameName:             request.GameName,
+		GameCoverURL:         request.GameCoverURL,
+		GameFirstReleaseDate: request.GameFirstReleaseDate,
+		GameRating:           request.GameRating,
+		PlatformID:           request.PlatformID,
+		ReleaseDate:          request.ReleaseDate,
+		IsOnSale:             request.IsOnSale,
+		CurrentPrice:         request.CurrentPrice,
+		SalePrice:            request.SalePrice,
+		TargetPrice:          request.TargetPrice,
+		Priority:             request.Priority,
+		MustHave:             request.MustHave,
+	}
+}
+
+func (a *WishlistRequestAdapter) AdaptUpdateRequestToWishlistItemModel(
+	request types.UpdateWishlistItemRequest,
+) models.WishlistItemToSave {
+	return models.WishlistItemToSave{
+		PlatformID:  request.PlatformID,
+		ReleaseDate: request.ReleaseDate,
+		TargetPrice: request.TargetPrice,
+		Priority:    request.Priority,
+		MustHave:    request.MustHave,
+	}
+}
+
+// AdaptPurchaseRequestToPurchaseModel leaves PurchaseDate zero when the request has none
+func (a *WishlistRequestAdapter) AdaptPurchaseRequestToPurchaseModel(
+	request types.PurchaseWishlistItemRequest,
+) models.WishlistPurchaseToSave {
+	purchase := models.WishlistPurchaseToSave{
+		PlatformID:         request.PlatformID,
+		SublocationID:      request.SublocationID,
+		DigitalLocationID:  request.DigitalLocationID,
+		Title:              request.Title,
+		Amount:             request.Amount,
+		PaymentMethod:      request.PaymentMethod,
+		SpendingCategoryID: request.SpendingCategoryID,
+		GameGenres:         adaptGameTerms(request.GameGenres),
+		GameThemes:         adaptGameTerms(request.GameThemes),
+	}
+	if request.PurchaseDate != nil {
+		purchase.PurchaseDate = time.Unix(*request.PurchaseDate, 0).UTC()
+	}
+	return purchase
+}
+
+// adaptGameTerms trims the genre or theme names sent with a purchase
+func adaptGameTerms(terms []types.LibraryRequestGameTerm) []models.GameMetadataTerm {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	result := make([]models.GameMetadataTerm, len(terms))
+	for i, term := range terms {
+		result[i] = models.GameMetadataTerm{
+			ID:   term.ID,
+			Name: strings.TrimSpace(term.Name),
+		}
+	}
+	return result
+}
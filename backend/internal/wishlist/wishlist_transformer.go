@@ -0,0 +1,142 @@
+package wishlist
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lokeam/qko-beta/internal/models"
+	"github.com/lokeam/qko-beta/internal/types"
+)
+
+// TransformWishlistItemDBToResponse converts a WishlistItemDB to a WishlistItemBFFResponse
+func TransformWishlistItemDBToResponse(db models.WishlistItemDB) types.WishlistItemBFFResponse {
+	response := types.WishlistItemBFFResponse{
+		ID:               db.ID,
+		GameID:           db.GameID,
+		Name:             db.GameName,
+		CoverURL:         db.GameCoverURL,
+		FirstReleaseDate: db.GameFirstReleaseDate,
+		PlatformID:       db.PlatformID,
+		PlatformName:     db.PlatformName,
+		ReleaseDate:      db.ReleaseDate,
+		IsOnSale:         db.IsOnSale,
+		CurrentPrice:     db.CurrentPrice,
+		SalePrice:        db.SalePrice,
+		TargetPrice:      db.TargetPrice,
+		Priority:         db.Priority,
+		MustHave:         db.MustHave,
+		CreatedAt:        db.CreatedAt.Unix(),
+		UpdatedAt:        db.UpdatedAt.Unix(),
+	}
+
+	if db.LastPriceCheck != nil {
+		response.LastPriceCheck = db.LastPriceCheck.Unix()
+	}
+
+	return response
+}
+
+// TransformWishlistItemsToBFFResponse builds the wishlist BFF response from db rows
+func TransformWishlistItemsToBFFResponse(items []models.WishlistItemDB) types.WishlistBFFResponse {
+	wishlistItems := make([]types.WishlistItemBFFResponse, 0, len(items))
+	itemsOnSale := 0
+
+	for _, item := range items {
+		wishlistItems = append(wishlistItems, TransformWishlistItemDBToResponse(item))
+		if item.IsOnSale {
+			itemsOnSale++
+		}
+	}
+
+	return types.WishlistBFFResponse{
+		WishlistItems: wishlistItems,
+		TotalItems:    len(wishlistItems),
+		ItemsOnSale:   itemsOnSale,
+	}
+}
+
+// TransformPriceHistoryToResponse builds the price history response for a wishlist entry
+func TransformPriceHistoryToResponse(
+	item models.WishlistItemDB,
+	history []models.GamePriceHistoryDB,
+) types.WishlistPriceHistoryResponse {
+	prices := make([]types.WishlistPricePointResponse, 0, len(history))
+	for _, price := range history {
+		prices = append(prices, types.WishlistPricePointResponse{
+			Provider:     price.Provider,
+			RegularPrice: price.RegularPrice,
+			SalePrice:    price.SalePrice,
+			CheckedAt:    price.CheckedAt.Unix(),
+		})
+	}
+
+	return types.WishlistPriceHistoryResponse{
+		GameID:      item.GameID,
+		PlatformID:  item.PlatformID,
+		TargetPrice: item.TargetPrice,
+		Prices:      prices,
+	}
+}
+
+// TransformWishlistSettingsToResponse converts wishlist settings to their response
+func TransformWishlistSettingsToResponse(settings models.WishlistSettingsDB) types.WishlistSettingsResponse {
+	return types.WishlistSettingsResponse{
+		ReleaseReminderDays: settings.ReleaseReminderDays,
+		MonthlyBudget:       settings.MonthlyBudget,
+	}
+}
+
+// TransformUpcomingReleasesToResponse groups releases by month, releases must be ordered by date.
+// today is the start of the current UTC day.
+func TransformUpcomingReleasesToResponse(
+	releases []models.WishlistReleaseDB,
+	today time.Time,
+	days int,
+	settings models.WishlistSettingsDB,
+) types.WishlistUpcomingResponse {
+	months := make([]types.WishlistReleaseMonthResponse, 0)
+
+	for _, release := range releases {
+		releaseDate := time.Unix(release.ReleaseDate, 0).UTC()
+		month := releaseDate.Format("2006-01")
+		if len(months) == 0 || months[len(months)-1].Month != month {
+			months = append(months, types.WishlistReleaseMonthResponse{Month: month})
+		}
+
+		current := &months[len(months)-1]
+		current.Items = append(current.Items, types.WishlistUpcomingItemResponse{
+			ID:               release.ID,
+			GameID:           release.GameID,
+			Name:             release.GameName,
+			CoverURL:         release.GameCoverURL,
+			PlatformID:       release.PlatformID,
+			PlatformName:     release.PlatformName,
+			ReleaseDate:      release.ReleaseDate,
+			DaysUntilRelease: int(releaseDate.Truncate(24*time.Hour).Sub(today).Hours() / 24),
+		})
+	}
+
+	return types.WishlistUpcomingResponse{
+		Days:                days,
+		ReleaseReminderDays: settings.ReleaseReminderDays,
+		TotalItems:          len(releases),
+		Months:              months,
+	}
+}
+
+// TransformWishlistPurchaseToResponse describes the library copy and purchase created from a wishlist entry
+func TransformWishlistPurchaseToResponse(
+	purchase models.WishlistPurchaseToSave,
+	result models.WishlistPurchaseResult,
+) types.WishlistPurchaseResponse {
+	return types.WishlistPurchaseResponse{
+		GameID:       purchase.GameID,
+		UserGameID:   result.UserGameID,
+		PlatformID:   result.PlatformID,
+		GameType:     purchase.GameType(),
+		PurchaseID:   fmt.Sprintf("one-%d", result.PurchaseID),
+		Title:        result.Title,
+		Amount:       purchase.Amount,
+		PurchaseDate: result.PurchaseDate.Unix(),
+	}
+}
@@ -0,0 +1,138 @@
+package wishlist
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lokeam/qko-beta/internal/interfaces"
+	"github.com/lokeam/qko-beta/internal/library"
+	"github.com/lokeam/qko-beta/internal/locations/digital"
+	"github.com/lokeam/qko-beta/internal/models"
+)
+
+// MaxPurchaseTitleLength matches one_time_purchases.title
+const MaxPurchaseTitleLength = 255
+
+type WishlistValidatorImpl struct{}
+
+func NewWishlistValidator() interfaces.WishlistValidator {
+	return &WishlistValidatorImpl{}
+}
+
+func (v *WishlistValidatorImpl) ValidateWishlistItem(item models.WishlistItemToSave) error {
+	if item.GameID <= 0 {
+		return fmt.Errorf("%w: game ID must be positive", ErrValidationFailed)
+	}
+
+	if item.PlatformID <= 0 {
+		return fmt.Errorf("%w: platform ID must be positive", ErrValidationFailed)
+	}
+
+	if item.CurrentPrice != nil && *item.CurrentPrice < 0 {
+		return fmt.Errorf("%w: current price cannot be negative", ErrValidationFailed)
+	}
+
+	if item.SalePrice != nil && *item.SalePrice < 0 {
+		return fmt.Errorf("%w: sale price cannot be negative", ErrValidationFailed)
+	}
+
+	if item.TargetPrice != nil && *item.TargetPrice < 0 {
+		return fmt.Errorf("%w: target price cannot be negative", ErrValidationFailed)
+	}
+
+	if item.IsOnSale && item.SalePrice == nil {
+		return fmt.Errorf("%w: sale price is required when item is on sale", ErrValidationFailed)
+	}
+
+	if item.Priority != nil && (*item.Priority < MinWishlistPriority || *item.Priority > MaxWishlistPriority) {
+		return fmt.Errorf("%w: priority must be between %d and %d", ErrValidationFailed, MinWishlistPriority, MaxWishlistPriority)
+	}
+
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidateGameID(gameID int64) error {
+	if gameID <= 0 {
+		return fmt.Errorf("%w: game ID must be positive", ErrValidationFailed)
+	}
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidateReleaseReminderDays(days int) error {
+	if days < 0 || days > MaxReleaseReminderDays {
+		return fmt.Errorf("%w: release reminder days must be between 0 and %d", ErrValidationFailed, MaxReleaseReminderDays)
+	}
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidateUpcomingDays(days int) error {
+	if days < 1 || days > MaxUpcomingDays {
+		return fmt.Errorf("%w: days must be between 1 and %d", ErrValidationFailed, MaxUpcomingDays)
+	}
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidateMonthlyBudget(budget float64) error {
+	if budget < 0 || budget > MaxMonthlyBudget {
+		return fmt.Errorf("%w: monthly budget must be between 0 and %.2f", ErrValidationFailed, MaxMonthlyBudget)
+	}
+	return nil
+}
+
+func (v *WishlistValidatorImpl) ValidatePurchase(purchase models.WishlistPurchaseToSave) error {
+	if purchase.GameID <= 0 {
+		return fmt.Errorf("%w: game ID must be positive", ErrValidationFailed)
+	}
+
+	if purchase.PlatformID < 0 {
+		return fmt.Errorf("%w: platform ID must be positive", ErrValidationFailed)
+	}
+
+	// The copy goes to exactly one place
+	if (purchase.SublocationID == "") == (purchase.DigitalLocationID == "") {
+		return fmt.Errorf("%w: either a sublocation or a digital location is required", ErrValidationFailed)
+	}
+	locationID := purchase.SublocationID
+	if locationID == "" {
+		locationID = purchase.DigitalLocationID
+	}
+	if _, err := uuid.Parse(locationID); err != nil {
+		return fmt.Errorf("%w: invalid location ID", ErrValidationFailed)
+	}
+
+	if purchase.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be greater than 0", ErrValidationFailed)
+	}
+
+	if !digital.ValidPaymentMethods[purchase.PaymentMethod] {
+		return fmt.Errorf("%w: invalid payment method %q", ErrValidationFailed, purchase.PaymentMethod)
+	}
+
+	if purchase.SpendingCategoryID < 0 {
+		return fmt.Errorf("%w: spending category ID cannot be negative", ErrValidationFailed)
+	}
+
+	if len(purchase.Title) > MaxPurchaseTitleLength {
+		return fmt.Errorf("%w: title must be at most %d characters", ErrValidationFailed, MaxPurchaseTitleLength)
+	}
+
+	if purchase.PurchaseDate.IsZero() {
+		return fmt.Errorf("%w: purchase date is required", ErrValidationFailed)
+	}
+
+	if err := library.ValidateGameTerms("genre", purchase.GameGenres); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+	if err := library.ValidateGameTerms("theme", purchase.GameThemes); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidationFailed, err)
+	}
+
+	return nil
+}
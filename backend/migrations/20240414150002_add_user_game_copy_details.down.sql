@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_user_games_condition;
+
+ALTER TABLE user_games
+    DROP COLUMN IF EXISTS acquired_date,
+    DROP COLUMN IF EXISTS has_manual,
+    DROP COLUMN IF EXISTS has_original_case,
+    DROP COLUMN IF EXISTS condition;
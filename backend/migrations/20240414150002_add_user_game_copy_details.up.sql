@@ -0,0 +1,8 @@
+-- Per-copy condition and completeness for physical games
+ALTER TABLE user_games
+    ADD COLUMN condition VARCHAR(20) CHECK (condition IN ('sealed', 'mint', 'good', 'fair', 'poor', 'loose')),
+    ADD COLUMN has_original_case BOOLEAN,
+    ADD COLUMN has_manual BOOLEAN,
+    ADD COLUMN acquired_date TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_user_games_condition ON user_games(user_id, condition);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_user_games_play_status;
+
+ALTER TABLE user_games
+    DROP CONSTRAINT IF EXISTS user_games_play_dates_check,
+    DROP COLUMN IF EXISTS completion_note,
+    DROP COLUMN IF EXISTS finished_at,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS play_status;
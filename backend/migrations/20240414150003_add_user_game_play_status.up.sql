@@ -0,0 +1,10 @@
+-- Play status and backlog tracking, shared by every copy of a game a user owns
+ALTER TABLE user_games
+    ADD COLUMN play_status VARCHAR(20) NOT NULL DEFAULT 'backlog'
+        CHECK (play_status IN ('backlog', 'playing', 'completed', 'hundred_percent', 'dropped', 'on_hold')),
+    ADD COLUMN started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN finished_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN completion_note TEXT,
+    ADD CONSTRAINT user_games_play_dates_check CHECK (finished_at IS NULL OR started_at IS NULL OR finished_at >= started_at);
+
+CREATE INDEX idx_user_games_play_status ON user_games(user_id, play_status);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_game_themes_theme_id;
+DROP INDEX IF EXISTS idx_game_genres_genre_id;
+DROP INDEX IF EXISTS idx_games_rating_id;
+DROP INDEX IF EXISTS idx_games_first_release_date_id;
+DROP INDEX IF EXISTS idx_games_lower_name_id;
+DROP INDEX IF EXISTS idx_user_games_user_favorite;
+DROP INDEX IF EXISTS idx_user_games_user_game_type;
+DROP INDEX IF EXISTS idx_user_games_user_platform;
+DROP INDEX IF EXISTS idx_user_games_user_created_at;
+DROP INDEX IF EXISTS idx_user_games_user_game;
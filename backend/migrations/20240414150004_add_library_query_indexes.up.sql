@@ -0,0 +1,11 @@
+-- Indexes backing the paginated library query endpoint
+CREATE INDEX idx_user_games_user_game ON user_games(user_id, game_id);
+CREATE INDEX idx_user_games_user_created_at ON user_games(user_id, created_at);
+CREATE INDEX idx_user_games_user_platform ON user_games(user_id, platform_id);
+CREATE INDEX idx_user_games_user_game_type ON user_games(user_id, game_type);
+CREATE INDEX idx_user_games_user_favorite ON user_games(user_id) WHERE favorite = true;
+CREATE INDEX idx_games_lower_name_id ON games(LOWER(name), id);
+CREATE INDEX idx_games_first_release_date_id ON games(first_release_date, id);
+CREATE INDEX idx_games_rating_id ON games(rating, id);
+CREATE INDEX idx_game_genres_genre_id ON game_genres(genre_id);
+CREATE INDEX idx_game_themes_theme_id ON game_themes(theme_id);
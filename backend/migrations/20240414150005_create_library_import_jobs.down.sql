@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_library_import_rows_job_status;
+DROP INDEX IF EXISTS idx_library_import_jobs_status;
+DROP INDEX IF EXISTS idx_library_import_jobs_user_id;
+
+DROP TABLE IF EXISTS library_import_rows;
+DROP TABLE IF EXISTS library_import_jobs;
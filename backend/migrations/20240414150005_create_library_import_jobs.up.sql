@@ -0,0 +1,39 @@
+-- Bulk library imports run as background jobs, each row keeps its own result for the report
+CREATE TABLE library_import_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('csv', 'json')),
+    dry_run BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    succeeded_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE library_import_rows (
+    id SERIAL PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES library_import_jobs(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    title VARCHAR(255),
+    igdb_id BIGINT,
+    platform VARCHAR(255),
+    game_type VARCHAR(50),
+    location VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'matched', 'imported', 'failed')),
+    matched_game_id BIGINT,
+    matched_game_name VARCHAR(255),
+    matched_platform_id BIGINT,
+    matched_platform_name VARCHAR(255),
+    matched_location_id UUID,
+    message TEXT,
+    UNIQUE (job_id, row_number)
+);
+
+CREATE INDEX idx_library_import_jobs_user_id ON library_import_jobs(user_id, created_at);
+CREATE INDEX idx_library_import_jobs_status ON library_import_jobs(status);
+CREATE INDEX idx_library_import_rows_job_status ON library_import_rows(job_id, status);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_user_data_exports_status;
+DROP INDEX IF EXISTS idx_user_data_exports_user_id;
+
+DROP TABLE IF EXISTS user_data_exports;
@@ -0,0 +1,15 @@
+-- Personal data export archives, the archive itself lives in the blob store under blob_key
+CREATE TABLE user_data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'expired')),
+    blob_key VARCHAR(255),
+    size_bytes BIGINT,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_user_data_exports_user_id ON user_data_exports(user_id, created_at);
+CREATE INDEX idx_user_data_exports_status ON user_data_exports(status, expires_at);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_library_import_rows_needs_review;
+
+DELETE FROM library_import_jobs WHERE source IN ('playnite', 'gog', 'steam');
+
+UPDATE library_import_rows SET status = 'failed' WHERE status IN ('needs_review', 'dismissed');
+
+ALTER TABLE library_import_rows
+    DROP COLUMN IF EXISTS external_id,
+    DROP CONSTRAINT library_import_rows_status_check,
+    ADD CONSTRAINT library_import_rows_status_check CHECK (status IN ('pending', 'matched', 'imported', 'failed'));
+
+ALTER TABLE library_import_jobs
+    DROP COLUMN IF EXISTS review_rows,
+    DROP CONSTRAINT library_import_jobs_source_check,
+    ADD CONSTRAINT library_import_jobs_source_check CHECK (source IN ('csv', 'json'));
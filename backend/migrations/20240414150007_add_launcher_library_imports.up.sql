@@ -0,0 +1,12 @@
+-- Launcher library exports (Playnite, GOG Galaxy, Steam) and a review queue for entries that couldn't be matched
+ALTER TABLE library_import_jobs
+    DROP CONSTRAINT library_import_jobs_source_check,
+    ADD CONSTRAINT library_import_jobs_source_check CHECK (source IN ('csv', 'json', 'playnite', 'gog', 'steam')),
+    ADD COLUMN review_rows INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE library_import_rows
+    DROP CONSTRAINT library_import_rows_status_check,
+    ADD CONSTRAINT library_import_rows_status_check CHECK (status IN ('pending', 'matched', 'imported', 'failed', 'needs_review', 'dismissed')),
+    ADD COLUMN external_id VARCHAR(255);
+
+CREATE INDEX idx_library_import_rows_needs_review ON library_import_rows(job_id) WHERE status = 'needs_review';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS collection_games;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS user_game_tags;
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,37 @@
+-- User owned tags and named collections, both link to individual copies in user_games
+CREATE TABLE user_tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_game_tags (
+    user_game_id INTEGER NOT NULL REFERENCES user_games(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES user_tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_game_id, tag_id)
+);
+
+CREATE TABLE collections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE collection_games (
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_game_id INTEGER NOT NULL REFERENCES user_games(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_id, user_game_id)
+);
+
+-- Names are unique per user regardless of case
+CREATE UNIQUE INDEX idx_user_tags_user_name ON user_tags(user_id, LOWER(name));
+CREATE UNIQUE INDEX idx_collections_user_name ON collections(user_id, LOWER(name));
+CREATE INDEX idx_user_game_tags_tag_id ON user_game_tags(tag_id);
+CREATE INDEX idx_collection_games_user_game_id ON collection_games(user_game_id);
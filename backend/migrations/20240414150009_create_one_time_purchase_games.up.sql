@@ -0,0 +1,10 @@
+-- Links a one-time purchase to the library copies it paid for.
+-- A purchase covering several copies (a bundle, a multi-pack) is split evenly between them.
+CREATE TABLE one_time_purchase_games (
+    purchase_id INTEGER NOT NULL REFERENCES one_time_purchases(id) ON DELETE CASCADE,
+    user_game_id INTEGER NOT NULL REFERENCES user_games(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (purchase_id, user_game_id)
+);
+
+CREATE INDEX idx_one_time_purchase_games_user_game_id ON one_time_purchase_games(user_game_id);
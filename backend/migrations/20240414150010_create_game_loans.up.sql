@@ -0,0 +1,22 @@
+-- Physical copies lent to friends. A copy with an open loan (returned_at IS NULL) is "on loan",
+-- it keeps its physical_game_locations row so it goes back to the same sublocation when returned.
+CREATE TABLE game_loans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_game_id INTEGER NOT NULL REFERENCES user_games(id) ON DELETE CASCADE,
+    borrower_name VARCHAR(100) NOT NULL,
+    borrower_contact VARCHAR(255),
+    lent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    due_at TIMESTAMP WITH TIME ZONE,
+    returned_at TIMESTAMP WITH TIME ZONE,
+    remind_when_overdue BOOLEAN NOT NULL DEFAULT false,
+    reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (due_at IS NULL OR due_at >= lent_at),
+    CHECK (returned_at IS NULL OR returned_at >= lent_at)
+);
+
+-- A copy can only be lent to one person at a time
+CREATE UNIQUE INDEX idx_game_loans_open_copy ON game_loans(user_game_id) WHERE returned_at IS NULL;
+CREATE INDEX idx_game_loans_user_open ON game_loans(user_id, due_at) WHERE returned_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_user_games_personal_rating;
+
+ALTER TABLE user_games
+    DROP COLUMN IF EXISTS copy_notes,
+    DROP COLUMN IF EXISTS private_notes,
+    DROP COLUMN IF EXISTS review,
+    DROP COLUMN IF EXISTS personal_rating;
@@ -0,0 +1,9 @@
+-- Personal rating, review and private notes, shared by every copy of a game like play status.
+-- copy_notes belong to a single copy.
+ALTER TABLE user_games
+    ADD COLUMN personal_rating SMALLINT CHECK (personal_rating BETWEEN 1 AND 5),
+    ADD COLUMN review TEXT,
+    ADD COLUMN private_notes TEXT,
+    ADD COLUMN copy_notes TEXT;
+
+CREATE INDEX idx_user_games_personal_rating ON user_games(user_id, personal_rating);
@@ -0,0 +1,41 @@
+-- Anything still in the trash is deleted for good, the unique constraints can't hold otherwise
+DELETE FROM one_time_purchases WHERE deleted_at IS NOT NULL;
+DELETE FROM user_games WHERE deleted_at IS NOT NULL;
+DELETE FROM sublocations WHERE deleted_at IS NOT NULL;
+DELETE FROM physical_locations WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_stored_items_on_trash ON user_games;
+DROP FUNCTION IF EXISTS update_stored_items_on_trash();
+
+CREATE OR REPLACE FUNCTION update_stored_items_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE sublocations
+        SET stored_items = stored_items + 1
+        WHERE id = NEW.sublocation_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE sublocations
+        SET stored_items = stored_items - 1
+        WHERE id = OLD.sublocation_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS recount_stored_items(UUID);
+
+DROP INDEX IF EXISTS idx_sublocations_active_name;
+DROP INDEX IF EXISTS idx_user_games_active_copy;
+ALTER TABLE sublocations ADD UNIQUE (physical_location_id, name);
+ALTER TABLE user_games ADD UNIQUE (user_id, game_id, platform_id, game_type, copy_number);
+
+DROP INDEX IF EXISTS idx_one_time_purchases_trash;
+DROP INDEX IF EXISTS idx_sublocations_trash;
+DROP INDEX IF EXISTS idx_physical_locations_trash;
+DROP INDEX IF EXISTS idx_user_games_trash;
+
+ALTER TABLE one_time_purchases DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE sublocations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE physical_locations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE user_games DROP COLUMN IF EXISTS deleted_at;
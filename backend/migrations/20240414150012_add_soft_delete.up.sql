@@ -0,0 +1,80 @@
+-- Soft deletion. A row with a deleted_at is in the user's trash and hidden from every read,
+-- the trash purge job removes it for good once it has been there for the retention period.
+-- Everything trashed by one delete shares the transaction's NOW(), so restoring a location
+-- brings back the sublocations and copies that went to the trash with it.
+ALTER TABLE user_games ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE physical_locations ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE sublocations ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE one_time_purchases ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_user_games_trash ON user_games(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_physical_locations_trash ON physical_locations(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_sublocations_trash ON sublocations(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_one_time_purchases_trash ON one_time_purchases(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Trashed rows shouldn't stop a game being added again or a sublocation name being reused.
+-- Each table has a single unique constraint, look its generated name up rather than guess it.
+DO $$
+DECLARE
+    constraint_name TEXT;
+BEGIN
+    SELECT conname INTO constraint_name
+    FROM pg_constraint
+    WHERE conrelid = 'user_games'::regclass AND contype = 'u';
+    EXECUTE format('ALTER TABLE user_games DROP CONSTRAINT %I', constraint_name);
+
+    SELECT conname INTO constraint_name
+    FROM pg_constraint
+    WHERE conrelid = 'sublocations'::regclass AND contype = 'u';
+    EXECUTE format('ALTER TABLE sublocations DROP CONSTRAINT %I', constraint_name);
+END $$;
+
+CREATE UNIQUE INDEX idx_user_games_active_copy
+    ON user_games(user_id, game_id, platform_id, game_type, copy_number)
+    WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_sublocations_active_name
+    ON sublocations(physical_location_id, name)
+    WHERE deleted_at IS NULL;
+
+-- stored_items only counts copies that aren't in the trash.
+-- Recounting instead of adding and subtracting keeps it right when a trashed copy is purged.
+CREATE OR REPLACE FUNCTION recount_stored_items(target_sublocation_id UUID)
+RETURNS VOID AS $$
+    UPDATE sublocations
+    SET stored_items = (
+        SELECT COUNT(*)
+        FROM physical_game_locations pgl
+        JOIN user_games ug ON ug.id = pgl.user_game_id
+        WHERE pgl.sublocation_id = target_sublocation_id
+        AND ug.deleted_at IS NULL
+    )
+    WHERE id = target_sublocation_id;
+$$ LANGUAGE sql;
+
+CREATE OR REPLACE FUNCTION update_stored_items_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM recount_stored_items(NEW.sublocation_id);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM recount_stored_items(OLD.sublocation_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_stored_items_on_trash()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM recount_stored_items(pgl.sublocation_id)
+    FROM physical_game_locations pgl
+    WHERE pgl.user_game_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_stored_items_on_trash
+AFTER UPDATE OF deleted_at ON user_games
+FOR EACH ROW
+WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION update_stored_items_on_trash();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_games_name_trgm;
+DROP INDEX IF EXISTS idx_games_search_vector;
+
+ALTER TABLE games DROP COLUMN IF EXISTS search_vector;
+
+-- pg_trgm is left installed, other databases on the server may rely on it
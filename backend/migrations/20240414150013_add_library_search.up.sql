@@ -0,0 +1,12 @@
+-- Library search. Game names and summaries are indexed for full-text search,
+-- names are also indexed for trigram similarity so misspelled titles still match.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE games
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(summary, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_games_search_vector ON games USING GIN (search_vector);
+CREATE INDEX idx_games_name_trgm ON games USING GIN (name gin_trgm_ops);
@@ -0,0 +1,8 @@
+DELETE FROM library_import_jobs WHERE source = 'barcode';
+
+ALTER TABLE library_import_jobs
+    DROP CONSTRAINT library_import_jobs_source_check,
+    ADD CONSTRAINT library_import_jobs_source_check CHECK (source IN ('csv', 'json', 'playnite', 'gog', 'steam'));
+
+DROP TABLE IF EXISTS barcode_confirmations;
+DROP TABLE IF EXISTS barcodes;
@@ -0,0 +1,31 @@
+-- Barcodes printed on physical game boxes mapped to IGDB games. UPC-A and EAN-13 codes are stored as GTIN-13.
+-- Mappings are shared by every user, a code can have several candidates (re-releases, regional variants)
+-- and users confirming a mapping rank it above the others.
+CREATE TABLE barcodes (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(13) NOT NULL CHECK (code ~ '^[0-9]{13}$'),
+    game_id BIGINT NOT NULL,
+    game_name VARCHAR(255) NOT NULL,
+    platform_id BIGINT NOT NULL,
+    platform_name VARCHAR(100) NOT NULL,
+    region VARCHAR(20) NOT NULL DEFAULT 'unknown' CHECK (region IN ('ntsc-u', 'ntsc-j', 'ntsc-k', 'pal', 'region-free', 'unknown')),
+    created_by VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (code, game_id, platform_id)
+);
+
+-- One confirmation per user and mapping, a user only confirms one mapping per code
+CREATE TABLE barcode_confirmations (
+    barcode_id INTEGER NOT NULL REFERENCES barcodes(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (barcode_id, user_id)
+);
+
+CREATE INDEX idx_barcode_confirmations_user ON barcode_confirmations(user_id);
+
+-- Scan sessions add their games through the library import pipeline
+ALTER TABLE library_import_jobs
+    DROP CONSTRAINT library_import_jobs_source_check,
+    ADD CONSTRAINT library_import_jobs_source_check CHECK (source IN ('csv', 'json', 'playnite', 'gog', 'steam', 'barcode'));
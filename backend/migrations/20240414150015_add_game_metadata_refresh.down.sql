@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_games_metadata_refreshed_at;
+
+ALTER TABLE games
+    DROP COLUMN IF EXISTS igdb_missing_since,
+    DROP COLUMN IF EXISTS metadata_refreshed_at;
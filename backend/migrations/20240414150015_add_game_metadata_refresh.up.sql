@@ -0,0 +1,7 @@
+-- Games are refreshed from IGDB in the background, the least recently refreshed first.
+-- igdb_missing_since is set while IGDB no longer returns a game and no replacement for it was found.
+ALTER TABLE games
+    ADD COLUMN metadata_refreshed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN igdb_missing_since TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_games_metadata_refreshed_at ON games(metadata_refreshed_at NULLS FIRST, id);
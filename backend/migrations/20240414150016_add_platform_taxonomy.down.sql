@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_platforms_platform_family_id;
+
+ALTER TABLE platforms
+    DROP COLUMN IF EXISTS synced_at,
+    DROP COLUMN IF EXISTS platform_group,
+    DROP COLUMN IF EXISTS logo_url,
+    DROP COLUMN IF EXISTS platform_family_id,
+    DROP COLUMN IF EXISTS generation,
+    DROP COLUMN IF EXISTS abbreviation;
+
+DROP TABLE IF EXISTS platform_families;
@@ -0,0 +1,20 @@
+-- Platforms are synced from IGDB's platforms and platform_families endpoints.
+-- platform_group is what the library, dashboard and analytics group platforms by,
+-- e.g. "PlayStation" or "Nintendo handhelds". It is NULL until a platform has been synced.
+CREATE TABLE platform_families (
+    id BIGINT PRIMARY KEY,  -- IGDB ID
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE platforms
+    ADD COLUMN abbreviation VARCHAR(50),
+    ADD COLUMN generation INTEGER,
+    ADD COLUMN platform_family_id BIGINT REFERENCES platform_families(id) ON DELETE SET NULL,
+    ADD COLUMN logo_url TEXT,
+    ADD COLUMN platform_group VARCHAR(255),
+    ADD COLUMN synced_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_platforms_platform_family_id ON platforms(platform_family_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_game_edition_contents;
+ALTER TABLE user_games DROP COLUMN IF EXISTS edition;
+
+DROP TABLE IF EXISTS user_game_addons;
+
+ALTER TABLE games DROP COLUMN IF EXISTS addons_synced_at;
+DROP TABLE IF EXISTS game_addons;
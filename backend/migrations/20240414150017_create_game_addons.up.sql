@@ -0,0 +1,47 @@
+-- DLC, expansions and other add-ons of a game, taken from IGDB's dlcs, expansions and standalone_expansions.
+-- Add-ons are shared by every user, games.addons_synced_at is when a game's list was last fetched.
+CREATE TABLE game_addons (
+    id BIGINT PRIMARY KEY,  -- IGDB ID
+    parent_game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    addon_type VARCHAR(20) NOT NULL CHECK (addon_type IN ('dlc', 'expansion', 'standalone', 'episode', 'season', 'pack', 'bundle')),
+    cover_url VARCHAR(255),
+    first_release_date BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_game_addons_parent_game ON game_addons(parent_game_id);
+
+ALTER TABLE games ADD COLUMN addons_synced_at TIMESTAMP WITH TIME ZONE;
+
+-- Add-ons a user bought on their own, a DLC purchase is linked through purchase_id
+CREATE TABLE user_game_addons (
+    id SERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    addon_id BIGINT NOT NULL REFERENCES game_addons(id) ON DELETE CASCADE,
+    platform_id BIGINT NOT NULL REFERENCES platforms(id),
+    purchase_id INTEGER REFERENCES one_time_purchases(id) ON DELETE SET NULL,
+    acquired_date TIMESTAMP WITH TIME ZONE,
+    notes VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, addon_id, platform_id)
+);
+
+CREATE INDEX idx_user_game_addons_addon ON user_game_addons(addon_id);
+
+-- Editions of a copy (Collector's, Game of the Year...) and what came in the box.
+-- Contents that are add-ons count as owned, the rest (art books, soundtracks, figures) are listed by name.
+ALTER TABLE user_games ADD COLUMN edition VARCHAR(100);
+
+CREATE TABLE user_game_edition_contents (
+    id SERIAL PRIMARY KEY,
+    user_game_id INTEGER NOT NULL REFERENCES user_games(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    addon_id BIGINT REFERENCES game_addons(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_game_edition_contents_user_game ON user_game_edition_contents(user_game_id);
+CREATE INDEX idx_user_game_edition_contents_addon ON user_game_edition_contents(addon_id) WHERE addon_id IS NOT NULL;
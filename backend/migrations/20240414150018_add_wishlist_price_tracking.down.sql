@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS game_price_history;
+
+DROP INDEX IF EXISTS idx_wishlist_game_platform;
+
+ALTER TABLE wishlist DROP COLUMN IF EXISTS sale_alert_sent_at;
+ALTER TABLE wishlist DROP COLUMN IF EXISTS target_price;
@@ -0,0 +1,19 @@
+-- Prices found by the scheduled price check. A sale alert is sent once the price drops to
+-- target_price, sale_alert_sent_at is cleared when it goes back above so the next drop alerts again.
+ALTER TABLE wishlist ADD COLUMN target_price DECIMAL(10,2) CHECK (target_price >= 0);
+ALTER TABLE wishlist ADD COLUMN sale_alert_sent_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_wishlist_game_platform ON wishlist(game_id, platform_id);
+
+-- Every price a provider reported, shared by every user wishlisting the game on that platform
+CREATE TABLE game_price_history (
+    id SERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    platform_id BIGINT NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    regular_price DECIMAL(10,2) NOT NULL,
+    sale_price DECIMAL(10,2),
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_game_price_history_game_platform ON game_price_history(game_id, platform_id, checked_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE wishlist DROP COLUMN IF EXISTS release_reminder_for;
+
+DROP TABLE IF EXISTS wishlist_settings;
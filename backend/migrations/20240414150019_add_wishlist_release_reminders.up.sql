@@ -0,0 +1,11 @@
+-- Per-user wishlist preferences. Users without a row get the defaults,
+-- a release_reminder_days of 0 turns release reminders off.
+CREATE TABLE wishlist_settings (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    release_reminder_days INTEGER NOT NULL DEFAULT 7 CHECK (release_reminder_days BETWEEN 0 AND 90),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The release date a reminder was sent for. A delayed game gets a new reminder once the new date comes around.
+ALTER TABLE wishlist ADD COLUMN release_reminder_for BIGINT;
@@ -0,0 +1,4 @@
+ALTER TABLE wishlist_settings DROP COLUMN IF EXISTS monthly_budget;
+
+ALTER TABLE wishlist DROP COLUMN IF EXISTS must_have;
+ALTER TABLE wishlist DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,6 @@
+-- Ranking for wishlist entries, priority 1 is the most wanted. Must-haves come before any priority.
+ALTER TABLE wishlist ADD COLUMN priority SMALLINT NOT NULL DEFAULT 3 CHECK (priority BETWEEN 1 AND 5);
+ALTER TABLE wishlist ADD COLUMN must_have BOOLEAN NOT NULL DEFAULT false;
+
+-- How much the user plans to spend on games each month, NULL until they set one
+ALTER TABLE wishlist_settings ADD COLUMN monthly_budget DECIMAL(10,2) CHECK (monthly_budget >= 0);
@@ -0,0 +1,2 @@
+-- The copies' previous values aren't kept, there is nothing to undo
+SELECT 1;
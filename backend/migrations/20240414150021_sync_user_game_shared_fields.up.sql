@@ -0,0 +1,22 @@
+-- Copies added before new copies inherited the fields shared by every copy of a game
+-- could disagree, take them from the user's oldest active copy
+UPDATE user_games ug
+SET play_status = oldest.play_status,
+    started_at = oldest.started_at,
+    finished_at = oldest.finished_at,
+    completion_note = oldest.completion_note,
+    personal_rating = oldest.personal_rating,
+    review = oldest.review,
+    private_notes = oldest.private_notes,
+    favorite = oldest.favorite
+FROM (
+    SELECT DISTINCT ON (user_id, game_id)
+        user_id, game_id, play_status, started_at, finished_at, completion_note,
+        personal_rating, review, private_notes, favorite
+    FROM user_games
+    WHERE deleted_at IS NULL
+    ORDER BY user_id, game_id, created_at, id
+) oldest
+WHERE ug.user_id = oldest.user_id
+    AND ug.game_id = oldest.game_id
+    AND ug.deleted_at IS NULL;
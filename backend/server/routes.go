@@ -13,11 +13,19 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/lokeam/qko-beta/app"
+	"github.com/lokeam/qko-beta/internal/addons"
 	"github.com/lokeam/qko-beta/internal/analytics"
+	"github.com/lokeam/qko-beta/internal/barcodes"
 	"github.com/lokeam/qko-beta/internal/appcontext"
+	"github.com/lokeam/qko-beta/internal/collections"
 	"github.com/lokeam/qko-beta/internal/dashboard"
+	"github.com/lokeam/qko-beta/internal/data_export"
+	"github.com/lokeam/qko-beta/internal/data_restore"
 	"github.com/lokeam/qko-beta/internal/health"
+	"github.com/lokeam/qko-beta/internal/infrastructure/blobstore"
 	"github.com/lokeam/qko-beta/internal/library"
+	"github.com/lokeam/qko-beta/internal/library_import"
+	"github.com/lokeam/qko-beta/internal/loans"
 	"github.com/lokeam/qko-beta/internal/locations/digital"
 	"github.com/lokeam/qko-beta/internal/locations/physical"
 	"github.com/lokeam/qko-beta/internal/locations/sublocation"
@@ -26,7 +34,9 @@ import (
 	customMiddleware "github.com/lokeam/qko-beta/internal/shared/middleware"
 	"github.com/lokeam/qko-beta/internal/spend_tracking"
 	"github.com/lokeam/qko-beta/internal/testutils/mocks"
+	"github.com/lokeam/qko-beta/internal/trash"
 	"github.com/lokeam/qko-beta/internal/users"
+	"github.com/lokeam/qko-beta/internal/wishlist"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -130,6 +140,11 @@ func (s *Server) SetupRoutes(appContext *appcontext.AppContext, services interfa
 		// Health
 		r.Get("/health", healthHandler)
 
+		// Signed download links are their own credential, so they sit outside of auth
+		if localBlobStore, ok := svc.BlobStore.(*blobstore.LocalBlobStore); ok {
+			r.Get(strings.TrimPrefix(blobstore.DownloadPath, "/api/v1"), localBlobStore.ServeSignedDownload)
+		}
+
 		// Protect routes with Auth0
 		r.Group(func(r chi.Router) {
 			r.Use(customMiddleware.EnsureValidToken())
@@ -158,6 +173,43 @@ func (s *Server) SetupRoutes(appContext *appcontext.AppContext, services interfa
 
 				// Register routes using the new pattern
 				library.RegisterLibraryRoutes(r, appContext, svc.Library, svc.Analytics)
+
+				// Bulk imports
+				r.Route("/imports", func(r chi.Router) {
+					library_import.RegisterLibraryImportRoutes(r, appContext, svc.LibraryImport)
+				})
+
+				// Tags and collections
+				r.Route("/tags", func(r chi.Router) {
+					collections.RegisterTagRoutes(r, appContext, svc.Collections)
+				})
+				r.Route("/collections", func(r chi.Router) {
+					collections.RegisterCollectionRoutes(r, appContext, svc.Collections)
+				})
+
+				// Loans of physical copies
+				r.Route("/loans", func(r chi.Router) {
+					loans.RegisterLoanRoutes(r, appContext, svc.Loans)
+				})
+
+				// Barcode lookups and scan sessions
+				r.Route("/barcodes", func(r chi.Router) {
+					barcodes.RegisterBarcodeRoutes(r, appContext, svc.Barcodes)
+				})
+
+				// DLC, expansions and editions
+				r.Route("/addons", func(r chi.Router) {
+					addons.RegisterAddonRoutes(r, appContext, svc.Addons)
+				})
+			})
+
+			// Wishlist
+			r.Route("/wishlist", func(r chi.Router) {
+				appContext.Logger.Info("Registering wishlist routes", map[string]any{
+					"path": "/api/v1/wishlist",
+				})
+
+				wishlist.RegisterWishlistRoutes(r, appContext, svc.Wishlist, svc.Analytics)
 			})
 
 			// Physical Locations
@@ -203,6 +255,15 @@ func (s *Server) SetupRoutes(appContext *appcontext.AppContext, services interfa
 				spend_tracking.RegisterSpendTrackingRoutes(r, appContext, svc.SpendTracking)
 			})
 
+			// Trash - deleted games, locations and purchases until they are purged
+			r.Route("/trash", func(r chi.Router) {
+				appContext.Logger.Info("Registering trash routes", map[string]any{
+					"path": "/api/v1/trash",
+				})
+
+				trash.RegisterTrashRoutes(r, appContext, svc.Trash)
+			})
+
 			// Dashboard
 			r.Route("/dashboard", func(r chi.Router) {
 				appContext.Logger.Debug("ENTERED dashboard route block", nil)
@@ -236,6 +297,16 @@ func (s *Server) SetupRoutes(appContext *appcontext.AppContext, services interfa
 					appContext.Logger.Info("User deletion service created successfully", nil)
 				}
 
+				// Personal data export
+				r.Route("/export", func(r chi.Router) {
+					data_export.RegisterDataExportRoutes(r, appContext, svc.DataExport)
+				})
+
+				// Restore from a personal data export
+				r.Route("/restore", func(r chi.Router) {
+					data_restore.RegisterDataRestoreRoutes(r, appContext, svc.DataRestore)
+				})
+
 				// Register unified user routes (profile + deletion)
 				if userService != nil && userDeletionService != nil {
 					users.RegisterUserRoutes(r, appContext, userService, userDeletionService)
@@ -253,16 +324,29 @@ func (s *Server) SetupRoutes(appContext *appcontext.AppContext, services interfa
 			})
 
 			appContext.Logger.Info("Routes registered", map[string]any{
-				"health":         "/api/v1/health",
-				"search":         "/api/v1/search",
-				"library":        "/api/v1/library",
-				"physical":       "/api/v1/locations/physical",
-				"sublocations":   "/api/v1/locations/sublocations",
-				"digital":        "/api/v1/locations/digital",
-				"spend-tracking": "/api/v1/spend-tracking",
-				"dashboard":      "/api/v1/dashboard",
-				"users":          "/api/v1/users",
-				"analytics":      "/api/v1/analytics",
+				"health":              "/api/v1/health",
+				"search":              "/api/v1/search",
+				"library":             "/api/v1/library",
+				"library-search":      "/api/v1/library/search",
+				"library-imports":     "/api/v1/library/imports",
+				"library-tags":        "/api/v1/library/tags",
+				"library-collections": "/api/v1/library/collections",
+				"library-loans":       "/api/v1/library/loans",
+				"library-barcodes":    "/api/v1/library/barcodes",
+				"library-addons":      "/api/v1/library/addons",
+				"wishlist":            "/api/v1/wishlist",
+				"wishlist-upcoming":   "/api/v1/wishlist/upcoming",
+				"wishlist-budget":     "/api/v1/wishlist/budget",
+				"physical":            "/api/v1/locations/physical",
+				"sublocations":        "/api/v1/locations/sublocations",
+				"digital":             "/api/v1/locations/digital",
+				"spend-tracking":      "/api/v1/spend-tracking",
+				"trash":               "/api/v1/trash",
+				"dashboard":           "/api/v1/dashboard",
+				"users":               "/api/v1/users",
+				"user-export":         "/api/v1/users/export",
+				"user-restore":        "/api/v1/users/restore",
+				"analytics":           "/api/v1/analytics",
 			})
 		})
 	})
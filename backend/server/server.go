@@ -18,6 +18,7 @@ type Server struct {
 	AppContext  *appcontext.AppContext
 	Logger      interfaces.Logger
 	Router      chi.Router
+	Services    *app.Services
 }
 
 func NewServer(
@@ -63,6 +64,13 @@ func NewServer(
 		}
 	} else {
 		services = appServices
+		s.Services = appServices
+
+		if err := appServices.StartBackgroundJobs(context.Background()); err != nil {
+			logger.Error("Failed to start background jobs", map[string]any{
+				"error": err.Error(),
+			})
+		}
 	}
 
 	s.Router = s.SetupRoutes(appContext, services)
@@ -81,6 +89,14 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	})
 
 	// Clean up tasks
+	if s.Services != nil {
+		if err := s.Services.StopBackgroundJobs(); err != nil {
+			s.Logger.Error("Failed to stop background jobs", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	return nil
 }
 